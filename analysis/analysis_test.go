@@ -0,0 +1,317 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/llir/llvm/analysis"
+	"github.com/llir/llvm/asm"
+	"github.com/llir/llvm/ir"
+)
+
+func TestCFG(t *testing.T) {
+	m, err := asm.ParseFile("testdata/cfg.ll")
+	if err != nil {
+		t.Fatalf("unable to parse file; %v", err)
+	}
+	f := findFunc(t, m, "unreachable")
+	g := analysis.NewCFG(f)
+	golden := []struct {
+		block     string
+		succs     []string
+		preds     []string
+		reachable bool
+	}{
+		// Duplicate switch edges are represented by a single edge.
+		{block: "entry", succs: []string{"exit"}, reachable: true},
+		{block: "dead", succs: []string{"exit"}},
+		{block: "dead_loop", succs: []string{"dead_loop"}, preds: []string{"dead_loop"}},
+		{block: "exit", preds: []string{"entry", "dead"}, reachable: true},
+	}
+	for _, gg := range golden {
+		block := findBlock(t, f, gg.block)
+		if got := blockNames(g.Succs(block)); !reflect.DeepEqual(gg.succs, got) {
+			t.Errorf("%q: successor mismatch; expected %q, got %q", gg.block, gg.succs, got)
+		}
+		if got := blockNames(g.Preds(block)); !reflect.DeepEqual(gg.preds, got) {
+			t.Errorf("%q: predecessor mismatch; expected %q, got %q", gg.block, gg.preds, got)
+		}
+		if got := g.Reachable(block); gg.reachable != got {
+			t.Errorf("%q: reachability mismatch; expected %v, got %v", gg.block, gg.reachable, got)
+		}
+	}
+	if g.Entry != f.Blocks[0] {
+		t.Errorf("entry basic block mismatch; expected %q, got %v", f.Blocks[0].Name, g.Entry)
+	}
+	want := []string{"exit"}
+	if got := blockNames(g.Exits()); !reflect.DeepEqual(want, got) {
+		t.Errorf("exit mismatch; expected %q, got %q", want, got)
+	}
+	// Function declarations have empty control flow graphs.
+	decl := analysis.NewCFG(findFunc(t, m, "decl"))
+	if decl.Entry != nil {
+		t.Errorf("entry basic block mismatch; expected nil, got %v", decl.Entry)
+	}
+	if roots := analysis.NewDomTree(decl).Roots(); len(roots) != 0 {
+		t.Errorf("dominator tree root mismatch; expected none, got %d", len(roots))
+	}
+	if roots := analysis.NewPostDomTree(decl).Roots(); len(roots) != 0 {
+		t.Errorf("post-dominator tree root mismatch; expected none, got %d", len(roots))
+	}
+}
+
+func TestDomTree(t *testing.T) {
+	golden := []struct {
+		// Function name.
+		name string
+		// Immediate dominators, dominance frontiers and roots of the dominator
+		// tree; basic blocks without immediate dominators are omitted.
+		idom     map[string]string
+		frontier map[string][]string
+		// Immediate post-dominators, post-dominance frontiers and roots of the
+		// post-dominator tree.
+		pidom     map[string]string
+		pfrontier map[string][]string
+		proots    []string
+	}{
+		{
+			name:      "diamond",
+			idom:      map[string]string{"a": "entry", "b": "entry", "exit": "entry"},
+			frontier:  map[string][]string{"a": {"exit"}, "b": {"exit"}},
+			pidom:     map[string]string{"entry": "exit", "a": "exit", "b": "exit"},
+			pfrontier: map[string][]string{"a": {"entry"}, "b": {"entry"}},
+			proots:    []string{"exit"},
+		},
+		{
+			name:      "loop",
+			idom:      map[string]string{"header": "entry", "body": "header", "exit": "header"},
+			frontier:  map[string][]string{"header": {"header"}, "body": {"header"}},
+			pidom:     map[string]string{"entry": "header", "header": "exit", "body": "header"},
+			pfrontier: map[string][]string{"header": {"header"}, "body": {"header"}},
+			proots:    []string{"exit"},
+		},
+		// Irreducible loop with the two entries a and b.
+		{
+			name:      "irreducible",
+			idom:      map[string]string{"a": "entry", "b": "entry", "exit": "a"},
+			frontier:  map[string][]string{"a": {"b"}, "b": {"a"}},
+			pidom:     map[string]string{"entry": "a", "a": "exit", "b": "a"},
+			pfrontier: map[string][]string{"a": {"a"}, "b": {"entry", "a"}},
+			proots:    []string{"exit"},
+		},
+		// Unreachable basic blocks are not part of the dominator tree.
+		{
+			name:      "unreachable",
+			idom:      map[string]string{"exit": "entry"},
+			pidom:     map[string]string{"entry": "exit", "dead": "exit"},
+			pfrontier: map[string][]string{"dead_loop": {"dead_loop"}},
+			proots:    []string{"dead_loop", "exit"},
+		},
+		// Infinite loops are roots of the post-dominator tree.
+		{
+			name:      "infinite",
+			idom:      map[string]string{"loop": "entry", "exit": "entry"},
+			frontier:  map[string][]string{"loop": {"loop"}},
+			pfrontier: map[string][]string{"loop": {"entry", "loop"}, "exit": {"entry"}},
+			proots:    []string{"loop", "exit"},
+		},
+		{
+			name:      "multi_exit",
+			idom:      map[string]string{"a": "entry", "b": "entry", "c": "b"},
+			pidom:     map[string]string{"b": "c"},
+			pfrontier: map[string][]string{"a": {"entry"}, "b": {"entry"}, "c": {"entry"}},
+			proots:    []string{"a", "c"},
+		},
+	}
+	m, err := asm.ParseFile("testdata/cfg.ll")
+	if err != nil {
+		t.Fatalf("unable to parse file; %v", err)
+	}
+	for _, g := range golden {
+		f := findFunc(t, m, g.name)
+		cfg := analysis.NewCFG(f)
+		check := func(kind string, tree *analysis.DomTree, idom map[string]string, frontier map[string][]string, roots []string) {
+			for _, block := range f.Blocks {
+				var got string
+				if d := tree.IDom(block); d != nil {
+					got = d.Name
+				}
+				if want := idom[block.Name]; want != got {
+					t.Errorf("%q: %s immediate dominator mismatch of %q; expected %q, got %q", g.name, kind, block.Name, want, got)
+				}
+				if want, got := frontier[block.Name], blockNames(tree.Frontier(block)); !reflect.DeepEqual(want, got) {
+					t.Errorf("%q: %s frontier mismatch of %q; expected %q, got %q", g.name, kind, block.Name, want, got)
+				}
+			}
+			if got := blockNames(tree.Roots()); !reflect.DeepEqual(roots, got) {
+				t.Errorf("%q: %s root mismatch; expected %q, got %q", g.name, kind, roots, got)
+			}
+		}
+		check("dominator", analysis.NewDomTree(cfg), g.idom, g.frontier, []string{"entry"})
+		check("post-dominator", analysis.NewPostDomTree(cfg), g.pidom, g.pfrontier, g.proots)
+	}
+}
+
+func TestDominates(t *testing.T) {
+	m, err := asm.ParseFile("testdata/cfg.ll")
+	if err != nil {
+		t.Fatalf("unable to parse file; %v", err)
+	}
+	f := findFunc(t, m, "unreachable")
+	tree := analysis.NewDomTree(analysis.NewCFG(f))
+	entry, dead, exit := findBlock(t, f, "entry"), findBlock(t, f, "dead"), findBlock(t, f, "exit")
+	golden := []struct {
+		a, b *ir.BasicBlock
+		want bool
+	}{
+		{a: entry, b: entry, want: true},
+		{a: entry, b: exit, want: true},
+		{a: exit, b: entry, want: false},
+		// Unreachable basic blocks are dominated by every basic block, and
+		// dominate only themselves.
+		{a: exit, b: dead, want: true},
+		{a: dead, b: dead, want: true},
+		{a: dead, b: exit, want: false},
+	}
+	for _, g := range golden {
+		if got := tree.Dominates(g.a, g.b); g.want != got {
+			t.Errorf("dominance mismatch of %q and %q; expected %v, got %v", g.a.Name, g.b.Name, g.want, got)
+		}
+	}
+	if tree.StrictlyDominates(entry, entry) {
+		t.Errorf("expected %q to not strictly dominate itself", entry.Name)
+	}
+	want := []string{"exit"}
+	if got := blockNames(tree.Children(entry)); !reflect.DeepEqual(want, got) {
+		t.Errorf("children mismatch of %q; expected %q, got %q", entry.Name, want, got)
+	}
+}
+
+// TestDominatesNaive validates the dominator and post-dominator trees against
+// the definition of dominance; i.e. a dominates b if every path from the root
+// to b passes through a.
+func TestDominatesNaive(t *testing.T) {
+	golden := []struct {
+		path string
+	}{
+		{path: "testdata/cfg.ll"},
+		{path: "../asm/testdata/term.ll"},
+		{path: "../asm/testdata/rand.ll"},
+		{path: "../asm/testdata/inst_other.ll"},
+	}
+	for _, g := range golden {
+		m, err := asm.ParseFile(g.path)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", g.path, err)
+			continue
+		}
+		for _, f := range m.Funcs {
+			if len(f.Blocks) == 0 {
+				continue
+			}
+			cfg := analysis.NewCFG(f)
+			dom := analysis.NewDomTree(cfg)
+			pdom := analysis.NewPostDomTree(cfg)
+			for _, a := range f.Blocks {
+				// Basic blocks reachable from the roots when avoiding a.
+				reach := reachableAvoiding([]*ir.BasicBlock{cfg.Entry}, cfg.Succs, a)
+				preach := reachableAvoiding(pdom.Roots(), cfg.Preds, a)
+				for _, b := range f.Blocks {
+					if a == b || !cfg.Reachable(a) || !cfg.Reachable(b) {
+						continue
+					}
+					if want, got := !reach[b], dom.Dominates(a, b); want != got {
+						t.Errorf("%q: %s dominance mismatch of %q and %q; expected %v, got %v", g.path, f.Ident(), a.Ident(), b.Ident(), want, got)
+					}
+				}
+				for _, b := range f.Blocks {
+					if a == b {
+						continue
+					}
+					if want, got := !preach[b], pdom.Dominates(a, b); want != got {
+						t.Errorf("%q: %s post-dominance mismatch of %q and %q; expected %v, got %v", g.path, f.Ident(), a.Ident(), b.Ident(), want, got)
+					}
+				}
+			}
+			// The dominance frontier of a consists of the basic blocks b not
+			// strictly dominated by a, with a predecessor dominated by a.
+			for _, a := range f.Blocks {
+				if !cfg.Reachable(a) {
+					continue
+				}
+				var want []string
+				for _, b := range f.Blocks {
+					if !cfg.Reachable(b) || dom.StrictlyDominates(a, b) {
+						continue
+					}
+					for _, pred := range cfg.Preds(b) {
+						if cfg.Reachable(pred) && dom.Dominates(a, pred) {
+							want = append(want, b.Name)
+							break
+						}
+					}
+				}
+				if got := blockNames(dom.Frontier(a)); !reflect.DeepEqual(want, got) {
+					t.Errorf("%q: %s frontier mismatch of %q; expected %q, got %q", g.path, f.Ident(), a.Ident(), want, got)
+				}
+			}
+		}
+	}
+}
+
+// ### [ Helper functions ] ####################################################
+
+// reachableAvoiding returns the basic blocks reachable from the given roots,
+// following the given edges, without passing through the basic block avoid.
+func reachableAvoiding(roots []*ir.BasicBlock, edges func(*ir.BasicBlock) []*ir.BasicBlock, avoid *ir.BasicBlock) map[*ir.BasicBlock]bool {
+	reachable := make(map[*ir.BasicBlock]bool)
+	var stack []*ir.BasicBlock
+	for _, root := range roots {
+		if root != avoid && !reachable[root] {
+			reachable[root] = true
+			stack = append(stack, root)
+		}
+	}
+	for len(stack) > 0 {
+		block := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, succ := range edges(block) {
+			if succ != avoid && !reachable[succ] {
+				reachable[succ] = true
+				stack = append(stack, succ)
+			}
+		}
+	}
+	return reachable
+}
+
+// findFunc returns the function of the given name in m.
+func findFunc(t *testing.T, m *ir.Module, name string) *ir.Function {
+	for _, f := range m.Funcs {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("unable to locate function %q", name)
+	return nil
+}
+
+// findBlock returns the basic block of the given name in f.
+func findBlock(t *testing.T, f *ir.Function, name string) *ir.BasicBlock {
+	for _, block := range f.Blocks {
+		if block.Name == name {
+			return block
+		}
+	}
+	t.Fatalf("unable to locate basic block %q in %s", name, f.Ident())
+	return nil
+}
+
+// blockNames returns the label names of the given basic blocks.
+func blockNames(blocks []*ir.BasicBlock) []string {
+	var names []string
+	for _, block := range blocks {
+		names = append(names, block.Name)
+	}
+	return names
+}
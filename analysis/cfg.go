@@ -0,0 +1,140 @@
+// Package analysis implements control flow analysis of LLVM IR functions,
+// including control flow graphs, dominator trees, post-dominator trees and
+// dominance frontiers.
+package analysis
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// CFG is the control flow graph of a function.
+type CFG struct {
+	// Function of the control flow graph.
+	Func *ir.Function
+	// Entry basic block of the function; or nil if the function has no basic
+	// blocks.
+	Entry *ir.BasicBlock
+	// Index of each basic block in the function.
+	index map[*ir.BasicBlock]int
+	// Successors of each basic block, indexed by basic block index.
+	succs [][]int
+	// Predecessors of each basic block, indexed by basic block index.
+	preds [][]int
+	// Reachability of each basic block from the entry basic block, indexed by
+	// basic block index.
+	reachable []bool
+}
+
+// NewCFG returns the control flow graph of the given function. Duplicate edges
+// (e.g. switch cases with the same target) are represented by a single edge.
+func NewCFG(f *ir.Function) *CFG {
+	g := &CFG{
+		Func:  f,
+		index: make(map[*ir.BasicBlock]int),
+		succs: make([][]int, len(f.Blocks)),
+		preds: make([][]int, len(f.Blocks)),
+	}
+	for i, block := range f.Blocks {
+		g.index[block] = i
+	}
+	for i, block := range f.Blocks {
+		if block.Term == nil {
+			continue
+		}
+		for _, succ := range block.Term.Succs() {
+			j, ok := g.index[succ]
+			if !ok || containsIndex(g.succs[i], j) {
+				continue
+			}
+			g.succs[i] = append(g.succs[i], j)
+			g.preds[j] = append(g.preds[j], i)
+		}
+	}
+	g.reachable = make([]bool, len(f.Blocks))
+	if len(f.Blocks) > 0 {
+		g.Entry = f.Blocks[0]
+		markReachable(g.succs, 0, g.reachable)
+	}
+	return g
+}
+
+// Succs returns the successor basic blocks of the given basic block, in order
+// of the successors of its terminator.
+func (g *CFG) Succs(block *ir.BasicBlock) []*ir.BasicBlock {
+	i, ok := g.index[block]
+	if !ok {
+		return nil
+	}
+	return g.blocks(g.succs[i])
+}
+
+// Preds returns the predecessor basic blocks of the given basic block, in
+// order of the predecessors in the function.
+func (g *CFG) Preds(block *ir.BasicBlock) []*ir.BasicBlock {
+	i, ok := g.index[block]
+	if !ok {
+		return nil
+	}
+	return g.blocks(g.preds[i])
+}
+
+// Reachable reports whether the given basic block is reachable from the entry
+// basic block.
+func (g *CFG) Reachable(block *ir.BasicBlock) bool {
+	i, ok := g.index[block]
+	return ok && g.reachable[i]
+}
+
+// Exits returns the basic blocks without successors (e.g. basic blocks
+// terminated by ret or unreachable), in order of the basic blocks in the
+// function.
+func (g *CFG) Exits() []*ir.BasicBlock {
+	var exits []*ir.BasicBlock
+	for i, succs := range g.succs {
+		if len(succs) == 0 {
+			exits = append(exits, g.Func.Blocks[i])
+		}
+	}
+	return exits
+}
+
+// blocks returns the basic blocks of the given basic block indices.
+func (g *CFG) blocks(indices []int) []*ir.BasicBlock {
+	if len(indices) == 0 {
+		return nil
+	}
+	blocks := make([]*ir.BasicBlock, len(indices))
+	for i, index := range indices {
+		blocks[i] = g.Func.Blocks[index]
+	}
+	return blocks
+}
+
+// ### [ Helper functions ] ####################################################
+
+// markReachable marks the nodes reachable from the given node, following the
+// given edges.
+func markReachable(edges [][]int, node int, reachable []bool) {
+	stack := []int{node}
+	reachable[node] = true
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, succ := range edges[node] {
+			if !reachable[succ] {
+				reachable[succ] = true
+				stack = append(stack, succ)
+			}
+		}
+	}
+}
+
+// containsIndex reports whether x is in indices.
+func containsIndex(indices []int, x int) bool {
+	for _, index := range indices {
+		if index == x {
+			return true
+		}
+	}
+	return false
+}
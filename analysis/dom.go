@@ -0,0 +1,319 @@
+package analysis
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// DomTree is the dominator tree or post-dominator tree of a function.
+//
+// The immediate dominators are computed using the iterative algorithm of
+// Cooper, Harvey and Kennedy [1], which handles irreducible control flow.
+//
+// [1]: https://www.cs.rice.edu/~keith/EMBED/dom.pdf
+type DomTree struct {
+	// Control flow graph of the function.
+	cfg *CFG
+	// Index of the root node; the root node of post-dominator trees is a
+	// virtual exit node, with index len(cfg.Func.Blocks).
+	root int
+	// Roots of the tree, in order of the basic blocks in the function; i.e. the
+	// entry basic block of dominator trees, and the basic blocks immediately
+	// succeeding the virtual exit node in the reverse control flow graph of
+	// post-dominator trees.
+	roots []int
+	// Immediate dominator of each node, indexed by node index; the immediate
+	// dominator of the root node is the root node itself, and the immediate
+	// dominator of nodes not in the tree is -1.
+	idom []int
+	// Children of each node in the tree, indexed by node index.
+	children [][]int
+	// Pre-order and post-order numbers of each node in the tree, indexed by
+	// node index; used to answer dominance queries in constant time.
+	pre, post []int
+	// Dominance frontier of each node, indexed by node index.
+	frontier [][]int
+}
+
+// NewDomTree returns the dominator tree of the function of the given control
+// flow graph, rooted at the entry basic block. Basic blocks unreachable from
+// the entry basic block are not part of the tree.
+func NewDomTree(g *CFG) *DomTree {
+	n := len(g.Func.Blocks)
+	if n == 0 {
+		return &DomTree{cfg: g, root: -1}
+	}
+	return newDomTree(g, 0, []int{0}, g.succs, g.preds)
+}
+
+// NewPostDomTree returns the post-dominator tree of the function of the given
+// control flow graph.
+//
+// The post-dominator tree is rooted at a virtual exit node, which succeeds the
+// roots of the tree. The roots are the basic blocks without successors, and
+// for each region from which no such basic block is reachable (e.g. infinite
+// loops), the last basic block of the region in the function. Thus, every
+// basic block of the function is part of the tree; basic blocks post-dominated
+// only by the virtual exit node have no immediate post-dominator.
+func NewPostDomTree(g *CFG) *DomTree {
+	n := len(g.Func.Blocks)
+	// Edges of the reverse control flow graph, extended with the virtual exit
+	// node.
+	succs := make([][]int, n+1)
+	preds := make([][]int, n+1)
+	copy(succs, g.preds)
+	copy(preds, g.succs)
+	reachable := make([]bool, n+1)
+	addRoot := func(i int) {
+		succs[n] = append(succs[n], i)
+		preds[i] = append(preds[i][:len(preds[i]):len(preds[i])], n)
+		markReachable(g.preds, i, reachable)
+	}
+	for i, s := range g.succs {
+		if len(s) == 0 {
+			addRoot(i)
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		if !reachable[i] {
+			addRoot(i)
+		}
+	}
+	roots := make([]int, 0, len(succs[n]))
+	for i := 0; i < n; i++ {
+		if containsIndex(succs[n], i) {
+			roots = append(roots, i)
+		}
+	}
+	return newDomTree(g, n, roots, succs, preds)
+}
+
+// newDomTree returns the dominator tree of the graph with the given edges,
+// rooted at the given node.
+func newDomTree(g *CFG, root int, roots []int, succs, preds [][]int) *DomTree {
+	t := &DomTree{
+		cfg:   g,
+		root:  root,
+		roots: roots,
+		idom:  immediateDominators(root, succs, preds),
+	}
+	n := len(t.idom)
+	t.children = make([][]int, n)
+	for i, idom := range t.idom {
+		if i != root && idom != -1 {
+			t.children[idom] = append(t.children[idom], i)
+		}
+	}
+	t.number()
+	// Dominance frontiers are computed in order of node index, and are thus
+	// sorted.
+	t.frontier = make([][]int, n)
+	for i := range t.idom {
+		if t.idom[i] == -1 {
+			continue
+		}
+		// The root node has no immediate dominator; walk up to and including
+		// the root node.
+		stop := t.idom[i]
+		if i == root {
+			stop = -1
+		}
+		for _, pred := range preds[i] {
+			if t.idom[pred] == -1 {
+				continue
+			}
+			for runner := pred; runner != stop; runner = t.parent(runner) {
+				if !containsIndex(t.frontier[runner], i) {
+					t.frontier[runner] = append(t.frontier[runner], i)
+				}
+			}
+		}
+	}
+	return t
+}
+
+// Roots returns the roots of the tree, in order of the basic blocks in the
+// function; i.e. the entry basic block for dominator trees, and the basic blocks
+// without successors and the representatives of infinite loops for
+// post-dominator trees.
+func (t *DomTree) Roots() []*ir.BasicBlock {
+	return t.blocks(t.roots)
+}
+
+// IDom returns the immediate dominator (or immediate post-dominator) of the
+// given basic block; or nil if the basic block is a root of the tree or not
+// part of the tree.
+func (t *DomTree) IDom(block *ir.BasicBlock) *ir.BasicBlock {
+	i := t.node(block)
+	if i == -1 || i == t.root {
+		return nil
+	}
+	idom := t.idom[i]
+	if t.isVirtual(idom) {
+		return nil
+	}
+	return t.cfg.Func.Blocks[idom]
+}
+
+// Children returns the basic blocks immediately dominated (or immediately
+// post-dominated) by the given basic block, in order of the basic blocks in
+// the function.
+func (t *DomTree) Children(block *ir.BasicBlock) []*ir.BasicBlock {
+	i := t.node(block)
+	if i == -1 {
+		return nil
+	}
+	return t.blocks(t.children[i])
+}
+
+// Dominates reports whether the basic block a dominates (or post-dominates)
+// the basic block b. Every basic block dominates itself. As in LLVM, basic
+// blocks not part of the tree are dominated by every basic block, and dominate
+// no basic block other than themselves.
+func (t *DomTree) Dominates(a, b *ir.BasicBlock) bool {
+	if a == b {
+		return true
+	}
+	i, j := t.node(a), t.node(b)
+	if j == -1 {
+		return true
+	}
+	if i == -1 {
+		return false
+	}
+	return t.pre[i] <= t.pre[j] && t.post[j] <= t.post[i]
+}
+
+// StrictlyDominates reports whether the basic block a dominates (or
+// post-dominates) the basic block b, and a is not b.
+func (t *DomTree) StrictlyDominates(a, b *ir.BasicBlock) bool {
+	return a != b && t.Dominates(a, b)
+}
+
+// Frontier returns the dominance frontier (or post-dominance frontier) of the
+// given basic block, in order of the basic blocks in the function.
+func (t *DomTree) Frontier(block *ir.BasicBlock) []*ir.BasicBlock {
+	i := t.node(block)
+	if i == -1 {
+		return nil
+	}
+	return t.blocks(t.frontier[i])
+}
+
+// node returns the node index of the given basic block, or -1 if the basic
+// block is not part of the tree.
+func (t *DomTree) node(block *ir.BasicBlock) int {
+	i, ok := t.cfg.index[block]
+	if !ok || t.idom[i] == -1 {
+		return -1
+	}
+	return i
+}
+
+// parent returns the parent of the given node in the tree, or -1 if the node
+// is the root node.
+func (t *DomTree) parent(i int) int {
+	if i == t.root {
+		return -1
+	}
+	return t.idom[i]
+}
+
+// isVirtual reports whether the given node is the virtual exit node of
+// post-dominator trees.
+func (t *DomTree) isVirtual(i int) bool {
+	return i == len(t.cfg.Func.Blocks)
+}
+
+// blocks returns the basic blocks of the given node indices.
+func (t *DomTree) blocks(indices []int) []*ir.BasicBlock {
+	return t.cfg.blocks(indices)
+}
+
+// number assigns pre-order and post-order numbers to the nodes of the tree.
+func (t *DomTree) number() {
+	n := len(t.idom)
+	t.pre = make([]int, n)
+	t.post = make([]int, n)
+	var preNum, postNum int
+	var visit func(i int)
+	visit = func(i int) {
+		t.pre[i] = preNum
+		preNum++
+		for _, child := range t.children[i] {
+			visit(child)
+		}
+		t.post[i] = postNum
+		postNum++
+	}
+	visit(t.root)
+}
+
+// ### [ Helper functions ] ####################################################
+
+// immediateDominators returns the immediate dominator of each node of the
+// graph with the given edges, rooted at the given node. The immediate
+// dominator of the root node is the root node itself, and the immediate
+// dominator of nodes unreachable from the root node is -1.
+func immediateDominators(root int, succs, preds [][]int) []int {
+	n := len(succs)
+	// Compute the post-order numbers of the nodes reachable from the root
+	// node, and the nodes in reverse post-order.
+	po := make([]int, n)
+	for i := range po {
+		po[i] = -1
+	}
+	var rpo []int
+	visited := make([]bool, n)
+	var visit func(i int)
+	visit = func(i int) {
+		visited[i] = true
+		for _, succ := range succs[i] {
+			if !visited[succ] {
+				visit(succ)
+			}
+		}
+		po[i] = len(rpo)
+		rpo = append(rpo, i)
+	}
+	visit(root)
+	for i, j := 0, len(rpo)-1; i < j; i, j = i+1, j-1 {
+		rpo[i], rpo[j] = rpo[j], rpo[i]
+	}
+	idom := make([]int, n)
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[root] = root
+	intersect := func(a, b int) int {
+		for a != b {
+			for po[a] < po[b] {
+				a = idom[a]
+			}
+			for po[b] < po[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, i := range rpo[1:] {
+			newIDom := -1
+			for _, pred := range preds[i] {
+				if idom[pred] == -1 {
+					continue
+				}
+				if newIDom == -1 {
+					newIDom = pred
+				} else {
+					newIDom = intersect(pred, newIDom)
+				}
+			}
+			if idom[i] != newIDom {
+				idom[i] = newIDom
+				changed = true
+			}
+		}
+	}
+	return idom
+}
@@ -0,0 +1,85 @@
+define void @diamond(i1 %cond) {
+entry:
+	br i1 %cond, label %a, label %b
+
+a:
+	br label %exit
+
+b:
+	br label %exit
+
+exit:
+	ret void
+}
+
+define void @loop(i1 %cond) {
+entry:
+	br label %header
+
+header:
+	br i1 %cond, label %body, label %exit
+
+body:
+	br label %header
+
+exit:
+	ret void
+}
+
+define void @irreducible(i1 %cond) {
+entry:
+	br i1 %cond, label %a, label %b
+
+a:
+	br i1 %cond, label %b, label %exit
+
+b:
+	br label %a
+
+exit:
+	ret void
+}
+
+define void @unreachable(i32 %x) {
+entry:
+	switch i32 %x, label %exit [
+		i32 0, label %exit
+		i32 1, label %exit
+	]
+
+dead:
+	br label %exit
+
+dead_loop:
+	br label %dead_loop
+
+exit:
+	ret void
+}
+
+define void @infinite(i1 %cond) {
+entry:
+	br i1 %cond, label %loop, label %exit
+
+loop:
+	br label %loop
+
+exit:
+	unreachable
+}
+
+define void @multi_exit(i1 %cond) {
+entry:
+	br i1 %cond, label %a, label %b
+
+a:
+	ret void
+
+b:
+	br label %c
+
+c:
+	unreachable
+}
+
+declare void @decl()
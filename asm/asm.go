@@ -15,44 +15,83 @@ import (
 
 // ParseFile parses the given LLVM IR assembly file into an LLVM IR module.
 func ParseFile(path string) (*ir.Module, error) {
+	return (&Config{}).ParseFile(path)
+}
+
+// Parse parses the given LLVM IR assembly file into an LLVM IR module, reading
+// from r.
+func Parse(r io.Reader) (*ir.Module, error) {
+	return (&Config{}).Parse(r)
+}
+
+// ParseBytes parses the given LLVM IR assembly file into an LLVM IR module,
+// reading from b.
+func ParseBytes(b []byte) (*ir.Module, error) {
+	return (&Config{}).ParseBytes(b)
+}
+
+// ParseString parses the given LLVM IR assembly file into an LLVM IR module,
+// reading from s.
+func ParseString(s string) (*ir.Module, error) {
+	return (&Config{}).ParseString(s)
+}
+
+// A Config specifies the configuration of the LLVM IR assembly parser. The zero
+// value is ready to use.
+type Config struct {
+	// Positions specifies whether to record the source positions of global
+	// variables, functions, basic blocks and instructions in the parsed LLVM IR
+	// module.
+	Positions bool
+}
+
+// ParseFile parses the given LLVM IR assembly file into an LLVM IR module.
+func (cfg *Config) ParseFile(path string) (*ir.Module, error) {
 	buf, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return ParseBytes(buf)
+	return cfg.parse(path, buf)
 }
 
 // Parse parses the given LLVM IR assembly file into an LLVM IR module, reading
 // from r.
-func Parse(r io.Reader) (*ir.Module, error) {
+func (cfg *Config) Parse(r io.Reader) (*ir.Module, error) {
 	buf, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return ParseBytes(buf)
+	return cfg.parse("", buf)
 }
 
 // ParseBytes parses the given LLVM IR assembly file into an LLVM IR module,
 // reading from b.
-func ParseBytes(b []byte) (*ir.Module, error) {
+func (cfg *Config) ParseBytes(b []byte) (*ir.Module, error) {
+	return cfg.parse("", b)
+}
+
+// ParseString parses the given LLVM IR assembly file into an LLVM IR module,
+// reading from s.
+func (cfg *Config) ParseString(s string) (*ir.Module, error) {
+	return cfg.parse("", []byte(s))
+}
+
+// parse parses the given LLVM IR assembly file into an LLVM IR module, reading
+// from b. The file name is used to report source positions, and may be empty.
+// Syntax and semantic errors of the assembly are reported as an ErrorList.
+func (cfg *Config) parse(filename string, b []byte) (*ir.Module, error) {
 	module, err := parseBytes(b)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, newErrorList(filename, err)
 	}
 	// Translate the AST of the module to an equivalent LLVM IR module.
-	m, err := irx.Translate(module)
+	m, err := irx.Translate(module, filename, cfg.Positions)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, newErrorList(filename, err)
 	}
 	return m, nil
 }
 
-// ParseString parses the given LLVM IR assembly file into an LLVM IR module,
-// reading from s.
-func ParseString(s string) (*ir.Module, error) {
-	return ParseBytes([]byte(s))
-}
-
 // parseBytes parses the given LLVM IR assembly file into an AST, reading from
 // b.
 func parseBytes(b []byte) (*ast.Module, error) {
@@ -0,0 +1,201 @@
+package asm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/llir/llvm/asm"
+)
+
+func TestPositions(t *testing.T) {
+	cfg := &asm.Config{Positions: true}
+	m, err := cfg.ParseFile("testdata/rand.ll")
+	if err != nil {
+		t.Fatalf("unable to parse file; %v", err)
+	}
+	if got, want := m.Globals[0].Pos.String(), "testdata/rand.ll:1:1"; got != want {
+		t.Errorf("global position mismatch; expected %q, got %q", want, got)
+	}
+	if got, want := m.Funcs[0].Pos.String(), "testdata/rand.ll:3:13"; got != want {
+		t.Errorf("function position mismatch; expected %q, got %q", want, got)
+	}
+	f := m.Funcs[1]
+	block := f.Blocks[0]
+	if got, want := block.Pos.String(), "testdata/rand.ll:7:7"; got != want {
+		t.Errorf("basic block position mismatch; expected %q, got %q", want, got)
+	}
+	golden := []string{
+		"testdata/rand.ll:7:7",
+		"testdata/rand.ll:8:7",
+		"testdata/rand.ll:9:7",
+		"testdata/rand.ll:10:2",
+		"testdata/rand.ll:11:7",
+	}
+	for i, inst := range block.Insts {
+		if got, want := inst.GetPos().String(), golden[i]; got != want {
+			t.Errorf("instruction %d position mismatch; expected %q, got %q", i, want, got)
+		}
+	}
+	if got, want := block.Term.GetPos().String(), "testdata/rand.ll:12:2"; got != want {
+		t.Errorf("terminator position mismatch; expected %q, got %q", want, got)
+	}
+
+	// Source positions are not recorded by default.
+	m, err = asm.ParseFile("testdata/rand.ll")
+	if err != nil {
+		t.Fatalf("unable to parse file; %v", err)
+	}
+	if pos := m.Funcs[1].Blocks[0].Insts[0].GetPos(); pos.IsValid() {
+		t.Errorf("unexpected instruction position %v", pos)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	golden := []struct {
+		input string
+		want  string
+	}{
+		// Syntax errors.
+		{
+			input: "@x = global i32 0\nglobal i32 1\n",
+			want:  `2:1: syntax error: unexpected "global"; expected one of`,
+		},
+		{
+			input: "define void @f() {\n\tret void\n",
+			want:  "3:1: syntax error: unexpected end of file; expected",
+		},
+		{
+			input: "define void @f() {\n\tret void 42\n}\n",
+			want:  `2:11: syntax error: unexpected integer literal "42"; expected`,
+		},
+		// Resolution errors.
+		{
+			input: "@x = global i32 0\n@y = global i32* @z\n",
+			want:  `2:18: unable to locate global identifier "z"`,
+		},
+		{
+			input: "@x = global i32 0\n@x = global i32 1\n",
+			want:  `2:1: global identifier "x" already present; previously defined at 1:1`,
+		},
+		{
+			input: "define i32 @f() {\n\t%x = add i32 1, 2\n\tret i32 %y\n}\n",
+			want:  `3:10: unable to locate local identifier "y"`,
+		},
+		{
+			input: "%t = type i32\n@x = global %u 0\n",
+			want:  `2:13: unable to locate type name "u"`,
+		},
+		{
+			input: "$c = comdat any\n@x = global i32 0, comdat\n",
+			want:  `2:20: unable to locate comdat name "$x"`,
+		},
+		{
+			input: "$c = comdat any\n$c = comdat largest\n",
+			want:  `2:1: comdat name "$c" already present; previously defined at 1:1`,
+		},
+		{
+			input: "declare void @f() #1\nattributes #0 = { nounwind }\n",
+			want:  `1:19: unable to locate attribute group ID "#1"`,
+		},
+		{
+			input: "attributes #0 = { nounwind }\nattributes #0 = { cold }\n",
+			want:  `2:12: attribute group ID "#0" already present; previously defined at 1:12`,
+		},
+		{
+			input: "define void @f() {\n\t%2 = add i32 1, 2\n\tret void\n}\n",
+			want:  `2:7: invalid local ID in function @f; expected %1, got %2`,
+		},
+		{
+			input: "declare cc 1234 void @f()\n",
+			want:  `1:12: support for calling convention ID 1234 not yet implemented`,
+		},
+		// Translation errors.
+		{
+			input: "define void @f() {\n\tret void\n}\n@x = global [2 x i32] [i32 1, i32 2, i32 3]\n",
+			want:  "4:1: array type mismatch",
+		},
+		{
+			input: "@x = global [2 x i32] zeroinitializer\n@y = global i32* getelementptr ([2 x i32], [2 x i32]* @x, i32 0, i32 1, i32 0)\n",
+			want:  "2:1: support for indexing element type *types.IntType not yet implemented",
+		},
+		{
+			input: "@x = global i32 true\n",
+			want:  `1:1: invalid integer constant "true" for type i32`,
+		},
+		{
+			input: "%t = type { i32 }\n@x = global %t zeroinitializer\n@y = global i32* getelementptr (%t, %t* @x, i32 0, i32 1)\n",
+			want:  "3:1: invalid index (1); exceeds struct field count (1)",
+		},
+		{
+			input: "define i32 @f({ i32 } %s) {\n\t%x = extractvalue { i32 } %s, 1\n\tret i32 %x\n}\n",
+			want:  "2:7: invalid index (1); exceeds struct field count (1)",
+		},
+		{
+			input: "@x = global i32 0\ndefine void @f() {\n\tcall void @x()\n\tret void\n}\n",
+			want:  "3:2: invalid callee signature type, expected *types.FuncType, got *types.IntType",
+		},
+		{
+			input: "define void @f() {\n\t%x = add i32 1, 2\n\tbr label %x\n}\n",
+			want:  "3:2: invalid target branch type, expected *ir.BasicBlock, got *ir.InstAdd",
+		},
+	}
+	for _, g := range golden {
+		_, err := asm.ParseString(g.input)
+		if err == nil {
+			t.Errorf("%q: expected error, got nil", g.input)
+			continue
+		}
+		if _, ok := err.(asm.ErrorList); !ok {
+			t.Errorf("%q: invalid error type; expected asm.ErrorList, got %T", g.input, err)
+		}
+		if got := err.Error(); !strings.HasPrefix(got, g.want) {
+			t.Errorf("%q: error mismatch; expected prefix %q, got %q", g.input, g.want, got)
+		}
+	}
+}
+
+func TestErrorFilename(t *testing.T) {
+	_, err := asm.ParseFile("testdata/invalid.ll")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	want := `testdata/invalid.ll:4:18: syntax error: unexpected local identifier "%x"`
+	if got := err.Error(); !strings.HasPrefix(got, want) {
+		t.Errorf("error mismatch; expected prefix %q, got %q", want, got)
+	}
+}
+
+func TestErrorList(t *testing.T) {
+	const input = `
+@x = global i32* @y
+@x = global i32 1
+
+define i32 @f() {
+	ret i32 %z
+}
+
+%t = type %u
+`
+	_, err := asm.ParseString(input)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	es, ok := err.(asm.ErrorList)
+	if !ok {
+		t.Fatalf("invalid error type; expected asm.ErrorList, got %T", err)
+	}
+	want := []string{
+		`2:18: unable to locate global identifier "y"`,
+		`3:1: global identifier "x" already present; previously defined at 2:1`,
+		`6:10: unable to locate local identifier "z"`,
+		`9:11: unable to locate type name "u"`,
+	}
+	if len(es) != len(want) {
+		t.Fatalf("number of errors mismatch; expected %d, got %d: %v", len(want), len(es), es)
+	}
+	for i, e := range es {
+		if got := e.Error(); got != want[i] {
+			t.Errorf("error %d mismatch; expected %q, got %q", i, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package asm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/llir/llvm/asm/internal/ast"
+	parseErrors "github.com/llir/llvm/asm/internal/errors"
+	"github.com/llir/llvm/asm/internal/token"
+	"github.com/llir/llvm/ir"
+	"github.com/pkg/errors"
+)
+
+// An Error represents a syntax or semantic error encountered while parsing LLVM
+// IR assembly.
+type Error struct {
+	// Source position of the error.
+	Pos ir.Position
+	// Error message.
+	Msg string
+}
+
+// Error returns the error message, prefixed by its source position.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %s", e.Pos, e.Msg)
+}
+
+// An ErrorList is a list of errors encountered while parsing LLVM IR assembly,
+// sorted by source position.
+type ErrorList []*Error
+
+// Error returns the error messages of the list, separated by newlines.
+func (es ErrorList) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// newErrorList returns a new error list based on the given parse or
+// translation error, reporting source positions within the given file.
+func newErrorList(filename string, err error) ErrorList {
+	var es ErrorList
+	var add func(pos token.Pos, err error)
+	add = func(pos token.Pos, err error) {
+		switch e := errors.Cause(err).(type) {
+		case *parseErrors.Error:
+			if e.Err != nil {
+				// Error returned by a semantic action of the parser.
+				add(e.ErrorToken.Pos, e.Err)
+				return
+			}
+			msg := fmt.Sprintf("syntax error: unexpected %s; expected %s", tokenDesc(e.ErrorToken), expectedDesc(e.ExpectedTokens))
+			es = append(es, &Error{Pos: irPos(filename, e.ErrorToken.Pos), Msg: msg})
+		case ast.ErrorList:
+			for _, err := range e {
+				add(pos, err)
+			}
+		case *ast.Error:
+			es = append(es, &Error{Pos: irPos(filename, e.Pos), Msg: e.Msg})
+		default:
+			es = append(es, &Error{Pos: irPos(filename, pos), Msg: e.Error()})
+		}
+	}
+	add(token.Pos{}, err)
+	sort.SliceStable(es, func(i, j int) bool {
+		return es[i].Pos.Offset < es[j].Pos.Offset
+	})
+	return es
+}
+
+// irPos returns the LLVM IR source position of the given token position within
+// the given file.
+func irPos(filename string, pos token.Pos) ir.Position {
+	return ir.Position{
+		Filename: filename,
+		Offset:   pos.Offset,
+		Line:     pos.Line,
+		Column:   pos.Column,
+	}
+}
+
+// tokenClasses maps from token identifiers of token classes to their
+// human-readable descriptions.
+var tokenClasses = map[string]string{
+	"$":             "end of file",
+	"INVALID":       "invalid token",
+	"global_ident":  "global identifier",
+	"local_ident":   "local identifier",
+	"label_ident":   "label",
+	"attr_group_id": "attribute group ID",
+	"comdat_name":   "comdat name",
+	"metadata_name": "metadata name",
+	"metadata_id":   "metadata ID",
+	"int_lit":       "integer literal",
+	"float_lit":     "floating-point literal",
+	"string_lit":    "string literal",
+	"int_type":      "integer type",
+}
+
+// tokenDesc returns a human-readable description of the given token.
+func tokenDesc(tok *token.Token) string {
+	id := token.TokMap.Id(tok.Type)
+	desc, ok := tokenClasses[id]
+	if !ok {
+		return fmt.Sprintf("%q", tok.Lit)
+	}
+	if tok.Type == token.EOF {
+		return desc
+	}
+	return fmt.Sprintf("%s %q", desc, tok.Lit)
+}
+
+// maxExpected specifies the maximum number of expected tokens to list in
+// syntax errors.
+const maxExpected = 10
+
+// expectedDesc returns a human-readable description of the given expected
+// tokens.
+func expectedDesc(ids []string) string {
+	var descs []string
+	for _, id := range ids {
+		switch id {
+		case "error", "empty", "INVALID":
+			// Skip pseudo-tokens of the parser.
+			continue
+		}
+		if desc, ok := tokenClasses[id]; ok {
+			descs = append(descs, desc)
+		} else {
+			descs = append(descs, fmt.Sprintf("%q", id))
+		}
+	}
+	switch n := len(descs); {
+	case n == 0:
+		return "nothing"
+	case n == 1:
+		return descs[0]
+	case n == 2:
+		return descs[0] + " or " + descs[1]
+	case n > maxExpected:
+		return fmt.Sprintf("one of %s, or %d other tokens", strings.Join(descs[:maxExpected], ", "), n-maxExpected)
+	default:
+		return fmt.Sprintf("one of %s or %s", strings.Join(descs[:n-1], ", "), descs[n-1])
+	}
+}
@@ -16,12 +16,14 @@ func Example() {
 	// Pretty-print the data types of the parsed LLVM IR module.
 	pretty.Println(m)
 	// Output:
-	//
 	// &ir.Module{
-	//     DataLayout:   "",
-	//     TargetTriple: "",
-	//     Types:        nil,
-	//     Globals:      {
+	//     SourceFilename: "",
+	//     DataLayout:     "",
+	//     TargetTriple:   "",
+	//     ModuleAsms:     nil,
+	//     Types:          nil,
+	//     Comdats:        nil,
+	//     Globals:        {
 	//         &ir.Global{
 	//             Name: "seed",
 	//             Typ:  &types.PointerType{
@@ -34,12 +36,24 @@ func Example() {
 	//                 Typ: &types.IntType{(CYCLIC REFERENCE)},
 	//                 X:   &big.Int{},
 	//             },
-	//             IsConst:  false,
-	//             Metadata: {
+	//             IsConst:               false,
+	//             Linkage:               0x0,
+	//             Visibility:            0x0,
+	//             DLLStorageClass:       0x0,
+	//             TLSModel:              0x0,
+	//             UnnamedAddr:           0x0,
+	//             ExternallyInitialized: false,
+	//             Section:               "",
+	//             Comdat:                (*ir.Comdat)(nil),
+	//             Align:                 0,
+	//             Metadata:              {
 	//             },
+	//             Pos: ir.Position{},
 	//         },
 	//     },
-	//     Funcs: {
+	//     Aliases: nil,
+	//     IFuncs:  nil,
+	//     Funcs:   {
 	//         &ir.Function{
 	//             Parent: &ir.Module{(CYCLIC REFERENCE)},
 	//             Name:   "abs",
@@ -50,8 +64,9 @@ func Example() {
 	//                     Ret:    &types.IntType{Name:"", Size:32},
 	//                     Params: {
 	//                         &types.Param{
-	//                             Name: "x",
-	//                             Typ:  &types.IntType{Name:"", Size:32},
+	//                             Name:  "x",
+	//                             Typ:   &types.IntType{Name:"", Size:32},
+	//                             Attrs: nil,
 	//                         },
 	//                     },
 	//                     Variadic: false,
@@ -63,17 +78,29 @@ func Example() {
 	//                 Ret:    &types.IntType{Name:"", Size:32},
 	//                 Params: {
 	//                     &types.Param{
-	//                         Name: "x",
-	//                         Typ:  &types.IntType{Name:"", Size:32},
+	//                         Name:  "x",
+	//                         Typ:   &types.IntType{Name:"", Size:32},
+	//                         Attrs: nil,
 	//                     },
 	//                 },
 	//                 Variadic: false,
 	//             },
-	//             CallConv: 0x0,
-	//             Blocks:   nil,
-	//             Metadata: {
+	//             Linkage:         0x0,
+	//             Visibility:      0x0,
+	//             DLLStorageClass: 0x0,
+	//             CallConv:        0x0,
+	//             RetAttrs:        nil,
+	//             UnnamedAddr:     0x0,
+	//             FuncAttrs:       nil,
+	//             Section:         "",
+	//             Comdat:          (*ir.Comdat)(nil),
+	//             Align:           0,
+	//             Personality:     nil,
+	//             Blocks:          nil,
+	//             Metadata:        {
 	//             },
-	//             mu: sync.Mutex{},
+	//             Pos: ir.Position{},
+	//             mu:  sync.Mutex{},
 	//         },
 	//         &ir.Function{
 	//             Parent: &ir.Module{(CYCLIC REFERENCE)},
@@ -96,19 +123,34 @@ func Example() {
 	//                 },
 	//                 Variadic: false,
 	//             },
-	//             CallConv: 0x0,
-	//             Blocks:   {
+	//             Linkage:         0x0,
+	//             Visibility:      0x0,
+	//             DLLStorageClass: 0x0,
+	//             CallConv:        0x0,
+	//             RetAttrs:        nil,
+	//             UnnamedAddr:     0x0,
+	//             FuncAttrs:       nil,
+	//             Section:         "",
+	//             Comdat:          (*ir.Comdat)(nil),
+	//             Align:           0,
+	//             Personality:     nil,
+	//             Blocks:          {
 	//                 &ir.BasicBlock{
 	//                     Parent: &ir.Function{(CYCLIC REFERENCE)},
 	//                     Name:   "0",
 	//                     Insts:  {
 	//                         &ir.InstLoad{
-	//                             Parent:   &ir.BasicBlock{(CYCLIC REFERENCE)},
-	//                             Name:     "1",
-	//                             Typ:      &types.IntType{(CYCLIC REFERENCE)},
-	//                             Src:      &ir.Global{(CYCLIC REFERENCE)},
-	//                             Metadata: {
+	//                             Parent:    &ir.BasicBlock{(CYCLIC REFERENCE)},
+	//                             Name:      "1",
+	//                             Typ:       &types.IntType{(CYCLIC REFERENCE)},
+	//                             Src:       &ir.Global{(CYCLIC REFERENCE)},
+	//                             Ordering:  0x0,
+	//                             SyncScope: "",
+	//                             Volatile:  false,
+	//                             Align:     0,
+	//                             Metadata:  {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                         &ir.InstMul{
 	//                             Parent: &ir.BasicBlock{(CYCLIC REFERENCE)},
@@ -121,8 +163,10 @@ func Example() {
 	//                                     abs: {0x15a4e35},
 	//                                 },
 	//                             },
-	//                             Metadata: {
+	//                             OverflowFlags: nil,
+	//                             Metadata:      {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                         &ir.InstAdd{
 	//                             Parent: &ir.BasicBlock{(CYCLIC REFERENCE)},
@@ -135,15 +179,22 @@ func Example() {
 	//                                     abs: {0x1},
 	//                                 },
 	//                             },
-	//                             Metadata: {
+	//                             OverflowFlags: nil,
+	//                             Metadata:      {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                         &ir.InstStore{
-	//                             Parent:   &ir.BasicBlock{(CYCLIC REFERENCE)},
-	//                             Src:      &ir.InstAdd{(CYCLIC REFERENCE)},
-	//                             Dst:      &ir.Global{(CYCLIC REFERENCE)},
-	//                             Metadata: {
+	//                             Parent:    &ir.BasicBlock{(CYCLIC REFERENCE)},
+	//                             Src:       &ir.InstAdd{(CYCLIC REFERENCE)},
+	//                             Dst:       &ir.Global{(CYCLIC REFERENCE)},
+	//                             Ordering:  0x0,
+	//                             SyncScope: "",
+	//                             Volatile:  false,
+	//                             Align:     0,
+	//                             Metadata:  {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                         &ir.InstCall{
 	//                             Parent: &ir.BasicBlock{(CYCLIC REFERENCE)},
@@ -153,9 +204,15 @@ func Example() {
 	//                             Args:   {
 	//                                 &ir.InstAdd{(CYCLIC REFERENCE)},
 	//                             },
-	//                             CallConv: 0x0,
-	//                             Metadata: {
+	//                             CallConv:       0x0,
+	//                             Tail:           0x0,
+	//                             FastMathFlags:  nil,
+	//                             RetAttrs:       nil,
+	//                             FuncAttrs:      nil,
+	//                             OperandBundles: nil,
+	//                             Metadata:       {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                     },
 	//                     Term: &ir.TermRet{
@@ -168,20 +225,30 @@ func Example() {
 	//                             Args:   {
 	//                                 &ir.InstAdd{(CYCLIC REFERENCE)},
 	//                             },
-	//                             CallConv: 0x0,
-	//                             Metadata: {
+	//                             CallConv:       0x0,
+	//                             Tail:           0x0,
+	//                             FastMathFlags:  nil,
+	//                             RetAttrs:       nil,
+	//                             FuncAttrs:      nil,
+	//                             OperandBundles: nil,
+	//                             Metadata:       {
 	//                             },
+	//                             Pos: ir.Position{},
 	//                         },
 	//                         Metadata: {
 	//                         },
+	//                         Pos: ir.Position{},
 	//                     },
+	//                     Pos: ir.Position{},
 	//                 },
 	//             },
 	//             Metadata: {
 	//             },
-	//             mu: sync.Mutex{},
+	//             Pos: ir.Position{},
+	//             mu:  sync.Mutex{},
 	//         },
 	//     },
+	//     AttrGroups:    nil,
 	//     NamedMetadata: nil,
 	//     Metadata:      nil,
 	// }
@@ -0,0 +1,42 @@
+package ast
+
+import "github.com/llir/llvm/asm/internal/token"
+
+// An Alias represents an LLVM IR alias.
+type Alias struct {
+	// Alias name.
+	Name string
+	// Content type.
+	Content Type
+	// Aliasee.
+	Aliasee Constant
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
+	// Thread local storage model.
+	TLSModel TLSModel
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
+	// Source position of the alias name; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetName returns the name of the value.
+func (alias *Alias) GetName() string {
+	return alias.Name
+}
+
+// SetName sets the name of the value.
+func (alias *Alias) SetName(name string) {
+	alias.Name = name
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*Alias) isValue() {}
+
+// isConstant ensures that only constants can be assigned to the ast.Constant
+// interface.
+func (*Alias) isConstant() {}
@@ -15,9 +15,13 @@ var (
 	_ ast.Constant = &ast.StructConst{}
 	_ ast.Constant = &ast.ZeroInitializerConst{}
 	_ ast.Constant = &ast.UndefConst{}
+	// Addresses of basic blocks
+	_ ast.Constant = &ast.BlockAddressConst{}
 	// Global variable and function addresses
 	_ ast.Constant = &ast.Global{}
 	_ ast.Constant = &ast.Function{}
+	_ ast.Constant = &ast.Alias{}
+	_ ast.Constant = &ast.IFunc{}
 )
 
 // Validate that the relevant types satisfy the ast.Constant interface.
@@ -134,6 +138,7 @@ var (
 	_ ast.Terminator = &ast.TermBr{}
 	_ ast.Terminator = &ast.TermCondBr{}
 	_ ast.Terminator = &ast.TermSwitch{}
+	_ ast.Terminator = &ast.TermIndirectBr{}
 	_ ast.Terminator = &ast.TermUnreachable{}
 )
 
@@ -142,6 +147,8 @@ var (
 	_ ast.NamedValue = &ast.Global{}
 	_ ast.NamedValue = &ast.GlobalDummy{}
 	_ ast.NamedValue = &ast.Function{}
+	_ ast.NamedValue = &ast.Alias{}
+	_ ast.NamedValue = &ast.IFunc{}
 	_ ast.NamedValue = &ast.Param{}
 	_ ast.NamedValue = &ast.BasicBlock{}
 	_ ast.NamedValue = &ast.LocalDummy{}
@@ -19,6 +19,7 @@ func TestRoundTrip(t *testing.T) {
 		// Top-level declarations.
 		{path: "../../../testdata/module.ll"},
 		{path: "../../../testdata/global.ll"},
+		{path: "../../../testdata/alias.ll"},
 		{path: "../../../testdata/func.ll"},
 		{path: "../../../testdata/metadata.ll"},
 		// Types.
@@ -39,6 +40,7 @@ func TestRoundTrip(t *testing.T) {
 		{path: "../../../testdata/inst_vector.ll"},
 		{path: "../../../testdata/inst_aggregate.ll"},
 		{path: "../../../testdata/inst_memory.ll"},
+		{path: "../../../testdata/inst_memory_addrspace.ll"},
 		{path: "../../../testdata/inst_conversion.ll"},
 		{path: "../../../testdata/inst_other.ll"},
 		// Terminators.
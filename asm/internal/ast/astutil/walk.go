@@ -75,7 +75,7 @@ type walker struct {
 // traversal.
 func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{})) {
 	switch x.(type) {
-	case []*ast.Global, []*ast.Function, []*ast.Param, []*ast.NamedMetadata, []*ast.Metadata, []ast.MetadataNode, []*ast.AttachedMD, []ast.Type, []*ast.NamedType, []ast.Value, []ast.Constant, []*ast.BasicBlock, []ast.Instruction, []*ast.Incoming, []*ast.Case:
+	case []*ast.Global, []*ast.Alias, []*ast.IFunc, []*ast.Function, []*ast.Param, []*ast.NamedMetadata, []*ast.Metadata, []ast.MetadataNode, []*ast.AttachedMD, []ast.Type, []*ast.NamedType, []ast.Value, []ast.NamedValue, []ast.Constant, []*ast.BasicBlock, []ast.Instruction, []*ast.Incoming, []*ast.Clause, []*ast.Case, []*ast.OperandBundle:
 		// unhashable type.
 	case *ast.Function:
 		if w.funcScope {
@@ -116,6 +116,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	// pointers to struct pointers
 	case **ast.Global:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.Alias:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.IFunc:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.Function:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.Param:
@@ -149,10 +153,14 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.VectorType:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.MMXType:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.LabelType:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.MetadataType:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.TokenType:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.ArrayType:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.StructType:
@@ -168,6 +176,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.NullConst:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.NoneConst:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.VectorConst:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.ArrayConst:
@@ -180,6 +190,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.UndefConst:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.BlockAddressConst:
+		w.walkBeforeAfter(*n, before, after)
 	// Constant expressions
 	case **ast.ExprAdd:
 		w.walkBeforeAfter(*n, before, after)
@@ -229,6 +241,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.ExprGetElementPtr:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.Index:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.ExprTrunc:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.ExprZExt:
@@ -317,6 +331,12 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.InstStore:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstFence:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstCmpXchg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstAtomicRMW:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.InstGetElementPtr:
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.InstTrunc:
@@ -357,6 +377,20 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.InstCall:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.Arg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.OperandBundle:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstVAArg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstLandingPad:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.Clause:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstCatchPad:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.InstCleanupPad:
+		w.walkBeforeAfter(*n, before, after)
 	// Terminators
 	case **ast.TermRet:
 		w.walkBeforeAfter(*n, before, after)
@@ -368,8 +402,23 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ast.Case:
 		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermIndirectBr:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermInvoke:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermResume:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermCatchSwitch:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermCatchRet:
+		w.walkBeforeAfter(*n, before, after)
+	case **ast.TermCleanupRet:
+		w.walkBeforeAfter(*n, before, after)
 	case **ast.TermUnreachable:
 		w.walkBeforeAfter(*n, before, after)
+	// Inline assembler expressions
+	case **ast.InlineAsm:
+		w.walkBeforeAfter(*n, before, after)
 
 	// pointers to slices
 	case *[]*ast.NamedMetadata:
@@ -386,8 +435,14 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case *[]*ast.Global:
 		w.walkBeforeAfter(*n, before, after)
+	case *[]*ast.Alias:
+		w.walkBeforeAfter(*n, before, after)
+	case *[]*ast.IFunc:
+		w.walkBeforeAfter(*n, before, after)
 	case *[]ast.Value:
 		w.walkBeforeAfter(*n, before, after)
+	case *[]ast.NamedValue:
+		w.walkBeforeAfter(*n, before, after)
 	case *[]ast.Constant:
 		w.walkBeforeAfter(*n, before, after)
 	case *[]*ast.Function:
@@ -402,6 +457,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case *[]*ast.Incoming:
 		w.walkBeforeAfter(*n, before, after)
+	case *[]*ast.Clause:
+		w.walkBeforeAfter(*n, before, after)
+	case *[]*ast.OperandBundle:
+		w.walkBeforeAfter(*n, before, after)
 
 	// These are ordered and grouped to match ../../ll.bnf
 	case *ast.Module:
@@ -411,6 +470,12 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Globals != nil {
 			w.walkBeforeAfter(&n.Globals, before, after)
 		}
+		if n.Aliases != nil {
+			w.walkBeforeAfter(&n.Aliases, before, after)
+		}
+		if n.IFuncs != nil {
+			w.walkBeforeAfter(&n.IFuncs, before, after)
+		}
 		if n.Funcs != nil {
 			w.walkBeforeAfter(&n.Funcs, before, after)
 		}
@@ -432,12 +497,29 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Metadata != nil {
 			w.walkBeforeAfter(&n.Metadata, before, after)
 		}
+	case []*ast.Alias:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ast.Alias:
+		w.walkBeforeAfter(&n.Content, before, after)
+		w.walkBeforeAfter(&n.Aliasee, before, after)
+	case []*ast.IFunc:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ast.IFunc:
+		w.walkBeforeAfter(&n.Content, before, after)
+		w.walkBeforeAfter(&n.Resolver, before, after)
 	case []*ast.Function:
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
 		}
 	case *ast.Function:
 		w.walkBeforeAfter(&n.Sig, before, after)
+		if n.Personality != nil {
+			w.walkBeforeAfter(&n.Personality, before, after)
+		}
 		if n.Blocks != nil {
 			w.walkBeforeAfter(&n.Blocks, before, after)
 		}
@@ -504,10 +586,14 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Elem, before, after)
 	case *ast.VectorType:
 		w.walkBeforeAfter(&n.Elem, before, after)
+	case *ast.MMXType:
+		// nothing to do.
 	case *ast.LabelType:
 		// nothing to do.
 	case *ast.MetadataType:
 		// nothing to do.
+	case *ast.TokenType:
+		// nothing to do.
 	case *ast.ArrayType:
 		w.walkBeforeAfter(&n.Elem, before, after)
 	case *ast.StructType:
@@ -529,6 +615,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
 		}
+	case []ast.NamedValue:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
 	case []ast.Constant:
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
@@ -539,6 +629,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Type, before, after)
 	case *ast.NullConst:
 		w.walkBeforeAfter(&n.Type, before, after)
+	case *ast.NoneConst:
+		// nothing to do.
 	case *ast.VectorConst:
 		w.walkBeforeAfter(&n.Type, before, after)
 		if n.Elems != nil {
@@ -560,6 +652,11 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Type, before, after)
 	case *ast.UndefConst:
 		w.walkBeforeAfter(&n.Type, before, after)
+	case *ast.BlockAddressConst:
+		// Block is not traversed, as it may reside outside of the scope of the
+		// function currently being traversed.
+		w.walkBeforeAfter(&n.Type, before, after)
+		w.walkBeforeAfter(&n.Func, before, after)
 	// Constant expressions
 	case *ast.ExprAdd:
 		w.walkBeforeAfter(&n.Type, before, after)
@@ -661,6 +758,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Indices != nil {
 			w.walkBeforeAfter(&n.Indices, before, after)
 		}
+	case *ast.Index:
+		w.walkBeforeAfter(&n.Constant, before, after)
 	case *ast.ExprTrunc:
 		w.walkBeforeAfter(&n.Type, before, after)
 		w.walkBeforeAfter(&n.From, before, after)
@@ -744,140 +843,286 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	case *ast.InstAdd:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFAdd:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstSub:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFSub:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstMul:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFMul:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstUDiv:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstSDiv:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFDiv:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstURem:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstSRem:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFRem:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstShl:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstLShr:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstAShr:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstAnd:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstOr:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstXor:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstExtractElement:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Index, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstInsertElement:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Elem, before, after)
 		w.walkBeforeAfter(&n.Index, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstShuffleVector:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
 		w.walkBeforeAfter(&n.Mask, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstExtractValue:
 		w.walkBeforeAfter(&n.X, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstInsertValue:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Elem, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstAlloca:
 		w.walkBeforeAfter(&n.Elem, before, after)
 		if n.NElems != nil {
 			w.walkBeforeAfter(&n.NElems, before, after)
 		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstLoad:
 		w.walkBeforeAfter(&n.Elem, before, after)
 		w.walkBeforeAfter(&n.Src, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstStore:
 		w.walkBeforeAfter(&n.Src, before, after)
 		w.walkBeforeAfter(&n.Dst, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.InstFence:
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.InstCmpXchg:
+		w.walkBeforeAfter(&n.Ptr, before, after)
+		w.walkBeforeAfter(&n.Cmp, before, after)
+		w.walkBeforeAfter(&n.New, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.InstAtomicRMW:
+		w.walkBeforeAfter(&n.Dst, before, after)
+		w.walkBeforeAfter(&n.X, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstGetElementPtr:
 		w.walkBeforeAfter(&n.Elem, before, after)
 		w.walkBeforeAfter(&n.Src, before, after)
 		if n.Indices != nil {
 			w.walkBeforeAfter(&n.Indices, before, after)
 		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstTrunc:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstZExt:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstSExt:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFPTrunc:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFPExt:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFPToUI:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFPToSI:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstUIToFP:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstSIToFP:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstPtrToInt:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstIntToPtr:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstBitCast:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstAddrSpaceCast:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstICmp:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstFCmp:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstPhi:
 		w.walkBeforeAfter(&n.Type, before, after)
 		if n.Incs != nil {
 			w.walkBeforeAfter(&n.Incs, before, after)
 		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case []*ast.Incoming:
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
@@ -889,29 +1134,96 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Cond, before, after)
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Y, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.InstCall:
 		w.walkBeforeAfter(&n.Type, before, after)
 		w.walkBeforeAfter(&n.Callee, before, after)
 		if n.Args != nil {
 			w.walkBeforeAfter(&n.Args, before, after)
 		}
+		if n.OperandBundles != nil {
+			w.walkBeforeAfter(&n.OperandBundles, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.Arg:
+		w.walkBeforeAfter(&n.Value, before, after)
+	case []*ast.OperandBundle:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ast.OperandBundle:
+		if n.Inputs != nil {
+			w.walkBeforeAfter(&n.Inputs, before, after)
+		}
+	case *ast.InstVAArg:
+		w.walkBeforeAfter(&n.ArgList, before, after)
+		w.walkBeforeAfter(&n.ArgType, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.InstLandingPad:
+		w.walkBeforeAfter(&n.Type, before, after)
+		if n.Clauses != nil {
+			w.walkBeforeAfter(&n.Clauses, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case []*ast.Clause:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ast.Clause:
+		w.walkBeforeAfter(&n.X, before, after)
+	case *ast.InstCatchPad:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.InstCleanupPad:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	// Terminators
 	case *ast.TermRet:
 		if n.X != nil {
 			w.walkBeforeAfter(&n.X, before, after)
 		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.TermBr:
 		w.walkBeforeAfter(&n.Target, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.TermCondBr:
 		w.walkBeforeAfter(&n.Cond, before, after)
 		w.walkBeforeAfter(&n.TargetTrue, before, after)
 		w.walkBeforeAfter(&n.TargetFalse, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.TermSwitch:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.TargetDefault, before, after)
 		if n.Cases != nil {
 			w.walkBeforeAfter(&n.Cases, before, after)
 		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case []*ast.Case:
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
@@ -919,8 +1231,63 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	case *ast.Case:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Target, before, after)
+	case *ast.TermIndirectBr:
+		w.walkBeforeAfter(&n.Addr, before, after)
+		if n.ValidTargets != nil {
+			w.walkBeforeAfter(&n.ValidTargets, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.TermInvoke:
+		w.walkBeforeAfter(&n.Type, before, after)
+		w.walkBeforeAfter(&n.Callee, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
+		w.walkBeforeAfter(&n.Normal, before, after)
+		w.walkBeforeAfter(&n.Exception, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.TermResume:
+		w.walkBeforeAfter(&n.X, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.TermCatchSwitch:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Handlers != nil {
+			w.walkBeforeAfter(&n.Handlers, before, after)
+		}
+		if n.Unwind != nil {
+			w.walkBeforeAfter(&n.Unwind, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.TermCatchRet:
+		w.walkBeforeAfter(&n.From, before, after)
+		w.walkBeforeAfter(&n.To, before, after)
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+	case *ast.TermCleanupRet:
+		w.walkBeforeAfter(&n.From, before, after)
+		if n.Unwind != nil {
+			w.walkBeforeAfter(&n.Unwind, before, after)
+		}
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
 	case *ast.TermUnreachable:
-		// nothing to do.
+		if n.Metadata != nil {
+			w.walkBeforeAfter(&n.Metadata, before, after)
+		}
+
+	// Inline assembler expressions
+	case *ast.InlineAsm:
+		w.walkBeforeAfter(&n.Type, before, after)
 
 	default:
 		panic(fmt.Errorf("support for type %T not yet implemented", x))
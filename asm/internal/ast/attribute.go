@@ -0,0 +1,128 @@
+package ast
+
+import "github.com/llir/llvm/asm/internal/token"
+
+// An Attribute represents a parameter, return or function attribute.
+//
+// Attribute may have one of the following underlying types.
+//
+//	ast.EnumAttr
+//	ast.AlignAttr
+//	ast.AlignStackAttr
+//	ast.DereferenceableAttr
+//	ast.DereferenceableOrNullAttr
+//	*ast.AllocSizeAttr
+//	*ast.StringAttr
+//	*ast.AttrGroupDef
+type Attribute interface {
+	// isAttribute ensures that only attributes can be assigned to the
+	// ast.Attribute interface.
+	isAttribute()
+}
+
+// EnumAttr represents the set of attributes without value.
+type EnumAttr uint
+
+// Enum attributes.
+const (
+	AttrAlwaysInline                EnumAttr = iota + 1 // alwaysinline
+	AttrArgMemOnly                                      // argmemonly
+	AttrBuiltin                                         // builtin
+	AttrByVal                                           // byval
+	AttrCold                                            // cold
+	AttrConvergent                                      // convergent
+	AttrInAlloca                                        // inalloca
+	AttrInReg                                           // inreg
+	AttrInaccessibleMemOrArgMemOnly                     // inaccessiblemem_or_argmemonly
+	AttrInaccessibleMemOnly                             // inaccessiblememonly
+	AttrInlineHint                                      // inlinehint
+	AttrJumpTable                                       // jumptable
+	AttrMinSize                                         // minsize
+	AttrNaked                                           // naked
+	AttrNest                                            // nest
+	AttrNoAlias                                         // noalias
+	AttrNoBuiltin                                       // nobuiltin
+	AttrNoCapture                                       // nocapture
+	AttrNoDuplicate                                     // noduplicate
+	AttrNoImplicitFloat                                 // noimplicitfloat
+	AttrNoInline                                        // noinline
+	AttrNoRecurse                                       // norecurse
+	AttrNoRedZone                                       // noredzone
+	AttrNoReturn                                        // noreturn
+	AttrNoUnwind                                        // nounwind
+	AttrNonLazyBind                                     // nonlazybind
+	AttrNonNull                                         // nonnull
+	AttrOptNone                                         // optnone
+	AttrOptSize                                         // optsize
+	AttrReadNone                                        // readnone
+	AttrReadOnly                                        // readonly
+	AttrReturned                                        // returned
+	AttrReturnsTwice                                    // returns_twice
+	AttrSExt                                            // signext
+	AttrSRet                                            // sret
+	AttrSSP                                             // ssp
+	AttrSSPReq                                          // sspreq
+	AttrSSPStrong                                       // sspstrong
+	AttrSafeStack                                       // safestack
+	AttrSanitizeAddress                                 // sanitize_address
+	AttrSanitizeMemory                                  // sanitize_memory
+	AttrSanitizeThread                                  // sanitize_thread
+	AttrSwiftError                                      // swifterror
+	AttrSwiftSelf                                       // swiftself
+	AttrUWTable                                         // uwtable
+	AttrWriteOnly                                       // writeonly
+	AttrZExt                                            // zeroext
+)
+
+// AlignAttr specifies the alignment in bytes of a parameter or return value.
+type AlignAttr int64
+
+// AlignStackAttr specifies the alignment in bytes of the stack of a function.
+type AlignStackAttr int64
+
+// DereferenceableAttr specifies the number of dereferenceable bytes of a
+// pointer.
+type DereferenceableAttr int64
+
+// DereferenceableOrNullAttr specifies the number of dereferenceable bytes of a
+// pointer, unless null.
+type DereferenceableOrNullAttr int64
+
+// AllocSizeAttr specifies which parameters of an allocation function hold the
+// size of the allocated memory.
+type AllocSizeAttr struct {
+	// Index of the element size parameter.
+	ElemSize int
+	// Index of the number of elements parameter; or -1 if not present.
+	NElems int
+}
+
+// StringAttr represents a target-dependent attribute.
+type StringAttr struct {
+	// Attribute key.
+	Key string
+	// Attribute value; or empty if not present.
+	Val string
+}
+
+// An AttrGroupDef represents an attribute group definition, or a reference to
+// an attribute group definition prior to resolution.
+type AttrGroupDef struct {
+	// Attribute group ID.
+	ID string
+	// Function attributes of the attribute group.
+	Attrs []Attribute
+	// Source position of the attribute group ID.
+	Pos token.Pos
+}
+
+// isAttribute ensures that only attributes can be assigned to the
+// ast.Attribute interface.
+func (EnumAttr) isAttribute()                  {}
+func (AlignAttr) isAttribute()                 {}
+func (AlignStackAttr) isAttribute()            {}
+func (DereferenceableAttr) isAttribute()       {}
+func (DereferenceableOrNullAttr) isAttribute() {}
+func (*AllocSizeAttr) isAttribute()            {}
+func (*StringAttr) isAttribute()               {}
+func (*AttrGroupDef) isAttribute()             {}
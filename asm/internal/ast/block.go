@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // A BasicBlock represents an LLVM IR basic block, which consists of a sequence
 // of non-branching instructions, terminated by a control flow instruction (e.g.
 // br or ret).
@@ -10,6 +12,8 @@ type BasicBlock struct {
 	Insts []Instruction
 	// Terminator of the basic block.
 	Term Terminator
+	// Source position of the basic block; or the zero value if unknown.
+	Pos token.Pos
 }
 
 // GetName returns the name of the value.
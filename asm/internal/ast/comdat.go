@@ -0,0 +1,26 @@
+package ast
+
+import "github.com/llir/llvm/asm/internal/token"
+
+// A Comdat represents a comdat definition, or a reference to a comdat
+// definition prior to resolution.
+type Comdat struct {
+	// Comdat name.
+	Name string
+	// Selection kind.
+	Kind SelectionKind
+	// Source position of the comdat name.
+	Pos token.Pos
+}
+
+// SelectionKind represents the set of comdat selection kinds.
+type SelectionKind uint
+
+// Comdat selection kinds.
+const (
+	SelectionKindAny          SelectionKind = iota // any
+	SelectionKindExactMatch                        // exactmatch
+	SelectionKindLargest                           // largest
+	SelectionKindNoDuplicates                      // noduplicates
+	SelectionKindSameSize                          // samesize
+)
@@ -0,0 +1,22 @@
+package ast
+
+// BlockAddressConst represents a block address constant.
+type BlockAddressConst struct {
+	// Constant type.
+	Type Type
+	// Parent function.
+	Func NamedValue
+	// Basic block of the parent function.
+	Block NamedValue
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*BlockAddressConst) isValue() {}
+
+// isConstant ensures that only constants can be assigned to the ast.Constant
+// interface.
+func (*BlockAddressConst) isConstant() {}
+
+// isMetadataNode ensures that only metadata nodes can be assigned to the
+// ast.MetadataNode interface.
+func (*BlockAddressConst) isMetadataNode() {}
@@ -30,6 +30,8 @@ type StructConst struct {
 	Type Type
 	// Struct fields.
 	Fields []Constant
+	// Packed struct constant.
+	Packed bool
 }
 
 // ZeroInitializerConst represents a zeroinitializer constant.
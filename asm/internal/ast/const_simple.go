@@ -22,16 +22,22 @@ type NullConst struct {
 	Type Type
 }
 
+// NoneConst represents a none token constant.
+type NoneConst struct {
+}
+
 // isValue ensures that only values can be assigned to the ast.Value interface.
 func (*IntConst) isValue()   {}
 func (*FloatConst) isValue() {}
 func (*NullConst) isValue()  {}
+func (*NoneConst) isValue()  {}
 
 // isConstant ensures that only constants can be assigned to the ast.Constant
 // interface.
 func (*IntConst) isConstant()   {}
 func (*FloatConst) isConstant() {}
 func (*NullConst) isConstant()  {}
+func (*NoneConst) isConstant()  {}
 
 // isMetadataNode ensures that only metadata nodes can be assigned to the
 // ast.MetadataNode interface.
@@ -12,6 +12,7 @@ package ast
 //    *ast.IntConst
 //    *ast.FloatConst
 //    *ast.NullConst
+//    *ast.NoneConst
 //
 // Complex constants
 //
@@ -22,15 +23,23 @@ package ast
 //    *ast.StructConst
 //    *ast.ZeroInitializerConst
 //
-// Global variable and function addresses
+// Global variable, function, alias and IFunc addresses
 //
 //    *ast.Global
 //    *ast.Function
+//    *ast.Alias
+//    *ast.IFunc
 //
 // Undefined value constants
 //
 //    *ast.UndefConst
 //
+// Addresses of basic blocks
+//
+// http://llvm.org/docs/LangRef.html#addresses-of-basic-blocks
+//
+//    *ast.BlockAddressConst
+//
 // Constant expressions
 //
 // http://llvm.org/docs/LangRef.html#constant-expressions
@@ -0,0 +1,40 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/asm/internal/token"
+)
+
+// An Error represents an error at a given position within LLVM IR assembly.
+type Error struct {
+	// Source position of the error.
+	Pos token.Pos
+	// Error message.
+	Msg string
+}
+
+// Errorf returns a new error at the given source position, with a message
+// formatted according to the format specifier.
+func Errorf(pos token.Pos, format string, a ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+// Error returns the error message, prefixed by the line and column number of
+// its source position.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+}
+
+// An ErrorList is a list of errors encountered within LLVM IR assembly.
+type ErrorList []error
+
+// Error returns the error messages of the list, separated by newlines.
+func (es ErrorList) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
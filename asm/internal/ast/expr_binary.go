@@ -18,6 +18,8 @@ type ExprAdd struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -74,6 +76,8 @@ type ExprSub struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -130,6 +134,8 @@ type ExprMul struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -186,6 +192,8 @@ type ExprUDiv struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Exact operation.
+	Exact bool
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -214,6 +222,8 @@ type ExprSDiv struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Exact operation.
+	Exact bool
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -18,6 +18,8 @@ type ExprShl struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -46,6 +48,8 @@ type ExprLShr struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Exact operation.
+	Exact bool
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -74,6 +78,8 @@ type ExprAShr struct {
 	Type Type
 	// Operands.
 	X, Y Constant
+	// Exact operation.
+	Exact bool
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
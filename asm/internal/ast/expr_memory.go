@@ -20,6 +20,8 @@ type ExprGetElementPtr struct {
 	Src Constant
 	// Element indices.
 	Indices []Constant
+	// In-bounds address computation.
+	InBounds bool
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
@@ -36,3 +38,16 @@ func (*ExprGetElementPtr) isConstExpr() {}
 // isMetadataNode ensures that only metadata nodes can be assigned to the
 // ast.MetadataNode interface.
 func (*ExprGetElementPtr) isMetadataNode() {}
+
+// ~~~ [ Index ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Index represents an element index of a getelementptr expression.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#getelementptr-instruction
+type Index struct {
+	// Element index.
+	Constant
+	// Index marked as inrange.
+	InRange bool
+}
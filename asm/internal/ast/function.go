@@ -1,10 +1,10 @@
 package ast
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/llir/llvm/asm/internal/token"
 	"github.com/llir/llvm/internal/enc"
 )
 
@@ -16,12 +16,34 @@ type Function struct {
 	Name string
 	// Function signature.
 	Sig *FuncType
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
 	// Calling convention.
 	CallConv CallConv
+	// Return attributes.
+	RetAttrs []Attribute
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
+	// Function attributes.
+	FuncAttrs []Attribute
+	// Section name; or empty if not present.
+	Section string
+	// Comdat; or nil if not present.
+	Comdat *Comdat
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Personality function used for exception handling; or nil if not present.
+	Personality Constant
 	// Basic blocks of the function; or nil if defined externally.
 	Blocks []*BasicBlock
 	// Metadata attached to the function.
 	Metadata []*AttachedMD
+	// Source position of the function name; or the zero value if unknown.
+	Pos token.Pos
 }
 
 // GetName returns the name of the value.
@@ -42,10 +64,11 @@ func (*Function) isValue() {}
 func (*Function) isConstant() {}
 
 // AssignIDs assigns unique local IDs to unnamed basic blocks and local
-// variables of the function.
-func (f *Function) AssignIDs() {
+// variables of the function. An error is returned if an explicit local ID is
+// out of sequence.
+func (f *Function) AssignIDs() error {
 	id := 0
-	setName := func(n NamedValue) {
+	setName := func(n NamedValue, pos token.Pos) error {
 		name := n.GetName()
 		switch {
 		case isUnnamed(name):
@@ -54,39 +77,65 @@ func (f *Function) AssignIDs() {
 		case isID(name):
 			want := strconv.Itoa(id)
 			if name != want {
-				panic(fmt.Errorf("invalid local ID in function %s; expected %s, got %s", enc.Global(f.Name), enc.Local(want), enc.Local(name)))
+				return Errorf(pos, "invalid local ID in function %s; expected %s, got %s", enc.Global(f.Name), enc.Local(want), enc.Local(name))
 			}
 			id++
 		}
+		return nil
 	}
 	for _, param := range f.Sig.Params {
 		// Assign local IDs to unnamed parameters of function definitions.
 		if len(f.Blocks) > 0 {
-			setName(param)
+			if err := setName(param, f.Pos); err != nil {
+				return err
+			}
 		}
 	}
 	for _, block := range f.Blocks {
 		// Assign local IDs to unnamed basic blocks.
-		setName(block)
+		if err := setName(block, block.Pos); err != nil {
+			return err
+		}
 		for _, inst := range block.Insts {
 			n, ok := inst.(NamedValue)
 			if !ok {
 				continue
 			}
-			if inst, ok := inst.(*InstCall); ok {
-				if _, ok := inst.Type.(*VoidType); ok {
-					continue
-				}
-				if sig, ok := inst.Type.(*FuncType); ok {
-					if _, ok := sig.Ret.(*VoidType); ok {
-						continue
-					}
-				}
+			if inst, ok := inst.(*InstCall); ok && IsVoidCall(inst.Type) {
+				continue
 			}
 			// Assign local IDs to unnamed local variables.
-			setName(n)
+			if err := setName(n, inst.GetPos()); err != nil {
+				return err
+			}
+		}
+		// Assign local IDs to unnamed local variables produced by terminators.
+		n, ok := block.Term.(NamedValue)
+		if !ok {
+			continue
 		}
+		if term, ok := block.Term.(*TermInvoke); ok && IsVoidCall(term.Type) {
+			continue
+		}
+		if err := setName(n, block.Term.GetPos()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsVoidCall reports whether the given type of a call instruction or invoke
+// terminator, either the return type or the callee signature, denotes a call
+// without result.
+func IsVoidCall(typ Type) bool {
+	switch typ := typ.(type) {
+	case *VoidType:
+		return true
+	case *FuncType:
+		_, ok := typ.Ret.(*VoidType)
+		return ok
 	}
+	return false
 }
 
 // isUnnamed reports whether the given identifier is unnamed.
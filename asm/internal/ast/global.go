@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // A Global represents an LLVM IR global variable definition or external global
 // variable declaration.
 type Global struct {
@@ -11,10 +13,30 @@ type Global struct {
 	Init Constant
 	// Immutability of the global variable.
 	Immutable bool
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
+	// Thread local storage model.
+	TLSModel TLSModel
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
 	// Address space; or 0 for default address space.
 	AddrSpace int
+	// Externally initialized.
+	ExternallyInitialized bool
+	// Section name; or empty if not present.
+	Section string
+	// Comdat; or nil if not present.
+	Comdat *Comdat
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Metadata attached to the global variable.
 	Metadata []*AttachedMD
+	// Source position of the global variable name; or the zero value if unknown.
+	Pos token.Pos
 }
 
 // GetName returns the name of the value.
@@ -40,6 +62,8 @@ type GlobalDummy struct {
 	Name string
 	// Type associated with the global.
 	Type Type
+	// Source position of the global identifier.
+	Pos token.Pos
 }
 
 // GetName returns the name of the value.
@@ -0,0 +1,36 @@
+package ast
+
+import "github.com/llir/llvm/asm/internal/token"
+
+// An IFunc represents an LLVM IR indirect function.
+type IFunc struct {
+	// IFunc name.
+	Name string
+	// Content type.
+	Content Type
+	// Resolver.
+	Resolver Constant
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// Source position of the IFunc name; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetName returns the name of the value.
+func (ifunc *IFunc) GetName() string {
+	return ifunc.Name
+}
+
+// SetName sets the name of the value.
+func (ifunc *IFunc) SetName(name string) {
+	ifunc.Name = name
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*IFunc) isValue() {}
+
+// isConstant ensures that only constants can be assigned to the ast.Constant
+// interface.
+func (*IFunc) isConstant() {}
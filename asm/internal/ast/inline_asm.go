@@ -0,0 +1,24 @@
+package ast
+
+// InlineAsm represents an inline assembler expression.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#inline-assembler-expressions
+type InlineAsm struct {
+	// Type of the inline assembler expression; either the return type or the
+	// function type of the callee.
+	Type Type
+	// Assembly instructions.
+	Asm string
+	// Constraints.
+	Constraint string
+	// Inline assembly has side effects.
+	SideEffect bool
+	// Inline assembly requires an aligned stack.
+	AlignStack bool
+	// Inline assembly uses the Intel dialect.
+	IntelDialect bool
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*InlineAsm) isValue() {}
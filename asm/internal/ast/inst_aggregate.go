@@ -5,6 +5,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ extractvalue ] ------------------------------------------------------
 
 // InstExtractValue represents an extractvalue instruction.
@@ -20,6 +22,13 @@ type InstExtractValue struct {
 	Indices []int64
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstExtractValue) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -56,6 +65,13 @@ type InstInsertValue struct {
 	Indices []int64
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstInsertValue) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
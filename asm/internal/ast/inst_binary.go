@@ -7,6 +7,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ add ] -----------------------------------------------------------------
 
 // InstAdd represents an addition instruction.
@@ -18,8 +20,17 @@ type InstAdd struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAdd) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -50,8 +61,17 @@ type InstFAdd struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFAdd) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -82,8 +102,17 @@ type InstSub struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSub) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -114,8 +143,17 @@ type InstFSub struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFSub) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -146,8 +184,17 @@ type InstMul struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstMul) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -178,8 +225,17 @@ type InstFMul struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFMul) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -210,8 +266,17 @@ type InstUDiv struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Exact operation.
+	Exact bool
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstUDiv) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -242,8 +307,17 @@ type InstSDiv struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Exact operation.
+	Exact bool
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSDiv) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -274,8 +348,17 @@ type InstFDiv struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFDiv) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -308,6 +391,13 @@ type InstURem struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstURem) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -340,6 +430,13 @@ type InstSRem struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSRem) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -370,8 +467,17 @@ type InstFRem struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFRem) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
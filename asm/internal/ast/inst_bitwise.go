@@ -7,6 +7,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ shl ] -----------------------------------------------------------------
 
 // InstShl represents a shift left instruction.
@@ -18,8 +20,17 @@ type InstShl struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstShl) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -50,8 +61,17 @@ type InstLShr struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Exact operation.
+	Exact bool
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstLShr) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -82,8 +102,17 @@ type InstAShr struct {
 	Name string
 	// Operands.
 	X, Y Value
+	// Exact operation.
+	Exact bool
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAShr) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -116,6 +145,13 @@ type InstAnd struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAnd) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -148,6 +184,13 @@ type InstOr struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstOr) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -180,6 +223,13 @@ type InstXor struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstXor) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -7,6 +7,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ trunc ] ---------------------------------------------------------------
 
 // InstTrunc represents a truncation instruction.
@@ -22,6 +24,13 @@ type InstTrunc struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstTrunc) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -56,6 +65,13 @@ type InstZExt struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstZExt) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -90,6 +106,13 @@ type InstSExt struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSExt) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -124,6 +147,13 @@ type InstFPTrunc struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFPTrunc) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -158,6 +188,13 @@ type InstFPExt struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFPExt) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -192,6 +229,13 @@ type InstFPToUI struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFPToUI) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -226,6 +270,13 @@ type InstFPToSI struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFPToSI) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -260,6 +311,13 @@ type InstUIToFP struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstUIToFP) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -294,6 +352,13 @@ type InstSIToFP struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSIToFP) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -328,6 +393,13 @@ type InstPtrToInt struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstPtrToInt) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -362,6 +434,13 @@ type InstIntToPtr struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstIntToPtr) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -396,6 +475,13 @@ type InstBitCast struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstBitCast) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -430,6 +516,13 @@ type InstAddrSpaceCast struct {
 	To Type
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAddrSpaceCast) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
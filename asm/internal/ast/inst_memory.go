@@ -5,6 +5,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ alloca ] --------------------------------------------------------------
 
 // InstAlloca represents an alloca instruction.
@@ -18,8 +20,19 @@ type InstAlloca struct {
 	Elem Type
 	// Number of elements; or nil if one element.
 	NElems Value
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Address space of the allocated memory; or 0 if default address space.
+	AddrSpace int
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAlloca) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -45,8 +58,24 @@ type InstLoad struct {
 	Elem Type
 	// Source address.
 	Src Value
+	// Atomic memory ordering constraints; or AtomicOrderingNone if not atomic.
+	Ordering AtomicOrdering
+	// Synchronization scope of atomic load; or the empty string if within the
+	// scope of the entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstLoad) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -70,16 +99,175 @@ type InstStore struct {
 	Src Value
 	// Destination address.
 	Dst Value
+	// Atomic memory ordering constraints; or AtomicOrderingNone if not atomic.
+	Ordering AtomicOrdering
+	// Synchronization scope of atomic store; or the empty string if within the
+	// scope of the entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstStore) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // --- [ fence ] ---------------------------------------------------------------
 
+// InstFence represents a fence instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#fence-instruction
+type InstFence struct {
+	// Atomic memory ordering constraints.
+	Ordering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFence) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// AtomicOrdering represents the set of atomic memory ordering constraints.
+type AtomicOrdering uint
+
+// Atomic memory ordering constraints.
+const (
+	AtomicOrderingNone                   AtomicOrdering = iota // not atomic.
+	AtomicOrderingUnordered                                    // unordered
+	AtomicOrderingMonotonic                                    // monotonic
+	AtomicOrderingAcquire                                      // acquire
+	AtomicOrderingRelease                                      // release
+	AtomicOrderingAcquireRelease                               // acq_rel
+	AtomicOrderingSequentiallyConsistent                       // seq_cst
+)
+
 // --- [ cmpxchg ] -------------------------------------------------------------
 
+// InstCmpXchg represents a cmpxchg instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cmpxchg-instruction
+type InstCmpXchg struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Address to read from, compare against and store to.
+	Ptr Value
+	// Value to compare against.
+	Cmp Value
+	// New value to store.
+	New Value
+	// Atomic memory ordering constraints on success.
+	SuccessOrdering AtomicOrdering
+	// Atomic memory ordering constraints on failure.
+	FailureOrdering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Weak cmpxchg, which may fail spuriously.
+	Weak bool
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCmpXchg) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstCmpXchg) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstCmpXchg) SetName(name string) {
+	inst.Name = name
+}
+
 // --- [ atomicrmw ] -----------------------------------------------------------
 
+// InstAtomicRMW represents an atomicrmw instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#atomicrmw-instruction
+type InstAtomicRMW struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Atomic operation.
+	Op AtomicOp
+	// Destination address.
+	Dst Value
+	// Operand.
+	X Value
+	// Atomic memory ordering constraints.
+	Ordering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAtomicRMW) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstAtomicRMW) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstAtomicRMW) SetName(name string) {
+	inst.Name = name
+}
+
+// AtomicOp represents the set of atomic operations of atomicrmw instructions.
+type AtomicOp uint
+
+// Atomic operations.
+const (
+	AtomicOpXChg AtomicOp = iota + 1 // xchg
+	AtomicOpAdd                      // add
+	AtomicOpSub                      // sub
+	AtomicOpAnd                      // and
+	AtomicOpNAnd                     // nand
+	AtomicOpOr                       // or
+	AtomicOpXor                      // xor
+	AtomicOpMax                      // max
+	AtomicOpMin                      // min
+	AtomicOpUMax                     // umax
+	AtomicOpUMin                     // umin
+)
+
 // --- [ getelementptr ] -------------------------------------------------------
 
 // InstGetElementPtr represents a getelementptr instruction.
@@ -95,8 +283,17 @@ type InstGetElementPtr struct {
 	Src Value
 	// Element indices.
 	Indices []Value
+	// In-bounds address computation.
+	InBounds bool
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstGetElementPtr) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -113,6 +310,9 @@ func (inst *InstGetElementPtr) SetName(name string) {
 func (*InstAlloca) isValue()        {}
 func (*InstLoad) isValue()          {}
 func (*InstStore) isValue()         {}
+func (*InstFence) isValue()         {}
+func (*InstCmpXchg) isValue()       {}
+func (*InstAtomicRMW) isValue()     {}
 func (*InstGetElementPtr) isValue() {}
 
 // isInst ensures that only instructions can be assigned to the ast.Instruction
@@ -120,4 +320,7 @@ func (*InstGetElementPtr) isValue() {}
 func (*InstAlloca) isInst()        {}
 func (*InstLoad) isInst()          {}
 func (*InstStore) isInst()         {}
+func (*InstFence) isInst()         {}
+func (*InstCmpXchg) isInst()       {}
+func (*InstAtomicRMW) isInst()     {}
 func (*InstGetElementPtr) isInst() {}
@@ -5,6 +5,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ icmp ] ----------------------------------------------------------------
 
 // InstICmp represents an icmp instruction.
@@ -20,6 +22,13 @@ type InstICmp struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstICmp) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -45,8 +54,17 @@ type InstFCmp struct {
 	Pred FloatPred
 	// Operands.
 	X, Y Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFCmp) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -72,8 +90,17 @@ type InstPhi struct {
 	Type Type
 	// Incoming values.
 	Incs []*Incoming
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstPhi) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -109,6 +136,13 @@ type InstSelect struct {
 	X, Y Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstSelect) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -138,8 +172,25 @@ type InstCall struct {
 	Args []Value
 	// Calling convention.
 	CallConv CallConv
+	// Tail call kind.
+	Tail Tail
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
+	// Return attributes.
+	RetAttrs []Attribute
+	// Function attributes.
+	FuncAttrs []Attribute
+	// Operand bundles.
+	OperandBundles []*OperandBundle
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCall) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -152,25 +203,209 @@ func (inst *InstCall) SetName(name string) {
 	inst.Name = name
 }
 
+// Tail represents the set of tail call kinds.
+type Tail uint
+
+// Tail call kinds.
+const (
+	TailNone     Tail = iota // no tail call marker.
+	TailTail                 // tail
+	TailMustTail             // musttail
+	TailNoTail               // notail
+)
+
+// Arg represents a function argument with parameter attributes at the call
+// site.
+type Arg struct {
+	// Argument value.
+	Value
+	// Parameter attributes.
+	Attrs []Attribute
+}
+
+// OperandBundle represents a tagged operand bundle of a call instruction.
+type OperandBundle struct {
+	// Operand bundle tag.
+	Tag string
+	// Operand bundle inputs.
+	Inputs []Value
+}
+
 // --- [ va_arg ] --------------------------------------------------------------
 
+// InstVAArg represents a va_arg instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#va_arg-instruction
+type InstVAArg struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Variable argument list.
+	ArgList Value
+	// Argument type.
+	ArgType Type
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstVAArg) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstVAArg) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstVAArg) SetName(name string) {
+	inst.Name = name
+}
+
 // --- [ landingpad ] ----------------------------------------------------------
 
+// InstLandingPad represents a landingpad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#landingpad-instruction
+type InstLandingPad struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Result type.
+	Type Type
+	// Specifies whether the landing pad is a cleanup.
+	Cleanup bool
+	// Exception clauses.
+	Clauses []*Clause
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstLandingPad) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstLandingPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstLandingPad) SetName(name string) {
+	inst.Name = name
+}
+
+// Clause represents an exception clause of a landingpad instruction.
+type Clause struct {
+	// Clause kind.
+	Kind ClauseKind
+	// Clause operand.
+	X Constant
+}
+
+// ClauseKind represents the set of exception clause kinds.
+type ClauseKind uint
+
+// Exception clause kinds.
+const (
+	ClauseKindCatch  ClauseKind = iota + 1 // catch
+	ClauseKindFilter                       // filter
+)
+
 // --- [ catchpad ] ------------------------------------------------------------
 
+// InstCatchPad represents a catchpad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchpad-instruction
+type InstCatchPad struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Parent catchswitch terminator.
+	Within Value
+	// Exception arguments.
+	Args []Value
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCatchPad) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstCatchPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstCatchPad) SetName(name string) {
+	inst.Name = name
+}
+
 // --- [ cleanuppad ] ----------------------------------------------------------
 
+// InstCleanupPad represents a cleanuppad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cleanuppad-instruction
+type InstCleanupPad struct {
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Parent exception pad; or the none token constant if not within a
+	// funclet.
+	Within Value
+	// Exception arguments.
+	Args []Value
+	// Metadata attached to the instruction.
+	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCleanupPad) GetPos() token.Pos {
+	return inst.Pos
+}
+
+// GetName returns the name of the value.
+func (inst *InstCleanupPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the value.
+func (inst *InstCleanupPad) SetName(name string) {
+	inst.Name = name
+}
+
 // isValue ensures that only values can be assigned to the ast.Value interface.
-func (*InstICmp) isValue()   {}
-func (*InstFCmp) isValue()   {}
-func (*InstPhi) isValue()    {}
-func (*InstSelect) isValue() {}
-func (*InstCall) isValue()   {}
+func (*InstICmp) isValue()       {}
+func (*InstFCmp) isValue()       {}
+func (*InstPhi) isValue()        {}
+func (*InstSelect) isValue()     {}
+func (*InstCall) isValue()       {}
+func (*InstVAArg) isValue()      {}
+func (*InstLandingPad) isValue() {}
+func (*InstCatchPad) isValue()   {}
+func (*InstCleanupPad) isValue() {}
 
 // isInst ensures that only instructions can be assigned to the ast.Instruction
 // interface.
-func (*InstICmp) isInst()   {}
-func (*InstFCmp) isInst()   {}
-func (*InstPhi) isInst()    {}
-func (*InstSelect) isInst() {}
-func (*InstCall) isInst()   {}
+func (*InstICmp) isInst()       {}
+func (*InstFCmp) isInst()       {}
+func (*InstPhi) isInst()        {}
+func (*InstSelect) isInst()     {}
+func (*InstCall) isInst()       {}
+func (*InstVAArg) isInst()      {}
+func (*InstLandingPad) isInst() {}
+func (*InstCatchPad) isInst()   {}
+func (*InstCleanupPad) isInst() {}
@@ -5,6 +5,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // --- [ extractelement ] ------------------------------------------------------
 
 // InstExtractElement represents an extractelement instruction.
@@ -20,6 +22,13 @@ type InstExtractElement struct {
 	Index Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstExtractElement) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -56,6 +65,13 @@ type InstInsertElement struct {
 	Index Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstInsertElement) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
@@ -92,6 +108,13 @@ type InstShuffleVector struct {
 	Mask Value
 	// Metadata attached to the instruction.
 	Metadata []*AttachedMD
+	// Source position of the instruction; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstShuffleVector) GetPos() token.Pos {
+	return inst.Pos
 }
 
 // GetName returns the name of the value.
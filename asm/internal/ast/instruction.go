@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // An Instruction represents a non-branching LLVM IR instruction.
 //
 // Instruction may have one of the following underlying types.
@@ -54,6 +56,9 @@ package ast
 //    *ast.InstAlloca
 //    *ast.InstLoad
 //    *ast.InstStore
+//    *ast.InstFence
+//    *ast.InstCmpXchg
+//    *ast.InstAtomicRMW
 //    *ast.InstGetElementPtr
 //
 // Conversion instructions
@@ -83,8 +88,40 @@ package ast
 //    *ast.InstPhi
 //    *ast.InstSelect
 //    *ast.InstCall
+//    *ast.InstVAArg
+//    *ast.InstLandingPad
+//    *ast.InstCatchPad
+//    *ast.InstCleanupPad
 type Instruction interface {
+	// GetPos returns the source position of the instruction.
+	GetPos() token.Pos
 	// isInst ensures that only instructions can be assigned to the
 	// ast.Instruction interface.
 	isInst()
 }
+
+// OverflowFlag represents the set of overflow flags of integer arithmetic
+// instructions and constant expressions.
+type OverflowFlag uint
+
+// Overflow flags.
+const (
+	OverflowFlagNUW OverflowFlag = iota + 1 // nuw
+	OverflowFlagNSW                         // nsw
+)
+
+// FastMathFlag represents the set of fast-math flags of floating-point
+// instructions.
+type FastMathFlag uint
+
+// Fast-math flags.
+const (
+	FastMathFlagNNaN     FastMathFlag = iota + 1 // nnan
+	FastMathFlagNInf                             // ninf
+	FastMathFlagNSZ                              // nsz
+	FastMathFlagARCP                             // arcp
+	FastMathFlagContract                         // contract
+	FastMathFlagAFN                              // afn
+	FastMathFlagReassoc                          // reassoc
+	FastMathFlagFast                             // fast
+)
@@ -0,0 +1,63 @@
+package ast
+
+// Linkage represents the set of linkage types.
+type Linkage uint
+
+// Linkage types.
+const (
+	LinkageNone                Linkage = iota // no linkage specified.
+	LinkageAppending                          // appending
+	LinkageAvailableExternally                // available_externally
+	LinkageCommon                             // common
+	LinkageInternal                           // internal
+	LinkageLinkOnce                           // linkonce
+	LinkageLinkOnceODR                        // linkonce_odr
+	LinkagePrivate                            // private
+	LinkageWeak                               // weak
+	LinkageWeakODR                            // weak_odr
+	LinkageExternal                           // external
+	LinkageExternWeak                         // extern_weak
+)
+
+// Visibility represents the set of visibility styles.
+type Visibility uint
+
+// Visibility styles.
+const (
+	VisibilityNone      Visibility = iota // no visibility style specified.
+	VisibilityDefault                     // default
+	VisibilityHidden                      // hidden
+	VisibilityProtected                   // protected
+)
+
+// DLLStorageClass represents the set of DLL storage classes.
+type DLLStorageClass uint
+
+// DLL storage classes.
+const (
+	DLLStorageClassNone      DLLStorageClass = iota // no DLL storage class specified.
+	DLLStorageClassDLLImport                        // dllimport
+	DLLStorageClassDLLExport                        // dllexport
+)
+
+// TLSModel represents the set of thread local storage models.
+type TLSModel uint
+
+// Thread local storage models.
+const (
+	TLSModelNone         TLSModel = iota // not thread local.
+	TLSModelGeneric                      // thread_local
+	TLSModelLocalDynamic                 // thread_local(localdynamic)
+	TLSModelInitialExec                  // thread_local(initialexec)
+	TLSModelLocalExec                    // thread_local(localexec)
+)
+
+// UnnamedAddr represents the set of unnamed address specifiers.
+type UnnamedAddr uint
+
+// Unnamed address specifiers.
+const (
+	UnnamedAddrNone             UnnamedAddr = iota // no unnamed address specified.
+	UnnamedAddrLocalUnnamedAddr                    // local_unnamed_addr
+	UnnamedAddrUnnamedAddr                         // unnamed_addr
+)
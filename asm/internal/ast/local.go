@@ -1,11 +1,15 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // LocalDummy represents a dummy local identifier.
 type LocalDummy struct {
 	// Local name.
 	Name string
 	// Type associated with the localIdent.
 	Type Type
+	// Source position of the local identifier.
+	Pos token.Pos
 }
 
 // GetName returns the name of the value.
@@ -5,6 +5,8 @@
 
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // A MetadataNode represents an LLVM IR metadata node.
 //
 // MetadataNode may have one of the following underlying types.
@@ -31,6 +33,8 @@ type Metadata struct {
 	ID string
 	// Metadata nodes.
 	Nodes []MetadataNode
+	// Source position of the metadata ID; or the zero value if unknown.
+	Pos token.Pos
 }
 
 // --- [ metadata string ] -----------------------------------------------------
@@ -88,6 +92,8 @@ func (*MetadataValue) isMetadataNode()  {}
 type MetadataIDDummy struct {
 	// Metadata ID.
 	ID string
+	// Source position of the metadata ID.
+	Pos token.Pos
 }
 
 // isValue ensures that only values can be assigned to the ast.Value interface.
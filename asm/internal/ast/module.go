@@ -5,18 +5,30 @@
 package ast
 
 // A Module represents an LLVM IR module, which consists of top-level type
-// definitions, global variables, functions, and metadata.
+// definitions, global variables, aliases, IFuncs, functions, and metadata.
 type Module struct {
+	// Source filename; or empty if not present.
+	SourceFilename string
 	// Data layout.
 	DataLayout string
 	// Target triple.
 	TargetTriple string
+	// Module-level inline assembly, one entry per line.
+	ModuleAsms []string
 	// Type definitions.
 	Types []*NamedType
+	// Comdat definitions of the module.
+	Comdats []*Comdat
 	// Global variables of the module.
 	Globals []*Global
+	// Aliases of the module.
+	Aliases []*Alias
+	// IFuncs of the module.
+	IFuncs []*IFunc
 	// Functions of the module.
 	Funcs []*Function
+	// Attribute group definitions of the module.
+	AttrGroupDefs []*AttrGroupDef
 	// Named metadata of the module.
 	NamedMetadata []*NamedMetadata
 	// Metadata of the module.
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // A Terminator represents an LLVM IR terminator.
 //
 // Terminator may have one of the following underlying types.
@@ -12,8 +14,16 @@ package ast
 //    *ast.TermBr
 //    *ast.TermCondBr
 //    *ast.TermSwitch
+//    *ast.TermIndirectBr
+//    *ast.TermInvoke
+//    *ast.TermResume
+//    *ast.TermCatchSwitch
+//    *ast.TermCatchRet
+//    *ast.TermCleanupRet
 //    *ast.TermUnreachable
 type Terminator interface {
+	// GetPos returns the source position of the terminator.
+	GetPos() token.Pos
 	// isTerm ensures that only terminators can be assigned to the ast.Terminator
 	// interface.
 	isTerm()
@@ -30,6 +40,13 @@ type TermRet struct {
 	X Value
 	// Metadata attached to the terminator.
 	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermRet) GetPos() token.Pos {
+	return term.Pos
 }
 
 // --- [ br ] ------------------------------------------------------------------
@@ -43,6 +60,13 @@ type TermBr struct {
 	Target NamedValue
 	// Metadata attached to the terminator.
 	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermBr) GetPos() token.Pos {
+	return term.Pos
 }
 
 // --- [ conditional br ] ------------------------------------------------------
@@ -60,6 +84,13 @@ type TermCondBr struct {
 	TargetFalse NamedValue
 	// Metadata attached to the terminator.
 	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCondBr) GetPos() token.Pos {
+	return term.Pos
 }
 
 // --- [ switch ] --------------------------------------------------------------
@@ -77,6 +108,13 @@ type TermSwitch struct {
 	Cases []*Case
 	// Metadata attached to the terminator.
 	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermSwitch) GetPos() token.Pos {
+	return term.Pos
 }
 
 // Case represents a case of a switch terminator.
@@ -89,16 +127,175 @@ type Case struct {
 
 // --- [ indirectbr ] ----------------------------------------------------------
 
+// TermIndirectBr represents an indirectbr terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#indirectbr-instruction
+type TermIndirectBr struct {
+	// Target address.
+	Addr Value
+	// Set of valid target basic blocks.
+	ValidTargets []NamedValue
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermIndirectBr) GetPos() token.Pos {
+	return term.Pos
+}
+
 // --- [ invoke ] --------------------------------------------------------------
 
+// TermInvoke represents an invoke terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#invoke-instruction
+type TermInvoke struct {
+	// Name of the local variable associated with the terminator.
+	Name string
+	// Type of the terminator; or callee type signature.
+	Type Type
+	// Callee.
+	Callee Value
+	// Function arguments.
+	Args []Value
+	// Calling convention.
+	CallConv CallConv
+	// Target branch when the callee returns normally.
+	Normal NamedValue
+	// Target branch when the callee unwinds through an exception.
+	Exception NamedValue
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermInvoke) GetPos() token.Pos {
+	return term.Pos
+}
+
+// GetName returns the name of the value.
+func (term *TermInvoke) GetName() string {
+	return term.Name
+}
+
+// SetName sets the name of the value.
+func (term *TermInvoke) SetName(name string) {
+	term.Name = name
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*TermInvoke) isValue() {}
+
 // --- [ resume ] --------------------------------------------------------------
 
+// TermResume represents a resume terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#resume-instruction
+type TermResume struct {
+	// Exception value being propagated.
+	X Value
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermResume) GetPos() token.Pos {
+	return term.Pos
+}
+
 // --- [ catchswitch ] ---------------------------------------------------------
 
+// TermCatchSwitch represents a catchswitch terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchswitch-instruction
+type TermCatchSwitch struct {
+	// Name of the local variable associated with the terminator.
+	Name string
+	// Parent exception pad; or the none token constant if not within a
+	// funclet.
+	Within Value
+	// Exception handlers.
+	Handlers []NamedValue
+	// Unwind target; or nil if unwinding to the caller.
+	Unwind NamedValue
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCatchSwitch) GetPos() token.Pos {
+	return term.Pos
+}
+
+// GetName returns the name of the value.
+func (term *TermCatchSwitch) GetName() string {
+	return term.Name
+}
+
+// SetName sets the name of the value.
+func (term *TermCatchSwitch) SetName(name string) {
+	term.Name = name
+}
+
+// isValue ensures that only values can be assigned to the ast.Value interface.
+func (*TermCatchSwitch) isValue() {}
+
 // --- [ catchret ] ------------------------------------------------------------
 
+// TermCatchRet represents a catchret terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchret-instruction
+type TermCatchRet struct {
+	// Exiting catchpad.
+	From Value
+	// Target branch.
+	To NamedValue
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCatchRet) GetPos() token.Pos {
+	return term.Pos
+}
+
 // --- [ cleanupret ] ----------------------------------------------------------
 
+// TermCleanupRet represents a cleanupret terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cleanupret-instruction
+type TermCleanupRet struct {
+	// Exiting cleanuppad.
+	From Value
+	// Unwind target; or nil if unwinding to the caller.
+	Unwind NamedValue
+	// Metadata attached to the terminator.
+	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCleanupRet) GetPos() token.Pos {
+	return term.Pos
+}
+
 // --- [ unreachable ] ---------------------------------------------------------
 
 // TermUnreachable represents an unreachable terminator.
@@ -108,6 +305,13 @@ type Case struct {
 type TermUnreachable struct {
 	// Metadata attached to the terminator.
 	Metadata []*AttachedMD
+	// Source position of the terminator; or the zero value if unknown.
+	Pos token.Pos
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermUnreachable) GetPos() token.Pos {
+	return term.Pos
 }
 
 // isTerm ensures that only terminators can be assigned to the ast.Terminator
@@ -116,4 +320,10 @@ func (*TermRet) isTerm()         {}
 func (*TermBr) isTerm()          {}
 func (*TermCondBr) isTerm()      {}
 func (*TermSwitch) isTerm()      {}
+func (*TermIndirectBr) isTerm()  {}
+func (*TermInvoke) isTerm()      {}
+func (*TermResume) isTerm()      {}
+func (*TermCatchSwitch) isTerm() {}
+func (*TermCatchRet) isTerm()    {}
+func (*TermCleanupRet) isTerm()  {}
 func (*TermUnreachable) isTerm() {}
@@ -22,6 +22,8 @@ type ArrayType struct {
 type StructType struct {
 	// Struct fields.
 	Fields []Type
+	// Packed struct type.
+	Packed bool
 	// Opaque struct type.
 	//
 	// References:
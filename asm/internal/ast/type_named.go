@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/llir/llvm/asm/internal/token"
+
 // NamedType represents the type definition of a type alias or an identified
 // struct type.
 type NamedType struct {
@@ -7,6 +9,8 @@ type NamedType struct {
 	Name string
 	// Type definition.
 	Def Type
+	// Source position of the type name; or the zero value if unknown.
+	Pos token.Pos
 }
 
 // GetName returns the name of the type.
@@ -21,6 +25,8 @@ func (*NamedType) isType() {}
 type NamedTypeDummy struct {
 	// Type name.
 	Name string
+	// Source position of the type identifier.
+	Pos token.Pos
 }
 
 // isType ensures that only types can be assigned to the ast.Type interface.
@@ -82,8 +82,18 @@ type VectorType struct {
 	Len int64
 }
 
+// --- [ x86_mmx ] -------------------------------------------------------------
+
+// MMXType represents an x86 MMX type.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#x86-mmx-type
+type MMXType struct {
+}
+
 // isType ensures that only types can be assigned to the ast.Type interface.
 func (*IntType) isType()     {}
 func (*FloatType) isType()   {}
 func (*PointerType) isType() {}
 func (*VectorType) isType()  {}
+func (*MMXType) isType()     {}
@@ -10,8 +10,10 @@ package ast
 //    *ast.FloatType
 //    *ast.PointerType
 //    *ast.VectorType
+//    *ast.MMXType
 //    *ast.LabelType
 //    *ast.MetadataType
+//    *ast.TokenType
 //    *ast.ArrayType
 //    *ast.StructType
 //    *ast.NamedType
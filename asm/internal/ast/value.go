@@ -7,6 +7,7 @@ package ast
 //
 //    ast.Constant
 //    ast.NamedValue
+//    *ast.InlineAsm
 type Value interface {
 	// isValue ensures that only values can be assigned to the ast.Value
 	// interface.
@@ -20,10 +21,14 @@ type Value interface {
 //    *ast.Global
 //    *ast.GlobalDummy
 //    *ast.Function
+//    *ast.Alias
+//    *ast.IFunc
 //    *ast.Param
 //    *ast.BasicBlock
 //    *ast.LocalDummy
 //    ast.Instruction
+//    *ast.TermInvoke
+//    *ast.TermCatchSwitch
 type NamedValue interface {
 	Value
 	// GetName returns the name of the value.
@@ -3,7 +3,6 @@
 package astx
 
 import (
-	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -37,16 +36,28 @@ func NewModule(decls interface{}) (*ast.Module, error) {
 	m := &ast.Module{}
 	for _, d := range ds {
 		switch d := d.(type) {
+		case *SourceFilename:
+			m.SourceFilename = d.s
 		case *DataLayout:
 			m.DataLayout = d.s
 		case *TargetTriple:
 			m.TargetTriple = d.s
+		case *ModuleAsm:
+			m.ModuleAsms = append(m.ModuleAsms, d.s)
 		case *ast.NamedType:
 			m.Types = append(m.Types, d)
+		case *ast.Comdat:
+			m.Comdats = append(m.Comdats, d)
 		case *ast.Global:
 			m.Globals = append(m.Globals, d)
+		case *ast.Alias:
+			m.Aliases = append(m.Aliases, d)
+		case *ast.IFunc:
+			m.IFuncs = append(m.IFuncs, d)
 		case *ast.Function:
 			m.Funcs = append(m.Funcs, d)
+		case *ast.AttrGroupDef:
+			m.AttrGroupDefs = append(m.AttrGroupDefs, d)
 		case *ast.NamedMetadata:
 			m.NamedMetadata = append(m.NamedMetadata, d)
 		case *ast.Metadata:
@@ -55,8 +66,7 @@ func NewModule(decls interface{}) (*ast.Module, error) {
 			dbg.Printf("support for %T not yet implemented", d)
 		}
 	}
-	m = fixModule(m)
-	return m, nil
+	return fixModule(m)
 }
 
 // TopLevelDecl represents a top-level declaration.
@@ -65,7 +75,7 @@ type TopLevelDecl interface{}
 // NewTopLevelDeclList returns a new top-level declaration list based on the
 // given top-level declaration.
 func NewTopLevelDeclList(decl interface{}) ([]TopLevelDecl, error) {
-	// Skip ignored top-level declaration; e.g. "source_filename".
+	// Skip ignored top-level declaration.
 	if decl == nil {
 		return []TopLevelDecl{}, nil
 	}
@@ -83,7 +93,7 @@ func AppendTopLevelDecl(decls, decl interface{}) ([]TopLevelDecl, error) {
 	if !ok {
 		return nil, errors.Errorf("invalid top-level declaration list type; expected []astx.TopLevelDecl, got %T", decls)
 	}
-	// Skip ignored top-level declaration; e.g. "source_filename".
+	// Skip ignored top-level declaration.
 	if decl == nil {
 		return ds, nil
 	}
@@ -94,6 +104,24 @@ func AppendTopLevelDecl(decls, decl interface{}) ([]TopLevelDecl, error) {
 	return append(ds, d), nil
 }
 
+// --- [ Source filename ] -----------------------------------------------------
+
+// SourceFilename specifies the source filename of a module.
+type SourceFilename struct {
+	// Unquoted source filename.
+	s string
+}
+
+// NewSourceFilename returns a new source filename based on the given string
+// token.
+func NewSourceFilename(name interface{}) (*SourceFilename, error) {
+	s, err := getTokenString(name)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &SourceFilename{s: unquote(s)}, nil
+}
+
 // --- [ Target specifiers ] ---------------------------------------------------
 
 // DataLayout specifies the data layout of a module.
@@ -128,6 +156,24 @@ func NewTargetTriple(triple interface{}) (*TargetTriple, error) {
 	return &TargetTriple{s: unquote(s)}, nil
 }
 
+// --- [ Module-level inline assembly ] ----------------------------------------
+
+// ModuleAsm specifies a line of module-level inline assembly.
+type ModuleAsm struct {
+	// Unquoted assembly line.
+	s string
+}
+
+// NewModuleAsm returns a new line of module-level inline assembly based on the
+// given string token.
+func NewModuleAsm(asm interface{}) (*ModuleAsm, error) {
+	s, err := getTokenString(asm)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ModuleAsm{s: unquote(s)}, nil
+}
+
 // --- [ Type definitions ] ----------------------------------------------------
 
 // NewTypeDef returns a new type definition based on the given type name and
@@ -141,7 +187,7 @@ func NewTypeDef(name, typ interface{}) (*ast.NamedType, error) {
 	if !ok {
 		return nil, errors.Errorf("invalid type; expected ast.Type, got %T", typ)
 	}
-	return &ast.NamedType{Name: unquote(n.name), Def: t}, nil
+	return &ast.NamedType{Name: unquote(n.name), Def: t, Pos: n.pos}, nil
 }
 
 // NewTypeDefOpaque returns a new opaque struct type definition based on the
@@ -152,26 +198,42 @@ func NewTypeDefOpaque(name interface{}) (*ast.NamedType, error) {
 		return nil, errors.Errorf("invalid type name type; expected *astx.LocalIdent, got %T", name)
 	}
 	t := &ast.StructType{Opaque: true}
-	return &ast.NamedType{Name: unquote(n.name), Def: t}, nil
+	return &ast.NamedType{Name: unquote(n.name), Def: t, Pos: n.pos}, nil
+}
+
+// --- [ Comdat definitions ] --------------------------------------------------
+
+// NewComdatDef returns a new comdat definition based on the given comdat name
+// and selection kind.
+func NewComdatDef(name, kind interface{}) (*ast.Comdat, error) {
+	n, ok := name.(*ComdatName)
+	if !ok {
+		return nil, errors.Errorf("invalid comdat name type; expected *astx.ComdatName, got %T", name)
+	}
+	k, ok := kind.(ast.SelectionKind)
+	if !ok {
+		return nil, errors.Errorf("invalid selection kind type; expected ast.SelectionKind, got %T", kind)
+	}
+	return &ast.Comdat{Name: n.name, Kind: k, Pos: n.pos}, nil
 }
 
 // --- [ Global variables ] ----------------------------------------------------
 
 // NewGlobalDecl returns a new global variable declaration based on the given
-// global variable name, address space, immutability, type and attached
-// metadata.
-func NewGlobalDecl(name, addrspace, immutable, typ, mds interface{}) (*ast.Global, error) {
+// global variable name, linkage, global options, immutability, type, section,
+// comdat, alignment and attached metadata.
+func NewGlobalDecl(name, linkage, opts, immutable, typ, section, comdat, align, mds interface{}) (*ast.Global, error) {
 	n, ok := name.(*GlobalIdent)
 	if !ok {
 		return nil, errors.Errorf("invalid global name type; expected *astx.GlobalIdent, got %T", name)
 	}
-	var space int
-	if addrspace != nil {
-		x, err := getInt64(addrspace)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		space = int(x)
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
+	o, ok := opts.(*GlobalOptions)
+	if !ok {
+		return nil, errors.Errorf("invalid global options type; expected *astx.GlobalOptions, got %T", opts)
 	}
 	imm, ok := immutable.(bool)
 	if !ok {
@@ -185,24 +247,35 @@ func NewGlobalDecl(name, addrspace, immutable, typ, mds interface{}) (*ast.Globa
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.Global{Name: unquote(n.name), Content: t, Immutable: imm, AddrSpace: space, Metadata: metadata}, nil
+	global := &ast.Global{Name: unquote(n.name), Content: t, Immutable: imm, Linkage: l, Metadata: metadata, Pos: n.pos}
+	o.apply(global)
+	if global.Section, err = getSection(section); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if global.Comdat, err = getComdat(comdat, global.Name); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if global.Align, err = getAlign(align); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return global, nil
 }
 
 // NewGlobalDef returns a new global variable definition based on the given
-// global variable name, address space, immutability, type, value and attached
-// metadata.
-func NewGlobalDef(name, addrspace, immutable, typ, val, mds interface{}) (*ast.Global, error) {
+// global variable name, linkage, global options, immutability, type, value,
+// section, comdat, alignment and attached metadata.
+func NewGlobalDef(name, linkage, opts, immutable, typ, val, section, comdat, align, mds interface{}) (*ast.Global, error) {
 	n, ok := name.(*GlobalIdent)
 	if !ok {
 		return nil, errors.Errorf("invalid global name type; expected *astx.GlobalIdent, got %T", name)
 	}
-	var space int
-	if addrspace != nil {
-		x, err := getInt64(addrspace)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		space = int(x)
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
+	o, ok := opts.(*GlobalOptions)
+	if !ok {
+		return nil, errors.Errorf("invalid global options type; expected *astx.GlobalOptions, got %T", opts)
 	}
 	imm, ok := immutable.(bool)
 	if !ok {
@@ -224,18 +297,228 @@ func NewGlobalDef(name, addrspace, immutable, typ, val, mds interface{}) (*ast.G
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.Global{Name: unquote(n.name), Content: t, Init: i, Immutable: imm, AddrSpace: space, Metadata: metadata}, nil
+	global := &ast.Global{Name: unquote(n.name), Content: t, Init: i, Immutable: imm, Linkage: l, Metadata: metadata, Pos: n.pos}
+	o.apply(global)
+	if global.Section, err = getSection(section); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if global.Comdat, err = getComdat(comdat, global.Name); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if global.Align, err = getAlign(align); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return global, nil
+}
+
+// GlobalOptions represents the optional specifiers of a global variable,
+// between its linkage and immutability.
+type GlobalOptions struct {
+	// Visibility style.
+	visibility ast.Visibility
+	// DLL storage class.
+	dllStorageClass ast.DLLStorageClass
+	// Thread local storage model.
+	tlsModel ast.TLSModel
+	// Unnamed address specifier.
+	unnamedAddr ast.UnnamedAddr
+	// Address space; or 0 for default address space.
+	addrSpace int
+	// Externally initialized.
+	externallyInitialized bool
+}
+
+// NewGlobalOptions returns a new global options specifier based on the given
+// visibility style, DLL storage class, thread local storage model, unnamed
+// address specifier, address space and externally initialized flag.
+func NewGlobalOptions(visibility, dllStorageClass, tlsModel, unnamedAddr, addrspace, externallyInitialized interface{}) (*GlobalOptions, error) {
+	vis, ok := visibility.(ast.Visibility)
+	if !ok {
+		return nil, errors.Errorf("invalid visibility style type; expected ast.Visibility, got %T", visibility)
+	}
+	dll, ok := dllStorageClass.(ast.DLLStorageClass)
+	if !ok {
+		return nil, errors.Errorf("invalid DLL storage class type; expected ast.DLLStorageClass, got %T", dllStorageClass)
+	}
+	tls, ok := tlsModel.(ast.TLSModel)
+	if !ok {
+		return nil, errors.Errorf("invalid thread local storage model type; expected ast.TLSModel, got %T", tlsModel)
+	}
+	unnamed, ok := unnamedAddr.(ast.UnnamedAddr)
+	if !ok {
+		return nil, errors.Errorf("invalid unnamed address specifier type; expected ast.UnnamedAddr, got %T", unnamedAddr)
+	}
+	var space int
+	if addrspace != nil {
+		x, err := getInt64(addrspace)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		space = int(x)
+	}
+	extInit, ok := externallyInitialized.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid externally initialized type; expected bool, got %T", externallyInitialized)
+	}
+	opts := &GlobalOptions{
+		visibility:            vis,
+		dllStorageClass:       dll,
+		tlsModel:              tls,
+		unnamedAddr:           unnamed,
+		addrSpace:             space,
+		externallyInitialized: extInit,
+	}
+	return opts, nil
+}
+
+// apply sets the global options of the given global variable.
+func (opts *GlobalOptions) apply(global *ast.Global) {
+	global.Visibility = opts.visibility
+	global.DLLStorageClass = opts.dllStorageClass
+	global.TLSModel = opts.tlsModel
+	global.UnnamedAddr = opts.unnamedAddr
+	global.AddrSpace = opts.addrSpace
+	global.ExternallyInitialized = opts.externallyInitialized
+}
+
+// --- [ Aliases ] -------------------------------------------------------------
+
+// NewAlias returns a new alias based on the given alias name, linkage, global
+// options, content type, aliasee type and aliasee. The aliasee type is nil if
+// implied by the aliasee constant expression.
+func NewAlias(name, linkage, opts, typ, aliaseeTyp, aliasee interface{}) (*ast.Alias, error) {
+	n, ok := name.(*GlobalIdent)
+	if !ok {
+		return nil, errors.Errorf("invalid alias name type; expected *astx.GlobalIdent, got %T", name)
+	}
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
+	o, ok := opts.(*GlobalOptions)
+	if !ok {
+		return nil, errors.Errorf("invalid global options type; expected *astx.GlobalOptions, got %T", opts)
+	}
+	if o.addrSpace != 0 {
+		return nil, errors.Errorf("invalid address space of alias %q; address space is inferred from the aliasee", unquote(n.name))
+	}
+	if o.externallyInitialized {
+		return nil, errors.Errorf("invalid externally initialized alias %q", unquote(n.name))
+	}
+	t, ok := typ.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid content type; expected ast.Type, got %T", typ)
+	}
+	if aliaseeTyp == nil {
+		aliaseeTyp = impliedType(t, aliasee)
+	}
+	v, err := NewValue(aliaseeTyp, aliasee)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a, ok := v.(ast.Constant)
+	if !ok {
+		return nil, errors.Errorf("invalid aliasee type; expected ast.Constant, got %T", v)
+	}
+	alias := &ast.Alias{
+		Name:            unquote(n.name),
+		Content:         t,
+		Aliasee:         a,
+		Linkage:         l,
+		Visibility:      o.visibility,
+		DLLStorageClass: o.dllStorageClass,
+		TLSModel:        o.tlsModel,
+		UnnamedAddr:     o.unnamedAddr,
+		Pos:             n.pos,
+	}
+	return alias, nil
+}
+
+// --- [ IFuncs ] --------------------------------------------------------------
+
+// NewIFunc returns a new IFunc based on the given IFunc name, linkage, global
+// options, content type, resolver type and resolver. The resolver type is nil
+// if implied by the resolver constant expression.
+func NewIFunc(name, linkage, opts, typ, resolverTyp, resolver interface{}) (*ast.IFunc, error) {
+	n, ok := name.(*GlobalIdent)
+	if !ok {
+		return nil, errors.Errorf("invalid IFunc name type; expected *astx.GlobalIdent, got %T", name)
+	}
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
+	o, ok := opts.(*GlobalOptions)
+	if !ok {
+		return nil, errors.Errorf("invalid global options type; expected *astx.GlobalOptions, got %T", opts)
+	}
+	if o.dllStorageClass != ast.DLLStorageClassNone || o.tlsModel != ast.TLSModelNone || o.unnamedAddr != ast.UnnamedAddrNone || o.addrSpace != 0 || o.externallyInitialized {
+		return nil, errors.Errorf("invalid global options of IFunc %q; only visibility style supported", unquote(n.name))
+	}
+	t, ok := typ.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid content type; expected ast.Type, got %T", typ)
+	}
+	if resolverTyp == nil {
+		resolverTyp = impliedType(t, resolver)
+	}
+	v, err := NewValue(resolverTyp, resolver)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	r, ok := v.(ast.Constant)
+	if !ok {
+		return nil, errors.Errorf("invalid resolver type; expected ast.Constant, got %T", v)
+	}
+	ifunc := &ast.IFunc{
+		Name:       unquote(n.name),
+		Content:    t,
+		Resolver:   r,
+		Linkage:    l,
+		Visibility: o.visibility,
+		Pos:        n.pos,
+	}
+	return ifunc, nil
+}
+
+// impliedType returns the implied type of the given aliasee or resolver constant
+// expression, based on the content type of the alias or IFunc.
+func impliedType(content ast.Type, expr interface{}) ast.Type {
+	switch expr := expr.(type) {
+	case *ast.ExprIntToPtr:
+		return expr.To
+	case *ast.ExprBitCast:
+		return expr.To
+	case *ast.ExprAddrSpaceCast:
+		return expr.To
+	case *ast.ExprGetElementPtr:
+		// The address space of the result is inherited from the source address.
+		typ := &ast.PointerType{Elem: content}
+		if src, ok := expr.Src.(*ast.GlobalDummy); ok {
+			if t, ok := src.Type.(*ast.PointerType); ok {
+				typ.AddrSpace = t.AddrSpace
+			}
+		}
+		return typ
+	default:
+		return &ast.PointerType{Elem: content}
+	}
 }
 
 // --- [ Functions ] -----------------------------------------------------------
 
 // NewFuncDecl returns a new function declaration based on the given attached
-// metadata and function header.
-func NewFuncDecl(mds, header interface{}) (*ast.Function, error) {
+// metadata, linkage and function header.
+func NewFuncDecl(mds, linkage, header interface{}) (*ast.Function, error) {
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
 	f, ok := header.(*ast.Function)
 	if !ok {
 		return nil, errors.Errorf("invalid function header type; expected *ast.Function, got %T", header)
 	}
+	f.Linkage = l
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -244,9 +527,19 @@ func NewFuncDecl(mds, header interface{}) (*ast.Function, error) {
 	return f, nil
 }
 
-// NewFuncHeader returns a new function header based on the given return type,
-// function name and parameters.
-func NewFuncHeader(callconv, ret, name, params interface{}) (*ast.Function, error) {
+// NewFuncHeader returns a new function header based on the given visibility
+// style, DLL storage class, calling convention, return attributes, return type,
+// function name, parameters, unnamed address specifier, function attributes,
+// section, comdat, alignment and personality.
+func NewFuncHeader(visibility, dllStorageClass, callconv, retAttrs, ret, name, params, unnamedAddr, funcAttrs, section, comdat, align, personality interface{}) (*ast.Function, error) {
+	vis, ok := visibility.(ast.Visibility)
+	if !ok {
+		return nil, errors.Errorf("invalid visibility style type; expected ast.Visibility, got %T", visibility)
+	}
+	dll, ok := dllStorageClass.(ast.DLLStorageClass)
+	if !ok {
+		return nil, errors.Errorf("invalid DLL storage class type; expected ast.DLLStorageClass, got %T", dllStorageClass)
+	}
 	var cc ast.CallConv
 	switch callconv := callconv.(type) {
 	case ast.CallConv:
@@ -276,21 +569,58 @@ func NewFuncHeader(callconv, ret, name, params interface{}) (*ast.Function, erro
 	default:
 		return nil, errors.Errorf("invalid function parameters type; expected *astx.Params or nil, got %T", params)
 	}
+	unnamed, ok := unnamedAddr.(ast.UnnamedAddr)
+	if !ok {
+		return nil, errors.Errorf("invalid unnamed address specifier type; expected ast.UnnamedAddr, got %T", unnamedAddr)
+	}
 	f := &ast.Function{
-		Name:     unquote(n.name),
-		Sig:      sig,
-		CallConv: cc,
+		Name:            unquote(n.name),
+		Sig:             sig,
+		Visibility:      vis,
+		DLLStorageClass: dll,
+		CallConv:        cc,
+		UnnamedAddr:     unnamed,
+		Pos:             n.pos,
+	}
+	var err error
+	if f.RetAttrs, err = getAttrs(retAttrs); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.FuncAttrs, err = getAttrs(funcAttrs); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.Section, err = getSection(section); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.Comdat, err = getComdat(comdat, f.Name); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.Align, err = getAlign(align); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	switch personality := personality.(type) {
+	case ast.Constant:
+		f.Personality = personality
+	case nil:
+		// no personality function.
+	default:
+		return nil, errors.Errorf("invalid personality type; expected ast.Constant or nil, got %T", personality)
 	}
 	return f, nil
 }
 
-// NewFuncDef returns a new function definition based on the given function
-// header, attached metadata and body.
-func NewFuncDef(header, mds, body interface{}) (*ast.Function, error) {
+// NewFuncDef returns a new function definition based on the given linkage,
+// function header, attached metadata and body.
+func NewFuncDef(linkage, header, mds, body interface{}) (*ast.Function, error) {
+	l, ok := linkage.(ast.Linkage)
+	if !ok {
+		return nil, errors.Errorf("invalid linkage type; expected ast.Linkage, got %T", linkage)
+	}
 	f, ok := header.(*ast.Function)
 	if !ok {
 		return nil, errors.Errorf("invalid function header type; expected *ast.Function, got %T", header)
 	}
+	f.Linkage = l
 	blocks, ok := body.([]*ast.BasicBlock)
 	if !ok {
 		return nil, errors.Errorf("invalid function body type; expected []*ast.BasicBlock, got %T", body)
@@ -348,13 +678,17 @@ func AppendParam(params, param interface{}) ([]*ast.Param, error) {
 	return append(ps, p), nil
 }
 
-// NewParam returns a new function parameter based on the given parameter type
-// and name.
-func NewParam(typ, name interface{}) (*ast.Param, error) {
+// NewParam returns a new function parameter based on the given parameter type,
+// parameter attributes and name.
+func NewParam(typ, attrs, name interface{}) (*ast.Param, error) {
 	t, ok := typ.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid type; expected ast.Type, got %T", typ)
 	}
+	as, err := getAttrs(attrs)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	var n string
 	switch name := name.(type) {
 	case *LocalIdent:
@@ -364,7 +698,7 @@ func NewParam(typ, name interface{}) (*ast.Param, error) {
 	default:
 		return nil, errors.Errorf("invalid local name type; expected *astx.LocalIdent or nil, got %T", name)
 	}
-	return &ast.Param{Name: n, Type: t}, nil
+	return &ast.Param{Name: n, Type: t, Attrs: as}, nil
 }
 
 // NewCallConv returns a new calling convention based on the given calling
@@ -455,8 +789,25 @@ func NewCallConv(id interface{}) (ast.CallConv, error) {
 	case 92:
 		return ast.CallConvX86_RegCall, nil
 	default:
-		panic(fmt.Errorf("support for calling convention ID %d not yet implemented", x))
+		return ast.CallConvNone, ast.Errorf(id.(*IntLit).pos, "support for calling convention ID %d not yet implemented", x)
+	}
+}
+
+// --- [ Attribute group definitions ] -----------------------------------------
+
+// NewAttrGroupDef returns a new attribute group definition based on the given
+// attribute group ID and function attributes.
+func NewAttrGroupDef(id, attrs interface{}) (*ast.AttrGroupDef, error) {
+	def, ok := id.(*ast.AttrGroupDef)
+	if !ok {
+		return nil, errors.Errorf("invalid attribute group ID type; expected *ast.AttrGroupDef, got %T", id)
 	}
+	as, ok := attrs.([]ast.Attribute)
+	if !ok {
+		return nil, errors.Errorf("invalid function attribute list type; expected []ast.Attribute, got %T", attrs)
+	}
+	def.Attrs = as
+	return def, nil
 }
 
 // --- [ Metadata definitions ] ------------------------------------------------
@@ -481,7 +832,7 @@ func NewNamedMetadataDef(name, ids interface{}) (*ast.NamedMetadata, error) {
 		Name: unquote(n.name),
 	}
 	for _, i := range is {
-		dummy := &ast.MetadataIDDummy{ID: i.ID}
+		dummy := &ast.MetadataIDDummy{ID: i.ID, Pos: i.Pos}
 		md.Metadata = append(md.Metadata, dummy)
 	}
 	return md, nil
@@ -524,6 +875,7 @@ func NewMetadataDef(id, md interface{}) (*ast.Metadata, error) {
 	metadata := &ast.Metadata{
 		ID:    i.ID,
 		Nodes: m.Nodes,
+		Pos:   i.Pos,
 	}
 	return metadata, nil
 }
@@ -591,66 +943,221 @@ func NewMetadataValue(node interface{}) (ast.MetadataNode, error) {
 	}
 }
 
+// === [ Attributes ] ==========================================================
+
+// NewAttrList returns a new attribute list based on the given attribute.
+func NewAttrList(attr interface{}) ([]ast.Attribute, error) {
+	a, ok := attr.(ast.Attribute)
+	if !ok {
+		return nil, errors.Errorf("invalid attribute type; expected ast.Attribute, got %T", attr)
+	}
+	return []ast.Attribute{a}, nil
+}
+
+// AppendAttr appends the given attribute to the attribute list.
+func AppendAttr(attrs, attr interface{}) ([]ast.Attribute, error) {
+	as, ok := attrs.([]ast.Attribute)
+	if !ok {
+		return nil, errors.Errorf("invalid attribute list type; expected []ast.Attribute, got %T", attrs)
+	}
+	a, ok := attr.(ast.Attribute)
+	if !ok {
+		return nil, errors.Errorf("invalid attribute type; expected ast.Attribute, got %T", attr)
+	}
+	return append(as, a), nil
+}
+
+// NewStringAttr returns a new string attribute based on the given key and
+// optional value string tokens.
+func NewStringAttr(key, val interface{}) (*ast.StringAttr, error) {
+	k, err := getTokenString(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a := &ast.StringAttr{Key: unquote(k)}
+	if val != nil {
+		v, err := getTokenString(val)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		a.Val = unquote(v)
+	}
+	return a, nil
+}
+
+// NewAlignAttr returns a new alignment attribute based on the given integer
+// literal.
+func NewAlignAttr(align interface{}) (ast.AlignAttr, error) {
+	x, err := getInt64(align)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return ast.AlignAttr(x), nil
+}
+
+// NewAlignStackAttr returns a new stack alignment attribute based on the given
+// integer literal.
+func NewAlignStackAttr(align interface{}) (ast.AlignStackAttr, error) {
+	x, err := getInt64(align)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return ast.AlignStackAttr(x), nil
+}
+
+// NewDereferenceableAttr returns a new dereferenceable attribute based on the
+// given integer literal.
+func NewDereferenceableAttr(n interface{}) (ast.DereferenceableAttr, error) {
+	x, err := getInt64(n)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return ast.DereferenceableAttr(x), nil
+}
+
+// NewDereferenceableOrNullAttr returns a new dereferenceable_or_null attribute
+// based on the given integer literal.
+func NewDereferenceableOrNullAttr(n interface{}) (ast.DereferenceableOrNullAttr, error) {
+	x, err := getInt64(n)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return ast.DereferenceableOrNullAttr(x), nil
+}
+
+// NewAllocSizeAttr returns a new allocsize attribute based on the given element
+// size parameter index and optional number of elements parameter index.
+func NewAllocSizeAttr(elemSize, nelems interface{}) (*ast.AllocSizeAttr, error) {
+	e, err := getInt64(elemSize)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a := &ast.AllocSizeAttr{ElemSize: int(e), NElems: -1}
+	if nelems != nil {
+		n, err := getInt64(nelems)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		a.NElems = int(n)
+	}
+	return a, nil
+}
+
+// NewAttrGroupID returns a new reference to an attribute group based on the
+// given attribute group ID token.
+func NewAttrGroupID(tok interface{}) (*ast.AttrGroupDef, error) {
+	t, err := getToken(tok)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	id := strings.TrimPrefix(string(t.Lit), "#")
+	return &ast.AttrGroupDef{ID: id, Pos: t.Pos}, nil
+}
+
+// getAttrs returns the attributes of the given optional attribute list.
+func getAttrs(attrs interface{}) ([]ast.Attribute, error) {
+	switch attrs := attrs.(type) {
+	case []ast.Attribute:
+		return attrs, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("invalid attribute list type; expected []ast.Attribute or nil, got %T", attrs)
+	}
+}
+
 // === [ Identifiers ] =========================================================
 
 // GlobalIdent represents a global identifier.
 type GlobalIdent struct {
 	// Global identifier name the without "@" prefix.
 	name string
+	// Source position of the identifier.
+	pos token.Pos
 }
 
 // NewGlobalIdent returns a new global identifier based on the given global
 // identifier token.
 func NewGlobalIdent(ident interface{}) (*GlobalIdent, error) {
-	s, err := getTokenString(ident)
+	tok, err := getToken(ident)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	s := string(tok.Lit)
 	if !strings.HasPrefix(s, "@") {
 		return nil, errors.Errorf(`invalid global identifier %q; missing "@" prefix`, s)
 	}
 	s = s[1:]
-	return &GlobalIdent{name: s}, nil
+	return &GlobalIdent{name: s, pos: tok.Pos}, nil
 }
 
 // LocalIdent represents a local identifier.
 type LocalIdent struct {
 	// Local identifier name the without "%" prefix.
 	name string
+	// Source position of the identifier.
+	pos token.Pos
 }
 
 // NewLocalIdent returns a new local identifier based on the given local
 // identifier token.
 func NewLocalIdent(ident interface{}) (*LocalIdent, error) {
-	s, err := getTokenString(ident)
+	tok, err := getToken(ident)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	s := string(tok.Lit)
 	if !strings.HasPrefix(s, "%") {
 		return nil, errors.Errorf(`invalid local identifier %q; missing "%%" prefix`, s)
 	}
 	s = s[1:]
-	return &LocalIdent{name: s}, nil
+	return &LocalIdent{name: s, pos: tok.Pos}, nil
 }
 
 // LabelIdent represents a label identifier.
 type LabelIdent struct {
 	// Label identifier name the without ":" suffix.
 	name string
+	// Source position of the identifier.
+	pos token.Pos
 }
 
 // NewLabelIdent returns a new label identifier based on the given label
 // identifier token.
 func NewLabelIdent(ident interface{}) (*LabelIdent, error) {
-	s, err := getTokenString(ident)
+	tok, err := getToken(ident)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	s := string(tok.Lit)
 	if !strings.HasSuffix(s, ":") {
 		return nil, errors.Errorf(`invalid label identifier %q; missing ":" suffix`, s)
 	}
 	s = s[:len(s)-1]
-	return &LabelIdent{name: s}, nil
+	return &LabelIdent{name: s, pos: tok.Pos}, nil
+}
+
+// ComdatName represents a comdat name.
+type ComdatName struct {
+	// Comdat name the without "$" prefix.
+	name string
+	// Source position of the comdat name.
+	pos token.Pos
+}
+
+// NewComdatName returns a new comdat name based on the given comdat name
+// token.
+func NewComdatName(name interface{}) (*ComdatName, error) {
+	tok, err := getToken(name)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	s := string(tok.Lit)
+	if !strings.HasPrefix(s, "$") {
+		return nil, errors.Errorf(`invalid comdat name %q; missing "$" prefix`, s)
+	}
+	s = s[1:]
+	return &ComdatName{name: unquote(s), pos: tok.Pos}, nil
 }
 
 // MetadataName represents a metadata name.
@@ -675,15 +1182,16 @@ func NewMetadataName(name interface{}) (*MetadataName, error) {
 
 // NewMetadataID returns a new metadata id based on the given metadata id token.
 func NewMetadataID(id interface{}) (*ast.MetadataIDDummy, error) {
-	s, err := getTokenString(id)
+	tok, err := getToken(id)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	s := string(tok.Lit)
 	if !strings.HasPrefix(s, "!") {
 		return nil, errors.Errorf(`invalid metadata id %q; missing "!" prefix`, s)
 	}
 	s = s[1:]
-	return &ast.MetadataIDDummy{ID: s}, nil
+	return &ast.MetadataIDDummy{ID: s, Pos: tok.Pos}, nil
 }
 
 // === [ Types ] ===============================================================
@@ -795,8 +1303,9 @@ func NewArrayType(len, elem interface{}) (*ast.ArrayType, error) {
 	return &ast.ArrayType{Elem: e, Len: l}, nil
 }
 
-// NewStructType returns a new struct type based on the given struct fields.
-func NewStructType(fields interface{}) (*ast.StructType, error) {
+// NewStructType returns a new struct type based on the given struct fields and
+// packedness.
+func NewStructType(fields interface{}, packed bool) (*ast.StructType, error) {
 	var fs []ast.Type
 	switch fields := fields.(type) {
 	case []ast.Type:
@@ -806,7 +1315,7 @@ func NewStructType(fields interface{}) (*ast.StructType, error) {
 	default:
 		return nil, errors.Errorf("invalid struct fields type; expected []ast.Type, got %T", fields)
 	}
-	return &ast.StructType{Fields: fs}, nil
+	return &ast.StructType{Fields: fs, Packed: packed}, nil
 }
 
 // NewTypeIdent returns a new type identifier based on the given local
@@ -816,7 +1325,7 @@ func NewTypeIdent(name interface{}) (*ast.NamedTypeDummy, error) {
 	if !ok {
 		return nil, errors.Errorf("invalid type name type; expected *astx.LocalIdent, got %T", name)
 	}
-	return &ast.NamedTypeDummy{Name: unquote(n.name)}, nil
+	return &ast.NamedTypeDummy{Name: unquote(n.name), Pos: n.pos}, nil
 }
 
 // === [ Values ] ==============================================================
@@ -852,9 +1361,9 @@ func NewValue(typ, val interface{}) (ast.Value, error) {
 	}
 	switch val := val.(type) {
 	case *LocalIdent:
-		return &ast.LocalDummy{Name: val.name, Type: t}, nil
+		return &ast.LocalDummy{Name: val.name, Type: t, Pos: val.pos}, nil
 	case *GlobalIdent:
-		return &ast.GlobalDummy{Name: val.name, Type: t}, nil
+		return &ast.GlobalDummy{Name: val.name, Type: t, Pos: val.pos}, nil
 	case *IntLit:
 		return &ast.IntConst{Type: t, Lit: val.lit}, nil
 	case *BoolLit:
@@ -863,6 +1372,8 @@ func NewValue(typ, val interface{}) (ast.Value, error) {
 		return &ast.FloatConst{Type: t, Lit: val.lit}, nil
 	case *NullLit:
 		return &ast.NullConst{Type: t}, nil
+	case *NoneLit:
+		return &ast.NoneConst{}, nil
 	case *ZeroInitializerLit:
 		return &ast.ZeroInitializerConst{Type: t}, nil
 	case *UndefLit:
@@ -947,6 +1458,13 @@ func NewValue(typ, val interface{}) (ast.Value, error) {
 		}
 		val.Type = t
 		return val, nil
+	case *ast.BlockAddressConst:
+		// Block address constant type should be of dummy type.
+		if _, ok := val.Type.(*ast.TypeDummy); !ok {
+			return nil, errors.Errorf("invalid block address constant type, expected *ast.TypeDummy, got %T", val.Type)
+		}
+		val.Type = t
+		return val, nil
 
 	// Binary expressions
 	case *ast.ExprAdd:
@@ -1241,12 +1759,93 @@ func NewValue(typ, val interface{}) (ast.Value, error) {
 		}
 		val.Type = t
 		return val, nil
+
+	// Inline assembler expressions.
+	case *ast.InlineAsm:
+		// Inline assembler expression type should be of dummy type.
+		if _, ok := val.Type.(*ast.TypeDummy); !ok {
+			return nil, errors.Errorf("invalid inline assembler expression type, expected *ast.TypeDummy, got %T", val.Type)
+		}
+		val.Type = t
+		return val, nil
 	default:
-		panic(fmt.Errorf("support for value type %T not yet implemented", val))
+		return nil, errors.Errorf("support for value type %T not yet implemented", val)
 	}
 }
 
-// === [ Constants ] ===========================================================
+// NewLabelList returns a new label list based on the given label.
+func NewLabelList(label interface{}) ([]ast.NamedValue, error) {
+	l, ok := label.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid label type; expected ast.NamedValue, got %T", label)
+	}
+	return []ast.NamedValue{l}, nil
+}
+
+// AppendLabel appends the given label to the label list.
+func AppendLabel(labels, label interface{}) ([]ast.NamedValue, error) {
+	ls, ok := labels.([]ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid label list type; expected []ast.NamedValue, got %T", labels)
+	}
+	l, ok := label.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid label type; expected ast.NamedValue, got %T", label)
+	}
+	return append(ls, l), nil
+}
+
+// NewLabel returns a new label based on the given label type and name.
+func NewLabel(typ, name interface{}) (ast.NamedValue, error) {
+	label, err := NewValue(typ, name)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	l, ok := label.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid label type; expected ast.NamedValue, got %T", label)
+	}
+	return l, nil
+}
+
+// --- [ Inline assembler expressions ] ----------------------------------------
+
+// NewInlineAsm returns a new inline assembler expression based on the given
+// side effect, stack alignment and Intel dialect flags, assembly instructions
+// and constraints.
+func NewInlineAsm(sideEffect, alignStack, intelDialect, asm, constraint interface{}) (*ast.InlineAsm, error) {
+	se, ok := sideEffect.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid side effect type; expected bool, got %T", sideEffect)
+	}
+	as, ok := alignStack.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid stack alignment type; expected bool, got %T", alignStack)
+	}
+	id, ok := intelDialect.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid Intel dialect type; expected bool, got %T", intelDialect)
+	}
+	a, err := getTokenString(asm)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	c, err := getTokenString(constraint)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	inlineAsm := &ast.InlineAsm{
+		Type:         &ast.TypeDummy{},
+		Asm:          unquote(a),
+		Constraint:   unquote(c),
+		SideEffect:   se,
+		AlignStack:   as,
+		IntelDialect: id,
+	}
+	return inlineAsm, nil
+}
+
+// === [ Constants ] ===========================================================
 
 // NewConstantList returns a new constant list based on the given constant.
 func NewConstantList(x interface{}) ([]ast.Constant, error) {
@@ -1287,15 +1886,17 @@ func NewConstant(typ, val interface{}) (ast.Constant, error) {
 type IntLit struct {
 	// Integer literal.
 	lit string
+	// Source position of the integer literal.
+	pos token.Pos
 }
 
 // NewIntLit returns a new integer literal based on the given integer token.
 func NewIntLit(tok interface{}) (*IntLit, error) {
-	s, err := getTokenString(tok)
+	t, err := getToken(tok)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &IntLit{lit: s}, nil
+	return &IntLit{lit: string(t.Lit), pos: t.Pos}, nil
 }
 
 // BoolLit represents a boolean literal.
@@ -1332,6 +1933,10 @@ func NewFloatLit(tok interface{}) (*FloatLit, error) {
 type NullLit struct {
 }
 
+// NoneLit represents a none token literal.
+type NoneLit struct {
+}
+
 // NewVectorConst returns a new vector constant based on the given elements.
 func NewVectorConst(elems interface{}) (*ast.VectorConst, error) {
 	es, ok := elems.([]ast.Constant)
@@ -1367,8 +1972,9 @@ func NewCharArrayConst(str interface{}) (*ast.CharArrayConst, error) {
 	return c, nil
 }
 
-// NewStructConst returns a new struct constant based on the given fields.
-func NewStructConst(fields interface{}) (*ast.StructConst, error) {
+// NewStructConst returns a new struct constant based on the given fields and
+// packedness.
+func NewStructConst(fields interface{}, packed bool) (*ast.StructConst, error) {
 	var fs []ast.Constant
 	switch fields := fields.(type) {
 	case []ast.Constant:
@@ -1378,7 +1984,7 @@ func NewStructConst(fields interface{}) (*ast.StructConst, error) {
 	default:
 		return nil, errors.Errorf("invalid struct fields type; expected []ast.Constant, got %T", fields)
 	}
-	return &ast.StructConst{Type: &ast.TypeDummy{}, Fields: fs}, nil
+	return &ast.StructConst{Type: &ast.TypeDummy{}, Fields: fs, Packed: packed}, nil
 }
 
 // ZeroInitializerLit represents a zeroinitializer literal.
@@ -1389,10 +1995,37 @@ type ZeroInitializerLit struct {
 type UndefLit struct {
 }
 
+// NewBlockAddressConst returns a new block address constant based on the given
+// parent function and basic block.
+func NewBlockAddressConst(f, block interface{}) (*ast.BlockAddressConst, error) {
+	fn, err := NewValue(&ast.TypeDummy{}, f)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fv, ok := fn.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid block address function type; expected ast.NamedValue, got %T", fn)
+	}
+	bb, err := NewValue(&ast.LabelType{}, block)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bv, ok := bb.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid block address basic block type; expected ast.NamedValue, got %T", bb)
+	}
+	return &ast.BlockAddressConst{Type: &ast.TypeDummy{}, Func: fv, Block: bv}, nil
+}
+
 // --- [ Binary expressions ] --------------------------------------------------
 
-// NewAddExpr returns a new add expression based on the given type and operands.
-func NewAddExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAdd, error) {
+// NewAddExpr returns a new add expression based on the given overflow flags,
+// type and operands.
+func NewAddExpr(flags, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAdd, error) {
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1401,7 +2034,7 @@ func NewAddExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAdd, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprAdd{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprAdd{Type: &ast.TypeDummy{}, X: x, Y: y, OverflowFlags: fs}, nil
 }
 
 // NewFAddExpr returns a new fadd expression based on the given type and
@@ -1418,8 +2051,13 @@ func NewFAddExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprFAdd, error) {
 	return &ast.ExprFAdd{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
 }
 
-// NewSubExpr returns a new sub expression based on the given type and operands.
-func NewSubExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSub, error) {
+// NewSubExpr returns a new sub expression based on the given overflow flags,
+// type and operands.
+func NewSubExpr(flags, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSub, error) {
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1428,7 +2066,7 @@ func NewSubExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSub, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprSub{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprSub{Type: &ast.TypeDummy{}, X: x, Y: y, OverflowFlags: fs}, nil
 }
 
 // NewFSubExpr returns a new fsub expression based on the given type and
@@ -1445,8 +2083,13 @@ func NewFSubExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprFSub, error) {
 	return &ast.ExprFSub{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
 }
 
-// NewMulExpr returns a new mul expression based on the given type and operands.
-func NewMulExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprMul, error) {
+// NewMulExpr returns a new mul expression based on the given overflow flags,
+// type and operands.
+func NewMulExpr(flags, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprMul, error) {
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1455,7 +2098,7 @@ func NewMulExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprMul, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprMul{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprMul{Type: &ast.TypeDummy{}, X: x, Y: y, OverflowFlags: fs}, nil
 }
 
 // NewFMulExpr returns a new fmul expression based on the given type and
@@ -1472,9 +2115,13 @@ func NewFMulExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprFMul, error) {
 	return &ast.ExprFMul{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
 }
 
-// NewUDivExpr returns a new udiv expression based on the given type and
-// operands.
-func NewUDivExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprUDiv, error) {
+// NewUDivExpr returns a new udiv expression based on the given exact flag, type
+// and operands.
+func NewUDivExpr(exact, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprUDiv, error) {
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1483,12 +2130,16 @@ func NewUDivExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprUDiv, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprUDiv{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprUDiv{Type: &ast.TypeDummy{}, X: x, Y: y, Exact: e}, nil
 }
 
-// NewSDivExpr returns a new sdiv expression based on the given type and
-// operands.
-func NewSDivExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSDiv, error) {
+// NewSDivExpr returns a new sdiv expression based on the given exact flag, type
+// and operands.
+func NewSDivExpr(exact, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSDiv, error) {
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1497,7 +2148,7 @@ func NewSDivExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprSDiv, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprSDiv{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprSDiv{Type: &ast.TypeDummy{}, X: x, Y: y, Exact: e}, nil
 }
 
 // NewFDivExpr returns a new fdiv expression based on the given type and
@@ -1558,8 +2209,13 @@ func NewFRemExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprFRem, error) {
 
 // --- [ Bitwise expressions ] -------------------------------------------------
 
-// NewShlExpr returns a new shl expression based on the given type and operands.
-func NewShlExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprShl, error) {
+// NewShlExpr returns a new shl expression based on the given overflow flags,
+// type and operands.
+func NewShlExpr(flags, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprShl, error) {
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1568,12 +2224,16 @@ func NewShlExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprShl, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprShl{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprShl{Type: &ast.TypeDummy{}, X: x, Y: y, OverflowFlags: fs}, nil
 }
 
-// NewLShrExpr returns a new lshr expression based on the given type and
-// operands.
-func NewLShrExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprLShr, error) {
+// NewLShrExpr returns a new lshr expression based on the given exact flag, type
+// and operands.
+func NewLShrExpr(exact, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprLShr, error) {
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1582,12 +2242,16 @@ func NewLShrExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprLShr, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprLShr{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprLShr{Type: &ast.TypeDummy{}, X: x, Y: y, Exact: e}, nil
 }
 
-// NewAShrExpr returns a new ashr expression based on the given type and
-// operands.
-func NewAShrExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAShr, error) {
+// NewAShrExpr returns a new ashr expression based on the given exact flag, type
+// and operands.
+func NewAShrExpr(exact, xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAShr, error) {
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewConstant(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -1596,7 +2260,7 @@ func NewAShrExpr(xTyp, xVal, yTyp, yVal interface{}) (*ast.ExprAShr, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.ExprAShr{Type: &ast.TypeDummy{}, X: x, Y: y}, nil
+	return &ast.ExprAShr{Type: &ast.TypeDummy{}, X: x, Y: y, Exact: e}, nil
 }
 
 // NewAndExpr returns a new and expression based on the given type and operands.
@@ -1733,8 +2397,13 @@ func NewInsertValueExpr(xTyp, xVal, elemTyp, elemVal, indices interface{}) (*ast
 // --- [ Memory expressions ] --------------------------------------------------
 
 // NewGetElementPtrExpr returns a new getelementptr expression based on the
-// given element type, source address type and value, and element indices.
-func NewGetElementPtrExpr(elem, srcTyp, srcVal, indices interface{}) (*ast.ExprGetElementPtr, error) {
+// given in-bounds flag, element type, source address type and value, and
+// element indices.
+func NewGetElementPtrExpr(inbounds, elem, srcTyp, srcVal, indices interface{}) (*ast.ExprGetElementPtr, error) {
+	in, ok := inbounds.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid inbounds flag type; expected bool, got %T", inbounds)
+	}
 	e, ok := elem.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid element type; expected ast.Type, got %T", elem)
@@ -1752,7 +2421,17 @@ func NewGetElementPtrExpr(elem, srcTyp, srcVal, indices interface{}) (*ast.ExprG
 	default:
 		return nil, errors.Errorf("invalid indices type; expected []ast.Constant or nil, got %T", indices)
 	}
-	return &ast.ExprGetElementPtr{Type: &ast.TypeDummy{}, Elem: e, Src: src, Indices: is}, nil
+	return &ast.ExprGetElementPtr{Type: &ast.TypeDummy{}, Elem: e, Src: src, Indices: is, InBounds: in}, nil
+}
+
+// NewInRangeIndex returns a new element index marked as inrange based on the
+// given type and value.
+func NewInRangeIndex(typ, val interface{}) (*ast.Index, error) {
+	c, err := NewConstant(typ, val)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.Index{Constant: c, InRange: true}, nil
 }
 
 // --- [ Conversion expressions ] ----------------------------------------------
@@ -2027,6 +2706,7 @@ func NewBasicBlock(name, insts, term interface{}) (*ast.BasicBlock, error) {
 	switch name := name.(type) {
 	case *LabelIdent:
 		block.Name = name.name
+		block.Pos = name.pos
 	case nil:
 		// unnamed basic block.
 	default:
@@ -2047,30 +2727,29 @@ func NewBasicBlock(name, insts, term interface{}) (*ast.BasicBlock, error) {
 	}
 	block.Insts = is
 	block.Term = t
+	// Use the position of the first instruction for unnamed basic blocks.
+	if block.Pos.Line == 0 {
+		if len(is) > 0 {
+			block.Pos = is[0].GetPos()
+		} else {
+			block.Pos = t.GetPos()
+		}
+	}
 	return block, nil
 }
 
 // === [ Instructions ] ========================================================
 
-// NewInstructionList returns a new instruction list based on the given
-// instruction.
-func NewInstructionList(inst interface{}) ([]ast.Instruction, error) {
-	// TODO: Remove once all instructions in the BNF are supported.
-	if inst == nil {
-		return []ast.Instruction{}, nil
-	}
-	i, ok := inst.(ast.Instruction)
-	if !ok {
-		return nil, errors.Errorf("invalid instruction type; expected ast.Instruction, got %T", inst)
-	}
-	return []ast.Instruction{i}, nil
-}
-
 // AppendInstruction appends the given instruction to the instruction list.
 func AppendInstruction(insts, inst interface{}) ([]ast.Instruction, error) {
-	is, ok := insts.([]ast.Instruction)
-	if !ok {
-		return nil, errors.Errorf("invalid instruction list type; expected []ast.Instruction, got %T", insts)
+	var is []ast.Instruction
+	switch insts := insts.(type) {
+	case []ast.Instruction:
+		is = insts
+	case nil:
+		// no instructions.
+	default:
+		return nil, errors.Errorf("invalid instruction list type; expected []ast.Instruction or nil, got %T", insts)
 	}
 	// TODO: Remove once all instructions in the BNF are supported.
 	if inst == nil {
@@ -2105,9 +2784,17 @@ func NewNamedInstruction(name, inst interface{}) (ast.Instruction, error) {
 
 // --- [ Binary instructions ] -------------------------------------------------
 
-// NewAddInst returns a new add instruction based on the given type, operands
-// and attached metadata.
-func NewAddInst(typ, xVal, yVal, mds interface{}) (*ast.InstAdd, error) {
+// NewAddInst returns a new add instruction based on the given opcode token,
+// overflow flags, type, operands and attached metadata.
+func NewAddInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstAdd, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2120,12 +2807,20 @@ func NewAddInst(typ, xVal, yVal, mds interface{}) (*ast.InstAdd, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstAdd{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstAdd{Pos: pos, X: x, Y: y, OverflowFlags: fs, Metadata: metadata}, nil
 }
 
-// NewFAddInst returns a new fadd instruction based on the given type, operands
-// and attached metadata.
-func NewFAddInst(typ, xVal, yVal, mds interface{}) (*ast.InstFAdd, error) {
+// NewFAddInst returns a new fadd instruction based on the given opcode token,
+// fast-math flags, type, operands and attached metadata.
+func NewFAddInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstFAdd, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2138,12 +2833,20 @@ func NewFAddInst(typ, xVal, yVal, mds interface{}) (*ast.InstFAdd, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFAdd{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFAdd{Pos: pos, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
-// NewSubInst returns a new sub instruction based on the given type, operands
-// and attached metadata.
-func NewSubInst(typ, xVal, yVal, mds interface{}) (*ast.InstSub, error) {
+// NewSubInst returns a new sub instruction based on the given opcode token,
+// overflow flags, type, operands and attached metadata.
+func NewSubInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstSub, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2156,12 +2859,20 @@ func NewSubInst(typ, xVal, yVal, mds interface{}) (*ast.InstSub, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSub{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstSub{Pos: pos, X: x, Y: y, OverflowFlags: fs, Metadata: metadata}, nil
 }
 
-// NewFSubInst returns a new fsub instruction based on the given type, operands
-// and attached metadata.
-func NewFSubInst(typ, xVal, yVal, mds interface{}) (*ast.InstFSub, error) {
+// NewFSubInst returns a new fsub instruction based on the given opcode token,
+// fast-math flags, type, operands and attached metadata.
+func NewFSubInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstFSub, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2174,12 +2885,20 @@ func NewFSubInst(typ, xVal, yVal, mds interface{}) (*ast.InstFSub, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFSub{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFSub{Pos: pos, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
-// NewMulInst returns a new mul instruction based on the given type, operands
-// and attached metadata.
-func NewMulInst(typ, xVal, yVal, mds interface{}) (*ast.InstMul, error) {
+// NewMulInst returns a new mul instruction based on the given opcode token,
+// overflow flags, type, operands and attached metadata.
+func NewMulInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstMul, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2192,12 +2911,20 @@ func NewMulInst(typ, xVal, yVal, mds interface{}) (*ast.InstMul, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstMul{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstMul{Pos: pos, X: x, Y: y, OverflowFlags: fs, Metadata: metadata}, nil
 }
 
-// NewFMulInst returns a new fmul instruction based on the given type, operands
-// and attached metadata.
-func NewFMulInst(typ, xVal, yVal, mds interface{}) (*ast.InstFMul, error) {
+// NewFMulInst returns a new fmul instruction based on the given opcode token,
+// fast-math flags, type, operands and attached metadata.
+func NewFMulInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstFMul, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2210,12 +2937,20 @@ func NewFMulInst(typ, xVal, yVal, mds interface{}) (*ast.InstFMul, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFMul{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFMul{Pos: pos, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
-// NewUDivInst returns a new udiv instruction based on the given type, operands
-// and attached metadata.
-func NewUDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstUDiv, error) {
+// NewUDivInst returns a new udiv instruction based on the given opcode token,
+// exact flag, type, operands and attached metadata.
+func NewUDivInst(opcode, exact, typ, xVal, yVal, mds interface{}) (*ast.InstUDiv, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2228,12 +2963,20 @@ func NewUDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstUDiv, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstUDiv{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstUDiv{Pos: pos, X: x, Y: y, Exact: e, Metadata: metadata}, nil
 }
 
-// NewSDivInst returns a new sdiv instruction based on the given type, operands
-// and attached metadata.
-func NewSDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstSDiv, error) {
+// NewSDivInst returns a new sdiv instruction based on the given opcode token,
+// exact flag, type, operands and attached metadata.
+func NewSDivInst(opcode, exact, typ, xVal, yVal, mds interface{}) (*ast.InstSDiv, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2246,12 +2989,20 @@ func NewSDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstSDiv, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSDiv{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstSDiv{Pos: pos, X: x, Y: y, Exact: e, Metadata: metadata}, nil
 }
 
-// NewFDivInst returns a new fdiv instruction based on the given type, operands
-// and attached metadata.
-func NewFDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstFDiv, error) {
+// NewFDivInst returns a new fdiv instruction based on the given opcode token,
+// fast-math flags, type, operands and attached metadata.
+func NewFDivInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstFDiv, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2264,12 +3015,16 @@ func NewFDivInst(typ, xVal, yVal, mds interface{}) (*ast.InstFDiv, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFDiv{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFDiv{Pos: pos, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
-// NewURemInst returns a new urem instruction based on the given type, operands
-// and attached metadata.
-func NewURemInst(typ, xVal, yVal, mds interface{}) (*ast.InstURem, error) {
+// NewURemInst returns a new urem instruction based on the given opcode token,
+// type, operands and attached metadata.
+func NewURemInst(opcode, typ, xVal, yVal, mds interface{}) (*ast.InstURem, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2282,12 +3037,16 @@ func NewURemInst(typ, xVal, yVal, mds interface{}) (*ast.InstURem, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstURem{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstURem{Pos: pos, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewSRemInst returns a new srem instruction based on the given type, operands
-// and attached metadata.
-func NewSRemInst(typ, xVal, yVal, mds interface{}) (*ast.InstSRem, error) {
+// NewSRemInst returns a new srem instruction based on the given opcode token,
+// type, operands and attached metadata.
+func NewSRemInst(opcode, typ, xVal, yVal, mds interface{}) (*ast.InstSRem, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2300,12 +3059,20 @@ func NewSRemInst(typ, xVal, yVal, mds interface{}) (*ast.InstSRem, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSRem{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstSRem{Pos: pos, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewFRemInst returns a new frem instruction based on the given type, operands
-// and attached metadata.
-func NewFRemInst(typ, xVal, yVal, mds interface{}) (*ast.InstFRem, error) {
+// NewFRemInst returns a new frem instruction based on the given opcode token,
+// fast-math flags, type, operands and attached metadata.
+func NewFRemInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstFRem, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2318,14 +3085,69 @@ func NewFRemInst(typ, xVal, yVal, mds interface{}) (*ast.InstFRem, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFRem{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFRem{Pos: pos, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
+}
+
+// NewOverflowFlagList returns a new overflow flag list based on the given
+// overflow flag.
+func NewOverflowFlagList(flag interface{}) ([]ast.OverflowFlag, error) {
+	f, ok := flag.(ast.OverflowFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid overflow flag type; expected ast.OverflowFlag, got %T", flag)
+	}
+	return []ast.OverflowFlag{f}, nil
+}
+
+// AppendOverflowFlag appends the given overflow flag to the overflow flag list.
+func AppendOverflowFlag(flags, flag interface{}) ([]ast.OverflowFlag, error) {
+	fs, ok := flags.([]ast.OverflowFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid overflow flag list type; expected []ast.OverflowFlag, got %T", flags)
+	}
+	f, ok := flag.(ast.OverflowFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid overflow flag type; expected ast.OverflowFlag, got %T", flag)
+	}
+	return append(fs, f), nil
+}
+
+// NewFastMathFlagList returns a new fast-math flag list based on the given
+// fast-math flag.
+func NewFastMathFlagList(flag interface{}) ([]ast.FastMathFlag, error) {
+	f, ok := flag.(ast.FastMathFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid fast-math flag type; expected ast.FastMathFlag, got %T", flag)
+	}
+	return []ast.FastMathFlag{f}, nil
+}
+
+// AppendFastMathFlag appends the given fast-math flag to the fast-math flag
+// list.
+func AppendFastMathFlag(flags, flag interface{}) ([]ast.FastMathFlag, error) {
+	fs, ok := flags.([]ast.FastMathFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid fast-math flag list type; expected []ast.FastMathFlag, got %T", flags)
+	}
+	f, ok := flag.(ast.FastMathFlag)
+	if !ok {
+		return nil, errors.Errorf("invalid fast-math flag type; expected ast.FastMathFlag, got %T", flag)
+	}
+	return append(fs, f), nil
 }
 
 // --- [ Bitwise instructions ] ------------------------------------------------
 
-// NewShlInst returns a new shl instruction based on the given type, operands
-// and attached metadata.
-func NewShlInst(typ, xVal, yVal, mds interface{}) (*ast.InstShl, error) {
+// NewShlInst returns a new shl instruction based on the given opcode token,
+// overflow flags, type, operands and attached metadata.
+func NewShlInst(opcode, flags, typ, xVal, yVal, mds interface{}) (*ast.InstShl, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getOverflowFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2338,12 +3160,20 @@ func NewShlInst(typ, xVal, yVal, mds interface{}) (*ast.InstShl, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstShl{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstShl{Pos: pos, X: x, Y: y, OverflowFlags: fs, Metadata: metadata}, nil
 }
 
-// NewLShrInst returns a new lshr instruction based on the given type, operands
-// and attached metadata.
-func NewLShrInst(typ, xVal, yVal, mds interface{}) (*ast.InstLShr, error) {
+// NewLShrInst returns a new lshr instruction based on the given opcode token,
+// exact flag, type, operands and attached metadata.
+func NewLShrInst(opcode, exact, typ, xVal, yVal, mds interface{}) (*ast.InstLShr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2356,12 +3186,20 @@ func NewLShrInst(typ, xVal, yVal, mds interface{}) (*ast.InstLShr, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstLShr{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstLShr{Pos: pos, X: x, Y: y, Exact: e, Metadata: metadata}, nil
 }
 
-// NewAShrInst returns a new ashr instruction based on the given type, operands
-// and attached metadata.
-func NewAShrInst(typ, xVal, yVal, mds interface{}) (*ast.InstAShr, error) {
+// NewAShrInst returns a new ashr instruction based on the given opcode token,
+// exact flag, type, operands and attached metadata.
+func NewAShrInst(opcode, exact, typ, xVal, yVal, mds interface{}) (*ast.InstAShr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e, ok := exact.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid exact flag type; expected bool, got %T", exact)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2374,12 +3212,16 @@ func NewAShrInst(typ, xVal, yVal, mds interface{}) (*ast.InstAShr, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstAShr{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstAShr{Pos: pos, X: x, Y: y, Exact: e, Metadata: metadata}, nil
 }
 
-// NewAndInst returns a new and instruction based on the given type, operands
-// and attached metadata.
-func NewAndInst(typ, xVal, yVal, mds interface{}) (*ast.InstAnd, error) {
+// NewAndInst returns a new and instruction based on the given opcode token,
+// type, operands and attached metadata.
+func NewAndInst(opcode, typ, xVal, yVal, mds interface{}) (*ast.InstAnd, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2392,12 +3234,16 @@ func NewAndInst(typ, xVal, yVal, mds interface{}) (*ast.InstAnd, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstAnd{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstAnd{Pos: pos, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewOrInst returns a new or instruction based on the given type, operands and
-// attached metadata.
-func NewOrInst(typ, xVal, yVal, mds interface{}) (*ast.InstOr, error) {
+// NewOrInst returns a new or instruction based on the given opcode token, type,
+// operands and attached metadata.
+func NewOrInst(opcode, typ, xVal, yVal, mds interface{}) (*ast.InstOr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2410,12 +3256,16 @@ func NewOrInst(typ, xVal, yVal, mds interface{}) (*ast.InstOr, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstOr{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstOr{Pos: pos, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewXorInst returns a new xor instruction based on the given type, operands
-// and attached metadata.
-func NewXorInst(typ, xVal, yVal, mds interface{}) (*ast.InstXor, error) {
+// NewXorInst returns a new xor instruction based on the given opcode token,
+// type, operands and attached metadata.
+func NewXorInst(opcode, typ, xVal, yVal, mds interface{}) (*ast.InstXor, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(typ, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2428,14 +3278,18 @@ func NewXorInst(typ, xVal, yVal, mds interface{}) (*ast.InstXor, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstXor{X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstXor{Pos: pos, X: x, Y: y, Metadata: metadata}, nil
 }
 
 // --- [ Vector instructions ] -------------------------------------------------
 
 // NewExtractElementInst returns a new extractelement instruction based on the
-// given vector, index and attached metadata.
-func NewExtractElementInst(xTyp, xVal, indexTyp, indexVal, mds interface{}) (*ast.InstExtractElement, error) {
+// given opcode token, vector, index and attached metadata.
+func NewExtractElementInst(opcode, xTyp, xVal, indexTyp, indexVal, mds interface{}) (*ast.InstExtractElement, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2448,12 +3302,16 @@ func NewExtractElementInst(xTyp, xVal, indexTyp, indexVal, mds interface{}) (*as
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstExtractElement{X: x, Index: index, Metadata: metadata}, nil
+	return &ast.InstExtractElement{Pos: pos, X: x, Index: index, Metadata: metadata}, nil
 }
 
 // NewInsertElementInst returns a new insertelement instruction based on the
-// given vector, element, index and attached metadata.
-func NewInsertElementInst(xTyp, xVal, elemTyp, elemVal, indexTyp, indexVal, mds interface{}) (*ast.InstInsertElement, error) {
+// given opcode token, vector, element, index and attached metadata.
+func NewInsertElementInst(opcode, xTyp, xVal, elemTyp, elemVal, indexTyp, indexVal, mds interface{}) (*ast.InstInsertElement, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2470,12 +3328,16 @@ func NewInsertElementInst(xTyp, xVal, elemTyp, elemVal, indexTyp, indexVal, mds
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstInsertElement{X: x, Elem: elem, Index: index, Metadata: metadata}, nil
+	return &ast.InstInsertElement{Pos: pos, X: x, Elem: elem, Index: index, Metadata: metadata}, nil
 }
 
 // NewShuffleVectorInst returns a new shufflevector instruction based on the
-// given vectors, shuffle mask and attached metadata.
-func NewShuffleVectorInst(xTyp, xVal, yTyp, yVal, maskTyp, maskVal, mds interface{}) (*ast.InstShuffleVector, error) {
+// given opcode token, vectors, shuffle mask and attached metadata.
+func NewShuffleVectorInst(opcode, xTyp, xVal, yTyp, yVal, maskTyp, maskVal, mds interface{}) (*ast.InstShuffleVector, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2492,14 +3354,18 @@ func NewShuffleVectorInst(xTyp, xVal, yTyp, yVal, maskTyp, maskVal, mds interfac
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstShuffleVector{X: x, Y: y, Mask: mask, Metadata: metadata}, nil
+	return &ast.InstShuffleVector{Pos: pos, X: x, Y: y, Mask: mask, Metadata: metadata}, nil
 }
 
 // --- [ Aggregate instructions ] ----------------------------------------------
 
-// NewExtractValueInst returns a new extractvalue instruction based on the
-// given aggregate value, indices and attached metadata.
-func NewExtractValueInst(xTyp, xVal, indices, mds interface{}) (*ast.InstExtractValue, error) {
+// NewExtractValueInst returns a new extractvalue instruction based on the given
+// opcode token, aggregate value, indices and attached metadata.
+func NewExtractValueInst(opcode, xTyp, xVal, indices, mds interface{}) (*ast.InstExtractValue, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2515,7 +3381,7 @@ func NewExtractValueInst(xTyp, xVal, indices, mds interface{}) (*ast.InstExtract
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstExtractValue{X: x, Indices: is, Metadata: metadata}, nil
+	return &ast.InstExtractValue{Pos: pos, X: x, Indices: is, Metadata: metadata}, nil
 }
 
 // NewIntLitList returns a new integer literal list based on the given integer
@@ -2541,9 +3407,13 @@ func AppendIntLit(is, i interface{}) ([]int64, error) {
 	return append(xs, x), nil
 }
 
-// NewInsertValueInst returns a new insertvalue instruction based on the
-// given aggregate value, element, indices and attached metadata.
-func NewInsertValueInst(xTyp, xVal, elemTyp, elemVal, indices, mds interface{}) (*ast.InstInsertValue, error) {
+// NewInsertValueInst returns a new insertvalue instruction based on the given
+// opcode token, aggregate value, element, indices and attached metadata.
+func NewInsertValueInst(opcode, xTyp, xVal, elemTyp, elemVal, indices, mds interface{}) (*ast.InstInsertValue, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	x, err := NewValue(xTyp, xVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2563,19 +3433,24 @@ func NewInsertValueInst(xTyp, xVal, elemTyp, elemVal, indices, mds interface{})
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstInsertValue{X: x, Elem: elem, Indices: is, Metadata: metadata}, nil
+	return &ast.InstInsertValue{Pos: pos, X: x, Elem: elem, Indices: is, Metadata: metadata}, nil
 }
 
 // --- [ Memory instructions ] -------------------------------------------------
 
-// NewAllocaInst returns a new alloca instruction based on the given element
-// type, number of elements and attached metadata.
-func NewAllocaInst(elem, nelems, mds interface{}) (*ast.InstAlloca, error) {
+// NewAllocaInst returns a new alloca instruction based on the given opcode
+// token, element type, number of elements, alignment, address space and
+// attached metadata.
+func NewAllocaInst(opcode, elem, nelems, align, addrspace, mds interface{}) (*ast.InstAlloca, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	e, ok := elem.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid element type; expected ast.Type, got %T", elem)
 	}
-	inst := &ast.InstAlloca{Elem: e}
+	inst := &ast.InstAlloca{Pos: pos, Elem: e}
 	switch nelems := nelems.(type) {
 	case ast.Value:
 		inst.NElems = nelems
@@ -2584,6 +3459,12 @@ func NewAllocaInst(elem, nelems, mds interface{}) (*ast.InstAlloca, error) {
 	default:
 		return nil, errors.Errorf("invalid number of elements type; expected ast.Value or nil, got %T", nelems)
 	}
+	if inst.Align, err = getAlign(align); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if inst.AddrSpace, err = getAddrSpace(addrspace); err != nil {
+		return nil, errors.WithStack(err)
+	}
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2592,29 +3473,59 @@ func NewAllocaInst(elem, nelems, mds interface{}) (*ast.InstAlloca, error) {
 	return inst, nil
 }
 
-// NewLoadInst returns a new load instruction based on the given element type,
-// source address type, value and attached metadata.
-func NewLoadInst(elem, srcTyp, srcVal, mds interface{}) (*ast.InstLoad, error) {
-	e, ok := elem.(ast.Type)
-	if !ok {
-		return nil, errors.Errorf("invalid element type; expected ast.Type, got %T", elem)
+// NewLoadInst returns a new load instruction based on the given opcode token,
+// volatile flag, element type, source address type and value, synchronization
+// scope, atomic memory ordering constraints, alignment and attached metadata.
+// The synchronization scope and atomic memory ordering constraints are nil for
+// non-atomic loads.
+func NewLoadInst(opcode, volatile, elem, srcTyp, srcVal, syncScope, ordering, align, mds interface{}) (*ast.InstLoad, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	v, ok := volatile.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid volatile flag type; expected bool, got %T", volatile)
+	}
+	e, ok := elem.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid element type; expected ast.Type, got %T", elem)
 	}
 	src, err := NewValue(srcTyp, srcVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	scope, o, err := getAtomic(syncScope, ordering)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a, err := getAlign(align)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	// Store e in InstLoad to evaluate against src.Type().Elem() after type
 	// resolution.
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstLoad{Elem: e, Src: src, Metadata: metadata}, nil
+	return &ast.InstLoad{Pos: pos, Elem: e, Src: src, Ordering: o, SyncScope: scope, Volatile: v, Align: a, Metadata: metadata}, nil
 }
 
-// NewStoreInst returns a new store instruction based on the given element type,
-// source address type, value and attached metadata.
-func NewStoreInst(srcTyp, srcVal, dstTyp, dstVal, mds interface{}) (*ast.InstStore, error) {
+// NewStoreInst returns a new store instruction based on the given opcode token,
+// volatile flag, source value type and value, destination address type and
+// value, synchronization scope, atomic memory ordering constraints, alignment
+// and attached metadata. The synchronization scope and atomic memory ordering
+// constraints are nil for non-atomic stores.
+func NewStoreInst(opcode, volatile, srcTyp, srcVal, dstTyp, dstVal, syncScope, ordering, align, mds interface{}) (*ast.InstStore, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	v, ok := volatile.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid volatile flag type; expected bool, got %T", volatile)
+	}
 	src, err := NewValue(srcTyp, srcVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2623,17 +3534,152 @@ func NewStoreInst(srcTyp, srcVal, dstTyp, dstVal, mds interface{}) (*ast.InstSto
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	scope, o, err := getAtomic(syncScope, ordering)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a, err := getAlign(align)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstStore{Pos: pos, Src: src, Dst: dst, Ordering: o, SyncScope: scope, Volatile: v, Align: a, Metadata: metadata}, nil
+}
+
+// NewFenceInst returns a new fence instruction based on the given opcode token,
+// synchronization scope, atomic memory ordering constraints and attached
+// metadata.
+func NewFenceInst(opcode, syncScope, ordering, mds interface{}) (*ast.InstFence, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	scope, ok := syncScope.(string)
+	if !ok {
+		return nil, errors.Errorf("invalid synchronization scope type; expected string, got %T", syncScope)
+	}
+	o, ok := ordering.(ast.AtomicOrdering)
+	if !ok {
+		return nil, errors.Errorf("invalid atomic memory ordering type; expected ast.AtomicOrdering, got %T", ordering)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstFence{Pos: pos, Ordering: o, SyncScope: scope, Metadata: metadata}, nil
+}
+
+// NewCmpXchgInst returns a new cmpxchg instruction based on the given opcode
+// token, weak and volatile flags, address type and value, compared type and
+// value, new type and value, synchronization scope, atomic memory ordering
+// constraints on success and failure, alignment and attached metadata.
+func NewCmpXchgInst(opcode, weak, volatile, ptrTyp, ptrVal, cmpTyp, cmpVal, newTyp, newVal, syncScope, success, failure, align, mds interface{}) (*ast.InstCmpXchg, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w, ok := weak.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid weak flag type; expected bool, got %T", weak)
+	}
+	v, ok := volatile.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid volatile flag type; expected bool, got %T", volatile)
+	}
+	ptr, err := NewValue(ptrTyp, ptrVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cmp, err := NewValue(cmpTyp, cmpVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	n, err := NewValue(newTyp, newVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	scope, ok := syncScope.(string)
+	if !ok {
+		return nil, errors.Errorf("invalid synchronization scope type; expected string, got %T", syncScope)
+	}
+	s, ok := success.(ast.AtomicOrdering)
+	if !ok {
+		return nil, errors.Errorf("invalid success atomic memory ordering type; expected ast.AtomicOrdering, got %T", success)
+	}
+	f, ok := failure.(ast.AtomicOrdering)
+	if !ok {
+		return nil, errors.Errorf("invalid failure atomic memory ordering type; expected ast.AtomicOrdering, got %T", failure)
+	}
+	a, err := getAlign(align)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstCmpXchg{Pos: pos, Ptr: ptr, Cmp: cmp, New: n, SuccessOrdering: s, FailureOrdering: f, SyncScope: scope, Weak: w, Volatile: v, Align: a, Metadata: metadata}, nil
+}
+
+// NewAtomicRMWInst returns a new atomicrmw instruction based on the given
+// opcode token, volatile flag, atomic operation, destination address type and
+// value, operand type and value, synchronization scope, atomic memory ordering
+// constraints, alignment and attached metadata.
+func NewAtomicRMWInst(opcode, volatile, op, dstTyp, dstVal, xTyp, xVal, syncScope, ordering, align, mds interface{}) (*ast.InstAtomicRMW, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	v, ok := volatile.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid volatile flag type; expected bool, got %T", volatile)
+	}
+	o, ok := op.(ast.AtomicOp)
+	if !ok {
+		return nil, errors.Errorf("invalid atomic operation type; expected ast.AtomicOp, got %T", op)
+	}
+	dst, err := NewValue(dstTyp, dstVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	x, err := NewValue(xTyp, xVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	scope, ok := syncScope.(string)
+	if !ok {
+		return nil, errors.Errorf("invalid synchronization scope type; expected string, got %T", syncScope)
+	}
+	ord, ok := ordering.(ast.AtomicOrdering)
+	if !ok {
+		return nil, errors.Errorf("invalid atomic memory ordering type; expected ast.AtomicOrdering, got %T", ordering)
+	}
+	a, err := getAlign(align)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstStore{Src: src, Dst: dst, Metadata: metadata}, nil
+	return &ast.InstAtomicRMW{Pos: pos, Op: o, Dst: dst, X: x, Ordering: ord, SyncScope: scope, Volatile: v, Align: a, Metadata: metadata}, nil
 }
 
 // NewGetElementPtrInst returns a new getelementptr instruction based on the
-// given element type, source address type and value, element indices and
-// attached metadata.
-func NewGetElementPtrInst(elem, srcTyp, srcVal, indices, mds interface{}) (*ast.InstGetElementPtr, error) {
+// given opcode token, in-bounds flag, element type, source address type and
+// value, element indices and attached metadata.
+func NewGetElementPtrInst(opcode, inbounds, elem, srcTyp, srcVal, indices, mds interface{}) (*ast.InstGetElementPtr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	in, ok := inbounds.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid inbounds flag type; expected bool, got %T", inbounds)
+	}
 	e, ok := elem.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid element type; expected ast.Type, got %T", elem)
@@ -2657,14 +3703,18 @@ func NewGetElementPtrInst(elem, srcTyp, srcVal, indices, mds interface{}) (*ast.
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstGetElementPtr{Elem: e, Src: src, Indices: is, Metadata: metadata}, nil
+	return &ast.InstGetElementPtr{Pos: pos, Elem: e, Src: src, Indices: is, InBounds: in, Metadata: metadata}, nil
 }
 
 // --- [ Conversion instructions ] ---------------------------------------------
 
-// NewTruncInst returns a new trunc instruction based on the given source value,
-// target type and attached metadata.
-func NewTruncInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstTrunc, error) {
+// NewTruncInst returns a new trunc instruction based on the given opcode token,
+// source value, target type and attached metadata.
+func NewTruncInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstTrunc, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2677,12 +3727,16 @@ func NewTruncInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstTrunc, error)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstTrunc{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstTrunc{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewZExtInst returns a new zext instruction based on the given source value,
-// target type and attached metadata.
-func NewZExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstZExt, error) {
+// NewZExtInst returns a new zext instruction based on the given opcode token,
+// source value, target type and attached metadata.
+func NewZExtInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstZExt, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2695,12 +3749,16 @@ func NewZExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstZExt, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstZExt{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstZExt{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewSExtInst returns a new sext instruction based on the given source value,
-// target type and attached metadata.
-func NewSExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstSExt, error) {
+// NewSExtInst returns a new sext instruction based on the given opcode token,
+// source value, target type and attached metadata.
+func NewSExtInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstSExt, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2713,12 +3771,16 @@ func NewSExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstSExt, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSExt{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstSExt{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewFPTruncInst returns a new fptrunc instruction based on the given source
-// value, target type and attached metadata.
-func NewFPTruncInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPTrunc, error) {
+// NewFPTruncInst returns a new fptrunc instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewFPTruncInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstFPTrunc, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2731,12 +3793,16 @@ func NewFPTruncInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPTrunc, er
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFPTrunc{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstFPTrunc{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewFPExtInst returns a new fpext instruction based on the given source value,
-// target type and attached metadata.
-func NewFPExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPExt, error) {
+// NewFPExtInst returns a new fpext instruction based on the given opcode token,
+// source value, target type and attached metadata.
+func NewFPExtInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstFPExt, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2749,12 +3815,16 @@ func NewFPExtInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPExt, error)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFPExt{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstFPExt{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewFPToUIInst returns a new fptoui instruction based on the given source
-// value, target type and attached metadata.
-func NewFPToUIInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToUI, error) {
+// NewFPToUIInst returns a new fptoui instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewFPToUIInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToUI, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2767,12 +3837,16 @@ func NewFPToUIInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToUI, erro
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFPToUI{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstFPToUI{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewFPToSIInst returns a new fptosi instruction based on the given source
-// value, target type and attached metadata.
-func NewFPToSIInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToSI, error) {
+// NewFPToSIInst returns a new fptosi instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewFPToSIInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToSI, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2785,12 +3859,16 @@ func NewFPToSIInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstFPToSI, erro
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFPToSI{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstFPToSI{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewUIToFPInst returns a new uitofp instruction based on the given source
-// value, target type and attached metadata.
-func NewUIToFPInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstUIToFP, error) {
+// NewUIToFPInst returns a new uitofp instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewUIToFPInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstUIToFP, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2803,12 +3881,16 @@ func NewUIToFPInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstUIToFP, erro
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstUIToFP{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstUIToFP{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewSIToFPInst returns a new sitofp instruction based on the given source
-// value, target type and attached metadata.
-func NewSIToFPInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstSIToFP, error) {
+// NewSIToFPInst returns a new sitofp instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewSIToFPInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstSIToFP, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2821,12 +3903,16 @@ func NewSIToFPInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstSIToFP, erro
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSIToFP{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstSIToFP{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewPtrToIntInst returns a new ptrtoint instruction based on the given source
-// value, target type and attached metadata.
-func NewPtrToIntInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstPtrToInt, error) {
+// NewPtrToIntInst returns a new ptrtoint instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewPtrToIntInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstPtrToInt, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2839,12 +3925,16 @@ func NewPtrToIntInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstPtrToInt,
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstPtrToInt{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstPtrToInt{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewIntToPtrInst returns a new inttoptr instruction based on the given source
-// value, target type and attached metadata.
-func NewIntToPtrInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstIntToPtr, error) {
+// NewIntToPtrInst returns a new inttoptr instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewIntToPtrInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstIntToPtr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2857,12 +3947,16 @@ func NewIntToPtrInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstIntToPtr,
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstIntToPtr{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstIntToPtr{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
-// NewBitCastInst returns a new bitcast instruction based on the given source
-// value, target type and attached metadata.
-func NewBitCastInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstBitCast, error) {
+// NewBitCastInst returns a new bitcast instruction based on the given opcode
+// token, source value, target type and attached metadata.
+func NewBitCastInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstBitCast, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2875,12 +3969,16 @@ func NewBitCastInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstBitCast, er
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstBitCast{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstBitCast{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
 // NewAddrSpaceCastInst returns a new addrspacecast instruction based on the
-// given source value, target type and attached metadata.
-func NewAddrSpaceCastInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstAddrSpaceCast, error) {
+// given opcode token, source value, target type and attached metadata.
+func NewAddrSpaceCastInst(opcode, fromTyp, fromVal, to, mds interface{}) (*ast.InstAddrSpaceCast, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	from, err := NewValue(fromTyp, fromVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -2893,14 +3991,18 @@ func NewAddrSpaceCastInst(fromTyp, fromVal, to, mds interface{}) (*ast.InstAddrS
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstAddrSpaceCast{From: from, To: t, Metadata: metadata}, nil
+	return &ast.InstAddrSpaceCast{Pos: pos, From: from, To: t, Metadata: metadata}, nil
 }
 
 // --- [ Other instructions ] --------------------------------------------------
 
-// NewICmpInst returns a new icmp instruction based on the given integer
-// predicate, type, operands and attached metadata.
-func NewICmpInst(pred, typ, xVal, yVal, mds interface{}) (*ast.InstICmp, error) {
+// NewICmpInst returns a new icmp instruction based on the given opcode token,
+// integer predicate, type, operands and attached metadata.
+func NewICmpInst(opcode, pred, typ, xVal, yVal, mds interface{}) (*ast.InstICmp, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	p, ok := pred.(ast.IntPred)
 	if !ok {
 		return nil, errors.Errorf("invalid integer predicate type; expected ast.IntPred, got %T", pred)
@@ -2917,12 +4019,21 @@ func NewICmpInst(pred, typ, xVal, yVal, mds interface{}) (*ast.InstICmp, error)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstICmp{Pred: p, X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstICmp{Pos: pos, Pred: p, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewFCmpInst returns a new fcmp instruction based on the given floating-point
-// predicate, type, operands and attached metadata.
-func NewFCmpInst(pred, typ, xVal, yVal, mds interface{}) (*ast.InstFCmp, error) {
+// NewFCmpInst returns a new fcmp instruction based on the given opcode token,
+// fast-math flags, floating-point predicate, type, operands and attached
+// metadata.
+func NewFCmpInst(opcode, flags, pred, typ, xVal, yVal, mds interface{}) (*ast.InstFCmp, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	p, ok := pred.(ast.FloatPred)
 	if !ok {
 		return nil, errors.Errorf("invalid floating-point predicate type; expected ast.FloatPred, got %T", pred)
@@ -2939,12 +4050,20 @@ func NewFCmpInst(pred, typ, xVal, yVal, mds interface{}) (*ast.InstFCmp, error)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstFCmp{Pred: p, X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstFCmp{Pos: pos, Pred: p, X: x, Y: y, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
-// NewPhiInst returns a new phi instruction based on the given incoming values
-// and attached metadata.
-func NewPhiInst(typ, incs, mds interface{}) (*ast.InstPhi, error) {
+// NewPhiInst returns a new phi instruction based on the given opcode token,
+// fast-math flags, type, incoming values and attached metadata.
+func NewPhiInst(opcode, flags, typ, incs, mds interface{}) (*ast.InstPhi, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	t, ok := typ.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid type; expected ast.Type, got %T", typ)
@@ -2964,7 +4083,7 @@ func NewPhiInst(typ, incs, mds interface{}) (*ast.InstPhi, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstPhi{Type: t, Incs: is, Metadata: metadata}, nil
+	return &ast.InstPhi{Pos: pos, Type: t, Incs: is, FastMathFlags: fs, Metadata: metadata}, nil
 }
 
 // NewIncomingList returns a new incoming value list based on the given incoming
@@ -3008,9 +4127,13 @@ func NewIncoming(x, pred interface{}) (*ast.Incoming, error) {
 	return &ast.Incoming{X: xx, Pred: p}, nil
 }
 
-// NewSelectInst returns a new select instruction based on the given selection
-// condition type and value, operands and attached metadata.
-func NewSelectInst(condTyp, condVal, xTyp, xVal, yTyp, yVal, mds interface{}) (*ast.InstSelect, error) {
+// NewSelectInst returns a new select instruction based on the given opcode
+// token, selection condition type and value, operands and attached metadata.
+func NewSelectInst(opcode, condTyp, condVal, xTyp, xVal, yTyp, yVal, mds interface{}) (*ast.InstSelect, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	cond, err := NewValue(condTyp, condVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -3027,43 +4150,39 @@ func NewSelectInst(condTyp, condVal, xTyp, xVal, yTyp, yVal, mds interface{}) (*
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstSelect{Cond: cond, X: x, Y: y, Metadata: metadata}, nil
+	return &ast.InstSelect{Pos: pos, Cond: cond, X: x, Y: y, Metadata: metadata}, nil
 }
 
-// NewCallInst returns a new call instruction based on the given return type,
-// callee name, function arguments and attached metadata.
-func NewCallInst(callconv, retTyp, callee, args, mds interface{}) (*ast.InstCall, error) {
+// NewCallInst returns a new call instruction based on the given opcode token,
+// tail call kind, fast-math flags, calling convention, return attributes,
+// return type, callee name, function arguments, function attributes, operand
+// bundles and attached metadata.
+func NewCallInst(opcode, tail, flags, callconv, retAttrs, retTyp, callee, args, funcAttrs, bundles, mds interface{}) (*ast.InstCall, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	t, ok := tail.(ast.Tail)
+	if !ok {
+		return nil, errors.Errorf("invalid tail call kind type; expected ast.Tail, got %T", tail)
+	}
+	fs, err := getFastMathFlags(flags)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	cconv, ok := callconv.(ast.CallConv)
 	if !ok {
 		return nil, errors.Errorf("invalid calling convention type; expected ast.CallConv, got %T", callconv)
 	}
+	ras, err := getAttrs(retAttrs)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	r, ok := retTyp.(ast.Type)
 	if !ok {
 		return nil, errors.Errorf("invalid return type; expected ast.Type, got %T", retTyp)
 	}
-	// Ad-hoc solution to update the type of bitcast expressions used as callees
-	// in call instructions. Note, the LLVM IR syntax of call instructions does
-	// not pertain all type information of the callee value use. E.g.
-	//
-	//    %42 = call i32 bitcast (i32 (...)* @open to i32 (i8*, i32, ...)*)(i8* %41, i32 0)
-	calleeType := r
-	if cc, ok := callee.(*ast.ExprBitCast); ok {
-		ccType, ok := cc.To.(*ast.PointerType)
-		if !ok {
-			return nil, errors.Errorf("invalid to type of callee bitcast expression; expected *ast.PointerType, got %T", cc.To)
-		}
-		if _, ok := ccType.Elem.(*ast.FuncType); !ok {
-			return nil, errors.Errorf("invalid to type of callee type pointer elem; expected *ast.FuncType, got %T", ccType.Elem)
-		}
-		// Infer the proper type of retType.
-		if rTyp, ok := retTyp.(*ast.FuncType); ok {
-			calleeType = &ast.PointerType{Elem: rTyp}
-		} else {
-			// Ignore retType if containing incomplete type information.
-			calleeType = ccType
-		}
-	}
-	c, err := NewValue(calleeType, callee)
+	c, err := newCallee(r, callee)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -3076,98 +4195,363 @@ func NewCallInst(callconv, retTyp, callee, args, mds interface{}) (*ast.InstCall
 	default:
 		return nil, errors.Errorf("invalid function arguments type; expected []ast.Value or nil, got %T", args)
 	}
-	metadata, err := uniqueMetadata(mds)
+	fas, err := getAttrs(funcAttrs)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.InstCall{Type: r, Callee: c, Args: as, CallConv: cconv, Metadata: metadata}, nil
-}
-
-// === [ Terminators ] =========================================================
-
-// --- [ ret ] -----------------------------------------------------------------
-
-// NewRetTerm returns a new ret terminator based on the given return type,
-// value and attached metadata.
-func NewRetTerm(xTyp, xVal, mds interface{}) (*ast.TermRet, error) {
+	var bs []*ast.OperandBundle
+	switch bundles := bundles.(type) {
+	case []*ast.OperandBundle:
+		bs = bundles
+	case nil:
+		// no operand bundles.
+	default:
+		return nil, errors.Errorf("invalid operand bundles type; expected []*ast.OperandBundle or nil, got %T", bundles)
+	}
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	if xTyp != nil && xVal != nil {
-		x, err := NewValue(xTyp, xVal)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		return &ast.TermRet{X: x, Metadata: metadata}, nil
-	}
-	return &ast.TermRet{Metadata: metadata}, nil
+	return &ast.InstCall{Pos: pos, Type: r, Callee: c, Args: as, CallConv: cconv, Tail: t, FastMathFlags: fs, RetAttrs: ras, FuncAttrs: fas, OperandBundles: bs, Metadata: metadata}, nil
 }
 
-// --- [ br ] ------------------------------------------------------------------
-
-// NewBrTerm returns a new unconditional br terminator based on the given target
-// branch and attached metadata.
-func NewBrTerm(targetTyp, targetVal, mds interface{}) (*ast.TermBr, error) {
-	target, err := NewValue(targetTyp, targetVal)
+// NewArg returns a new function argument based on the given type, parameter
+// attributes and value. The value is returned unwrapped if no parameter
+// attributes are present.
+func NewArg(typ, attrs, val interface{}) (ast.Value, error) {
+	v, err := NewValue(typ, val)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	t, ok := target.(ast.NamedValue)
-	if !ok {
-		return nil, errors.Errorf("invalid target branch type; expected ast.NamedValue, got %T", target)
-	}
-	metadata, err := uniqueMetadata(mds)
+	as, err := getAttrs(attrs)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.TermBr{Target: t, Metadata: metadata}, nil
+	if len(as) == 0 {
+		return v, nil
+	}
+	return &ast.Arg{Value: v, Attrs: as}, nil
 }
 
-// --- [ conditional br ] ------------------------------------------------------
-
-// NewCondBrTerm returns a new conditional br terminator based on the given
-// branching condition type and value, conditional target branches and attached
-// metadata.
-func NewCondBrTerm(condTyp, condVal, targetTrueTyp, targetTrueVal, targetFalseTyp, targetFalseVal, mds interface{}) (*ast.TermCondBr, error) {
-	cond, err := NewValue(condTyp, condVal)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-	targetTrue, err := NewValue(targetTrueTyp, targetTrueVal)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-	tTrue, ok := targetTrue.(ast.NamedValue)
+// NewOperandBundleList returns a new operand bundle list based on the given
+// operand bundle.
+func NewOperandBundleList(bundle interface{}) ([]*ast.OperandBundle, error) {
+	b, ok := bundle.(*ast.OperandBundle)
 	if !ok {
-		return nil, errors.Errorf("invalid true target branch type; expected ast.NamedValue, got %T", targetTrue)
-	}
-	targetFalse, err := NewValue(targetFalseTyp, targetFalseVal)
-	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.Errorf("invalid operand bundle type; expected *ast.OperandBundle, got %T", bundle)
 	}
-	tFalse, ok := targetFalse.(ast.NamedValue)
+	return []*ast.OperandBundle{b}, nil
+}
+
+// AppendOperandBundle appends the given operand bundle to the operand bundle
+// list.
+func AppendOperandBundle(bundles, bundle interface{}) ([]*ast.OperandBundle, error) {
+	bs, ok := bundles.([]*ast.OperandBundle)
 	if !ok {
-		return nil, errors.Errorf("invalid false target branch type; expected ast.NamedValue, got %T", targetFalse)
+		return nil, errors.Errorf("invalid operand bundle list type; expected []*ast.OperandBundle, got %T", bundles)
 	}
-	metadata, err := uniqueMetadata(mds)
-	if err != nil {
-		return nil, errors.WithStack(err)
+	b, ok := bundle.(*ast.OperandBundle)
+	if !ok {
+		return nil, errors.Errorf("invalid operand bundle type; expected *ast.OperandBundle, got %T", bundle)
 	}
-	return &ast.TermCondBr{Cond: cond, TargetTrue: tTrue, TargetFalse: tFalse, Metadata: metadata}, nil
+	return append(bs, b), nil
 }
 
-// --- [ switch ] --------------------------------------------------------------
-
-// NewSwitchTerm returns a new switch terminator based on the given control
-// variable type and value, default target branch, switch cases and attached
-// metadata.
-func NewSwitchTerm(xTyp, xVal, targetDefaultTyp, targetDefaultVal, cases, mds interface{}) (*ast.TermSwitch, error) {
-	x, err := NewValue(xTyp, xVal)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-	targetDefault, err := NewValue(targetDefaultTyp, targetDefaultVal)
+// NewOperandBundle returns a new operand bundle based on the given tag and
+// inputs.
+func NewOperandBundle(tag, inputs interface{}) (*ast.OperandBundle, error) {
+	t, err := getTokenString(tag)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var is []ast.Value
+	switch inputs := inputs.(type) {
+	case []ast.Value:
+		is = inputs
+	case nil:
+		// no inputs.
+	default:
+		return nil, errors.Errorf("invalid operand bundle inputs type; expected []ast.Value or nil, got %T", inputs)
+	}
+	return &ast.OperandBundle{Tag: unquote(t), Inputs: is}, nil
+}
+
+// newCallee returns a new callee value based on the given return type, or
+// callee type signature, of a call instruction or invoke terminator.
+func newCallee(retTyp ast.Type, callee interface{}) (ast.Value, error) {
+	// Ad-hoc solution to update the type of bitcast expressions used as callees
+	// in call instructions and invoke terminators. Note, the LLVM IR syntax of
+	// call instructions does not pertain all type information of the callee
+	// value use. E.g.
+	//
+	//    %42 = call i32 bitcast (i32 (...)* @open to i32 (i8*, i32, ...)*)(i8* %41, i32 0)
+	calleeType := retTyp
+	if cc, ok := callee.(*ast.ExprBitCast); ok {
+		ccType, ok := cc.To.(*ast.PointerType)
+		if !ok {
+			return nil, errors.Errorf("invalid to type of callee bitcast expression; expected *ast.PointerType, got %T", cc.To)
+		}
+		if _, ok := ccType.Elem.(*ast.FuncType); !ok {
+			return nil, errors.Errorf("invalid to type of callee type pointer elem; expected *ast.FuncType, got %T", ccType.Elem)
+		}
+		// Infer the proper type of retType.
+		if rTyp, ok := retTyp.(*ast.FuncType); ok {
+			calleeType = &ast.PointerType{Elem: rTyp}
+		} else {
+			// Ignore retType if containing incomplete type information.
+			calleeType = ccType
+		}
+	}
+	return NewValue(calleeType, callee)
+}
+
+// NewVAArgInst returns a new va_arg instruction based on the given opcode
+// token, argument list type, argument list, argument type and attached
+// metadata.
+func NewVAArgInst(opcode, argListTyp, argListVal, argType, mds interface{}) (*ast.InstVAArg, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	argList, err := NewValue(argListTyp, argListVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	t, ok := argType.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid argument type; expected ast.Type, got %T", argType)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstVAArg{Pos: pos, ArgList: argList, ArgType: t, Metadata: metadata}, nil
+}
+
+// NewLandingPadInst returns a new landingpad instruction based on the given
+// opcode token, result type, cleanup flag, exception clauses and attached
+// metadata.
+func NewLandingPadInst(opcode, typ, cleanup, clauses, mds interface{}) (*ast.InstLandingPad, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	t, ok := typ.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid result type; expected ast.Type, got %T", typ)
+	}
+	c, ok := cleanup.(bool)
+	if !ok {
+		return nil, errors.Errorf("invalid cleanup flag type; expected bool, got %T", cleanup)
+	}
+	var cs []*ast.Clause
+	switch clauses := clauses.(type) {
+	case []*ast.Clause:
+		cs = clauses
+	case nil:
+		// no clauses.
+	default:
+		return nil, errors.Errorf("invalid exception clauses type; expected []*ast.Clause or nil, got %T", clauses)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstLandingPad{Pos: pos, Type: t, Cleanup: c, Clauses: cs, Metadata: metadata}, nil
+}
+
+// NewClauseList returns a new exception clause list based on the given clause.
+func NewClauseList(clause interface{}) ([]*ast.Clause, error) {
+	c, ok := clause.(*ast.Clause)
+	if !ok {
+		return nil, errors.Errorf("invalid exception clause type; expected *ast.Clause, got %T", clause)
+	}
+	return []*ast.Clause{c}, nil
+}
+
+// AppendClause appends the given clause to the exception clause list.
+func AppendClause(clauses, clause interface{}) ([]*ast.Clause, error) {
+	cs, ok := clauses.([]*ast.Clause)
+	if !ok {
+		return nil, errors.Errorf("invalid exception clause list type; expected []*ast.Clause, got %T", clauses)
+	}
+	c, ok := clause.(*ast.Clause)
+	if !ok {
+		return nil, errors.Errorf("invalid exception clause type; expected *ast.Clause, got %T", clause)
+	}
+	return append(cs, c), nil
+}
+
+// NewClause returns a new exception clause based on the given clause kind and
+// operand.
+func NewClause(kind ast.ClauseKind, xTyp, xVal interface{}) (*ast.Clause, error) {
+	x, err := NewConstant(xTyp, xVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.Clause{Kind: kind, X: x}, nil
+}
+
+// NewCatchPadInst returns a new catchpad instruction based on the given opcode
+// token, parent catchswitch, exception arguments and attached metadata.
+func NewCatchPadInst(opcode, within, args, mds interface{}) (*ast.InstCatchPad, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w, err := NewValue(&ast.TokenType{}, within)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	as, err := exceptionArgs(args)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstCatchPad{Pos: pos, Within: w, Args: as, Metadata: metadata}, nil
+}
+
+// NewCleanupPadInst returns a new cleanuppad instruction based on the given
+// opcode token, parent exception pad, exception arguments and attached
+// metadata.
+func NewCleanupPadInst(opcode, within, args, mds interface{}) (*ast.InstCleanupPad, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w, err := NewValue(&ast.TokenType{}, within)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	as, err := exceptionArgs(args)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.InstCleanupPad{Pos: pos, Within: w, Args: as, Metadata: metadata}, nil
+}
+
+// exceptionArgs returns the exception arguments of a catchpad or cleanuppad
+// instruction.
+func exceptionArgs(args interface{}) ([]ast.Value, error) {
+	switch args := args.(type) {
+	case []ast.Value:
+		return args, nil
+	case nil:
+		// no arguments.
+		return nil, nil
+	default:
+		return nil, errors.Errorf("invalid exception arguments type; expected []ast.Value or nil, got %T", args)
+	}
+}
+
+// === [ Terminators ] =========================================================
+
+// --- [ ret ] -----------------------------------------------------------------
+
+// NewRetTerm returns a new ret terminator based on the given opcode token,
+// return type, value and attached metadata.
+func NewRetTerm(opcode, xTyp, xVal, mds interface{}) (*ast.TermRet, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if xTyp != nil && xVal != nil {
+		x, err := NewValue(xTyp, xVal)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return &ast.TermRet{Pos: pos, X: x, Metadata: metadata}, nil
+	}
+	return &ast.TermRet{Pos: pos, Metadata: metadata}, nil
+}
+
+// --- [ br ] ------------------------------------------------------------------
+
+// NewBrTerm returns a new unconditional br terminator based on the given opcode
+// token, target branch and attached metadata.
+func NewBrTerm(opcode, targetTyp, targetVal, mds interface{}) (*ast.TermBr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	target, err := NewValue(targetTyp, targetVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	t, ok := target.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid target branch type; expected ast.NamedValue, got %T", target)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermBr{Pos: pos, Target: t, Metadata: metadata}, nil
+}
+
+// --- [ conditional br ] ------------------------------------------------------
+
+// NewCondBrTerm returns a new conditional br terminator based on the given
+// opcode token, branching condition type and value, conditional target branches
+// and attached metadata.
+func NewCondBrTerm(opcode, condTyp, condVal, targetTrueTyp, targetTrueVal, targetFalseTyp, targetFalseVal, mds interface{}) (*ast.TermCondBr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cond, err := NewValue(condTyp, condVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	targetTrue, err := NewValue(targetTrueTyp, targetTrueVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tTrue, ok := targetTrue.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid true target branch type; expected ast.NamedValue, got %T", targetTrue)
+	}
+	targetFalse, err := NewValue(targetFalseTyp, targetFalseVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tFalse, ok := targetFalse.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid false target branch type; expected ast.NamedValue, got %T", targetFalse)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermCondBr{Pos: pos, Cond: cond, TargetTrue: tTrue, TargetFalse: tFalse, Metadata: metadata}, nil
+}
+
+// --- [ switch ] --------------------------------------------------------------
+
+// NewSwitchTerm returns a new switch terminator based on the given opcode
+// token, control variable type and value, default target branch, switch cases
+// and attached metadata.
+func NewSwitchTerm(opcode, xTyp, xVal, targetDefaultTyp, targetDefaultVal, cases, mds interface{}) (*ast.TermSwitch, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	x, err := NewValue(xTyp, xVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	targetDefault, err := NewValue(targetDefaultTyp, targetDefaultVal)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -3188,7 +4572,7 @@ func NewSwitchTerm(xTyp, xVal, targetDefaultTyp, targetDefaultVal, cases, mds in
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.TermSwitch{X: x, TargetDefault: tDefault, Cases: cs, Metadata: metadata}, nil
+	return &ast.TermSwitch{Pos: pos, X: x, TargetDefault: tDefault, Cases: cs, Metadata: metadata}, nil
 }
 
 // NewCaseList returns a new switch case list based on the given case.
@@ -3235,27 +4619,414 @@ func NewCase(xTyp, xVal, targetTyp, targetVal interface{}) (*ast.Case, error) {
 	return &ast.Case{X: x, Target: t}, nil
 }
 
+// NewIndirectBrTerm returns a new indirectbr terminator based on the given
+// opcode token, target address, set of valid target basic blocks and attached
+// metadata.
+func NewIndirectBrTerm(opcode, addrTyp, addrVal, validTargets, mds interface{}) (*ast.TermIndirectBr, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	addr, err := NewValue(addrTyp, addrVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var ts []ast.NamedValue
+	switch validTargets := validTargets.(type) {
+	case []ast.NamedValue:
+		ts = validTargets
+	case nil:
+		// no valid targets.
+	default:
+		return nil, errors.Errorf("invalid valid target basic blocks type; expected []ast.NamedValue or nil, got %T", validTargets)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermIndirectBr{Pos: pos, Addr: addr, ValidTargets: ts, Metadata: metadata}, nil
+}
+
+// --- [ invoke ] --------------------------------------------------------------
+
+// NewInvokeTerm returns a new invoke terminator based on the given opcode token,
+// calling convention, return type, callee name, function arguments, normal and
+// exception target branches and attached metadata.
+func NewInvokeTerm(opcode, callconv, retTyp, callee, args, normalTyp, normalVal, exceptionTyp, exceptionVal, mds interface{}) (*ast.TermInvoke, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cconv, ok := callconv.(ast.CallConv)
+	if !ok {
+		return nil, errors.Errorf("invalid calling convention type; expected ast.CallConv, got %T", callconv)
+	}
+	r, ok := retTyp.(ast.Type)
+	if !ok {
+		return nil, errors.Errorf("invalid return type; expected ast.Type, got %T", retTyp)
+	}
+	c, err := newCallee(r, callee)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var as []ast.Value
+	switch args := args.(type) {
+	case []ast.Value:
+		as = args
+	case nil:
+		// no arguments.
+	default:
+		return nil, errors.Errorf("invalid function arguments type; expected []ast.Value or nil, got %T", args)
+	}
+	normal, err := NewValue(normalTyp, normalVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	n, ok := normal.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid normal target branch type; expected ast.NamedValue, got %T", normal)
+	}
+	exception, err := NewValue(exceptionTyp, exceptionVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e, ok := exception.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid exception target branch type; expected ast.NamedValue, got %T", exception)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermInvoke{Pos: pos, Type: r, Callee: c, Args: as, CallConv: cconv, Normal: n, Exception: e, Metadata: metadata}, nil
+}
+
+// NewNamedTerminator returns a named terminator based on the given local
+// variable name and terminator.
+func NewNamedTerminator(name, term interface{}) (ast.Terminator, error) {
+	// namedTerminator represents a named terminator.
+	type namedTerminator interface {
+		ast.Terminator
+		ast.NamedValue
+	}
+	n, ok := name.(*LocalIdent)
+	if !ok {
+		return nil, errors.Errorf("invalid local variable name type; expected *astx.LocalIdent, got %T", name)
+	}
+	t, ok := term.(namedTerminator)
+	if !ok {
+		return nil, errors.Errorf("invalid terminator type; expected namedTerminator, got %T", term)
+	}
+	t.SetName(unquote(n.name))
+	return t, nil
+}
+
+// --- [ resume ] --------------------------------------------------------------
+
+// NewResumeTerm returns a new resume terminator based on the given opcode
+// token, exception value and attached metadata.
+func NewResumeTerm(opcode, xTyp, xVal, mds interface{}) (*ast.TermResume, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	x, err := NewValue(xTyp, xVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermResume{Pos: pos, X: x, Metadata: metadata}, nil
+}
+
+// --- [ catchswitch ] ---------------------------------------------------------
+
+// NewCatchSwitchTerm returns a new catchswitch terminator based on the given
+// opcode token, parent exception pad, exception handlers, unwind target and
+// attached metadata. A nil unwind target unwinds to the caller.
+func NewCatchSwitchTerm(opcode, within, handlers, unwindTyp, unwindVal, mds interface{}) (*ast.TermCatchSwitch, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	w, err := NewValue(&ast.TokenType{}, within)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var hs []ast.NamedValue
+	switch handlers := handlers.(type) {
+	case []ast.NamedValue:
+		hs = handlers
+	case nil:
+		// no exception handlers.
+	default:
+		return nil, errors.Errorf("invalid exception handlers type; expected []ast.NamedValue or nil, got %T", handlers)
+	}
+	unwind, err := newUnwindTarget(unwindTyp, unwindVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermCatchSwitch{Pos: pos, Within: w, Handlers: hs, Unwind: unwind, Metadata: metadata}, nil
+}
+
+// --- [ catchret ] ------------------------------------------------------------
+
+// NewCatchRetTerm returns a new catchret terminator based on the given opcode
+// token, catchpad, target branch and attached metadata.
+func NewCatchRetTerm(opcode, from, toTyp, toVal, mds interface{}) (*ast.TermCatchRet, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	f, err := NewValue(&ast.TokenType{}, from)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	to, err := NewValue(toTyp, toVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	t, ok := to.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid target branch type; expected ast.NamedValue, got %T", to)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermCatchRet{Pos: pos, From: f, To: t, Metadata: metadata}, nil
+}
+
+// --- [ cleanupret ] ----------------------------------------------------------
+
+// NewCleanupRetTerm returns a new cleanupret terminator based on the given
+// opcode token, cleanuppad, unwind target and attached metadata. A nil unwind
+// target unwinds to the caller.
+func NewCleanupRetTerm(opcode, from, unwindTyp, unwindVal, mds interface{}) (*ast.TermCleanupRet, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	f, err := NewValue(&ast.TokenType{}, from)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	unwind, err := newUnwindTarget(unwindTyp, unwindVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metadata, err := uniqueMetadata(mds)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ast.TermCleanupRet{Pos: pos, From: f, Unwind: unwind, Metadata: metadata}, nil
+}
+
+// newUnwindTarget returns the unwind target branch of a catchswitch or
+// cleanupret terminator based on the given type and value. A nil unwind target
+// is returned if the terminator unwinds to the caller.
+func newUnwindTarget(unwindTyp, unwindVal interface{}) (ast.NamedValue, error) {
+	if unwindTyp == nil && unwindVal == nil {
+		// unwind to caller.
+		return nil, nil
+	}
+	unwind, err := NewValue(unwindTyp, unwindVal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	u, ok := unwind.(ast.NamedValue)
+	if !ok {
+		return nil, errors.Errorf("invalid unwind target branch type; expected ast.NamedValue, got %T", unwind)
+	}
+	return u, nil
+}
+
+// --- [ unreachable ] ---------------------------------------------------------
+
 // NewUnreachableTerm returns a new unreachable terminator based on the given
-// attached metadata.
-func NewUnreachableTerm(mds interface{}) (*ast.TermUnreachable, error) {
+// opcode token and attached metadata.
+func NewUnreachableTerm(opcode, mds interface{}) (*ast.TermUnreachable, error) {
+	pos, err := getTokenPos(opcode)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	metadata, err := uniqueMetadata(mds)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return &ast.TermUnreachable{Metadata: metadata}, nil
+	return &ast.TermUnreachable{Pos: pos, Metadata: metadata}, nil
 }
 
 // ### [ Helper functions ] ####################################################
 
-// getTokenString returns the string literal of the given token.
-func getTokenString(tok interface{}) (string, error) {
+// NewSection returns a new section name based on the given string token.
+func NewSection(name interface{}) (string, error) {
+	s, err := getTokenString(name)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return unquote(s), nil
+}
+
+// NewComdat returns a new comdat reference based on the given comdat keyword
+// token and optional comdat name. A comdat reference without name refers to
+// the comdat of the same name as the global variable or function.
+func NewComdat(keyword, name interface{}) (*ast.Comdat, error) {
+	switch name := name.(type) {
+	case *ComdatName:
+		return &ast.Comdat{Name: name.name, Pos: name.pos}, nil
+	case nil:
+		pos, err := getTokenPos(keyword)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return &ast.Comdat{Pos: pos}, nil
+	default:
+		return nil, errors.Errorf("invalid comdat name type; expected *astx.ComdatName or nil, got %T", name)
+	}
+}
+
+// NewSyncScope returns a new synchronization scope based on the given string
+// or keyword token.
+func NewSyncScope(scope interface{}) (string, error) {
+	s, err := getTokenString(scope)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return unquote(s), nil
+}
+
+// getAtomic returns the synchronization scope and atomic memory ordering
+// constraints of the given optional atomic memory access.
+func getAtomic(syncScope, ordering interface{}) (string, ast.AtomicOrdering, error) {
+	if syncScope == nil && ordering == nil {
+		// not atomic.
+		return "", ast.AtomicOrderingNone, nil
+	}
+	scope, ok := syncScope.(string)
+	if !ok {
+		return "", 0, errors.Errorf("invalid synchronization scope type; expected string, got %T", syncScope)
+	}
+	o, ok := ordering.(ast.AtomicOrdering)
+	if !ok {
+		return "", 0, errors.Errorf("invalid atomic memory ordering type; expected ast.AtomicOrdering, got %T", ordering)
+	}
+	return scope, o, nil
+}
+
+// getSection returns the section name of the given optional section.
+func getSection(section interface{}) (string, error) {
+	switch section := section.(type) {
+	case string:
+		return section, nil
+	case nil:
+		return "", nil
+	default:
+		return "", errors.Errorf("invalid section type; expected string or nil, got %T", section)
+	}
+}
+
+// getComdat returns the comdat reference of the given optional comdat, of a
+// global variable or function with the given name.
+func getComdat(comdat interface{}, name string) (*ast.Comdat, error) {
+	switch comdat := comdat.(type) {
+	case *ast.Comdat:
+		if len(comdat.Name) == 0 {
+			comdat.Name = name
+		}
+		return comdat, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("invalid comdat type; expected *ast.Comdat or nil, got %T", comdat)
+	}
+}
+
+// getAlign returns the alignment in bytes of the given optional alignment.
+func getAlign(align interface{}) (int, error) {
+	if align == nil {
+		return 0, nil
+	}
+	x, err := getInt64(align)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return int(x), nil
+}
+
+// getAddrSpace returns the address space of the given optional address space.
+func getAddrSpace(addrspace interface{}) (int, error) {
+	if addrspace == nil {
+		return 0, nil
+	}
+	x, err := getInt64(addrspace)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return int(x), nil
+}
+
+// getOverflowFlags returns the overflow flags of the given optional overflow
+// flag list.
+func getOverflowFlags(flags interface{}) ([]ast.OverflowFlag, error) {
+	switch flags := flags.(type) {
+	case []ast.OverflowFlag:
+		return flags, nil
+	case nil:
+		// no overflow flags.
+		return nil, nil
+	default:
+		return nil, errors.Errorf("invalid overflow flag list type; expected []ast.OverflowFlag or nil, got %T", flags)
+	}
+}
+
+// getFastMathFlags returns the fast-math flags of the given optional fast-math
+// flag list.
+func getFastMathFlags(flags interface{}) ([]ast.FastMathFlag, error) {
+	switch flags := flags.(type) {
+	case []ast.FastMathFlag:
+		return flags, nil
+	case nil:
+		// no fast-math flags.
+		return nil, nil
+	default:
+		return nil, errors.Errorf("invalid fast-math flag list type; expected []ast.FastMathFlag or nil, got %T", flags)
+	}
+}
+
+// getToken returns the given token.
+func getToken(tok interface{}) (*token.Token, error) {
 	t, ok := tok.(*token.Token)
 	if !ok {
-		return "", errors.Errorf("invalid token type; expected *token.Token, got %T", tok)
+		return nil, errors.Errorf("invalid token type; expected *token.Token, got %T", tok)
+	}
+	return t, nil
+}
+
+// getTokenString returns the string literal of the given token.
+func getTokenString(tok interface{}) (string, error) {
+	t, err := getToken(tok)
+	if err != nil {
+		return "", errors.WithStack(err)
 	}
 	return string(t.Lit), nil
 }
 
+// getTokenPos returns the source position of the given token.
+func getTokenPos(tok interface{}) (token.Pos, error) {
+	t, err := getToken(tok)
+	if err != nil {
+		return token.Pos{}, errors.WithStack(err)
+	}
+	return t.Pos, nil
+}
+
 // getInt64 returns the int64 representation of the given integer literal.
 func getInt64(lit interface{}) (int64, error) {
 	l, ok := lit.(*IntLit)
@@ -3,13 +3,18 @@
 // Per module.
 //
 //    1. Index type definitions.
-//    2. Index global variables.
-//    3. Index functions.
-//    4. Index metadata.
-//    5. Fix type definitions.
-//    6. Resolve named types.
-//    7. Resolve global identifiers.
-//    8. Resolve metadata nodes.
+//    2. Index comdat definitions.
+//    3. Index global variables.
+//    4. Index functions.
+//    5. Index attribute groups.
+//    6. Index metadata.
+//    7. Fix type definitions.
+//    8. Resolve comdats.
+//    9. Resolve attribute groups.
+//    10. Resolve named types.
+//    11. Resolve global identifiers.
+//    12. Resolve basic blocks of block addresses.
+//    13. Resolve metadata nodes.
 //
 // Per function.
 //
@@ -26,52 +31,99 @@ import (
 
 	"github.com/llir/llvm/asm/internal/ast"
 	"github.com/llir/llvm/asm/internal/ast/astutil"
+	"github.com/llir/llvm/asm/internal/token"
 	"github.com/llir/llvm/internal/enc"
 )
 
 // === [ Modules ] =============================================================
 
 // fixModule replaces dummy values within the given module with their real
-// values.
-func fixModule(m *ast.Module) *ast.Module {
+// values. All resolution errors encountered are reported as an ast.ErrorList.
+func fixModule(m *ast.Module) (*ast.Module, error) {
 	fix := &fixer{
-		globals:  make(map[string]ast.NamedValue),
-		types:    make(map[string]*ast.NamedType),
-		metadata: make(map[string]*ast.Metadata),
+		globals:    make(map[string]ast.NamedValue),
+		types:      make(map[string]*ast.NamedType),
+		comdats:    make(map[string]*ast.Comdat),
+		attrGroups: make(map[string]*ast.AttrGroupDef),
+		metadata:   make(map[string]*ast.Metadata),
 	}
 
 	// Index type definitions.
 	for _, typ := range m.Types {
 		name := typ.Name
-		if _, ok := fix.types[name]; ok {
-			panic(fmt.Errorf("type name %q already present; old `%v`, new `%v`", name, fix.types[name], typ))
+		if prev, ok := fix.types[name]; ok {
+			fix.errorf(typ.Pos, "type name %q already present; previously defined at %s", name, posString(prev.Pos))
+			continue
 		}
 		fix.types[name] = typ
 	}
 
+	// Index comdat definitions.
+	for _, c := range m.Comdats {
+		name := c.Name
+		if prev, ok := fix.comdats[name]; ok {
+			fix.errorf(c.Pos, "comdat name %q already present; previously defined at %s", enc.Comdat(name), posString(prev.Pos))
+			continue
+		}
+		fix.comdats[name] = c
+	}
+
 	// Index global variables.
 	for _, global := range m.Globals {
 		name := global.Name
-		if _, ok := fix.globals[name]; ok {
-			panic(fmt.Errorf("global identifier %q already present; old `%v`, new `%v`", name, fix.globals[name], global))
+		if prev, ok := fix.globals[name]; ok {
+			fix.errorf(global.Pos, "global identifier %q already present; previously defined at %s", name, posString(globalPos(prev)))
+			continue
 		}
 		fix.globals[name] = global
 	}
 
+	// Index aliases.
+	for _, alias := range m.Aliases {
+		name := alias.Name
+		if prev, ok := fix.globals[name]; ok {
+			fix.errorf(alias.Pos, "global identifier %q already present; previously defined at %s", name, posString(globalPos(prev)))
+			continue
+		}
+		fix.globals[name] = alias
+	}
+
+	// Index IFuncs.
+	for _, ifunc := range m.IFuncs {
+		name := ifunc.Name
+		if prev, ok := fix.globals[name]; ok {
+			fix.errorf(ifunc.Pos, "global identifier %q already present; previously defined at %s", name, posString(globalPos(prev)))
+			continue
+		}
+		fix.globals[name] = ifunc
+	}
+
 	// Index functions.
 	for _, f := range m.Funcs {
 		name := f.Name
-		if _, ok := fix.globals[name]; ok {
-			panic(fmt.Errorf("global identifier %q already present; old `%v`, new `%v`", name, fix.globals[name], f))
+		if prev, ok := fix.globals[name]; ok {
+			fix.errorf(f.Pos, "global identifier %q already present; previously defined at %s", name, posString(globalPos(prev)))
+			continue
 		}
 		fix.globals[name] = f
 	}
 
+	// Index attribute groups.
+	for _, def := range m.AttrGroupDefs {
+		id := def.ID
+		if prev, ok := fix.attrGroups[id]; ok {
+			fix.errorf(def.Pos, "attribute group ID %q already present; previously defined at %s", "#"+id, posString(prev.Pos))
+			continue
+		}
+		fix.attrGroups[id] = def
+	}
+
 	// Index metadata.
 	for _, md := range m.Metadata {
 		id := md.ID
-		if _, ok := fix.metadata[id]; ok {
-			panic(fmt.Errorf("metadata ID %q already present; old `%v`, new `%v`", id, fix.metadata[id], md))
+		if prev, ok := fix.metadata[id]; ok {
+			fix.errorf(md.Pos, "metadata ID %q already present; previously defined at %s", enc.Metadata(id), posString(prev.Pos))
+			continue
 		}
 		fix.metadata[id] = md
 	}
@@ -81,6 +133,26 @@ func fixModule(m *ast.Module) *ast.Module {
 		typ.Def = fix.fixType(typ.Def)
 	}
 
+	// Resolve comdats.
+	for _, global := range m.Globals {
+		if global.Comdat != nil {
+			global.Comdat = fix.fixComdat(global.Comdat)
+		}
+	}
+	for _, f := range m.Funcs {
+		if f.Comdat != nil {
+			f.Comdat = fix.fixComdat(f.Comdat)
+		}
+	}
+
+	// Resolve attribute groups.
+	for _, def := range m.AttrGroupDefs {
+		fix.fixAttrs(def.Attrs)
+	}
+	for _, f := range m.Funcs {
+		fix.fixAttrs(f.FuncAttrs)
+	}
+
 	// Resolve named types.
 	resolveTypes := func(node interface{}) {
 		p, ok := node.(*ast.Type)
@@ -91,9 +163,13 @@ func fixModule(m *ast.Module) *ast.Module {
 		if !ok {
 			return
 		}
-		typ := fix.getType(old.Name)
+		typ, ok := fix.getType(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		if typ.Def == nil {
-			panic(fmt.Errorf("invalid type definition %q; expected underlying definition, got nil", typ.Name))
+			fix.errorf(typ.Pos, "invalid type definition %q; expected underlying definition, got nil", typ.Name)
+			return
 		}
 		*p = typ
 	}
@@ -109,7 +185,10 @@ func fixModule(m *ast.Module) *ast.Module {
 		if !ok {
 			return
 		}
-		global := fix.getGlobal(old.Name)
+		global, ok := fix.getGlobal(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		// TODO: Validate type of old and new global.
 		*p = global
 	}
@@ -125,7 +204,10 @@ func fixModule(m *ast.Module) *ast.Module {
 		if !ok {
 			return
 		}
-		global := fix.getGlobal(old.Name)
+		global, ok := fix.getGlobal(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		// TODO: Validate type of old and new global.
 		*p = global
 	}
@@ -141,10 +223,14 @@ func fixModule(m *ast.Module) *ast.Module {
 		if !ok {
 			return
 		}
-		global := fix.getGlobal(old.Name)
+		global, ok := fix.getGlobal(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		g, ok := global.(ast.Constant)
 		if !ok {
-			panic(fmt.Errorf("invalid global type of %q; expected ast.Constant, got %T", global.GetName(), global))
+			fix.errorf(old.Pos, "invalid global type of %q; expected ast.Constant, got %T", global.GetName(), global)
+			return
 		}
 		// TODO: Validate type of old and new global.
 		*p = g
@@ -156,24 +242,57 @@ func fixModule(m *ast.Module) *ast.Module {
 		fix.fixFunc(f)
 	}
 
+	// Resolve basic blocks of block addresses. Block addresses may refer to
+	// basic blocks of any function, and are therefore resolved after the local
+	// IDs of all functions have been assigned.
+	resolveBlockAddresses := func(node interface{}) {
+		c, ok := node.(*ast.BlockAddressConst)
+		if !ok {
+			return
+		}
+		old, ok := c.Block.(*ast.LocalDummy)
+		if !ok {
+			return
+		}
+		f, ok := c.Func.(*ast.Function)
+		if !ok {
+			// Unresolved global identifiers have already been reported.
+			if _, ok := c.Func.(*ast.GlobalDummy); !ok {
+				fix.errorf(old.Pos, "invalid block address function type of %q; expected *ast.Function, got %T", c.Func.GetName(), c.Func)
+			}
+			return
+		}
+		block, ok := fix.getBlock(f, old.Name, old.Pos)
+		if !ok {
+			return
+		}
+		c.Block = block
+	}
+	astutil.Walk(m, resolveBlockAddresses)
+
 	// Resolve metadata nodes.
 	resolveMetadataNodes := func(node interface{}) {
 		switch p := node.(type) {
 		case *ast.MetadataNode:
 			if old, ok := (*p).(*ast.MetadataIDDummy); ok {
-				metadata := fix.getMetadata(old.ID)
-				*p = metadata
+				if metadata, ok := fix.getMetadata(old.ID, old.Pos); ok {
+					*p = metadata
+				}
 			}
 		case *ast.Value:
 			if old, ok := (*p).(*ast.MetadataIDDummy); ok {
-				metadata := fix.getMetadata(old.ID)
-				*p = metadata
+				if metadata, ok := fix.getMetadata(old.ID, old.Pos); ok {
+					*p = metadata
+				}
 			}
 		}
 	}
 	astutil.Walk(m, resolveMetadataNodes)
 
-	return m
+	if len(fix.errs) > 0 {
+		return nil, fix.errs
+	}
+	return m, nil
 }
 
 // === [ Type definitions ] ====================================================
@@ -196,8 +315,12 @@ func (fix *fixer) fixType(old ast.Type) ast.Type {
 		old.Elem = fix.fixType(old.Elem)
 	case *ast.VectorType:
 		old.Elem = fix.fixType(old.Elem)
+	case *ast.MMXType:
+		// nothing to do.
 	case *ast.LabelType:
 		// nothing to do.
+	case *ast.TokenType:
+		// nothing to do.
 	case *ast.MetadataType:
 		// nothing to do.
 	case *ast.ArrayType:
@@ -208,16 +331,51 @@ func (fix *fixer) fixType(old ast.Type) ast.Type {
 		}
 	case *ast.NamedType:
 		if old.Def == nil {
-			old.Def = fix.getType(old.Name)
+			if typ, ok := fix.getType(old.Name, old.Pos); ok {
+				old.Def = typ
+			}
 		}
 	case *ast.NamedTypeDummy:
-		return fix.getType(old.Name)
+		if typ, ok := fix.getType(old.Name, old.Pos); ok {
+			return typ
+		}
 	default:
 		panic(fmt.Errorf("support for type %T not yet implemented", old))
 	}
 	return old
 }
 
+// === [ Comdats ] =============================================================
+
+// fixComdat replaces the given comdat reference with its comdat definition.
+func (fix *fixer) fixComdat(old *ast.Comdat) *ast.Comdat {
+	c, ok := fix.comdats[old.Name]
+	if !ok {
+		fix.errorf(old.Pos, "unable to locate comdat name %q", enc.Comdat(old.Name))
+		return old
+	}
+	return c
+}
+
+// === [ Attribute groups ] ====================================================
+
+// fixAttrs replaces references to attribute groups within the given function
+// attributes with their attribute group definitions.
+func (fix *fixer) fixAttrs(attrs []ast.Attribute) {
+	for i, attr := range attrs {
+		old, ok := attr.(*ast.AttrGroupDef)
+		if !ok {
+			continue
+		}
+		def, ok := fix.attrGroups[old.ID]
+		if !ok {
+			fix.errorf(old.Pos, "unable to locate attribute group ID %q", "#"+old.ID)
+			continue
+		}
+		attrs[i] = def
+	}
+}
+
 // === [ Functions ] ===========================================================
 
 // fixFunc replaces dummy values within the given function with their real
@@ -232,13 +390,17 @@ func (fix *fixer) fixFunc(f *ast.Function) {
 	fix.locals = make(map[string]ast.NamedValue)
 
 	// Assign unique local IDs to unnamed basic blocks and instructions.
-	f.AssignIDs()
+	if err := f.AssignIDs(); err != nil {
+		fix.errs = append(fix.errs, err)
+		return
+	}
 
 	// Index basic blocks.
 	for _, block := range f.Blocks {
 		name := block.Name
 		if _, ok := fix.locals[name]; ok {
-			panic(fmt.Errorf("basic block label %q already present for function %s; old `%v`, new `%v`", name, enc.Global(f.Name), fix.locals[name], block))
+			fix.errorf(block.Pos, "basic block label %q already present for function %s", name, enc.Global(f.Name))
+			continue
 		}
 		fix.locals[name] = block
 	}
@@ -247,7 +409,8 @@ func (fix *fixer) fixFunc(f *ast.Function) {
 	for _, param := range f.Sig.Params {
 		name := param.Name
 		if _, ok := fix.locals[name]; ok {
-			panic(fmt.Errorf("function parameter name %q already present for function %s; old `%v`, new `%v`", name, enc.Global(f.Name), fix.locals[name], param))
+			fix.errorf(f.Pos, "function parameter name %q already present for function %s", name, enc.Global(f.Name))
+			continue
 		}
 		fix.locals[name] = param
 	}
@@ -255,25 +418,34 @@ func (fix *fixer) fixFunc(f *ast.Function) {
 	// Index local variables produced by instructions.
 	for _, block := range f.Blocks {
 		for _, inst := range block.Insts {
+			pos := inst.GetPos()
 			if inst, ok := inst.(ast.NamedValue); ok {
 				// Ignore local value if of type void.
-				if inst, ok := inst.(*ast.InstCall); ok {
-					if _, ok := inst.Type.(*ast.VoidType); ok {
-						continue
-					}
-					if sig, ok := inst.Type.(*ast.FuncType); ok {
-						if _, ok := sig.Ret.(*ast.VoidType); ok {
-							continue
-						}
-					}
+				if inst, ok := inst.(*ast.InstCall); ok && ast.IsVoidCall(inst.Type) {
+					continue
 				}
 				name := inst.GetName()
 				if _, ok := fix.locals[name]; ok {
-					panic(fmt.Errorf("instruction name %q already present for function %s; old `%v`, new `%v`", name, enc.Global(f.Name), fix.locals[name], inst))
+					fix.errorf(pos, "instruction name %q already present for function %s", name, enc.Global(f.Name))
+					continue
 				}
 				fix.locals[name] = inst
 			}
 		}
+		// Index local variable produced by terminator.
+		term, ok := block.Term.(ast.NamedValue)
+		if !ok {
+			continue
+		}
+		if term, ok := block.Term.(*ast.TermInvoke); ok && ast.IsVoidCall(term.Type) {
+			continue
+		}
+		name := term.GetName()
+		if _, ok := fix.locals[name]; ok {
+			fix.errorf(block.Term.GetPos(), "terminator name %q already present for function %s", name, enc.Global(f.Name))
+			continue
+		}
+		fix.locals[name] = term
 	}
 
 	// Resolve values of local identifiers.
@@ -286,7 +458,10 @@ func (fix *fixer) fixFunc(f *ast.Function) {
 		if !ok {
 			return
 		}
-		local := fix.getLocal(old.Name)
+		local, ok := fix.getLocal(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		// TODO: Validate type of old and new local.
 		*p = local
 	}
@@ -302,7 +477,10 @@ func (fix *fixer) fixFunc(f *ast.Function) {
 		if !ok {
 			return
 		}
-		local := fix.getLocal(old.Name)
+		local, ok := fix.getLocal(old.Name, old.Pos)
+		if !ok {
+			return
+		}
 		// TODO: Validate type of old and new local.
 		*p = local
 	}
@@ -318,6 +496,11 @@ type fixer struct {
 
 	// types maps from type identifiers to their real types.
 	types map[string]*ast.NamedType
+	// comdats maps from comdat names to their comdat definitions.
+	comdats map[string]*ast.Comdat
+	// attrGroups maps from attribute group IDs to their attribute group
+	// definitions.
+	attrGroups map[string]*ast.AttrGroupDef
 	// globals maps global identifiers to their real values.
 	globals map[string]ast.NamedValue
 	// metadata maps metadata IDs to their real metadata.
@@ -327,40 +510,96 @@ type fixer struct {
 
 	// locals maps local identifiers to their real values.
 	locals map[string]ast.NamedValue
+
+	// List of errors encountered during resolution.
+	errs ast.ErrorList
 }
 
-// getType returns the type of the given type name.
-func (fix *fixer) getType(name string) *ast.NamedType {
+// errorf records a resolution error at the given source position, with a
+// message formatted according to the format specifier. Duplicate errors are
+// ignored, as unresolved dummy values may be visited more than once.
+func (fix *fixer) errorf(pos token.Pos, format string, a ...interface{}) {
+	err := ast.Errorf(pos, format, a...)
+	for _, prev := range fix.errs {
+		if prev, ok := prev.(*ast.Error); ok && *prev == *err {
+			return
+		}
+	}
+	fix.errs = append(fix.errs, err)
+}
+
+// getType returns the type of the given type name, referenced at pos.
+func (fix *fixer) getType(name string, pos token.Pos) (*ast.NamedType, bool) {
 	typ, ok := fix.types[name]
 	if !ok {
-		panic(fmt.Errorf("unable to locate type name %q", name))
+		fix.errorf(pos, "unable to locate type name %q", name)
+		return nil, false
 	}
-	return typ
+	return typ, true
 }
 
-// getGlobal returns the global value of the given global identifier.
-func (fix *fixer) getGlobal(name string) ast.NamedValue {
+// getGlobal returns the global value of the given global identifier,
+// referenced at pos.
+func (fix *fixer) getGlobal(name string, pos token.Pos) (ast.NamedValue, bool) {
 	global, ok := fix.globals[name]
 	if !ok {
-		panic(fmt.Errorf("unable to locate global identifier %q", name))
+		fix.errorf(pos, "unable to locate global identifier %q", name)
+		return nil, false
 	}
-	return global
+	return global, true
 }
 
-// getMetadata returns the metadata of the given metadata ID.
-func (fix *fixer) getMetadata(id string) *ast.Metadata {
+// getMetadata returns the metadata of the given metadata ID, referenced at pos.
+func (fix *fixer) getMetadata(id string, pos token.Pos) (*ast.Metadata, bool) {
 	metadata, ok := fix.metadata[id]
 	if !ok {
-		panic(fmt.Errorf("unable to locate metadata ID %q", enc.Metadata(id)))
+		fix.errorf(pos, "unable to locate metadata ID %q", enc.Metadata(id))
+		return nil, false
+	}
+	return metadata, true
+}
+
+// getBlock returns the basic block of the given label name within the given
+// function, referenced at pos.
+func (fix *fixer) getBlock(f *ast.Function, name string, pos token.Pos) (*ast.BasicBlock, bool) {
+	for _, block := range f.Blocks {
+		if block.Name == name {
+			return block, true
+		}
 	}
-	return metadata
+	fix.errorf(pos, "unable to locate basic block label %q in function %s", name, enc.Global(f.Name))
+	return nil, false
 }
 
-// getLocal returns the local value of the given local identifier.
-func (fix *fixer) getLocal(name string) ast.NamedValue {
+// getLocal returns the local value of the given local identifier, referenced
+// at pos.
+func (fix *fixer) getLocal(name string, pos token.Pos) (ast.NamedValue, bool) {
 	local, ok := fix.locals[name]
 	if !ok {
-		panic(fmt.Errorf("unable to locate local identifier %q", name))
+		fix.errorf(pos, "unable to locate local identifier %q", name)
+		return nil, false
 	}
-	return local
+	return local, true
+}
+
+// globalPos returns the source position of the given global variable or
+// function.
+func globalPos(global ast.NamedValue) token.Pos {
+	switch global := global.(type) {
+	case *ast.Global:
+		return global.Pos
+	case *ast.Function:
+		return global.Pos
+	case *ast.Alias:
+		return global.Pos
+	case *ast.IFunc:
+		return global.Pos
+	default:
+		panic(fmt.Errorf("support for global value %T not yet implemented", global))
+	}
+}
+
+// posString returns the line and column number of the given source position.
+func posString(pos token.Pos) string {
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/llir/llvm/ir"
 	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/types"
-	"github.com/pkg/errors"
+	"github.com/llir/llvm/ir/value"
 )
 
 // irConstant returns the corresponding LLVM IR constant of the given constant.
@@ -15,11 +15,34 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 	switch old := old.(type) {
 	// Simple constants
 	case *ast.IntConst:
-		return constant.NewIntFromString(old.Lit, m.irType(old.Type))
+		typ := m.irType(old.Type)
+		t, ok := typ.(*types.IntType)
+		if !ok {
+			m.fatalf("invalid integer constant type; expected *types.IntType, got %T", typ)
+		}
+		switch lit := old.Lit; {
+		case types.IsBool(t):
+			if lit != "0" && lit != "1" && lit != "false" && lit != "true" {
+				m.fatalf("invalid integer constant %q for type i1", lit)
+			}
+		case lit == "false" || lit == "true":
+			m.fatalf("invalid integer constant %q for type %s", lit, t)
+		}
+		return constant.NewIntFromString(old.Lit, t)
 	case *ast.FloatConst:
-		return constant.NewFloatFromString(old.Lit, m.irType(old.Type))
+		typ := m.irType(old.Type)
+		if _, ok := typ.(*types.FloatType); !ok {
+			m.fatalf("invalid floating-point constant type; expected *types.FloatType, got %T", typ)
+		}
+		return constant.NewFloatFromString(old.Lit, typ)
 	case *ast.NullConst:
-		return constant.NewNull(m.irType(old.Type))
+		typ := m.irType(old.Type)
+		if _, ok := typ.(*types.PointerType); !ok {
+			m.fatalf("invalid null pointer constant type; expected *types.PointerType, got %T", typ)
+		}
+		return constant.NewNull(typ)
+	case *ast.NoneConst:
+		return constant.None
 
 	// Complex constants
 	case *ast.VectorConst:
@@ -29,7 +52,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		}
 		c := constant.NewVector(elems...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("vector type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("vector type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ArrayConst:
@@ -38,10 +61,10 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 			typ := m.irType(old.Type)
 			t, ok := typ.(*types.ArrayType)
 			if !ok {
-				panic(errors.Errorf("invalid array type; expected *types.ArrayType, got %T", typ))
+				m.fatalf("invalid array type; expected *types.ArrayType, got %T", typ)
 			}
 			if t.Len != 0 {
-				panic(errors.Errorf("invalid number of array elements; expected 0, got %d", t.Len))
+				m.errorf("invalid number of array elements; expected %d, got 0", t.Len)
 			}
 			c := &constant.Array{
 				Typ: t,
@@ -54,7 +77,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		}
 		c := constant.NewArray(elems...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("array type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("array type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.CharArrayConst:
@@ -63,10 +86,10 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 			typ := m.irType(old.Type)
 			t, ok := typ.(*types.ArrayType)
 			if !ok {
-				panic(errors.Errorf("invalid array type; expected *types.ArrayType, got %T", typ))
+				m.fatalf("invalid array type; expected *types.ArrayType, got %T", typ)
 			}
 			if t.Len != 0 {
-				panic(errors.Errorf("invalid number of array elements; expected 0, got %d", t.Len))
+				m.errorf("invalid number of array elements; expected %d, got 0", t.Len)
 			}
 			c := &constant.Array{
 				Typ:       t,
@@ -82,7 +105,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		}
 		c := constant.NewArray(elems...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("character array type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("character array type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		c.CharArray = true
 		return c
@@ -93,18 +116,17 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		}
 		c := constant.NewStruct(fields...)
 		got := c.Typ
+		got.Packed = old.Packed
 		oldType := m.irType(old.Type)
 		want, ok := oldType.(*types.StructType)
 		if !ok {
-			panic(fmt.Errorf("invalid struct type; expected *types.StructType, got %T", oldType))
+			m.fatalf("invalid struct type; expected *types.StructType, got %T", oldType)
 		}
-		// TODO: Figure out how to validate the bodies of got and want. After name
-		// is copied from want to got, only name identity is used for type
-		// equality.
+		// Copy the name from want to got, to validate both the type name and the
+		// struct body of identified struct types.
 		got.Name = want.Name
 		if !got.Equal(want) {
-			err := errors.Errorf("struct type mismatch; expected `%v`, got `%v`", want, got)
-			m.errs = append(m.errs, err)
+			m.errorf("struct type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ZeroInitializerConst:
@@ -112,7 +134,24 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 	case *ast.UndefConst:
 		return constant.NewUndef(m.irType(old.Type))
 
-	// Global variable and function addresses
+	// Addresses of basic blocks
+	case *ast.BlockAddressConst:
+		v := m.getGlobal(old.Func.GetName())
+		f, ok := v.(*ir.Function)
+		if !ok {
+			panic(fmt.Errorf("invalid block address function type; expected *ir.Function, got %T", v))
+		}
+		// The basic blocks of f may not yet have been translated, as block
+		// addresses may refer to functions defined later on. Therefore, record
+		// the block address for later resolution of its basic block.
+		c := constant.NewBlockAddress(f, nil)
+		m.blockAddrs = append(m.blockAddrs, &blockAddr{c: c, name: old.Block.GetName()})
+		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
+			m.errorf("block address type mismatch; expected `%v`, got `%v`", want, got)
+		}
+		return c
+
+	// Global variable, function, alias and IFunc addresses
 	case *ast.Global:
 		// TODO: Validate old.Type against type of resolved global?
 		// Not possible currently, as globals have already been resolved by astx.
@@ -135,194 +174,231 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 			panic(fmt.Errorf("invalid function type; expected *ir.Function, got %T", v))
 		}
 		return f
+	case *ast.Alias:
+		v := m.getGlobal(old.Name)
+		alias, ok := v.(*ir.Alias)
+		if !ok {
+			panic(fmt.Errorf("invalid alias type; expected *ir.Alias, got %T", v))
+		}
+		return alias
+	case *ast.IFunc:
+		v := m.getGlobal(old.Name)
+		ifunc, ok := v.(*ir.IFunc)
+		if !ok {
+			panic(fmt.Errorf("invalid IFunc type; expected *ir.IFunc, got %T", v))
+		}
+		return ifunc
 
 	// Binary expressions
 	case *ast.ExprAdd:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
-		c := constant.NewAdd(x, y)
+		c := constant.NewAdd(x, y, irConstOverflowFlags(old.OverflowFlags)...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("add expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("add expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFAdd:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFAdd(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fadd expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fadd expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSub:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
-		c := constant.NewSub(x, y)
+		c := constant.NewSub(x, y, irConstOverflowFlags(old.OverflowFlags)...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("sub expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("sub expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFSub:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFSub(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fsub expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fsub expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprMul:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
-		c := constant.NewMul(x, y)
+		c := constant.NewMul(x, y, irConstOverflowFlags(old.OverflowFlags)...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("mul expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("mul expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFMul:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFMul(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fmul expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fmul expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprUDiv:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewUDiv(x, y)
+		c.Exact = old.Exact
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("udiv expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("udiv expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSDiv:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewSDiv(x, y)
+		c.Exact = old.Exact
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("sdiv expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("sdiv expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFDiv:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFDiv(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fdiv expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fdiv expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprURem:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewURem(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("urem expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("urem expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSRem:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewSRem(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("srem expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("srem expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFRem:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFRem(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("frem expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("frem expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
 	// Bitwise expressions
 	case *ast.ExprShl:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
-		c := constant.NewShl(x, y)
+		c := constant.NewShl(x, y, irConstOverflowFlags(old.OverflowFlags)...)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("shl expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("shl expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprLShr:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewLShr(x, y)
+		c.Exact = old.Exact
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("lshr expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("lshr expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprAShr:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewAShr(x, y)
+		c.Exact = old.Exact
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("ashr expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("ashr expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprAnd:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewAnd(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("and expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("and expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprOr:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewOr(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("or expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("or expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprXor:
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewXor(x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("xor expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("xor expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
 	// Aggregate expressions
 	case *ast.ExprExtractValue:
 		x := m.irConstant(old.X)
+		// Validate indices.
+		m.aggregateElemType(x.Type(), old.Indices)
 		c := constant.NewExtractValue(x, old.Indices)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("extractvalue expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("extractvalue expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprInsertValue:
 		x, elem := m.irConstant(old.X), m.irConstant(old.Elem)
+		if got, want := elem.Type(), m.aggregateElemType(x.Type(), old.Indices); !got.Equal(want) {
+			m.errorf("insertvalue element type mismatch; expected `%v`, got `%v`", want, got)
+		}
 		c := constant.NewInsertValue(x, elem, old.Indices)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("insertvalue expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("insertvalue expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
 	// Vector expressions
 	case *ast.ExprExtractElement:
 		x, index := m.irConstant(old.X), m.irConstant(old.Index)
+		if _, ok := x.Type().(*types.VectorType); !ok {
+			m.fatalf("invalid vector type; expected *types.VectorType, got %T", x.Type())
+		}
 		c := constant.NewExtractElement(x, index)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("extractelement expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("extractelement expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprInsertElement:
 		x, elem, index := m.irConstant(old.X), m.irConstant(old.Elem), m.irConstant(old.Index)
 		c := constant.NewInsertElement(x, elem, index)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("insertelement expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("insertelement expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprShuffleVector:
 		x, y, mask := m.irConstant(old.X), m.irConstant(old.Y), m.irConstant(old.Mask)
 		c := constant.NewShuffleVector(x, y, mask)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("shufflevector expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("shufflevector expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
 	// Memory expressions
 	case *ast.ExprGetElementPtr:
 		src := m.irConstant(old.Src)
-		if srcType, ok := src.Type().(*types.PointerType); !ok {
-			panic(errors.Errorf("invalid source type; expected *types.PointerType, got %T", src.Type()))
-		} else if got, want := srcType.Elem, m.irType(old.Elem); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("source element type mismatch; expected `%v`, got `%v`", want, got))
+		srcType, ok := src.Type().(*types.PointerType)
+		if !ok {
+			m.fatalf("invalid source type; expected *types.PointerType, got %T", src.Type())
+		}
+		if got, want := srcType.Elem, m.irType(old.Elem); !got.Equal(want) {
+			m.errorf("source element type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		var indices []constant.Constant
+		var vs []value.Value
 		for _, oldIndex := range old.Indices {
 			index := m.irConstant(oldIndex)
 			indices = append(indices, index)
+			vs = append(vs, index)
 		}
+		// Validate indices.
+		m.gepElemType(srcType.Elem, vs)
 		c := constant.NewGetElementPtr(src, indices...)
+		c.InBounds = old.InBounds
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("getelementptr expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("getelementptr expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
+	case *ast.Index:
+		index := constant.NewIndex(m.irConstant(old.Constant))
+		index.InRange = old.InRange
+		return index
 
 	// Conversion expressions
 	case *ast.ExprTrunc:
@@ -330,7 +406,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewTrunc(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("trunc expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("trunc expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprZExt:
@@ -338,7 +414,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewZExt(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("zext expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("zext expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSExt:
@@ -346,7 +422,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewSExt(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("sext expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("sext expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFPTrunc:
@@ -354,7 +430,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewFPTrunc(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fptrunc expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fptrunc expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFPExt:
@@ -362,7 +438,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewFPExt(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fpext expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fpext expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFPToUI:
@@ -370,7 +446,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewFPToUI(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fptoui expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fptoui expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFPToSI:
@@ -378,7 +454,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewFPToSI(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fptosi expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fptosi expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprUIToFP:
@@ -386,7 +462,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewUIToFP(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("uitofp expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("uitofp expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSIToFP:
@@ -394,7 +470,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewSIToFP(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("sitofp expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("sitofp expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprPtrToInt:
@@ -402,7 +478,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewPtrToInt(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("ptrtoint expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("ptrtoint expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprIntToPtr:
@@ -410,7 +486,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewIntToPtr(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("inttoptr expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("inttoptr expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprBitCast:
@@ -418,7 +494,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewBitCast(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("bitcast expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("bitcast expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprAddrSpaceCast:
@@ -426,7 +502,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		to := m.irType(old.To)
 		c := constant.NewAddrSpaceCast(from, to)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("addrspacecast expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("addrspacecast expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
@@ -436,7 +512,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewICmp(cond, x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("icmp expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("icmp expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprFCmp:
@@ -444,7 +520,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewFCmp(cond, x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("fcmp expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("fcmp expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 	case *ast.ExprSelect:
@@ -452,7 +528,7 @@ func (m *Module) irConstant(old ast.Constant) constant.Constant {
 		x, y := m.irConstant(old.X), m.irConstant(old.Y)
 		c := constant.NewSelect(cond, x, y)
 		if got, want := c.Type(), m.irType(old.Type); !got.Equal(want) {
-			m.errs = append(m.errs, errors.Errorf("select expression type mismatch; expected `%v`, got `%v`", want, got))
+			m.errorf("select expression type mismatch; expected `%v`, got `%v`", want, got)
 		}
 		return c
 
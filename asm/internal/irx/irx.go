@@ -5,8 +5,12 @@ package irx
 import (
 	"fmt"
 
+	"github.com/llir/llvm/asm/internal/ast"
+	"github.com/llir/llvm/asm/internal/token"
 	"github.com/llir/llvm/internal/enc"
 	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
@@ -21,10 +25,18 @@ type Module struct {
 
 	// types maps from type identifiers to their corresponding LLVM IR types.
 	types map[string]types.Type
+	// comdats maps from comdat names to their corresponding LLVM IR comdats.
+	comdats map[string]*ir.Comdat
+	// attrGroups maps from attribute group IDs to their corresponding LLVM IR
+	// attribute groups.
+	attrGroups map[string]*attr.Group
 	// globals maps global identifiers to their corresponding LLVM IR values.
 	globals map[string]value.Named
 	// metadata maps metadata IDs to their corresponding LLVM IR metadata.
 	metadata map[string]*metadata.Metadata
+	// blockAddrs records block address constants, the basic blocks of which
+	// are resolved once all functions have been translated.
+	blockAddrs []*blockAddr
 
 	// Per function.
 
@@ -33,20 +45,42 @@ type Module struct {
 	locals map[string]value.Named
 
 	// List of errors encountered during translation.
-	errs []error
+	errs ast.ErrorList
+
+	// Source positions.
+
+	// filename specifies the file name recorded in source positions.
+	filename string
+	// positions specifies whether to record source positions in the LLVM IR
+	// module.
+	positions bool
+	// pos specifies the source position of the global variable, function,
+	// instruction or terminator currently being translated.
+	pos token.Pos
 }
 
 // NewModule returns a new module generator.
 func NewModule() *Module {
 	m := ir.NewModule()
 	return &Module{
-		Module:   m,
-		types:    make(map[string]types.Type),
-		globals:  make(map[string]value.Named),
-		metadata: make(map[string]*metadata.Metadata),
+		Module:     m,
+		types:      make(map[string]types.Type),
+		comdats:    make(map[string]*ir.Comdat),
+		attrGroups: make(map[string]*attr.Group),
+		globals:    make(map[string]value.Named),
+		metadata:   make(map[string]*metadata.Metadata),
 	}
 }
 
+// blockAddr represents a block address constant pending resolution of its
+// basic block.
+type blockAddr struct {
+	// Block address constant.
+	c *constant.BlockAddress
+	// Label name of the basic block.
+	name string
+}
+
 // getType returns the type of the given type name.
 func (m *Module) getType(name string) types.Type {
 	typ, ok := m.types[name]
@@ -56,6 +90,24 @@ func (m *Module) getType(name string) types.Type {
 	return typ
 }
 
+// getComdat returns the comdat of the given comdat name.
+func (m *Module) getComdat(name string) *ir.Comdat {
+	c, ok := m.comdats[name]
+	if !ok {
+		panic(fmt.Errorf("unable to locate comdat name %q", name))
+	}
+	return c
+}
+
+// getAttrGroup returns the attribute group of the given attribute group ID.
+func (m *Module) getAttrGroup(id string) *attr.Group {
+	g, ok := m.attrGroups[id]
+	if !ok {
+		panic(fmt.Errorf("unable to locate attribute group ID %q", "#"+id))
+	}
+	return g
+}
+
 // getGlobal returns the global value of the given global identifier.
 func (m *Module) getGlobal(name string) value.Named {
 	global, ok := m.globals[name]
@@ -82,3 +134,36 @@ func (m *Module) getLocal(name string) value.Named {
 	}
 	return local
 }
+
+// irPos returns the LLVM IR source position corresponding to the given token
+// position; or the zero value if source positions are not recorded.
+func (m *Module) irPos(pos token.Pos) ir.Position {
+	if !m.positions {
+		return ir.Position{}
+	}
+	return ir.Position{
+		Filename: m.filename,
+		Offset:   pos.Offset,
+		Line:     pos.Line,
+		Column:   pos.Column,
+	}
+}
+
+// errorf records an error at the source position currently being translated,
+// with a message formatted according to the format specifier.
+func (m *Module) errorf(format string, a ...interface{}) {
+	m.errs = append(m.errs, ast.Errorf(m.pos, format, a...))
+}
+
+// bailout is the panic value used by fatalf to abort the translation of a
+// module.
+type bailout struct{}
+
+// fatalf records an error at the source position currently being translated,
+// with a message formatted according to the format specifier, and aborts the
+// translation of the module. It is used for errors from which translation
+// cannot proceed.
+func (m *Module) fatalf(format string, a ...interface{}) {
+	m.errorf(format, a...)
+	panic(bailout{})
+}
@@ -32,7 +32,7 @@ func (m *Module) irMetadataNode(old ast.MetadataNode) metadata.Node {
 		c := m.irConstant(old)
 		md, ok := c.(metadata.Node)
 		if !ok {
-			panic(fmt.Errorf("invalid constant type; expected metadata.Node, got %T", c))
+			m.fatalf("invalid constant type; expected metadata.Node, got %T", c)
 		}
 		return md
 	default:
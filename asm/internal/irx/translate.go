@@ -3,13 +3,20 @@
 // Per module.
 //
 //    1. Index type definitions.
-//    2. Index global variables.
+//    2. Index comdat definitions.
+//    3. Index attribute groups.
+//    4. Index global variables.
 //       - Store preliminary content type.
-//    3. Index function.
+//    5. Index aliases and IFuncs.
+//       - Store preliminary content type.
+//    6. Index function.
 //       - Store type.
-//    4. Fix type definitions.
-//    5. Fix globals.
-//    6. Fix functions.
+//    7. Fix type definitions.
+//    8. Fix attribute groups.
+//    9. Fix globals.
+//    10. Fix aliases and IFuncs.
+//    11. Fix functions.
+//    12. Fix basic blocks of block addresses.
 //
 // Per function.
 //
@@ -27,29 +34,48 @@ import (
 	"github.com/llir/llvm/asm/internal/ast"
 	"github.com/llir/llvm/internal/enc"
 	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
 	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
-	"github.com/pkg/errors"
 )
 
 // === [ Modules ] =============================================================
 
 // Translate translates the AST of the given module to an equivalent LLVM IR
-// module.
-func Translate(module *ast.Module) (*ir.Module, error) {
+// module. If positions is set, the source positions of global variables,
+// functions, basic blocks and instructions are recorded in the LLVM IR module,
+// using the given file name. All errors encountered during translation are
+// reported as an ast.ErrorList.
+func Translate(module *ast.Module, filename string, positions bool) (_ *ir.Module, err error) {
 	m := NewModule()
+	m.filename = filename
+	m.positions = positions
+	// Translation is aborted by fatalf on errors from which it cannot proceed.
+	// Any other panic indicates a bug and is therefore propagated.
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(bailout); !ok {
+				panic(e)
+			}
+			err = m.errs
+		}
+	}()
 
-	// Set target specifiers.
+	// Set source filename, target specifiers and module-level inline assembly.
+	m.SourceFilename = module.SourceFilename
 	m.DataLayout = module.DataLayout
 	m.TargetTriple = module.TargetTriple
+	m.ModuleAsms = module.ModuleAsms
 
 	// Index type definitions.
 	for _, old := range module.Types {
+		m.pos = old.Pos
 		name := old.Name
 		if _, ok := m.types[name]; ok {
-			panic(fmt.Errorf("type name %q already present; old `%v`, new `%v`", name, m.types[name], old))
+			m.errorf("type name %q already present; old `%v`, new `%v`", name, m.types[name], old)
+			continue
 		}
 		typ := newEmptyNamedType(old.Def)
 		typ.SetName(name)
@@ -57,15 +83,37 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 		m.types[name] = typ
 	}
 
+	// Index comdat definitions.
+	for _, old := range module.Comdats {
+		c := m.NewComdat(old.Name, ir.SelectionKind(old.Kind))
+		m.comdats[old.Name] = c
+	}
+
+	// Index attribute groups.
+	for _, old := range module.AttrGroupDefs {
+		m.pos = old.Pos
+		id := old.ID
+		if _, ok := m.attrGroups[id]; ok {
+			m.errorf("attribute group ID %q already present; old `%v`, new `%v`", "#"+id, m.attrGroups[id], old)
+			continue
+		}
+		g := &attr.Group{ID: id}
+		m.AttrGroups = append(m.AttrGroups, g)
+		m.attrGroups[id] = g
+	}
+
 	// Index global variables.
 	for _, old := range module.Globals {
+		m.pos = old.Pos
 		name := old.Name
 		if _, ok := m.globals[name]; ok {
-			panic(fmt.Errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old))
+			m.errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old)
+			continue
 		}
 		global := &ir.Global{
 			Name:     name,
 			Metadata: make(map[string]*metadata.Metadata),
+			Pos:      m.irPos(old.Pos),
 		}
 		// Store preliminary content type.
 		content := m.irType(old.Content)
@@ -75,17 +123,58 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 		m.globals[name] = global
 	}
 
+	// Index aliases.
+	for _, old := range module.Aliases {
+		m.pos = old.Pos
+		name := old.Name
+		if _, ok := m.globals[name]; ok {
+			m.errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old)
+			continue
+		}
+		// Store preliminary content type.
+		alias := &ir.Alias{
+			Name: name,
+			Typ:  types.NewPointer(m.irType(old.Content)),
+			Pos:  m.irPos(old.Pos),
+		}
+		m.Aliases = append(m.Aliases, alias)
+		m.globals[name] = alias
+	}
+
+	// Index IFuncs.
+	for _, old := range module.IFuncs {
+		m.pos = old.Pos
+		name := old.Name
+		if _, ok := m.globals[name]; ok {
+			m.errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old)
+			continue
+		}
+		// Store preliminary content type.
+		content := m.irType(old.Content)
+		ifunc := &ir.IFunc{
+			Name:    name,
+			Typ:     types.NewPointer(content),
+			Content: content,
+			Pos:     m.irPos(old.Pos),
+		}
+		m.IFuncs = append(m.IFuncs, ifunc)
+		m.globals[name] = ifunc
+	}
+
 	// Index functions.
 	for _, old := range module.Funcs {
+		m.pos = old.Pos
 		name := old.Name
 		if _, ok := m.globals[name]; ok {
-			panic(fmt.Errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old))
+			m.errorf("global identifier %q already present; old `%v`, new `%v`", name, m.globals[name], old)
+			continue
 		}
 		// Store type.
 		oldSig := m.irType(old.Sig)
 		sig, ok := oldSig.(*types.FuncType)
 		if !ok {
-			panic(fmt.Errorf("invalid function signature type, expected *types.FuncType, got %T", oldSig))
+			m.errorf("invalid function signature type, expected *types.FuncType, got %T", oldSig)
+			continue
 		}
 		typ := types.NewPointer(sig)
 		f := &ir.Function{
@@ -94,6 +183,7 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 			Typ:      typ,
 			Sig:      sig,
 			Metadata: make(map[string]*metadata.Metadata),
+			Pos:      m.irPos(old.Pos),
 		}
 		m.Funcs = append(m.Funcs, f)
 		m.globals[name] = f
@@ -101,9 +191,11 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 
 	// Index metadata.
 	for _, old := range module.Metadata {
+		m.pos = old.Pos
 		id := old.ID
 		if _, ok := m.metadata[id]; ok {
-			panic(fmt.Errorf("metadata ID %q already present; old `%v`, new `%v`", id, m.metadata[id], old))
+			m.errorf("metadata ID %q already present; old `%v`, new `%v`", id, m.metadata[id], old)
+			continue
 		}
 		md := &metadata.Metadata{
 			ID: id,
@@ -112,16 +204,38 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 		m.metadata[id] = md
 	}
 
+	// Duplicate identifiers prevent the remaining definitions from being
+	// resolved.
+	if len(m.errs) > 0 {
+		return nil, m.errs
+	}
+
 	// Fix type definitions.
 	for _, typ := range module.Types {
 		m.typeDef(typ)
 	}
 
+	// Fix attribute groups.
+	for _, old := range module.AttrGroupDefs {
+		g := m.getAttrGroup(old.ID)
+		g.Attrs = m.irAttrs(old.Attrs)
+	}
+
 	// Fix globals.
 	for _, global := range module.Globals {
 		m.globalDecl(global)
 	}
 
+	// Fix aliases.
+	for _, alias := range module.Aliases {
+		m.aliasDef(alias)
+	}
+
+	// Fix IFuncs.
+	for _, ifunc := range module.IFuncs {
+		m.ifuncDef(ifunc)
+	}
+
 	// Fix functions.
 	for _, f := range module.Funcs {
 		m.funcDecl(f)
@@ -148,9 +262,13 @@ func Translate(module *ast.Module) (*ir.Module, error) {
 		m.metadataDef(md)
 	}
 
+	// Fix basic blocks of block addresses.
+	for _, addr := range m.blockAddrs {
+		m.blockAddr(addr)
+	}
+
 	if len(m.errs) > 0 {
-		// TODO: Return a list of all errors.
-		return nil, m.errs[0]
+		return nil, m.errs
 	}
 	return m.Module, nil
 }
@@ -170,8 +288,12 @@ func newEmptyNamedType(old ast.Type) types.Type {
 		return &types.PointerType{}
 	case *ast.VectorType:
 		return &types.VectorType{}
+	case *ast.MMXType:
+		return &types.MMXType{}
 	case *ast.LabelType:
 		return &types.LabelType{}
+	case *ast.TokenType:
+		return &types.TokenType{}
 	case *ast.MetadataType:
 		return &types.MetadataType{}
 	case *ast.ArrayType:
@@ -232,12 +354,24 @@ func (m *Module) typeDef(old *ast.NamedType) {
 		}
 		typ.Elem = d.Elem
 		typ.Len = d.Len
+	case *types.MMXType:
+		_, ok := def.(*types.MMXType)
+		if !ok {
+			panic(fmt.Errorf("invalid type; expected *types.MMXType, got %T", def))
+		}
+		// nothing to do.
 	case *types.LabelType:
 		_, ok := def.(*types.LabelType)
 		if !ok {
 			panic(fmt.Errorf("invalid type; expected *types.LabelType, got %T", def))
 		}
 		// nothing to do.
+	case *types.TokenType:
+		_, ok := def.(*types.TokenType)
+		if !ok {
+			panic(fmt.Errorf("invalid type; expected *types.TokenType, got %T", def))
+		}
+		// nothing to do.
 	case *types.MetadataType:
 		_, ok := def.(*types.MetadataType)
 		if !ok {
@@ -257,6 +391,7 @@ func (m *Module) typeDef(old *ast.NamedType) {
 			panic(fmt.Errorf("invalid type; expected *types.StructType, got %T", def))
 		}
 		typ.Fields = d.Fields
+		typ.Packed = d.Packed
 		typ.Opaque = d.Opaque
 	default:
 		panic(fmt.Errorf("support for type %T not yet implemented", typ))
@@ -268,6 +403,7 @@ func (m *Module) typeDef(old *ast.NamedType) {
 // globalDecl translates the given global variable declaration to LLVM IR,
 // emitting code to m.
 func (m *Module) globalDecl(old *ast.Global) {
+	m.pos = old.Pos
 	v := m.getGlobal(old.Name)
 	global, ok := v.(*ir.Global)
 	if !ok {
@@ -291,6 +427,58 @@ func (m *Module) globalDecl(old *ast.Global) {
 	typ.AddrSpace = old.AddrSpace
 	global.Typ = typ
 	global.IsConst = old.Immutable
+	global.Linkage = ir.Linkage(old.Linkage)
+	global.Visibility = ir.Visibility(old.Visibility)
+	global.DLLStorageClass = ir.DLLStorageClass(old.DLLStorageClass)
+	global.TLSModel = ir.TLSModel(old.TLSModel)
+	global.UnnamedAddr = ir.UnnamedAddr(old.UnnamedAddr)
+	global.ExternallyInitialized = old.ExternallyInitialized
+	global.Section = old.Section
+	if old.Comdat != nil {
+		global.Comdat = m.getComdat(old.Comdat.Name)
+	}
+	global.Align = old.Align
+}
+
+// === [ Aliases ] =============================================================
+
+// aliasDef translates the given alias definition to LLVM IR, emitting code to
+// m.
+func (m *Module) aliasDef(old *ast.Alias) {
+	m.pos = old.Pos
+	v := m.getGlobal(old.Name)
+	alias, ok := v.(*ir.Alias)
+	if !ok {
+		panic(fmt.Errorf("invalid alias type; expected *ir.Alias, got %T", v))
+	}
+	alias.Aliasee = m.irConstant(old.Aliasee)
+	// The address space of the alias is inferred from the aliasee.
+	typ := types.NewPointer(m.irType(old.Content))
+	if t, ok := alias.Aliasee.Type().(*types.PointerType); ok {
+		typ.AddrSpace = t.AddrSpace
+	}
+	alias.Typ = typ
+	alias.Linkage = ir.Linkage(old.Linkage)
+	alias.Visibility = ir.Visibility(old.Visibility)
+	alias.DLLStorageClass = ir.DLLStorageClass(old.DLLStorageClass)
+	alias.TLSModel = ir.TLSModel(old.TLSModel)
+	alias.UnnamedAddr = ir.UnnamedAddr(old.UnnamedAddr)
+}
+
+// === [ IFuncs ] ==============================================================
+
+// ifuncDef translates the given IFunc definition to LLVM IR, emitting code to
+// m.
+func (m *Module) ifuncDef(old *ast.IFunc) {
+	m.pos = old.Pos
+	v := m.getGlobal(old.Name)
+	ifunc, ok := v.(*ir.IFunc)
+	if !ok {
+		panic(fmt.Errorf("invalid IFunc type; expected *ir.IFunc, got %T", v))
+	}
+	ifunc.Resolver = m.irConstant(old.Resolver)
+	ifunc.Linkage = ir.Linkage(old.Linkage)
+	ifunc.Visibility = ir.Visibility(old.Visibility)
 }
 
 // === [ Functions ] ===========================================================
@@ -298,14 +486,36 @@ func (m *Module) globalDecl(old *ast.Global) {
 // funcDecl translates the given function declaration to LLVM IR, emitting code
 // to m.
 func (m *Module) funcDecl(oldFunc *ast.Function) {
+	m.pos = oldFunc.Pos
 	v := m.getGlobal(oldFunc.Name)
 	f, ok := v.(*ir.Function)
 	if !ok {
 		panic(fmt.Errorf("invalid function type for function %s; expected *ir.Function, got %T", enc.Global(oldFunc.Name), v))
 	}
 
-	// Fix calling convention.
+	// Fix linkage, visibility, DLL storage class, calling convention and unnamed
+	// address specifier.
+	f.Linkage = ir.Linkage(oldFunc.Linkage)
+	f.Visibility = ir.Visibility(oldFunc.Visibility)
+	f.DLLStorageClass = ir.DLLStorageClass(oldFunc.DLLStorageClass)
 	f.CallConv = ir.CallConv(oldFunc.CallConv)
+	f.UnnamedAddr = ir.UnnamedAddr(oldFunc.UnnamedAddr)
+
+	// Fix return and function attributes.
+	f.RetAttrs = m.irAttrs(oldFunc.RetAttrs)
+	f.FuncAttrs = m.irAttrs(oldFunc.FuncAttrs)
+
+	// Fix section, comdat and alignment.
+	f.Section = oldFunc.Section
+	if oldFunc.Comdat != nil {
+		f.Comdat = m.getComdat(oldFunc.Comdat.Name)
+	}
+	f.Align = oldFunc.Align
+
+	// Fix personality function.
+	if oldFunc.Personality != nil {
+		f.Personality = m.irConstant(oldFunc.Personality)
+	}
 
 	// Fix attached metadata.
 	f.Metadata = m.irMetadata(oldFunc.Metadata)
@@ -322,7 +532,8 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 	for _, param := range f.Params() {
 		name := param.Name
 		if _, ok := m.locals[name]; ok {
-			panic(fmt.Errorf("local identifier %q already present for function %s; old `%v`, new `%v`", name, f.Ident(), m.locals[name], param))
+			m.errorf("local identifier %q already present for function %s; old `%v`, new `%v`", name, f.Ident(), m.locals[name], param)
+			continue
 		}
 		m.locals[name] = param
 	}
@@ -331,11 +542,12 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 	for _, old := range oldFunc.Blocks {
 		name := old.Name
 		if _, ok := m.locals[name]; ok {
-			panic(fmt.Errorf("local identifier %q already present for function %s; old `%v`, new `%v`", name, f.Ident(), m.locals[name], old))
+			m.fatalf("local identifier %q already present for function %s; old `%v`, new `%v`", name, f.Ident(), m.locals[name], old)
 		}
 		block := &ir.BasicBlock{
 			Name:   name,
 			Parent: f,
+			Pos:    m.irPos(old.Pos),
 		}
 		f.Blocks = append(f.Blocks, block)
 		m.locals[name] = block
@@ -488,6 +700,22 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 				inst = &ir.InstStore{
 					Parent: block,
 				}
+			case *ast.InstFence:
+				// Fence instructions produce no value, and are thus not assigned
+				// names.
+				inst = &ir.InstFence{
+					Parent: block,
+				}
+			case *ast.InstCmpXchg:
+				inst = &ir.InstCmpXchg{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
+			case *ast.InstAtomicRMW:
+				inst = &ir.InstAtomicRMW{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
 			case *ast.InstGetElementPtr:
 				inst = &ir.InstGetElementPtr{
 					Parent: block,
@@ -587,10 +815,31 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 					Parent: block,
 					Name:   oldInst.Name,
 				}
+			case *ast.InstVAArg:
+				inst = &ir.InstVAArg{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
+			case *ast.InstLandingPad:
+				inst = &ir.InstLandingPad{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
+			case *ast.InstCatchPad:
+				inst = &ir.InstCatchPad{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
+			case *ast.InstCleanupPad:
+				inst = &ir.InstCleanupPad{
+					Parent: block,
+					Name:   oldInst.Name,
+				}
 
 			default:
 				panic(fmt.Errorf("support for instruction %T not yet implemented", oldInst))
 			}
+			inst.SetPos(m.irPos(oldInst.GetPos()))
 			block.Insts = append(block.Insts, inst)
 
 			// TODO: Validate if it is required to store a preliminary type of
@@ -605,14 +854,34 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 			// Index local variable.
 			if inst, ok := inst.(value.Named); ok {
 				// Ignore local value if of type void.
-				if oldInst, ok := oldInst.(*ast.InstCall); ok {
-					if _, ok := oldInst.Type.(*ast.VoidType); ok {
-						continue
-					}
+				if oldInst, ok := oldInst.(*ast.InstCall); ok && ast.IsVoidCall(oldInst.Type) {
+					continue
 				}
 				m.locals[inst.GetName()] = inst
 			}
 		}
+
+		// Index local variable produced by invoke and catchswitch terminators.
+		// The terminator is created in advance, as its result may be used by
+		// other basic blocks.
+		switch oldTerm := oldBlock.Term.(type) {
+		case *ast.TermInvoke:
+			term := &ir.TermInvoke{
+				Parent: block,
+				Name:   oldTerm.Name,
+			}
+			block.Term = term
+			if !ast.IsVoidCall(oldTerm.Type) {
+				m.locals[term.Name] = term
+			}
+		case *ast.TermCatchSwitch:
+			term := &ir.TermCatchSwitch{
+				Parent: block,
+				Name:   oldTerm.Name,
+			}
+			block.Term = term
+			m.locals[term.Name] = term
+		}
 	}
 
 	// Fix basic blocks.
@@ -628,6 +897,7 @@ func (m *Module) funcDecl(oldFunc *ast.Function) {
 // metadataDef translates the given metadata definition to LLVM IR, emitting
 // code to m.
 func (m *Module) metadataDef(oldMetadata *ast.Metadata) {
+	m.pos = oldMetadata.Pos
 	md := m.getMetadata(oldMetadata.ID)
 	for _, oldNode := range oldMetadata.Nodes {
 		node := m.metadataNode(oldNode)
@@ -662,7 +932,7 @@ func (m *Module) metadataNode(oldNode ast.MetadataNode) metadata.Node {
 		c := m.irConstant(oldNode)
 		md, ok := c.(metadata.Node)
 		if !ok {
-			panic(fmt.Sprintf("invalid metadata node type; expected metadata.Node, got %T", c))
+			m.fatalf("invalid metadata node type; expected metadata.Node, got %T", c)
 		}
 		return md
 	default:
@@ -670,6 +940,23 @@ func (m *Module) metadataNode(oldNode ast.MetadataNode) metadata.Node {
 	}
 }
 
+// === [ Block addresses ] =====================================================
+
+// blockAddr resolves the basic block of the given block address constant.
+func (m *Module) blockAddr(addr *blockAddr) {
+	f, ok := addr.c.Func.(*ir.Function)
+	if !ok {
+		panic(fmt.Errorf("invalid block address function type; expected *ir.Function, got %T", addr.c.Func))
+	}
+	for _, block := range f.Blocks {
+		if block.Name == addr.name {
+			addr.c.Block = block
+			return
+		}
+	}
+	panic(fmt.Errorf("unable to locate basic block %s in function %s", enc.Local(addr.name), f.Ident()))
+}
+
 // === [ Identifiers ] =========================================================
 
 // === [ Types ] ===============================================================
@@ -700,6 +987,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 	for i := 0; i < len(oldBlock.Insts); i++ {
 		oldInst := oldBlock.Insts[i]
 		v := block.Insts[i]
+		m.pos = oldInst.GetPos()
 		switch oldInst := oldInst.(type) {
 		// Binary instructions
 		case *ast.InstAdd:
@@ -709,6 +997,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.OverflowFlags = irOverflowFlags(oldInst.OverflowFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstFAdd:
 			inst, ok := v.(*ir.InstFAdd)
@@ -717,6 +1006,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstSub:
 			inst, ok := v.(*ir.InstSub)
@@ -725,6 +1015,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.OverflowFlags = irOverflowFlags(oldInst.OverflowFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstFSub:
 			inst, ok := v.(*ir.InstFSub)
@@ -733,6 +1024,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstMul:
 			inst, ok := v.(*ir.InstMul)
@@ -741,6 +1033,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.OverflowFlags = irOverflowFlags(oldInst.OverflowFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstFMul:
 			inst, ok := v.(*ir.InstFMul)
@@ -749,6 +1042,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstUDiv:
 			inst, ok := v.(*ir.InstUDiv)
@@ -757,6 +1051,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.Exact = oldInst.Exact
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstSDiv:
 			inst, ok := v.(*ir.InstSDiv)
@@ -765,6 +1060,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.Exact = oldInst.Exact
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstFDiv:
 			inst, ok := v.(*ir.InstFDiv)
@@ -773,6 +1069,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstURem:
 			inst, ok := v.(*ir.InstURem)
@@ -797,6 +1094,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 
 		// Bitwise instructions
@@ -807,6 +1105,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.OverflowFlags = irOverflowFlags(oldInst.OverflowFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstLShr:
 			inst, ok := v.(*ir.InstLShr)
@@ -815,6 +1114,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.Exact = oldInst.Exact
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstAShr:
 			inst, ok := v.(*ir.InstAShr)
@@ -823,6 +1123,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.X = m.irValue(oldInst.X)
 			inst.Y = m.irValue(oldInst.Y)
+			inst.Exact = oldInst.Exact
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstAnd:
 			inst, ok := v.(*ir.InstAnd)
@@ -858,7 +1159,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			x := m.irValue(oldInst.X)
 			t, ok := x.Type().(*types.VectorType)
 			if !ok {
-				panic(fmt.Errorf("invalid vector type; expected *types.VectorType, got %T", x.Type()))
+				m.fatalf("invalid vector type; expected *types.VectorType, got %T", x.Type())
 			}
 			inst.Typ = t.Elem
 			inst.X = x
@@ -890,7 +1191,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 				panic(fmt.Errorf("invalid instruction type; expected *ir.InstExtractValue, got %T", v))
 			}
 			x := m.irValue(oldInst.X)
-			typ := aggregateElemType(x.Type(), oldInst.Indices)
+			typ := m.aggregateElemType(x.Type(), oldInst.Indices)
 			inst.Typ = typ
 			inst.X = x
 			inst.Indices = oldInst.Indices
@@ -900,8 +1201,13 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			if !ok {
 				panic(fmt.Errorf("invalid instruction type; expected *ir.InstInsertValue, got %T", v))
 			}
-			inst.X = m.irValue(oldInst.X)
-			inst.Elem = m.irValue(oldInst.Elem)
+			x := m.irValue(oldInst.X)
+			elem := m.irValue(oldInst.Elem)
+			if got, want := elem.Type(), m.aggregateElemType(x.Type(), oldInst.Indices); !got.Equal(want) {
+				m.errorf("element type mismatch; expected `%v`, got `%v`", want, got)
+			}
+			inst.X = x
+			inst.Elem = elem
 			inst.Indices = oldInst.Indices
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 
@@ -913,11 +1219,13 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			elem := m.irType(oldInst.Elem)
 			typ := types.NewPointer(elem)
+			typ.AddrSpace = oldInst.AddrSpace
 			inst.Typ = typ
 			inst.Elem = elem
 			if oldInst.NElems != nil {
 				inst.NElems = m.irValue(oldInst.NElems)
 			}
+			inst.Align = oldInst.Align
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstLoad:
 			inst, ok := v.(*ir.InstLoad)
@@ -927,14 +1235,18 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			src := m.irValue(oldInst.Src)
 			srcType, ok := src.Type().(*types.PointerType)
 			if !ok {
-				panic(fmt.Errorf("invalid source type; expected *types.PointerType, got %T", src.Type()))
+				m.fatalf("invalid source type; expected *types.PointerType, got %T", src.Type())
 			}
 			typ := srcType.Elem
 			if got, want := typ, m.irType(oldInst.Elem); !got.Equal(want) {
-				m.errs = append(m.errs, errors.Errorf("source element type mismatch; expected `%v`, got `%v`", want, got))
+				m.errorf("source element type mismatch; expected `%v`, got `%v`", want, got)
 			}
 			inst.Typ = typ
 			inst.Src = src
+			inst.Ordering = ir.AtomicOrdering(oldInst.Ordering)
+			inst.SyncScope = oldInst.SyncScope
+			inst.Volatile = oldInst.Volatile
+			inst.Align = oldInst.Align
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstStore:
 			inst, ok := v.(*ir.InstStore)
@@ -943,6 +1255,47 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			}
 			inst.Src = m.irValue(oldInst.Src)
 			inst.Dst = m.irValue(oldInst.Dst)
+			inst.Ordering = ir.AtomicOrdering(oldInst.Ordering)
+			inst.SyncScope = oldInst.SyncScope
+			inst.Volatile = oldInst.Volatile
+			inst.Align = oldInst.Align
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstFence:
+			inst, ok := v.(*ir.InstFence)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstFence, got %T", v))
+			}
+			inst.Ordering = ir.AtomicOrdering(oldInst.Ordering)
+			inst.SyncScope = oldInst.SyncScope
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstCmpXchg:
+			inst, ok := v.(*ir.InstCmpXchg)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstCmpXchg, got %T", v))
+			}
+			inst.Ptr = m.irValue(oldInst.Ptr)
+			inst.Cmp = m.irValue(oldInst.Cmp)
+			inst.New = m.irValue(oldInst.New)
+			inst.Typ = types.NewStruct(inst.Cmp.Type(), types.I1)
+			inst.SuccessOrdering = ir.AtomicOrdering(oldInst.SuccessOrdering)
+			inst.FailureOrdering = ir.AtomicOrdering(oldInst.FailureOrdering)
+			inst.SyncScope = oldInst.SyncScope
+			inst.Weak = oldInst.Weak
+			inst.Volatile = oldInst.Volatile
+			inst.Align = oldInst.Align
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstAtomicRMW:
+			inst, ok := v.(*ir.InstAtomicRMW)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstAtomicRMW, got %T", v))
+			}
+			inst.Op = ir.AtomicOp(oldInst.Op)
+			inst.Dst = m.irValue(oldInst.Dst)
+			inst.X = m.irValue(oldInst.X)
+			inst.Ordering = ir.AtomicOrdering(oldInst.Ordering)
+			inst.SyncScope = oldInst.SyncScope
+			inst.Volatile = oldInst.Volatile
+			inst.Align = oldInst.Align
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstGetElementPtr:
 			inst, ok := v.(*ir.InstGetElementPtr)
@@ -952,47 +1305,23 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			src := m.irValue(oldInst.Src)
 			srcType, ok := src.Type().(*types.PointerType)
 			if !ok {
-				m.errs = append(m.errs, errors.Errorf("invalid source type; expected *types.PointerType, got %T", src.Type()))
+				m.fatalf("invalid source type; expected *types.PointerType, got %T", src.Type())
 			}
 			elem := srcType.Elem
 			if got, want := elem, m.irType(oldInst.Elem); !got.Equal(want) {
-				m.errs = append(m.errs, errors.Errorf("source element type mismatch; expected `%v`, got `%v`", want, got))
+				m.errorf("source element type mismatch; expected `%v`, got `%v`", want, got)
 			}
 			var indices []value.Value
 			for _, oldIndex := range oldInst.Indices {
 				index := m.irValue(oldIndex)
 				indices = append(indices, index)
 			}
-			e := elem
-			for i, index := range indices {
-				if i == 0 {
-					// Ignore checking the 0th index as it simply follows the pointer of
-					// src.
-					//
-					// ref: http://llvm.org/docs/GetElementPtr.html#why-is-the-extra-0-index-required
-					continue
-				}
-				switch t := e.(type) {
-				case *types.PointerType:
-					// ref: http://llvm.org/docs/GetElementPtr.html#what-is-dereferenced-by-gep
-					panic("unable to index into element of pointer type; for more information, see http://llvm.org/docs/GetElementPtr.html#what-is-dereferenced-by-gep")
-				case *types.ArrayType:
-					e = t.Elem
-				case *types.StructType:
-					idx, ok := index.(*constant.Int)
-					if !ok {
-						panic(fmt.Errorf("invalid index type for structure element; expected *constant.Int, got %T", index))
-					}
-					e = t.Fields[idx.Int64()]
-				default:
-					panic(fmt.Errorf("support for indexing element type %T not yet implemented", e))
-				}
-			}
-			typ := types.NewPointer(e)
+			typ := types.NewPointer(m.gepElemType(elem, indices))
 			inst.Typ = typ
 			inst.Elem = elem
 			inst.Src = src
 			inst.Indices = indices
+			inst.InBounds = oldInst.InBounds
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 
 		// Conversion instructions
@@ -1135,6 +1464,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			inst.Pred = pred
 			inst.X = x
 			inst.Y = y
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstPhi:
 			inst, ok := v.(*ir.InstPhi)
@@ -1147,7 +1477,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 				v := m.getLocal(oldInc.Pred.GetName())
 				pred, ok := v.(*ir.BasicBlock)
 				if !ok {
-					panic(fmt.Errorf("invalid basic block type; expected *ir.BasicBlock, got %T", v))
+					m.fatalf("invalid basic block type; expected *ir.BasicBlock, got %T", v)
 				}
 				inc := &ir.Incoming{
 					X:    x,
@@ -1155,6 +1485,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 				}
 				inst.Incs = append(inst.Incs, inc)
 			}
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 		case *ast.InstSelect:
 			inst, ok := v.(*ir.InstSelect)
@@ -1170,23 +1501,80 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			if !ok {
 				panic(fmt.Errorf("invalid instruction type; expected *ir.InstCall, got %T", v))
 			}
-			callee := m.irValue(oldInst.Callee)
+			for _, oldArg := range oldInst.Args {
+				arg := m.irArg(oldArg)
+				inst.Args = append(inst.Args, arg)
+			}
+			callee := m.irCallee(oldInst.Callee, inst.Args)
 			typ, ok := callee.Type().(*types.PointerType)
 			if !ok {
-				panic(fmt.Errorf("invalid callee type, expected *types.PointerType, got %T", callee.Type()))
+				m.fatalf("invalid callee type, expected *types.PointerType, got %T", callee.Type())
 			}
 			sig, ok := typ.Elem.(*types.FuncType)
 			if !ok {
-				panic(fmt.Errorf("invalid callee signature type, expected *types.FuncType, got %T", typ.Elem))
+				m.fatalf("invalid callee signature type, expected *types.FuncType, got %T", typ.Elem)
 			}
 			inst.Callee = callee
 			inst.Sig = sig
 			// TODO: Validate oldInst.Type against inst.Sig.
+			inst.CallConv = ir.CallConv(oldInst.CallConv)
+			inst.Tail = ir.Tail(oldInst.Tail)
+			inst.FastMathFlags = irFastMathFlags(oldInst.FastMathFlags)
+			inst.RetAttrs = m.irAttrs(oldInst.RetAttrs)
+			inst.FuncAttrs = m.irAttrs(oldInst.FuncAttrs)
+			for _, oldBundle := range oldInst.OperandBundles {
+				bundle := &ir.OperandBundle{Tag: oldBundle.Tag}
+				for _, oldInput := range oldBundle.Inputs {
+					input := m.irValue(oldInput)
+					bundle.Inputs = append(bundle.Inputs, input)
+				}
+				inst.OperandBundles = append(inst.OperandBundles, bundle)
+			}
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstVAArg:
+			inst, ok := v.(*ir.InstVAArg)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstVAArg, got %T", v))
+			}
+			inst.ArgList = m.irValue(oldInst.ArgList)
+			inst.ArgType = m.irType(oldInst.ArgType)
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstLandingPad:
+			inst, ok := v.(*ir.InstLandingPad)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstLandingPad, got %T", v))
+			}
+			inst.Typ = m.irType(oldInst.Type)
+			inst.Cleanup = oldInst.Cleanup
+			for _, oldClause := range oldInst.Clauses {
+				clause := &ir.Clause{
+					Kind: ir.ClauseKind(oldClause.Kind),
+					X:    m.irConstant(oldClause.X),
+				}
+				inst.Clauses = append(inst.Clauses, clause)
+			}
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstCatchPad:
+			inst, ok := v.(*ir.InstCatchPad)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstCatchPad, got %T", v))
+			}
+			inst.Within = m.irValue(oldInst.Within)
+			for _, oldArg := range oldInst.Args {
+				arg := m.irValue(oldArg)
+				inst.Args = append(inst.Args, arg)
+			}
+			inst.Metadata = m.irMetadata(oldInst.Metadata)
+		case *ast.InstCleanupPad:
+			inst, ok := v.(*ir.InstCleanupPad)
+			if !ok {
+				panic(fmt.Errorf("invalid instruction type; expected *ir.InstCleanupPad, got %T", v))
+			}
+			inst.Within = m.irValue(oldInst.Within)
 			for _, oldArg := range oldInst.Args {
 				arg := m.irValue(oldArg)
 				inst.Args = append(inst.Args, arg)
 			}
-			inst.CallConv = ir.CallConv(oldInst.CallConv)
 			inst.Metadata = m.irMetadata(oldInst.Metadata)
 
 		default:
@@ -1195,6 +1583,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 	}
 
 	// Fix terminator.
+	m.pos = oldBlock.Term.GetPos()
 	switch oldTerm := oldBlock.Term.(type) {
 	case *ast.TermRet:
 		term := &ir.TermRet{
@@ -1212,9 +1601,10 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 		v := m.irValue(oldTerm.Target)
 		target, ok := v.(*ir.BasicBlock)
 		if !ok {
-			panic(fmt.Errorf("invalid target branch type, expected *ir.BasicBlock, got %T", v))
+			m.fatalf("invalid target branch type, expected *ir.BasicBlock, got %T", v)
 		}
 		term.Target = target
+		term.Successors = []*ir.BasicBlock{target}
 		term.Metadata = m.irMetadata(oldTerm.Metadata)
 		block.Term = term
 	case *ast.TermCondBr:
@@ -1224,12 +1614,12 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 		tTrue := m.irValue(oldTerm.TargetTrue)
 		targetTrue, ok := tTrue.(*ir.BasicBlock)
 		if !ok {
-			panic(fmt.Errorf("invalid true target branch type, expected *ir.BasicBlock, got %T", tTrue))
+			m.fatalf("invalid true target branch type, expected *ir.BasicBlock, got %T", tTrue)
 		}
 		tFalse := m.irValue(oldTerm.TargetFalse)
 		targetFalse, ok := tFalse.(*ir.BasicBlock)
 		if !ok {
-			panic(fmt.Errorf("invalid false target branch type, expected *ir.BasicBlock, got %T", tFalse))
+			m.fatalf("invalid false target branch type, expected *ir.BasicBlock, got %T", tFalse)
 		}
 		successors := []*ir.BasicBlock{targetTrue, targetFalse}
 		term.Cond = m.irValue(oldTerm.Cond)
@@ -1246,7 +1636,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 		v := m.getLocal(oldTerm.TargetDefault.GetName())
 		targetDefault, ok := v.(*ir.BasicBlock)
 		if !ok {
-			panic(fmt.Errorf("invalid default target branch type, expected *ir.BasicBlock, got %T", v))
+			m.fatalf("invalid default target branch type, expected *ir.BasicBlock, got %T", v)
 		}
 		term.TargetDefault = targetDefault
 		successors := []*ir.BasicBlock{targetDefault}
@@ -1254,12 +1644,12 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 			xx := m.irConstant(oldCase.X)
 			x, ok := xx.(*constant.Int)
 			if !ok {
-				panic(fmt.Errorf("invalid x type, expected *constant.Int, got %T", xx))
+				m.fatalf("invalid x type, expected *constant.Int, got %T", xx)
 			}
 			v := m.getLocal(oldCase.Target.GetName())
 			target, ok := v.(*ir.BasicBlock)
 			if !ok {
-				panic(fmt.Errorf("invalid target branch type, expected *ir.BasicBlock, got %T", v))
+				m.fatalf("invalid target branch type, expected *ir.BasicBlock, got %T", v)
 			}
 			c := &ir.Case{
 				X:      x,
@@ -1271,6 +1661,122 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 		term.Successors = successors
 		term.Metadata = m.irMetadata(oldTerm.Metadata)
 		block.Term = term
+	case *ast.TermIndirectBr:
+		term := &ir.TermIndirectBr{
+			Parent: block,
+		}
+		term.Addr = m.irValue(oldTerm.Addr)
+		for _, oldTarget := range oldTerm.ValidTargets {
+			v := m.getLocal(oldTarget.GetName())
+			target, ok := v.(*ir.BasicBlock)
+			if !ok {
+				m.fatalf("invalid target branch type, expected *ir.BasicBlock, got %T", v)
+			}
+			term.ValidTargets = append(term.ValidTargets, target)
+			term.Successors = append(term.Successors, target)
+		}
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+		block.Term = term
+	case *ast.TermInvoke:
+		term, ok := block.Term.(*ir.TermInvoke)
+		if !ok {
+			panic(fmt.Errorf("invalid terminator type; expected *ir.TermInvoke, got %T", block.Term))
+		}
+		for _, oldArg := range oldTerm.Args {
+			arg := m.irValue(oldArg)
+			term.Args = append(term.Args, arg)
+		}
+		callee := m.irCallee(oldTerm.Callee, term.Args)
+		typ, ok := callee.Type().(*types.PointerType)
+		if !ok {
+			m.fatalf("invalid callee type, expected *types.PointerType, got %T", callee.Type())
+		}
+		sig, ok := typ.Elem.(*types.FuncType)
+		if !ok {
+			m.fatalf("invalid callee signature type, expected *types.FuncType, got %T", typ.Elem)
+		}
+		term.Callee = callee
+		term.Sig = sig
+		// TODO: Validate oldTerm.Type against term.Sig.
+		term.CallConv = ir.CallConv(oldTerm.CallConv)
+		v := m.getLocal(oldTerm.Normal.GetName())
+		normal, ok := v.(*ir.BasicBlock)
+		if !ok {
+			m.fatalf("invalid normal target branch type, expected *ir.BasicBlock, got %T", v)
+		}
+		v = m.getLocal(oldTerm.Exception.GetName())
+		exception, ok := v.(*ir.BasicBlock)
+		if !ok {
+			m.fatalf("invalid exception target branch type, expected *ir.BasicBlock, got %T", v)
+		}
+		term.Normal = normal
+		term.Exception = exception
+		term.Successors = []*ir.BasicBlock{normal, exception}
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+	case *ast.TermResume:
+		term := &ir.TermResume{
+			Parent: block,
+		}
+		term.X = m.irValue(oldTerm.X)
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+		block.Term = term
+	case *ast.TermCatchSwitch:
+		term, ok := block.Term.(*ir.TermCatchSwitch)
+		if !ok {
+			panic(fmt.Errorf("invalid terminator type; expected *ir.TermCatchSwitch, got %T", block.Term))
+		}
+		term.Within = m.irValue(oldTerm.Within)
+		var successors []*ir.BasicBlock
+		for _, oldHandler := range oldTerm.Handlers {
+			v := m.getLocal(oldHandler.GetName())
+			handler, ok := v.(*ir.BasicBlock)
+			if !ok {
+				m.fatalf("invalid exception handler type, expected *ir.BasicBlock, got %T", v)
+			}
+			term.Handlers = append(term.Handlers, handler)
+			successors = append(successors, handler)
+		}
+		if oldTerm.Unwind != nil {
+			v := m.getLocal(oldTerm.Unwind.GetName())
+			unwind, ok := v.(*ir.BasicBlock)
+			if !ok {
+				m.fatalf("invalid unwind target branch type, expected *ir.BasicBlock, got %T", v)
+			}
+			term.Unwind = unwind
+			successors = append(successors, unwind)
+		}
+		term.Successors = successors
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+	case *ast.TermCatchRet:
+		term := &ir.TermCatchRet{
+			Parent: block,
+		}
+		term.From = m.irValue(oldTerm.From)
+		v := m.getLocal(oldTerm.To.GetName())
+		to, ok := v.(*ir.BasicBlock)
+		if !ok {
+			m.fatalf("invalid target branch type, expected *ir.BasicBlock, got %T", v)
+		}
+		term.To = to
+		term.Successors = []*ir.BasicBlock{to}
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+		block.Term = term
+	case *ast.TermCleanupRet:
+		term := &ir.TermCleanupRet{
+			Parent: block,
+		}
+		term.From = m.irValue(oldTerm.From)
+		if oldTerm.Unwind != nil {
+			v := m.getLocal(oldTerm.Unwind.GetName())
+			unwind, ok := v.(*ir.BasicBlock)
+			if !ok {
+				m.fatalf("invalid unwind target branch type, expected *ir.BasicBlock, got %T", v)
+			}
+			term.Unwind = unwind
+			term.Successors = []*ir.BasicBlock{unwind}
+		}
+		term.Metadata = m.irMetadata(oldTerm.Metadata)
+		block.Term = term
 	case *ast.TermUnreachable:
 		term := &ir.TermUnreachable{
 			Parent: block,
@@ -1280,6 +1786,7 @@ func (m *Module) basicBlock(oldBlock *ast.BasicBlock, block *ir.BasicBlock) {
 	default:
 		panic(fmt.Errorf("support for terminator %T not yet implemented", oldTerm))
 	}
+	block.Term.SetPos(m.irPos(oldBlock.Term.GetPos()))
 }
 
 // === [ Instructions ] ========================================================
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/llir/llvm/asm/internal/ast"
+	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
 )
 
 // irType returns the corresponding LLVM IR type of the given type.
@@ -16,6 +18,7 @@ func (m *Module) irType(old ast.Type) types.Type {
 		params := make([]*types.Param, len(old.Params))
 		for i, oldParam := range old.Params {
 			params[i] = types.NewParam(oldParam.Name, m.irType(oldParam.Type))
+			params[i].Attrs = m.irAttrs(oldParam.Attrs)
 		}
 		typ := types.NewFunc(m.irType(old.Ret), params...)
 		typ.Variadic = old.Variadic
@@ -45,8 +48,12 @@ func (m *Module) irType(old ast.Type) types.Type {
 		return typ
 	case *ast.VectorType:
 		return types.NewVector(m.irType(old.Elem), old.Len)
+	case *ast.MMXType:
+		return types.MMX
 	case *ast.LabelType:
 		return types.Label
+	case *ast.TokenType:
+		return types.Token
 	case *ast.MetadataType:
 		return types.Metadata
 	case *ast.ArrayType:
@@ -57,6 +64,7 @@ func (m *Module) irType(old ast.Type) types.Type {
 			fields[i] = m.irType(oldField)
 		}
 		typ := types.NewStruct(fields...)
+		typ.Packed = old.Packed
 		typ.Opaque = old.Opaque
 		return typ
 	case *ast.NamedType:
@@ -72,23 +80,62 @@ func (m *Module) irType(old ast.Type) types.Type {
 
 // aggregateElemType returns the element type of the given aggregate type, based
 // on the specified indices.
-func aggregateElemType(t types.Type, indices []int64) types.Type {
+func (m *Module) aggregateElemType(t types.Type, indices []int64) types.Type {
 	if len(indices) == 0 {
 		return t
 	}
 	index := indices[0]
 	switch t := t.(type) {
 	case *types.ArrayType:
-		if index >= t.Len {
-			panic(fmt.Errorf("invalid index (%d); exceeds array length (%d)", index, t.Len))
+		if index < 0 || index >= t.Len {
+			m.fatalf("invalid index (%d); exceeds array length (%d)", index, t.Len)
 		}
-		return aggregateElemType(t.Elem, indices[1:])
+		return m.aggregateElemType(t.Elem, indices[1:])
 	case *types.StructType:
-		if index >= int64(len(t.Fields)) {
-			panic(fmt.Errorf("invalid index (%d); exceeds struct field count (%d)", index, len(t.Fields)))
+		if index < 0 || index >= int64(len(t.Fields)) {
+			m.fatalf("invalid index (%d); exceeds struct field count (%d)", index, len(t.Fields))
 		}
-		return aggregateElemType(t.Fields[index], indices[1:])
+		return m.aggregateElemType(t.Fields[index], indices[1:])
 	default:
-		panic(fmt.Errorf("invalid aggregate value type; expected *types.ArrayType or *types.StructType, got %T", t))
+		m.fatalf("invalid aggregate value type; expected *types.ArrayType or *types.StructType, got %T", t)
+		return nil
 	}
 }
+
+// gepElemType returns the element type of the address computed by a
+// getelementptr instruction or expression, based on the given source element
+// type and indices.
+func (m *Module) gepElemType(elem types.Type, indices []value.Value) types.Type {
+	e := elem
+	for i, index := range indices {
+		if i == 0 {
+			// Ignore checking the 0th index as it simply follows the pointer of
+			// src.
+			//
+			// ref: http://llvm.org/docs/GetElementPtr.html#why-is-the-extra-0-index-required
+			continue
+		}
+		switch t := e.(type) {
+		case *types.PointerType:
+			// ref: http://llvm.org/docs/GetElementPtr.html#what-is-dereferenced-by-gep
+			m.fatalf("unable to index into element of pointer type; for more information, see http://llvm.org/docs/GetElementPtr.html#what-is-dereferenced-by-gep")
+		case *types.ArrayType:
+			e = t.Elem
+		case *types.StructType:
+			if idx, ok := index.(*constant.Index); ok {
+				index = idx.Constant
+			}
+			idx, ok := index.(*constant.Int)
+			if !ok {
+				m.fatalf("invalid index type for structure element; expected *constant.Int, got %T", index)
+			}
+			if !idx.X.IsInt64() || idx.Int64() < 0 || idx.Int64() >= int64(len(t.Fields)) {
+				m.fatalf("invalid index (%v); exceeds struct field count (%d)", idx.X, len(t.Fields))
+			}
+			e = t.Fields[idx.Int64()]
+		default:
+			m.fatalf("support for indexing element type %T not yet implemented", e)
+		}
+	}
+	return e
+}
@@ -5,11 +5,49 @@ import (
 
 	"github.com/llir/llvm/asm/internal/ast"
 	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 )
 
 // ### [ Helper functions ] ####################################################
 
+// irAttrs returns the corresponding LLVM IR attributes of the given attributes.
+func (m *Module) irAttrs(old []ast.Attribute) []attr.Attribute {
+	if len(old) == 0 {
+		return nil
+	}
+	attrs := make([]attr.Attribute, len(old))
+	for i, oldAttr := range old {
+		switch oldAttr := oldAttr.(type) {
+		case ast.EnumAttr:
+			attrs[i] = attr.Enum(oldAttr)
+		case ast.AlignAttr:
+			attrs[i] = attr.Align(oldAttr)
+		case ast.AlignStackAttr:
+			attrs[i] = attr.AlignStack(oldAttr)
+		case ast.DereferenceableAttr:
+			attrs[i] = attr.Dereferenceable(oldAttr)
+		case ast.DereferenceableOrNullAttr:
+			attrs[i] = attr.DereferenceableOrNull(oldAttr)
+		case *ast.AllocSizeAttr:
+			a := &attr.AllocSize{ElemSize: oldAttr.ElemSize}
+			if oldAttr.NElems != -1 {
+				a.NElems = oldAttr.NElems
+				a.HasNElems = true
+			}
+			attrs[i] = a
+		case *ast.StringAttr:
+			attrs[i] = &attr.String{Key: oldAttr.Key, Val: oldAttr.Val}
+		case *ast.AttrGroupDef:
+			attrs[i] = m.getAttrGroup(oldAttr.ID)
+		default:
+			panic(fmt.Errorf("support for attribute %T not yet implemented", oldAttr))
+		}
+	}
+	return attrs
+}
+
 // irIntPred returns the corresponding LLVM IR integer predicate of the given
 // integer predicate.
 func irIntPred(cond ast.IntPred) ir.IntPred {
@@ -78,6 +116,36 @@ func irFloatPred(cond ast.FloatPred) ir.FloatPred {
 	panic(fmt.Errorf("support for floating-point predicate %v not yet implemented", cond))
 }
 
+// irOverflowFlags returns the corresponding LLVM IR overflow flags of the given
+// overflow flags.
+func irOverflowFlags(oldFlags []ast.OverflowFlag) []ir.OverflowFlag {
+	var flags []ir.OverflowFlag
+	for _, oldFlag := range oldFlags {
+		flags = append(flags, ir.OverflowFlag(oldFlag))
+	}
+	return flags
+}
+
+// irConstOverflowFlags returns the corresponding LLVM IR constant expression
+// overflow flags of the given overflow flags.
+func irConstOverflowFlags(oldFlags []ast.OverflowFlag) []constant.OverflowFlag {
+	var flags []constant.OverflowFlag
+	for _, oldFlag := range oldFlags {
+		flags = append(flags, constant.OverflowFlag(oldFlag))
+	}
+	return flags
+}
+
+// irFastMathFlags returns the corresponding LLVM IR fast-math flags of the
+// given fast-math flags.
+func irFastMathFlags(oldFlags []ast.FastMathFlag) []ir.FastMathFlag {
+	var flags []ir.FastMathFlag
+	for _, oldFlag := range oldFlags {
+		flags = append(flags, ir.FastMathFlag(oldFlag))
+	}
+	return flags
+}
+
 // irMetadata returns the corresponding LLVM IR metadata of the given list of
 // attached metadata.
 func (m *Module) irMetadata(oldMDs []*ast.AttachedMD) map[string]*metadata.Metadata {
@@ -86,11 +154,13 @@ func (m *Module) irMetadata(oldMDs []*ast.AttachedMD) map[string]*metadata.Metad
 		key := oldMD.Name
 		node := m.metadataNode(oldMD.Metadata)
 		if prev, ok := mds[key]; ok {
-			panic(fmt.Errorf("attached metadata for metadata name %q already present; previous `%v`, new `%v`", key, prev, m.Metadata))
+			m.errorf("attached metadata for metadata name %q already present; previous `%v`, new `%v`", key, prev, node)
+			continue
 		}
 		md, ok := node.(*metadata.Metadata)
 		if !ok {
-			panic(fmt.Errorf("invalid metadata type; expected *metadata.Metadata, got %T", node))
+			m.errorf("invalid metadata type; expected *metadata.Metadata, got %T", node)
+			continue
 		}
 		mds[key] = md
 	}
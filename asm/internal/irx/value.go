@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/llir/llvm/asm/internal/ast"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
 )
 
@@ -17,10 +19,10 @@ func (m *Module) irValue(old ast.Value) value.Value {
 	case ast.NamedValue:
 		switch old := old.(type) {
 		// Global identifiers.
-		case *ast.Global, *ast.GlobalDummy, *ast.Function:
+		case *ast.Global, *ast.GlobalDummy, *ast.Function, *ast.Alias, *ast.IFunc:
 			return m.getGlobal(old.GetName())
 		// Local identifiers.
-		case *ast.Param, *ast.BasicBlock, *ast.LocalDummy, ast.Instruction:
+		case *ast.Param, *ast.BasicBlock, *ast.LocalDummy, ast.Instruction, *ast.TermInvoke, *ast.TermCatchSwitch:
 			return m.getLocal(old.GetName())
 		default:
 			panic(fmt.Errorf("support for named value %T not yet implemented", old))
@@ -32,3 +34,40 @@ func (m *Module) irValue(old ast.Value) value.Value {
 		panic(fmt.Errorf("support for value %T not yet implemented", old))
 	}
 }
+
+// irArg returns the corresponding LLVM IR function argument of the given
+// function argument.
+func (m *Module) irArg(old ast.Value) value.Value {
+	if old, ok := old.(*ast.Arg); ok {
+		return ir.NewArg(m.irValue(old.Value), m.irAttrs(old.Attrs)...)
+	}
+	return m.irValue(old)
+}
+
+// irCallee returns the corresponding LLVM IR callee of the given callee, based
+// on the translated function arguments of the call site.
+func (m *Module) irCallee(old ast.Value, args []value.Value) value.Value {
+	oldAsm, ok := old.(*ast.InlineAsm)
+	if !ok {
+		return m.irValue(old)
+	}
+	// The type of inline assembler expressions is either the function type of
+	// the callee, or its return type in which case the parameter types are
+	// inferred from the function arguments.
+	var sig *types.FuncType
+	switch t := m.irType(oldAsm.Type).(type) {
+	case *types.FuncType:
+		sig = t
+	default:
+		var params []*types.Param
+		for _, arg := range args {
+			params = append(params, types.NewParam("", arg.Type()))
+		}
+		sig = types.NewFunc(t, params...)
+	}
+	asm := ir.NewInlineAsm(sig, oldAsm.Asm, oldAsm.Constraint)
+	asm.SideEffect = oldAsm.SideEffect
+	asm.AlignStack = oldAsm.AlignStack
+	asm.IntelDialect = oldAsm.IntelDialect
+	return asm
+}
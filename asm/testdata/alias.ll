@@ -0,0 +1,67 @@
+; Aliases.
+@a1 = alias i32, i32* @g1
+
+; Aliasee defined after its use.
+@a2 = alias i32, i32* @g2
+
+; Alias of alias.
+@a3 = alias i32, i32* @a1
+
+; Alias of function.
+@a4 = alias i32 (i32), i32 (i32)* @f
+
+; Constant expression aliasee.
+@a5 = alias i8, bitcast (i32* @g1 to i8*)
+@a6 = alias i32, getelementptr ([2 x i32], [2 x i32]* @g3, i32 0, i32 1)
+
+; Address space inferred from aliasee.
+@a7 = alias i32, i32 addrspace(1)* @g4
+
+; Linkage.
+@a8 = private alias i32, i32* @g1
+@a9 = internal alias i32, i32* @g1
+@a10 = linkonce alias i32, i32* @g1
+@a11 = linkonce_odr alias i32, i32* @g1
+@a12 = weak alias i32, i32* @g1
+@a13 = weak_odr alias i32, i32* @g1
+
+; Visibility.
+@a14 = hidden alias i32, i32* @g1
+@a15 = protected alias i32, i32* @g1
+
+; DLL storage class.
+@a16 = dllexport alias i32, i32* @g1
+
+; Thread local storage model.
+@a17 = thread_local(initialexec) alias i32, i32* @g5
+
+; Unnamed address.
+@a18 = unnamed_addr alias i32, i32* @g1
+@a19 = local_unnamed_addr alias i32, i32* @g1
+
+; IFuncs.
+@i1 = ifunc i32 (i32), i32 (i32)* ()* @resolver
+
+; Linkage and visibility.
+@i2 = weak hidden ifunc i32 (i32), i32 (i32)* ()* @resolver
+
+@g1 = global i32 1
+@g2 = global i32 2
+@g3 = global [2 x i32] [i32 3, i32 4]
+@g4 = addrspace(1) global i32 5
+@g5 = thread_local(initialexec) global i32 6
+
+define i32 @f(i32 %x) {
+	ret i32 %x
+}
+
+define i32 (i32)* @resolver() {
+	ret i32 (i32)* @f
+}
+
+define i32 @use() {
+	%x = load i32, i32* @a2
+	%y = call i32 @a4(i32 %x)
+	%z = call i32 @i1(i32 %y)
+	ret i32 %z
+}
@@ -38,11 +38,25 @@
 ; Hexadecimal floating-point literal.
 @g27 = global double 0x0000000000000000
 @g28 = global x86_fp80 0xK00000000000000000000
-; TODO: add test case for 0xL floating-point constant.
-;@g29 = global fp128 0xL00000000000000000000000000000000
-; TODO: add test case for 0xM floating-point constant.
-;@g30 = global ppc_fp128 0xM00000000000000000000000000000000
+@g29 = global fp128 0xL00000000000000000000000000000000
+@g30 = global ppc_fp128 0xM00000000000000000000000000000000
 @g31 = global half 0xH0000
+@g55 = global double 0x3FB999999999999A
+@g56 = global float 0x3FB99999A0000000
+@g57 = global double 0x7FF0000000000000
+@g58 = global float 0xFFF0000000000000
+@g59 = global double 0x7FF8000000000000
+@g60 = global half 0xH7C00
+@g61 = global half 0xH7E00
+@g62 = global x86_fp80 0xK3FFBCCCCCCCCCCCCCCCD
+@g63 = global x86_fp80 0xKFFFF8000000000000000
+@g64 = global x86_fp80 0xK7FFFC000000000000000
+@g65 = global fp128 0xL999999999999999A3FFB999999999999
+@g66 = global fp128 0xL0000000000000000FFFF000000000000
+@g67 = global fp128 0xL00000000000000007FFF800000000000
+@g68 = global ppc_fp128 0xM3FB999999999999ABC5999999999999A
+@g69 = global ppc_fp128 0xMFFF00000000000000000000000000000
+@g70 = global ppc_fp128 0xM7FF80000000000000000000000000000
 
 ; --- [ Pointer constant ] -----------------------------------------------------
 
@@ -95,6 +109,28 @@
 
 @g51 = global i8* undef
 
+; --- [ Block address constant ] -----------------------------------------------
+
+; Forward reference to basic blocks of function defined later on.
+@g52 = global i8* blockaddress(@f2, %foo)
+@g53 = global [2 x i8*] [i8* blockaddress(@f2, %foo), i8* blockaddress(@f2, %bar)]
+
+; Unnamed basic block.
+@g54 = global i8* blockaddress(@f3, %1)
+
 define void @f1() {
 	ret void
 }
+
+define void @f2(i1 %cond) {
+	br i1 %cond, label %foo, label %bar
+foo:
+	ret void
+bar:
+	ret void
+}
+
+define void @f3() {
+	br label %1
+	ret void
+}
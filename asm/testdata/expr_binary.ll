@@ -6,6 +6,10 @@ define i32 @add_1() {
 	ret i32 add (i32 30, i32 12)
 }
 
+define i32 @add_2() {
+	ret i32 add nsw nuw (i32 30, i32 12)
+}
+
 ; ~~~ [ fadd ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define double @fadd_1() {
@@ -42,6 +46,10 @@ define i32 @udiv_1() {
 	ret i32 udiv (i32 84, i32 2)
 }
 
+define i32 @udiv_2() {
+	ret i32 udiv exact (i32 84, i32 2)
+}
+
 ; ~~~ [ sdiv ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define i32 @sdiv_1() {
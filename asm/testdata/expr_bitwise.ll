@@ -6,12 +6,20 @@ define i32 @shl_1() {
 	ret i32 shl (i32 21, i32 1)
 }
 
+define i32 @shl_2() {
+	ret i32 shl nuw (i32 21, i32 1)
+}
+
 ; ~~~ [ lshr ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define i32 @lshr_1() {
 	ret i32 lshr (i32 84, i32 1)
 }
 
+define i32 @lshr_2() {
+	ret i32 lshr exact (i32 84, i32 1)
+}
+
 ; ~~~ [ ashr ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define i32 @ashr_1() {
@@ -20,3 +20,13 @@ define i32* @getelementptr_3() {
 	; Inbounds.
 	ret i32* getelementptr inbounds (i32, i32* @x)
 }
+
+define i32* @getelementptr_4() {
+	; Inrange index.
+	ret i32* getelementptr ({ i32, { [2 x i32], i8 } }, { i32, { [2 x i32], i8 } }* @y, i32 0, inrange i32 1, i32 0, i32 1)
+}
+
+define i32* @getelementptr_5() {
+	; Full expression.
+	ret i32* getelementptr inbounds ({ i32, { [2 x i32], i8 } }, { i32, { [2 x i32], i8 } }* @y, i32 0, inrange i32 1, i32 0, i32 1)
+}
@@ -1,4 +1,4 @@
-$g28 = comdat any
+$g29 = comdat any
 $com1 = comdat exactmatch
 
 ; Mutable.
@@ -359,3 +359,9 @@ define double @frem_5() {
 	%result = frem arcp fast ninf nnan nsz double 85.0, 43.0, !foo !{!"bar"}, !baz !{!"qux"}
 	ret double %result
 }
+
+define double @frem_6() {
+	; Additional fast-math flags.
+	%result = frem afn contract reassoc double 85.0, 43.0
+	ret double %result
+}
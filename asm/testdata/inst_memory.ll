@@ -72,6 +72,30 @@ define i32 @load_6(i32* %x) {
 	ret i32 %result
 }
 
+define i32 @load_7(i32* %x) {
+	; Atomic instruction.
+	%result = load atomic i32, i32* %x acquire, align 4
+	ret i32 %result
+}
+
+define i32 @load_8(i32* %x) {
+	; Full atomic instruction.
+	%result = load atomic volatile i32, i32* %x syncscope("agent") seq_cst, align 4, !foo !{!"bar"}
+	ret i32 %result
+}
+
+define i32 @load_9(i32* %x) {
+	; Non-temporal metadata.
+	%result = load i32, i32* %x, align 4, !nontemporal !{i32 1}
+	ret i32 %result
+}
+
+define i32 @load_10(i32* %x) {
+	; Invariant load metadata.
+	%result = load i32, i32* %x, align 4, !invariant.load !{}
+	ret i32 %result
+}
+
 ; ~~~ [ store ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define void @store_1(i32* %x) {
@@ -110,17 +134,79 @@ define void @store_6(i32* %x) {
 	ret void
 }
 
+define void @store_7(i32* %x) {
+	; Atomic instruction.
+	store atomic i32 42, i32* %x release, align 4
+	ret void
+}
+
+define void @store_8(i32* %x) {
+	; Full atomic instruction.
+	store atomic volatile i32 42, i32* %x syncscope("singlethread") monotonic, align 4, !foo !{!"bar"}
+	ret void
+}
+
+define void @store_9(i32* %x) {
+	; Non-temporal metadata.
+	store i32 42, i32* %x, align 4, !nontemporal !{i32 1}
+	ret void
+}
+
 ; ~~~ [ fence ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for fence instruction.
+define void @fence_1() {
+	; Plain instruction.
+	fence acquire
+	ret void
+}
+
+define void @fence_2() {
+	; Full instruction.
+	fence syncscope("singlethread") seq_cst, !foo !{!"bar"}
+	ret void
+}
 
 ; ~~~ [ cmpxchg ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for cmpxchg instruction.
+define { i32, i1 } @cmpxchg_1(i32* %x) {
+	; Plain instruction.
+	%result = cmpxchg i32* %x, i32 1, i32 2 acq_rel monotonic
+	ret { i32, i1 } %result
+}
+
+define { i32, i1 } @cmpxchg_2(i32* %x) {
+	; Full instruction.
+	%result = cmpxchg weak volatile i32* %x, i32 1, i32 2 syncscope("agent") seq_cst acquire, align 4, !foo !{!"bar"}
+	ret { i32, i1 } %result
+}
 
 ; ~~~ [ atomicrmw ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for atomicrmw instruction.
+define i32 @atomicrmw_1(i32* %x) {
+	; Plain instruction.
+	%result = atomicrmw add i32* %x, i32 1 monotonic
+	ret i32 %result
+}
+
+define i32 @atomicrmw_2(i32* %x) {
+	; Full instruction.
+	%result = atomicrmw volatile umax i32* %x, i32 1 syncscope("singlethread") seq_cst, align 4, !foo !{!"bar"}
+	ret i32 %result
+}
+
+define i32 @atomicrmw_3(i32* %x) {
+	; Atomic operations.
+	%1 = atomicrmw xchg i32* %x, i32 1 acquire
+	%2 = atomicrmw sub i32* %x, i32 1 release
+	%3 = atomicrmw and i32* %x, i32 1 acq_rel
+	%4 = atomicrmw nand i32* %x, i32 1 seq_cst
+	%5 = atomicrmw or i32* %x, i32 1 monotonic
+	%6 = atomicrmw xor i32* %x, i32 1 monotonic
+	%7 = atomicrmw max i32* %x, i32 1 monotonic
+	%8 = atomicrmw min i32* %x, i32 1 monotonic
+	%9 = atomicrmw umin i32* %x, i32 1 monotonic
+	ret i32 %9
+}
 
 ; ~~~ [ getelementptr ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
@@ -0,0 +1,25 @@
+; --- [ Memory instructions with address space ] -------------------------------
+
+; The alloca address space must match the address space specified by the data
+; layout.
+target datalayout = "A5"
+
+; ~~~ [ alloca ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+define i32 addrspace(5)* @alloca_1() {
+	; Address space operand.
+	%result = alloca i32, addrspace(5)
+	ret i32 addrspace(5)* %result
+}
+
+define i32 addrspace(5)* @alloca_2() {
+	; Alignment and address space operands.
+	%result = alloca i32, align 8, addrspace(5)
+	ret i32 addrspace(5)* %result
+}
+
+define i32 addrspace(5)* @alloca_3() {
+	; Full instruction.
+	%result = alloca i32, i32 10, align 8, addrspace(5), !foo !{!"bar"}, !baz !{!"qux"}
+	ret i32 addrspace(5)* %result
+}
@@ -140,6 +140,18 @@ baz:
 	ret i32 %result
 }
 
+define double @phi_5(i1 %cond) {
+	br i1 %cond, label %foo, label %bar
+foo:
+	br label %baz
+bar:
+	br label %baz
+baz:
+	; Fast-math flags.
+	%result = phi nnan nsz double [ 42.0, %foo ], [ 37.0, %bar ]
+	ret double %result
+}
+
 ; ~~~ [ select ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
 define i32 @select_1(i1 %cond) {
@@ -379,20 +391,178 @@ define double @call_18() {
 	ret double %result
 }
 
+define i32 @call_19() {
+	; Parameter attributes.
+	%result = call i32 @k(i32 signext 11, i32 inreg zeroext 22)
+	ret i32 %result
+}
+
+define i32 @call_20() {
+	; Operand bundles.
+	%result = call i32 @f() [ "deopt"(i32 10, i64 20), "foo"() ]
+	ret i32 %result
+}
+
+define void @call_21() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @n() to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	%cs = catchswitch within none [label %handler] unwind to caller
+handler:
+	%cp = catchpad within %cs []
+	; Funclet operand bundle.
+	call void @n() [ "funclet"(token %cp) ]
+	catchret from %cp to label %normal
+}
+
+define void @call_22() {
+	; Inline assembler expressions.
+	call void asm sideeffect "nop", ""()
+	%1 = call i32 asm "bswap $0", "=r,r"(i32 42)
+	%2 = call i32 asm alignstack inteldialect "mov $0, $1", "=r,r"(i32 %1)
+	%3 = call i32 (i32) asm "\09add $0, $1", "=r,r"(i32 %2)
+	ret void
+}
+
 ; ~~~ [ va_arg ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for va_arg instruction.
+declare void @llvm.va_start(i8*)
+
+declare void @llvm.va_end(i8*)
+
+define i32 @va_arg_1(i32 %n, ...) {
+	%ap = alloca i8*
+	%ap2 = bitcast i8** %ap to i8*
+	call void @llvm.va_start(i8* %ap2)
+	; Plain instruction.
+	%result = va_arg i8** %ap, i32
+	call void @llvm.va_end(i8* %ap2)
+	ret i32 %result
+}
+
+define double @va_arg_2(i32 %n, ...) {
+	%ap = alloca i8*
+	%ap2 = bitcast i8** %ap to i8*
+	call void @llvm.va_start(i8* %ap2)
+	; Metadata.
+	%result = va_arg i8** %ap, double, !foo !{!"bar"}, !baz !{!"qux"}
+	call void @llvm.va_end(i8* %ap2)
+	ret double %result
+}
 
 ; ~~~ [ landingpad ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for landingpad instruction.
+@_ZTIi = external global i8*
+
+declare i32 @__gxx_personality_v0(...)
+
+declare void @n()
+
+define void @landingpad_1() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @n()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	; Cleanup landing pad.
+	%result = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %result
+}
+
+define void @landingpad_2() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @n()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	; Catch clause.
+	%result = landingpad { i8*, i32 }
+		catch i8** @_ZTIi
+	resume { i8*, i32 } %result
+}
+
+define void @landingpad_3() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @n()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	; Multiple clauses.
+	%result = landingpad { i8*, i32 }
+		cleanup
+		catch i8** @_ZTIi
+		catch i8* null
+		filter [1 x i8**] [i8** @_ZTIi]
+	resume { i8*, i32 } %result
+}
+
+define void @landingpad_4() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @n()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	; Metadata.
+	%result = landingpad { i8*, i32 }
+		cleanup, !foo !{!"bar"}, !baz !{!"qux"}
+	resume { i8*, i32 } %result
+}
 
 ; ~~~ [ catchpad ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for catchpad instruction.
+declare i32 @__CxxFrameHandler3(...)
+
+define void @catchpad_1() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @n()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	%cs = catchswitch within none [label %handler] unwind to caller
+handler:
+	; Plain instruction.
+	%cp = catchpad within %cs []
+	catchret from %cp to label %normal
+}
+
+define void @catchpad_2() personality i32 (...)* @__CxxFrameHandler3 {
+	%x = alloca i32
+	invoke void @n()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	%cs = catchswitch within none [label %handler] unwind to caller
+handler:
+	; Exception arguments and metadata.
+	%cp = catchpad within %cs [i8** @_ZTIi, i32 8, i32* %x], !foo !{!"bar"}, !baz !{!"qux"}
+	catchret from %cp to label %normal
+}
 
 ; ~~~ [ cleanuppad ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for cleanuppad instruction.
+define void @cleanuppad_1() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @n()
+		to label %normal unwind label %cleanup
+normal:
+	ret void
+cleanup:
+	; Plain instruction.
+	%cp = cleanuppad within none []
+	cleanupret from %cp unwind to caller
+}
+
+define void @cleanuppad_2() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @n()
+		to label %normal unwind label %cleanup
+normal:
+	ret void
+cleanup:
+	; Exception arguments and metadata.
+	%cp = cleanuppad within none [i32 1, i8** @_ZTIi], !foo !{!"bar"}, !baz !{!"qux"}
+	cleanupret from %cp unwind to caller
+}
 
 attributes #0 = { "qux" }
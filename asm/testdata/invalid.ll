@@ -0,0 +1,6 @@
+define i32 @f(i32 %x) {
+	%y = add i32 %x, 1
+	; missing comma between operands.
+	%z = add i32 %y %x
+	ret i32 %z
+}
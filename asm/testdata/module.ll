@@ -12,6 +12,7 @@ target triple = "x86_64-unknown-linux"
 ; --- [ Module-level inline assembly ] -----------------------------------------
 
 module asm "foo"
+module asm "\09.globl bar"
 
 ; --- [ Type definitions ] -----------------------------------------------------
 
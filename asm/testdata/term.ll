@@ -97,27 +97,212 @@ case3:
 
 ; ~~~ [ indirectbr ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for indirectbr terminator.
+define void @indirectbr_1(i1 %cond) {
+	%addr = select i1 %cond, i8* blockaddress(@indirectbr_1, %foo), i8* blockaddress(@indirectbr_1, %bar)
+	indirectbr i8* %addr, [label %foo, label %bar]
+foo:
+	ret void
+bar:
+	ret void
+}
+
+define void @indirectbr_2() {
+	; Metadata.
+	indirectbr i8* blockaddress(@indirectbr_2, %foo), [label %foo], !foo !{!"bar"}, !baz !{!"qux"}
+foo:
+	ret void
+}
 
 ; ~~~ [ invoke ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for invoke terminator.
+declare i32 @__gxx_personality_v0(...)
+
+define i32 @f(i32 %x, i32 %y) {
+	ret i32 42
+}
+
+define void @g() {
+	ret void
+}
+
+define i32 @invoke_1() personality i32 (...)* @__gxx_personality_v0 {
+	; Plain terminator.
+	%result = invoke i32 @f(i32 1, i32 2)
+		to label %normal unwind label %exception
+normal:
+	ret i32 %result
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %x
+}
+
+define void @invoke_2() personality i32 (...)* @__gxx_personality_v0 {
+	; Callee with void return type.
+	invoke void @g()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %x
+}
+
+define i32 @invoke_3() personality i32 (...)* @__gxx_personality_v0 {
+	; Calling convention and metadata.
+	%result = invoke fastcc i32 @f(i32 1, i32 2)
+		to label %normal unwind label %exception, !foo !{!"bar"}, !baz !{!"qux"}
+normal:
+	ret i32 %result
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %x
+}
+
+define i32 @invoke_4() personality i32 (...)* @__gxx_personality_v0 {
+	; Result used before its definition in layout order.
+	br label %entry
+use:
+	ret i32 %result
+entry:
+	%result = invoke i32 @f(i32 1, i32 2)
+		to label %use unwind label %exception
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %x
+}
+
+define i32 @invoke_5() personality i32 (...)* @__gxx_personality_v0 {
+	; Inline assembler expression callee.
+	%result = invoke i32 asm sideeffect "bswap $0", "=r,r"(i32 42)
+		to label %normal unwind label %exception
+normal:
+	ret i32 %result
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	resume { i8*, i32 } %x
+}
 
 ; ~~~ [ resume ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for resume terminator.
+define void @resume_1() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @g()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	; Plain terminator.
+	resume { i8*, i32 } %x
+}
+
+define void @resume_2() personality i32 (...)* @__gxx_personality_v0 {
+	invoke void @g()
+		to label %normal unwind label %exception
+normal:
+	ret void
+exception:
+	%x = landingpad { i8*, i32 }
+		cleanup
+	; Metadata.
+	resume { i8*, i32 } %x, !foo !{!"bar"}, !baz !{!"qux"}
+}
 
 ; ~~~ [ catchswitch ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for catchswitch terminator.
+declare i32 @__CxxFrameHandler3(...)
+
+define void @catchswitch_1() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	; Unwind to caller.
+	%cs = catchswitch within none [label %handler] unwind to caller
+handler:
+	%cp = catchpad within %cs []
+	catchret from %cp to label %normal
+}
+
+define void @catchswitch_2() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	; Multiple handlers and unwind label.
+	%cs = catchswitch within none [label %handler_1, label %handler_2] unwind label %cleanup
+handler_1:
+	%cp1 = catchpad within %cs [i8* null, i32 64, i8* null]
+	catchret from %cp1 to label %normal
+handler_2:
+	%cp2 = catchpad within %cs []
+	catchret from %cp2 to label %normal
+cleanup:
+	%cleanup_pad = cleanuppad within none []
+	cleanupret from %cleanup_pad unwind to caller
+}
+
+define void @catchswitch_3() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	; Unnamed and metadata.
+	%1 = catchswitch within none [label %handler] unwind to caller, !foo !{!"bar"}, !baz !{!"qux"}
+handler:
+	%2 = catchpad within %1 []
+	catchret from %2 to label %normal
+}
 
 ; ~~~ [ catchret ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for catchret terminator.
+define void @catchret_1() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %dispatch
+normal:
+	ret void
+dispatch:
+	%cs = catchswitch within none [label %handler] unwind to caller
+handler:
+	%cp = catchpad within %cs []
+	; Metadata.
+	catchret from %cp to label %normal, !foo !{!"bar"}, !baz !{!"qux"}
+}
 
 ; ~~~ [ cleanupret ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
-; TODO: add test cases for cleanupret terminator.
+define void @cleanupret_1() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %cleanup
+normal:
+	ret void
+cleanup:
+	%cp = cleanuppad within none []
+	; Unwind to caller.
+	cleanupret from %cp unwind to caller
+}
+
+define void @cleanupret_2() personality i32 (...)* @__CxxFrameHandler3 {
+	invoke void @g()
+		to label %normal unwind label %cleanup_1
+normal:
+	ret void
+cleanup_1:
+	%cp1 = cleanuppad within none []
+	; Unwind label and metadata.
+	cleanupret from %cp1 unwind label %cleanup_2, !foo !{!"bar"}, !baz !{!"qux"}
+cleanup_2:
+	%cp2 = cleanuppad within none []
+	cleanupret from %cp2 unwind to caller
+}
 
 ; ~~~ [ unreachable ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
 
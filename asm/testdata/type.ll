@@ -15,8 +15,7 @@
 %t6 = type double
 
 ; MMX type
-; TODO: add test case for MMX type.
-;%t7 = type x86_mmx
+%t7 = type x86_mmx
 
 ; Pointer type
 %t8 = type i32*
@@ -29,8 +28,7 @@
 %t11 = type label
 
 ; Token type
-; TODO: add test case for token type.
-;%t12 = type token
+%t12 = type token
 
 ; Metadata type
 %t13 = type metadata
@@ -48,6 +46,9 @@
 
 %t19 = type %t5
 
+; Recursive struct type
+%t20 = type { i32, %t20* }
+
 ; --- [ Void type ] ------------------------------------------------------------
 
 declare void @f1()
@@ -76,7 +77,7 @@ declare ppc_fp128 @f15()
 
 ; --- [ MMX type ] -------------------------------------------------------------
 
-; TODO: add test case for MMX type.
+declare x86_mmx @f40(x86_mmx %x)
 
 ; --- [ Pointer type ] ---------------------------------------------------------
 
@@ -96,7 +97,8 @@ foo:
 
 ; --- [ Token type ] -----------------------------------------------------------
 
-; TODO: add test case for token type.
+; Token values are covered by the exception handling tests of inst_other.ll and
+; term.ll.
 
 ; --- [ Metadata type ] --------------------------------------------------------
 
@@ -118,8 +120,7 @@ declare <{ i32, i8, i32 }> @f25()
 
 declare %t5 @f26()
 declare %t6 @f27()
-; TODO: add test case for MMX type.
-;declare %t7 @f28()
+declare %t7 @f28()
 declare %t8 @f29()
 declare %t9 @f30()
 declare %t10 @f31()
@@ -132,3 +133,4 @@ declare %t16 @f36()
 declare %t17 @f37()
 declare %t18 @f38()
 declare %t19 @f39()
+declare %t20* @f41(%t20 %x)
@@ -0,0 +1,223 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir/attr"
+)
+
+// attrIndexFunc is the attribute index of function attributes; index 0 refers
+// to return attributes, and index i+1 to the attributes of parameter i.
+const attrIndexFunc = 0xFFFFFFFF
+
+// attrGroup is an attribute group entry of a bitcode file.
+type attrGroup struct {
+	// Attribute index of the attribute group.
+	index uint64
+	// Attributes of the attribute group.
+	attrs []attr.Attribute
+	// Corresponding LLVM IR attribute group of function attributes; created on
+	// first use.
+	group *attr.Group
+}
+
+// Enum attributes, indexed by attribute kind.
+var enumAttrs = map[uint64]attr.Enum{
+	2:  attr.AlwaysInline,
+	3:  attr.ByVal,
+	4:  attr.InlineHint,
+	5:  attr.InReg,
+	6:  attr.MinSize,
+	7:  attr.Naked,
+	8:  attr.Nest,
+	9:  attr.NoAlias,
+	10: attr.NoBuiltin,
+	11: attr.NoCapture,
+	12: attr.NoDuplicate,
+	13: attr.NoImplicitFloat,
+	14: attr.NoInline,
+	15: attr.NonLazyBind,
+	16: attr.NoRedZone,
+	17: attr.NoReturn,
+	18: attr.NoUnwind,
+	19: attr.OptSize,
+	20: attr.ReadNone,
+	21: attr.ReadOnly,
+	22: attr.Returned,
+	23: attr.ReturnsTwice,
+	24: attr.SExt,
+	26: attr.SSP,
+	27: attr.SSPReq,
+	28: attr.SSPStrong,
+	29: attr.SRet,
+	30: attr.SanitizeAddress,
+	31: attr.SanitizeThread,
+	32: attr.SanitizeMemory,
+	33: attr.UWTable,
+	34: attr.ZExt,
+	35: attr.Builtin,
+	36: attr.Cold,
+	37: attr.OptNone,
+	38: attr.InAlloca,
+	39: attr.NonNull,
+	40: attr.JumpTable,
+	43: attr.Convergent,
+	44: attr.SafeStack,
+	45: attr.ArgMemOnly,
+	46: attr.SwiftSelf,
+	47: attr.SwiftError,
+	48: attr.NoRecurse,
+	49: attr.InaccessibleMemOnly,
+	50: attr.InaccessibleMemOrArgMemOnly,
+	52: attr.WriteOnly,
+}
+
+// Attribute kinds of integer attributes.
+const (
+	attrKindAlign                 = 1
+	attrKindStackAlign            = 25
+	attrKindDereferenceable       = 41
+	attrKindDereferenceableOrNull = 42
+	attrKindAllocSize             = 51
+)
+
+// allocSizeNoNElems specifies that the number of elements parameter of an
+// allocsize attribute is not present.
+const allocSizeNoNElems = 0xFFFFFFFF
+
+// paramAttrGroupBlock decodes the given attribute group block.
+func (d *decoder) paramAttrGroupBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		if rec.Code != paramAttrGroupCodeEntry {
+			panic(fmt.Errorf("support for attribute group record code %d not yet implemented", rec.Code))
+		}
+		// [group ID, attribute index, attributes...]
+		d.expectOps(rec, 2)
+		g := &attrGroup{index: rec.Ops[1]}
+		ops := rec.Ops[2:]
+		for len(ops) > 0 {
+			var a attr.Attribute
+			a, ops = d.attribute(ops)
+			g.attrs = append(g.attrs, a)
+		}
+		d.attrGroups[rec.Ops[0]] = g
+	}
+}
+
+// attribute decodes the first attribute of the given attribute group
+// operands, and returns the remaining operands.
+func (d *decoder) attribute(ops []uint64) (attr.Attribute, []uint64) {
+	enc := ops[0]
+	ops = ops[1:]
+	switch enc {
+	case attrEncEnum, attrEncType, attrEncTypeVal:
+		// [kind] or [kind, type]
+		if len(ops) < 1 {
+			panic(fmt.Errorf("invalid enum attribute; missing attribute kind"))
+		}
+		kind := ops[0]
+		ops = ops[1:]
+		if enc == attrEncTypeVal {
+			// The types of type attributes (e.g. byval) are not represented by
+			// LLVM IR attributes.
+			if len(ops) < 1 {
+				panic(fmt.Errorf("invalid type attribute; missing type"))
+			}
+			ops = ops[1:]
+		}
+		a, ok := enumAttrs[kind]
+		if !ok {
+			panic(fmt.Errorf("support for attribute kind %d not yet implemented", kind))
+		}
+		return a, ops
+	case attrEncInt:
+		// [kind, value]
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid integer attribute; expected 2 operands, got %d", len(ops)))
+		}
+		kind, val := ops[0], ops[1]
+		ops = ops[2:]
+		switch kind {
+		case attrKindAlign:
+			return attr.Align(val), ops
+		case attrKindStackAlign:
+			return attr.AlignStack(val), ops
+		case attrKindDereferenceable:
+			return attr.Dereferenceable(val), ops
+		case attrKindDereferenceableOrNull:
+			return attr.DereferenceableOrNull(val), ops
+		case attrKindAllocSize:
+			a := &attr.AllocSize{ElemSize: int(val >> 32)}
+			if n := val & 0xFFFFFFFF; n != allocSizeNoNElems {
+				a.NElems = int(n)
+				a.HasNElems = true
+			}
+			return a, ops
+		default:
+			panic(fmt.Errorf("support for integer attribute kind %d not yet implemented", kind))
+		}
+	case attrEncString, attrEncStringVal:
+		// [key..., 0] or [key..., 0, value..., 0]
+		var key, val string
+		key, ops = nullTerminated(ops)
+		if enc == attrEncStringVal {
+			val, ops = nullTerminated(ops)
+		}
+		return &attr.String{Key: key, Val: val}, ops
+	default:
+		panic(fmt.Errorf("support for attribute encoding %d not yet implemented", enc))
+	}
+}
+
+// nullTerminated returns the null-terminated string at the start of the given
+// operands, and the operands following the null character.
+func nullTerminated(ops []uint64) (string, []uint64) {
+	for i, op := range ops {
+		if op == 0 {
+			return recordString(ops[:i]), ops[i+1:]
+		}
+	}
+	panic(fmt.Errorf("invalid string attribute; missing null terminator"))
+}
+
+// paramAttrBlock decodes the given attribute list block.
+func (d *decoder) paramAttrBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		if rec.Code != paramAttrCodeEntry {
+			panic(fmt.Errorf("support for attribute list record code %d not yet implemented", rec.Code))
+		}
+		// [group IDs...]
+		var list []*attrGroup
+		for _, id := range rec.Ops {
+			g, ok := d.attrGroups[id]
+			if !ok {
+				panic(fmt.Errorf("invalid attribute group ID %d; no such attribute group", id))
+			}
+			list = append(list, g)
+		}
+		d.attrLists = append(d.attrLists, list)
+	}
+}
+
+// attrList returns the attribute groups of the given attribute list ID, where
+// 0 denotes an empty attribute list.
+func (d *decoder) attrList(id uint64) []*attrGroup {
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.attrLists)) {
+		panic(fmt.Errorf("invalid attribute list ID %d; no such attribute list", id))
+	}
+	return d.attrLists[id-1]
+}
+
+// funcAttrGroup returns the LLVM IR attribute group of the given function
+// attributes. The ID of the attribute group is assigned once the module has
+// been decoded.
+func (d *decoder) funcAttrGroup(g *attrGroup) *attr.Group {
+	if g.group == nil {
+		g.group = &attr.Group{Attrs: g.attrs}
+	}
+	return g.group
+}
@@ -0,0 +1,130 @@
+// Package bitcode implements a parser and a writer for LLVM IR bitcode files.
+//
+// The parser decodes the bitstream container of a bitcode file and translates
+// its module, type, constant, metadata, function and symbol table blocks to an
+// LLVM IR module. The resulting module is equivalent to the one produced by
+// asm.ParseFile on the output of llvm-dis for the same bitcode file.
+//
+// The writer encodes an LLVM IR module into the same blocks, as accepted by
+// llvm-dis and the parser of this package. As with llvm-as, the aggregate
+// constant expressions extractvalue and insertvalue are folded before being
+// written, since bitcode has no representation for them; writing fails with an
+// "unsupported constant expression" error if they cannot be folded.
+//
+// References:
+//    https://llvm.org/docs/BitCodeFormat.html
+package bitcode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/pkg/errors"
+)
+
+// ParseFile parses the given LLVM IR bitcode file into an LLVM IR module.
+func ParseFile(path string) (*ir.Module, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ParseBytes(buf)
+}
+
+// Parse parses the given LLVM IR bitcode file into an LLVM IR module, reading
+// from r.
+func Parse(r io.Reader) (*ir.Module, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ParseBytes(buf)
+}
+
+// ParseBytes parses the given LLVM IR bitcode file into an LLVM IR module,
+// reading from b.
+func ParseBytes(b []byte) (*ir.Module, error) {
+	buf, err := stripHeader(b)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	blocks, err := bitstream.Parse(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse bitstream")
+	}
+	m, err := decode(blocks)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode bitcode module")
+	}
+	return m, nil
+}
+
+// WriteFile writes the given LLVM IR module to the given bitcode file.
+func WriteFile(path string, m *ir.Module) error {
+	buf, err := encodeBytes(m)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Write writes the given LLVM IR module as a bitcode file to w.
+func Write(w io.Writer, m *ir.Module) error {
+	buf, err := encodeBytes(m)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := w.Write(buf); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// encodeBytes encodes the given LLVM IR module into the contents of a bitcode
+// file.
+func encodeBytes(m *ir.Module) ([]byte, error) {
+	blocks, err := encode(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode bitcode module")
+	}
+	buf := append([]byte{}, magic...)
+	return append(buf, bitstream.Write(blocks)...), nil
+}
+
+var (
+	// magic is the magic number of LLVM IR bitcode files ("BC" 0x0C0DE).
+	magic = []byte{'B', 'C', 0xC0, 0xDE}
+	// wrapperMagic is the magic number of the bitcode wrapper header, used for
+	// instance by Darwin.
+	wrapperMagic = []byte{0xDE, 0xC0, 0x17, 0x0B}
+)
+
+// stripHeader returns the bitstream of the given bitcode file, after removing
+// the optional wrapper header and the magic number of the file.
+func stripHeader(b []byte) ([]byte, error) {
+	if bytes.HasPrefix(b, wrapperMagic) {
+		// Wrapper header: magic, version, offset, size and CPU type, each
+		// stored as a 32-bit little-endian integer.
+		const headerSize = 5 * 4
+		if len(b) < headerSize {
+			return nil, errors.New("invalid bitcode wrapper header; file too short")
+		}
+		offset := uint64(binary.LittleEndian.Uint32(b[8:]))
+		size := uint64(binary.LittleEndian.Uint32(b[12:]))
+		if offset+size > uint64(len(b)) {
+			return nil, errors.Errorf("invalid bitcode wrapper header; bitcode range [%d, %d) outside of file of size %d", offset, offset+size, len(b))
+		}
+		b = b[offset : offset+size]
+	}
+	if !bytes.HasPrefix(b, magic) {
+		return nil, errors.New("invalid bitcode file; missing magic number")
+	}
+	return b[len(magic):], nil
+}
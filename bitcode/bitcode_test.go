@@ -0,0 +1,181 @@
+package bitcode_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/llir/llvm/asm"
+	"github.com/llir/llvm/bitcode"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+func TestParseFile(t *testing.T) {
+	// Each foo.bc is compared against foo.ll, the output of llvm-dis for
+	// foo.bc.
+	golden := []struct {
+		path string
+	}{
+		// Top-level declarations.
+		{path: "testdata/module.bc"},
+		{path: "testdata/metadata.bc"},
+		// Constants and constant expressions.
+		{path: "testdata/const.bc"},
+		{path: "testdata/expr_other.bc"},
+		// Instructions.
+		{path: "testdata/inst.bc"},
+		{path: "testdata/inst_vector.bc"},
+		{path: "testdata/inst_aggregate.bc"},
+		{path: "testdata/inst_memory_addrspace.bc"},
+		// Terminators.
+		{path: "testdata/term.bc"},
+		// Forward references and function-local metadata.
+		{path: "testdata/fwd.bc"},
+		// Pseudo-random number generator.
+		{path: "testdata/rand.bc"},
+	}
+	dmp := diffmatchpatch.New()
+	for _, g := range golden {
+		m, err := bitcode.ParseFile(g.path)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", g.path, err)
+			continue
+		}
+		llPath := strings.TrimSuffix(g.path, ".bc") + ".ll"
+		wantModule, err := asm.ParseFile(llPath)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", llPath, err)
+			continue
+		}
+		want := wantModule.String()
+		got := m.String()
+		if want != got {
+			diffs := dmp.DiffMain(want, got, false)
+			t.Errorf("%q: module mismatch; expected %q, got %q\ndiff: %v", g.path, want, got, dmp.DiffPrettyText(diffs))
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	// Each foo.ll is written as bitcode, and the module parsed from the written
+	// bitcode is compared against foo.ll, and against the module parsed from
+	// foo.bc, the output of llvm-as for foo.ll.
+	golden := []struct {
+		path string
+		// Constant expressions of the module are folded when written, and
+		// thus the written module differs from foo.ll.
+		folded bool
+	}{
+		// Top-level declarations.
+		{path: "testdata/module.ll"},
+		{path: "testdata/metadata.ll"},
+		// Constants and constant expressions.
+		{path: "testdata/const.ll"},
+		{path: "testdata/expr_other.ll"},
+		{path: "testdata/expr_aggregate.ll", folded: true},
+		// Instructions.
+		{path: "testdata/inst.ll"},
+		{path: "testdata/inst_vector.ll"},
+		{path: "testdata/inst_aggregate.ll"},
+		{path: "testdata/inst_memory_addrspace.ll"},
+		// Terminators.
+		{path: "testdata/term.ll"},
+		// Forward references and function-local metadata.
+		{path: "testdata/fwd.ll"},
+		// Pseudo-random number generator.
+		{path: "testdata/rand.ll"},
+	}
+	dmp := diffmatchpatch.New()
+	for _, g := range golden {
+		wantModule, err := asm.ParseFile(g.path)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", g.path, err)
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := bitcode.Write(buf, wantModule); err != nil {
+			t.Errorf("%q: unable to write bitcode; %v", g.path, err)
+			continue
+		}
+		m, err := bitcode.ParseBytes(buf.Bytes())
+		if err != nil {
+			t.Errorf("%q: unable to parse written bitcode; %v", g.path, err)
+			continue
+		}
+		got := m.String()
+		if !g.folded {
+			want := wantModule.String()
+			if want != got {
+				diffs := dmp.DiffMain(want, got, false)
+				t.Errorf("%q: module mismatch; expected %q, got %q\ndiff: %v", g.path, want, got, dmp.DiffPrettyText(diffs))
+			}
+		}
+		bcPath := strings.TrimSuffix(g.path, ".ll") + ".bc"
+		llvmModule, err := bitcode.ParseFile(bcPath)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", bcPath, err)
+			continue
+		}
+		if want := llvmModule.String(); want != got {
+			diffs := dmp.DiffMain(want, got, false)
+			t.Errorf("%q: module mismatch against llvm-as output %q; expected %q, got %q\ndiff: %v", g.path, bcPath, want, got, dmp.DiffPrettyText(diffs))
+		}
+	}
+}
+
+func TestParseBytesWrapper(t *testing.T) {
+	const path = "testdata/term.bc"
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%q: unable to read file; %v", path, err)
+	}
+	want, err := bitcode.ParseBytes(buf)
+	if err != nil {
+		t.Fatalf("%q: unable to parse file; %v", path, err)
+	}
+	// Wrap the bitcode file in a bitcode wrapper header, followed by padding.
+	header := make([]byte, 5*4)
+	for i, v := range []uint32{0x0B17C0DE, 0, uint32(len(header)), uint32(len(buf)), 0} {
+		binary.LittleEndian.PutUint32(header[4*i:], v)
+	}
+	wrapped := append(append(header, buf...), 0, 0, 0, 0)
+	got, err := bitcode.ParseBytes(wrapped)
+	if err != nil {
+		t.Fatalf("%q: unable to parse wrapped file; %v", path, err)
+	}
+	if want, got := want.String(), got.String(); want != got {
+		t.Errorf("%q: module mismatch; expected %q, got %q", path, want, got)
+	}
+}
+
+func TestParseBytesErrors(t *testing.T) {
+	golden := []struct {
+		input []byte
+		want  string
+	}{
+		{
+			input: []byte("; ModuleID = 'foo'\n"),
+			want:  "missing magic number",
+		},
+		{
+			input: []byte{0xDE, 0xC0, 0x17, 0x0B, 0, 0, 0, 0},
+			want:  "file too short",
+		},
+		{
+			input: []byte{'B', 'C', 0xC0, 0xDE, 0x35, 0x14},
+			want:  "unable to parse bitstream",
+		},
+	}
+	for _, g := range golden {
+		_, err := bitcode.ParseBytes(g.input)
+		if err == nil {
+			t.Errorf("%q: expected error containing %q, got nil", g.input, g.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), g.want) {
+			t.Errorf("%q: error mismatch; expected error containing %q, got %q", g.input, g.want, err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package bitcode
+
+// Block IDs.
+const (
+	blockModule            = 8
+	blockParamAttr         = 9
+	blockParamAttrGroup    = 10
+	blockConstants         = 11
+	blockFunction          = 12
+	blockIdentification    = 13
+	blockValueSymtab       = 14
+	blockMetadata          = 15
+	blockMetadataAttach    = 16
+	blockType              = 17
+	blockUselist           = 18
+	blockModuleStrtab      = 19
+	blockGlobalValSummary  = 20
+	blockOperandBundleTags = 21
+	blockMetadataKind      = 22
+	blockStrtab            = 23
+	blockFullLTOSummary    = 24
+	blockSymtab            = 25
+	blockSyncScopeNames    = 26
+)
+
+// Record codes of IDENTIFICATION_BLOCK.
+const (
+	identCodeString = 1
+	identCodeEpoch  = 2
+)
+
+// Record codes of MODULE_BLOCK.
+const (
+	moduleCodeVersion        = 1
+	moduleCodeTriple         = 2
+	moduleCodeDataLayout     = 3
+	moduleCodeAsm            = 4
+	moduleCodeSectionName    = 5
+	moduleCodeDepLib         = 6
+	moduleCodeGlobalVar      = 7
+	moduleCodeFunction       = 8
+	moduleCodeAliasOld       = 9
+	moduleCodeGCName         = 11
+	moduleCodeComdat         = 12
+	moduleCodeVSTOffset      = 13
+	moduleCodeAlias          = 14
+	moduleCodeMetadataValues = 15
+	moduleCodeSourceFilename = 16
+	moduleCodeHash           = 17
+	moduleCodeIFunc          = 18
+)
+
+// Record codes of PARAMATTR_BLOCK and PARAMATTR_GROUP_BLOCK.
+const (
+	paramAttrCodeEntry      = 2
+	paramAttrGroupCodeEntry = 3
+)
+
+// Record codes of TYPE_BLOCK_NEW.
+const (
+	typeCodeNumEntry      = 1
+	typeCodeVoid          = 2
+	typeCodeFloat         = 3
+	typeCodeDouble        = 4
+	typeCodeLabel         = 5
+	typeCodeOpaque        = 6
+	typeCodeInteger       = 7
+	typeCodePointer       = 8
+	typeCodeFunctionOld   = 9
+	typeCodeHalf          = 10
+	typeCodeArray         = 11
+	typeCodeVector        = 12
+	typeCodeX86FP80       = 13
+	typeCodeFP128         = 14
+	typeCodePPCFP128      = 15
+	typeCodeMetadata      = 16
+	typeCodeX86MMX        = 17
+	typeCodeStructAnon    = 18
+	typeCodeStructName    = 19
+	typeCodeStructNamed   = 20
+	typeCodeFunction      = 21
+	typeCodeToken         = 22
+	typeCodeBFloat        = 23
+	typeCodeX86AMX        = 24
+	typeCodeOpaquePointer = 25
+)
+
+// Record codes of VALUE_SYMTAB_BLOCK.
+const (
+	vstCodeEntry   = 1
+	vstCodeBBEntry = 2
+	vstCodeFnEntry = 3
+)
+
+// Record codes of METADATA_BLOCK.
+const (
+	metadataCodeStringOld            = 1
+	metadataCodeValue                = 2
+	metadataCodeNode                 = 3
+	metadataCodeName                 = 4
+	metadataCodeDistinctNode         = 5
+	metadataCodeKind                 = 6
+	metadataCodeLocation             = 7
+	metadataCodeOldNode              = 8
+	metadataCodeOldFnNode            = 9
+	metadataCodeNamedNode            = 10
+	metadataCodeAttachment           = 11
+	metadataCodeStrings              = 35
+	metadataCodeGlobalDeclAttachment = 36
+	metadataCodeIndexOffset          = 38
+	metadataCodeIndex                = 39
+)
+
+// Record codes of CONSTANTS_BLOCK.
+const (
+	cstCodeSetType               = 1
+	cstCodeNull                  = 2
+	cstCodeUndef                 = 3
+	cstCodeInteger               = 4
+	cstCodeWideInteger           = 5
+	cstCodeFloat                 = 6
+	cstCodeAggregate             = 7
+	cstCodeString                = 8
+	cstCodeCString               = 9
+	cstCodeCEBinop               = 10
+	cstCodeCECast                = 11
+	cstCodeCEGEP                 = 12
+	cstCodeCESelect              = 13
+	cstCodeCEExtractElt          = 14
+	cstCodeCEInsertElt           = 15
+	cstCodeCEShuffleVec          = 16
+	cstCodeCECmp                 = 17
+	cstCodeInlineAsmOld          = 18
+	cstCodeCEShufVecEx           = 19
+	cstCodeCEInboundsGEP         = 20
+	cstCodeBlockAddress          = 21
+	cstCodeData                  = 22
+	cstCodeInlineAsmOld2         = 23
+	cstCodeCEGEPWithInrangeIndex = 24
+	cstCodeCEUnop                = 25
+	cstCodePoison                = 26
+	cstCodeDSOLocalEquivalent    = 27
+	cstCodeInlineAsmOld3         = 28
+	cstCodeNoCFIValue            = 29
+	cstCodeInlineAsm             = 30
+)
+
+// Record codes of FUNCTION_BLOCK.
+const (
+	funcCodeDeclareBlocks      = 1
+	funcCodeInstBinop          = 2
+	funcCodeInstCast           = 3
+	funcCodeInstGEPOld         = 4
+	funcCodeInstSelect         = 5
+	funcCodeInstExtractElt     = 6
+	funcCodeInstInsertElt      = 7
+	funcCodeInstShuffleVec     = 8
+	funcCodeInstCmp            = 9
+	funcCodeInstRet            = 10
+	funcCodeInstBr             = 11
+	funcCodeInstSwitch         = 12
+	funcCodeInstInvoke         = 13
+	funcCodeInstUnreachable    = 15
+	funcCodeInstPhi            = 16
+	funcCodeInstAlloca         = 19
+	funcCodeInstLoad           = 20
+	funcCodeInstVAArg          = 23
+	funcCodeInstStoreOld       = 24
+	funcCodeInstExtractVal     = 26
+	funcCodeInstInsertVal      = 27
+	funcCodeInstCmp2           = 28
+	funcCodeInstVSelect        = 29
+	funcCodeInstInboundsGEPOld = 30
+	funcCodeInstIndirectBr     = 31
+	funcCodeDebugLocAgain      = 33
+	funcCodeInstCall           = 34
+	funcCodeDebugLoc           = 35
+	funcCodeInstFence          = 36
+	funcCodeInstCmpXchgOld     = 37
+	funcCodeInstAtomicRMWOld   = 38
+	funcCodeInstResume         = 39
+	funcCodeInstLandingPadOld  = 40
+	funcCodeInstLoadAtomic     = 41
+	funcCodeInstStoreAtomicOld = 42
+	funcCodeInstGEP            = 43
+	funcCodeInstStore          = 44
+	funcCodeInstStoreAtomic    = 45
+	funcCodeInstCmpXchg        = 46
+	funcCodeInstLandingPad     = 47
+	funcCodeInstCleanupRet     = 48
+	funcCodeInstCatchRet       = 49
+	funcCodeInstCatchPad       = 50
+	funcCodeInstCleanupPad     = 51
+	funcCodeInstCatchSwitch    = 52
+	funcCodeOperandBundle      = 55
+	funcCodeInstUnop           = 56
+	funcCodeInstCallBr         = 57
+	funcCodeInstFreeze         = 58
+	funcCodeInstAtomicRMW      = 59
+)
+
+// Record codes of OPERAND_BUNDLE_TAGS_BLOCK.
+const (
+	operandBundleTagCode = 1
+)
+
+// Record codes of SYNC_SCOPE_NAMES_BLOCK.
+const (
+	syncScopeName = 1
+)
+
+// Record codes of STRTAB_BLOCK.
+const (
+	strtabBlob = 1
+)
+
+// Attribute encodings of PARAMATTR_GROUP_BLOCK entries.
+const (
+	attrEncEnum      = 0
+	attrEncInt       = 1
+	attrEncString    = 3
+	attrEncStringVal = 4
+	attrEncType      = 5
+	attrEncTypeVal   = 6
+)
+
+// Binary opcodes.
+const (
+	binopAdd  = 0
+	binopSub  = 1
+	binopMul  = 2
+	binopUDiv = 3
+	binopSDiv = 4 // fdiv for floating-point operands
+	binopURem = 5
+	binopSRem = 6 // frem for floating-point operands
+	binopShl  = 7
+	binopLShr = 8
+	binopAShr = 9
+	binopAnd  = 10
+	binopOr   = 11
+	binopXor  = 12
+)
+
+// Cast opcodes.
+const (
+	castTrunc         = 0
+	castZExt          = 1
+	castSExt          = 2
+	castFPToUI        = 3
+	castFPToSI        = 4
+	castUIToFP        = 5
+	castSIToFP        = 6
+	castFPTrunc       = 7
+	castFPExt         = 8
+	castPtrToInt      = 9
+	castIntToPtr      = 10
+	castBitCast       = 11
+	castAddrSpaceCast = 12
+)
+
+// Overflow flags of binary operations.
+const (
+	overflowNUW = 1 << 0
+	overflowNSW = 1 << 1
+)
+
+// Predicates of comparison operations; floating-point predicates range from
+// 0 to 15 and integer predicates from 32 to 41.
+const (
+	predFirstFloat = 0
+	predLastFloat  = 15
+	predFirstInt   = 32
+	predLastInt    = 41
+)
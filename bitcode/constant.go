@@ -0,0 +1,565 @@
+package bitcode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/internal/floats"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// pendingConst is a constant pending materialization, as constants may refer
+// to constants defined later on in the same constants block.
+type pendingConst struct {
+	// Type of the constant.
+	typ types.Type
+	// Constant record.
+	rec *bitstream.Record
+	// Materialization in progress; used to detect cyclic constants.
+	busy bool
+}
+
+// constantsBlock decodes the given constants block, appending its constants to
+// the value table.
+func (d *decoder) constantsBlock(block *bitstream.Block) {
+	// The type of constants defaults to i32 until set by a SETTYPE record.
+	var typ types.Type = types.I32
+	start := uint64(len(d.values))
+	d.pending = make(map[uint64]*pendingConst)
+	for _, rec := range records(block) {
+		if rec.Code == cstCodeSetType {
+			d.expectOps(rec, 1)
+			typ = d.typ(rec.Ops[0])
+			continue
+		}
+		d.pending[uint64(len(d.values))] = &pendingConst{typ: typ, rec: rec}
+		d.values = append(d.values, nil)
+	}
+	for id := start; id < uint64(len(d.values)); id++ {
+		d.constValue(id)
+	}
+	d.pending = nil
+}
+
+// constValue returns the value of the given value ID, materializing pending
+// constants as needed.
+func (d *decoder) constValue(id uint64) value.Value {
+	if p, ok := d.pending[id]; ok {
+		if p.busy {
+			panic(fmt.Errorf("invalid constant of value ID %d; cyclic constant", id))
+		}
+		p.busy = true
+		v := d.constant(p.typ, p.rec)
+		delete(d.pending, id)
+		d.values[id] = v
+		return v
+	}
+	if id >= uint64(len(d.values)) || d.values[id] == nil {
+		panic(fmt.Errorf("invalid value ID %d of constant operand; no such value", id))
+	}
+	return d.values[id]
+}
+
+// constOperand returns the constant of the given value ID.
+func (d *decoder) constOperand(id uint64) constant.Constant {
+	v := d.constValue(id)
+	c, ok := v.(constant.Constant)
+	if !ok {
+		panic(fmt.Errorf("invalid constant operand type of value ID %d; expected constant.Constant, got %T", id, v))
+	}
+	return c
+}
+
+// constant decodes the given constant record of the given type.
+func (d *decoder) constant(typ types.Type, rec *bitstream.Record) value.Value {
+	ops := rec.Ops
+	switch rec.Code {
+	// Simple constants.
+	case cstCodeNull:
+		return zeroValue(typ)
+	case cstCodeUndef:
+		return constant.NewUndef(typ)
+	case cstCodePoison:
+		panic(fmt.Errorf("support for poison constants not yet implemented"))
+	case cstCodeInteger:
+		// [signed value]
+		d.expectOps(rec, 1)
+		return newInt(typ, big.NewInt(decodeSigned(ops[0])))
+	case cstCodeWideInteger:
+		// [signed words...]
+		d.expectOps(rec, 1)
+		x := new(big.Int)
+		for i := len(ops) - 1; i >= 0; i-- {
+			x.Lsh(x, 64)
+			x.Or(x, new(big.Int).SetUint64(uint64(decodeSigned(ops[i]))))
+		}
+		return newInt(typ, x)
+	case cstCodeFloat:
+		return newFloat(typ, ops)
+	// Complex constants.
+	case cstCodeAggregate:
+		// [value IDs...]
+		var elems []constant.Constant
+		for _, op := range ops {
+			elems = append(elems, d.constOperand(op))
+		}
+		switch t := typ.(type) {
+		case *types.StructType:
+			return &constant.Struct{Typ: t, Fields: elems}
+		case *types.ArrayType:
+			return &constant.Array{Typ: t, Elems: elems}
+		case *types.VectorType:
+			return &constant.Vector{Typ: t, Elems: elems}
+		default:
+			panic(fmt.Errorf("invalid aggregate constant type; expected struct, array or vector type, got %T", typ))
+		}
+	case cstCodeString, cstCodeCString:
+		// [chars...]
+		t, ok := typ.(*types.ArrayType)
+		if !ok {
+			panic(fmt.Errorf("invalid character array constant type; expected *types.ArrayType, got %T", typ))
+		}
+		c := &constant.Array{Typ: t, CharArray: true}
+		for _, op := range ops {
+			c.Elems = append(c.Elems, constant.NewInt(int64(op), t.Elem))
+		}
+		if rec.Code == cstCodeCString {
+			c.Elems = append(c.Elems, constant.NewInt(0, t.Elem))
+		}
+		return c
+	case cstCodeData:
+		// [elements...]
+		var elemType types.Type
+		switch t := typ.(type) {
+		case *types.ArrayType:
+			elemType = t.Elem
+		case *types.VectorType:
+			elemType = t.Elem
+		default:
+			panic(fmt.Errorf("invalid data constant type; expected array or vector type, got %T", typ))
+		}
+		var elems []constant.Constant
+		for _, op := range ops {
+			switch elemType := elemType.(type) {
+			case *types.IntType:
+				elems = append(elems, newInt(elemType, new(big.Int).SetUint64(op)))
+			case *types.FloatType:
+				elems = append(elems, newFloat(elemType, []uint64{op}))
+			default:
+				panic(fmt.Errorf("invalid data constant element type; expected integer or floating-point type, got %T", elemType))
+			}
+		}
+		if t, ok := typ.(*types.VectorType); ok {
+			return &constant.Vector{Typ: t, Elems: elems}
+		}
+		return &constant.Array{Typ: typ.(*types.ArrayType), Elems: elems}
+	// Constant expressions.
+	case cstCodeCEBinop:
+		// [opcode, lhs, rhs, flags]
+		d.expectOps(rec, 3)
+		x, y := d.constOperand(ops[1]), d.constOperand(ops[2])
+		return constBinop(ops[0], x, y, opt(ops, 3))
+	case cstCodeCECast:
+		// [opcode, operand type, operand]
+		d.expectOps(rec, 3)
+		return constCast(ops[0], d.constOperand(ops[2]), typ)
+	case cstCodeCEGEP, cstCodeCEInboundsGEP, cstCodeCEGEPWithInrangeIndex:
+		// [source element type, (flags), (type, index)...]
+		inBounds := rec.Code == cstCodeCEInboundsGEP
+		inRange := -1
+		if rec.Code == cstCodeCEGEPWithInrangeIndex || len(ops)%2 == 1 {
+			// Source element type; inferred from the type of the source
+			// address.
+			d.expectOps(rec, 1)
+			ops = ops[1:]
+		}
+		if rec.Code == cstCodeCEGEPWithInrangeIndex {
+			d.expectOps(rec, 2)
+			inBounds = ops[0]&1 != 0
+			inRange = int(ops[0] >> 1)
+			ops = ops[1:]
+		}
+		if len(ops) < 2 || len(ops)%2 != 0 {
+			panic(fmt.Errorf("invalid getelementptr constant expression; invalid number of operands"))
+		}
+		src := d.constOperand(ops[1])
+		var indices []constant.Constant
+		for i := 2; i < len(ops); i += 2 {
+			index := d.constOperand(ops[i+1])
+			if len(indices) == inRange {
+				idx := constant.NewIndex(index)
+				idx.InRange = true
+				index = idx
+			}
+			indices = append(indices, index)
+		}
+		c := constant.NewGetElementPtr(src, indices...)
+		c.InBounds = inBounds
+		return c
+	case cstCodeCESelect:
+		// [cond, true value, false value]
+		d.expectOps(rec, 3)
+		return constant.NewSelect(d.constOperand(ops[0]), d.constOperand(ops[1]), d.constOperand(ops[2]))
+	case cstCodeCEExtractElt:
+		// [vector type, vector, (index type), index]
+		d.expectOps(rec, 3)
+		return constant.NewExtractElement(d.constOperand(ops[1]), d.constOperand(ops[len(ops)-1]))
+	case cstCodeCEInsertElt:
+		// [vector, element, (index type), index]
+		d.expectOps(rec, 3)
+		return constant.NewInsertElement(d.constOperand(ops[0]), d.constOperand(ops[1]), d.constOperand(ops[len(ops)-1]))
+	case cstCodeCEShuffleVec:
+		// [vector 1, vector 2, mask]
+		d.expectOps(rec, 3)
+		return constant.NewShuffleVector(d.constOperand(ops[0]), d.constOperand(ops[1]), d.constOperand(ops[2]))
+	case cstCodeCEShufVecEx:
+		// [operand type, vector 1, vector 2, mask]
+		d.expectOps(rec, 4)
+		return constant.NewShuffleVector(d.constOperand(ops[1]), d.constOperand(ops[2]), d.constOperand(ops[3]))
+	case cstCodeCECmp:
+		// [operand type, lhs, rhs, predicate]
+		d.expectOps(rec, 4)
+		x, y := d.constOperand(ops[1]), d.constOperand(ops[2])
+		pred := ops[3]
+		if isFloatPred(pred) {
+			return constant.NewFCmp(constant.FloatPred(floatPred(pred)), x, y)
+		}
+		return constant.NewICmp(constant.IntPred(intPred(pred)), x, y)
+	case cstCodeBlockAddress:
+		// [function type, function, basic block index]
+		d.expectOps(rec, 3)
+		v := d.constValue(ops[1])
+		f, ok := v.(*ir.Function)
+		if !ok {
+			panic(fmt.Errorf("invalid block address function type; expected *ir.Function, got %T", v))
+		}
+		// The basic blocks of f may not yet have been decoded, as block
+		// addresses may refer to functions defined later on. Therefore, record
+		// the block address for later resolution of its basic block.
+		c := constant.NewBlockAddress(f, nil)
+		d.blockAddrs = append(d.blockAddrs, &blockAddr{c: c, index: ops[2]})
+		return c
+	case cstCodeInlineAsm, cstCodeInlineAsmOld3, cstCodeInlineAsmOld2:
+		return d.inlineAsm(typ, rec)
+	case cstCodeCEUnop:
+		panic(fmt.Errorf("support for unary constant expressions not yet implemented"))
+	default:
+		panic(fmt.Errorf("support for constant record code %d not yet implemented", rec.Code))
+	}
+}
+
+// inlineAsm decodes the given inline assembler expression record.
+func (d *decoder) inlineAsm(typ types.Type, rec *bitstream.Record) *ir.InlineAsm {
+	// [(function type), flags, asm size, asm chars..., constraint size,
+	// constraint chars...]
+	ops := rec.Ops
+	if rec.Code == cstCodeInlineAsm {
+		d.expectOps(rec, 1)
+		typ = types.NewPointer(d.typ(ops[0]))
+		ops = ops[1:]
+	}
+	ptr, ok := typ.(*types.PointerType)
+	if !ok {
+		panic(fmt.Errorf("invalid inline assembler expression type; expected *types.PointerType, got %T", typ))
+	}
+	sig, ok := ptr.Elem.(*types.FuncType)
+	if !ok {
+		panic(fmt.Errorf("invalid inline assembler expression type; expected *types.FuncType, got %T", ptr.Elem))
+	}
+	if len(ops) < 2 {
+		panic(fmt.Errorf("invalid inline assembler expression; missing operands"))
+	}
+	flags := ops[0]
+	asm, ops := sizedString(ops[1:])
+	constraint, _ := sizedString(ops)
+	v := ir.NewInlineAsm(sig, asm, constraint)
+	v.SideEffect = flags&1 != 0
+	v.AlignStack = flags&2 != 0
+	v.IntelDialect = (flags>>2)&1 != 0
+	return v
+}
+
+// sizedString returns the string of the given operands prefixed by its size,
+// and the operands following the string.
+func sizedString(ops []uint64) (string, []uint64) {
+	if len(ops) < 1 || ops[0] > uint64(len(ops)-1) {
+		panic(fmt.Errorf("invalid string operand; size out of range"))
+	}
+	n := ops[0]
+	return recordString(ops[1 : 1+n]), ops[1+n:]
+}
+
+// zeroValue returns the null value of the given type.
+func zeroValue(typ types.Type) constant.Constant {
+	switch t := typ.(type) {
+	case *types.IntType:
+		return constant.NewInt(0, t)
+	case *types.FloatType:
+		return constant.NewFloat(0, t)
+	case *types.PointerType:
+		return constant.NewNull(t)
+	case *types.TokenType:
+		return constant.None
+	default:
+		return constant.NewZeroInitializer(t)
+	}
+}
+
+// decodeSigned decodes the given sign rotated value, which stores the sign in
+// the least significant bit.
+func decodeSigned(v uint64) int64 {
+	if v&1 == 0 {
+		return int64(v >> 1)
+	}
+	if v != 1 {
+		return -int64(v >> 1)
+	}
+	// There is no such thing as -0 with integers; "-0" encodes MinInt64.
+	return math.MinInt64
+}
+
+// newInt returns a new integer constant of the given type, based on the two's
+// complement bit pattern x truncated to the bit width of the type.
+func newInt(typ types.Type, x *big.Int) *constant.Int {
+	t, ok := typ.(*types.IntType)
+	if !ok {
+		panic(fmt.Errorf("invalid integer constant type; expected *types.IntType, got %T", typ))
+	}
+	// Interpret x as a signed integer of the bit width of t, as printed by
+	// llvm-dis; except for booleans.
+	bits := uint(t.Size)
+	mod := new(big.Int).Lsh(big.NewInt(1), bits)
+	y := new(big.Int).Mod(x, mod)
+	if bits > 1 && y.Bit(int(bits-1)) == 1 {
+		y.Sub(y, mod)
+	}
+	return &constant.Int{Typ: t, X: y}
+}
+
+// newFloat returns a new floating-point constant of the given type, based on
+// the given bit pattern words.
+func newFloat(typ types.Type, ops []uint64) *constant.Float {
+	t, ok := typ.(*types.FloatType)
+	if !ok {
+		panic(fmt.Errorf("invalid floating-point constant type; expected *types.FloatType, got %T", typ))
+	}
+	if len(ops) < 1 {
+		panic(fmt.Errorf("invalid floating-point constant; missing value"))
+	}
+	c := &constant.Float{Typ: t}
+	var x float64
+	switch t.Kind {
+	case types.FloatKindIEEE_16:
+		x = floats.NewFloat16FromBits(uint16(ops[0])).Float64()
+	case types.FloatKindIEEE_32:
+		x = float64(math.Float32frombits(uint32(ops[0])))
+	case types.FloatKindIEEE_64:
+		x = math.Float64frombits(ops[0])
+	case types.FloatKindDoubleExtended_80:
+		// [sign and exponent << 48 | mantissa >> 16, mantissa & 0xFFFF]
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid x86_fp80 constant; expected 2 words, got %d", len(ops)))
+		}
+		f := floats.NewFloat80FromBits(uint16(ops[0]>>48), ops[0]<<16|ops[1]&0xFFFF)
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
+		return c
+	case types.FloatKindIEEE_128:
+		// [low word, high word]
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid fp128 constant; expected 2 words, got %d", len(ops)))
+		}
+		f := floats.NewFloat128FromBits(ops[1], ops[0])
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
+		return c
+	case types.FloatKindDoubleDouble_128:
+		// [high-order double, low-order double]
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid ppc_fp128 constant; expected 2 words, got %d", len(ops)))
+		}
+		f := floats.NewFloat128PPCFromBits(ops[0], ops[1])
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
+		return c
+	default:
+		panic(fmt.Errorf("support for floating-point kind %v not yet implemented", t.Kind))
+	}
+	if math.IsNaN(x) {
+		c.NaN = true
+		return c
+	}
+	c.X = big.NewFloat(x)
+	return c
+}
+
+// constBinop returns a new binary constant expression based on the given
+// opcode, operands and flags.
+func constBinop(opcode uint64, x, y constant.Constant, flags uint64) constant.Constant {
+	isFloat := isFloatType(x.Type())
+	switch opcode {
+	case binopAdd:
+		if isFloat {
+			return constant.NewFAdd(x, y)
+		}
+		return constant.NewAdd(x, y, constOverflowFlags(flags)...)
+	case binopSub:
+		if isFloat {
+			return constant.NewFSub(x, y)
+		}
+		return constant.NewSub(x, y, constOverflowFlags(flags)...)
+	case binopMul:
+		if isFloat {
+			return constant.NewFMul(x, y)
+		}
+		return constant.NewMul(x, y, constOverflowFlags(flags)...)
+	case binopUDiv:
+		c := constant.NewUDiv(x, y)
+		c.Exact = flags&1 != 0
+		return c
+	case binopSDiv:
+		if isFloat {
+			return constant.NewFDiv(x, y)
+		}
+		c := constant.NewSDiv(x, y)
+		c.Exact = flags&1 != 0
+		return c
+	case binopURem:
+		return constant.NewURem(x, y)
+	case binopSRem:
+		if isFloat {
+			return constant.NewFRem(x, y)
+		}
+		return constant.NewSRem(x, y)
+	case binopShl:
+		return constant.NewShl(x, y, constOverflowFlags(flags)...)
+	case binopLShr:
+		c := constant.NewLShr(x, y)
+		c.Exact = flags&1 != 0
+		return c
+	case binopAShr:
+		c := constant.NewAShr(x, y)
+		c.Exact = flags&1 != 0
+		return c
+	case binopAnd:
+		return constant.NewAnd(x, y)
+	case binopOr:
+		return constant.NewOr(x, y)
+	case binopXor:
+		return constant.NewXor(x, y)
+	default:
+		panic(fmt.Errorf("support for binary opcode %d not yet implemented", opcode))
+	}
+}
+
+// constOverflowFlags returns the overflow flags of the given encoded flags.
+func constOverflowFlags(flags uint64) []constant.OverflowFlag {
+	var fs []constant.OverflowFlag
+	if flags&overflowNUW != 0 {
+		fs = append(fs, constant.OverflowFlagNUW)
+	}
+	if flags&overflowNSW != 0 {
+		fs = append(fs, constant.OverflowFlagNSW)
+	}
+	return fs
+}
+
+// constCast returns a new conversion constant expression based on the given
+// opcode, operand and target type.
+func constCast(opcode uint64, from constant.Constant, to types.Type) constant.Constant {
+	switch opcode {
+	case castTrunc:
+		return constant.NewTrunc(from, to)
+	case castZExt:
+		return constant.NewZExt(from, to)
+	case castSExt:
+		return constant.NewSExt(from, to)
+	case castFPToUI:
+		return constant.NewFPToUI(from, to)
+	case castFPToSI:
+		return constant.NewFPToSI(from, to)
+	case castUIToFP:
+		return constant.NewUIToFP(from, to)
+	case castSIToFP:
+		return constant.NewSIToFP(from, to)
+	case castFPTrunc:
+		return constant.NewFPTrunc(from, to)
+	case castFPExt:
+		return constant.NewFPExt(from, to)
+	case castPtrToInt:
+		return constant.NewPtrToInt(from, to)
+	case castIntToPtr:
+		return constant.NewIntToPtr(from, to)
+	case castBitCast:
+		return constant.NewBitCast(from, to)
+	case castAddrSpaceCast:
+		return constant.NewAddrSpaceCast(from, to)
+	default:
+		panic(fmt.Errorf("support for cast opcode %d not yet implemented", opcode))
+	}
+}
+
+// isFloatType reports whether the given type is a floating-point type or a
+// vector of floating-point types.
+func isFloatType(t types.Type) bool {
+	if v, ok := t.(*types.VectorType); ok {
+		t = v.Elem
+	}
+	_, ok := t.(*types.FloatType)
+	return ok
+}
+
+// isFloatPred reports whether the given encoded predicate is a floating-point
+// predicate.
+func isFloatPred(pred uint64) bool {
+	return pred <= predLastFloat
+}
+
+// floatPred returns the floating-point predicate of the given encoded
+// predicate.
+func floatPred(pred uint64) int {
+	return int(floatPreds[pred-predFirstFloat])
+}
+
+// floatPreds specifies the floating-point predicates, as encoded in LLVM
+// bitcode.
+var floatPreds = []ir.FloatPred{
+	ir.FloatFalse,
+	ir.FloatOEQ,
+	ir.FloatOGT,
+	ir.FloatOGE,
+	ir.FloatOLT,
+	ir.FloatOLE,
+	ir.FloatONE,
+	ir.FloatORD,
+	ir.FloatUNO,
+	ir.FloatUEQ,
+	ir.FloatUGT,
+	ir.FloatUGE,
+	ir.FloatULT,
+	ir.FloatULE,
+	ir.FloatUNE,
+	ir.FloatTrue,
+}
+
+// intPred returns the integer predicate of the given encoded predicate.
+func intPred(pred uint64) int {
+	if pred < predFirstInt || pred > predLastInt {
+		panic(fmt.Errorf("invalid integer predicate %d", pred))
+	}
+	return int(pred-predFirstInt) + 1
+}
@@ -0,0 +1,342 @@
+package bitcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+	"github.com/pkg/errors"
+)
+
+// decoder decodes the blocks of a bitcode file into an LLVM IR module.
+type decoder struct {
+	// Module being decoded.
+	m *ir.Module
+
+	// Per module.
+
+	// Module version; version 2 and above store the names of global values in
+	// the string table.
+	version uint64
+	// String table of the bitcode file.
+	strtab []byte
+	// Type table, indexed by type ID.
+	types []types.Type
+	// Identified struct types, as opposed to struct type literals.
+	identified map[*types.StructType]bool
+	// Attribute groups, indexed by attribute group ID.
+	attrGroups map[uint64]*attrGroup
+	// Attribute lists, indexed by attribute list ID - 1.
+	attrLists [][]*attrGroup
+	// Section names, indexed by section ID - 1.
+	sectionNames []string
+	// Comdats, indexed by comdat ID - 1.
+	comdats []*ir.Comdat
+	// Value table, indexed by value ID; module-level values are followed by the
+	// values local to the function currently being decoded.
+	values []value.Value
+	// Constants of the constants block being decoded, pending materialization;
+	// indexed by value ID.
+	pending map[uint64]*pendingConst
+	// Metadata kind names, indexed by metadata kind ID.
+	mdKinds map[uint64]string
+	// Metadata table, indexed by metadata ID; module-level metadata are
+	// followed by the metadata local to the function currently being decoded.
+	mds []metadata.Node
+	// Operand bundle tags, indexed by operand bundle tag ID.
+	bundleTags []string
+	// Synchronization scope names, indexed by synchronization scope ID.
+	syncScopes []string
+	// Functions with bodies, in the order of their function blocks.
+	bodies []*ir.Function
+	// Global values pending resolution of their constant operands, which may
+	// be forward references to module-level constants.
+	deferred []func()
+	// Block address constants pending resolution of their basic blocks.
+	blockAddrs []*blockAddr
+	// Call-site function attributes of call and invoke instructions; used for
+	// the numbering of attribute groups.
+	callAttrs map[ir.Instruction]*attr.Group
+
+	// Per function.
+
+	// Function being decoded.
+	f *ir.Function
+	// Value ID of the next instruction of the function.
+	nextValueID uint64
+	// Instructions and terminators of the function, in order of occurrence.
+	insts []ir.Instruction
+	// Index of the basic block being decoded.
+	cur int
+	// Operand bundles of the next call or invoke instruction.
+	bundles []*ir.OperandBundle
+	// Placeholders of forward referenced values, indexed by value ID.
+	fwds map[uint64]*fwdValue
+}
+
+// blockAddr is a block address constant pending resolution of its basic block.
+type blockAddr struct {
+	// Block address constant.
+	c *constant.BlockAddress
+	// Index of the basic block within its parent function.
+	index uint64
+}
+
+// decode decodes the LLVM IR module of the given top-level blocks of a bitcode
+// file.
+func decode(blocks []*bitstream.Block) (m *ir.Module, err error) {
+	d := &decoder{
+		m:          ir.NewModule(),
+		identified: make(map[*types.StructType]bool),
+		attrGroups: make(map[uint64]*attrGroup),
+		mdKinds:    make(map[uint64]string),
+		callAttrs:  make(map[ir.Instruction]*attr.Group),
+	}
+	// Report unexpected input as an error, rather than crashing the caller.
+	defer func() {
+		if e := recover(); e != nil {
+			err = errors.Errorf("%v", e)
+		}
+	}()
+	var module *bitstream.Block
+	for _, block := range blocks {
+		switch block.ID {
+		case blockModule:
+			if module != nil {
+				panic(fmt.Errorf("invalid bitcode file; multiple module blocks"))
+			}
+			module = block
+		case blockStrtab:
+			for _, rec := range records(block) {
+				if rec.Code == strtabBlob {
+					d.strtab = rec.Blob
+				}
+			}
+		case blockIdentification, blockSymtab:
+			// Producer identification and symbol tables are not needed to
+			// decode the module.
+		default:
+			panic(fmt.Errorf("support for top-level block ID %d not yet implemented", block.ID))
+		}
+	}
+	if module == nil {
+		panic(fmt.Errorf("invalid bitcode file; missing module block"))
+	}
+	d.moduleBlock(module)
+	d.order()
+	return d.m, nil
+}
+
+// records returns the records of the given block, ignoring nested blocks.
+func records(block *bitstream.Block) []*bitstream.Record {
+	var recs []*bitstream.Record
+	for _, e := range block.Entries {
+		if rec, ok := e.(*bitstream.Record); ok {
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
+
+// === [ Module ] ==============================================================
+
+// moduleBlock decodes the given module block.
+func (d *decoder) moduleBlock(block *bitstream.Block) {
+	for _, e := range block.Entries {
+		switch e := e.(type) {
+		case *bitstream.Block:
+			switch e.ID {
+			case blockParamAttrGroup:
+				d.paramAttrGroupBlock(e)
+			case blockParamAttr:
+				d.paramAttrBlock(e)
+			case blockType:
+				d.typeBlock(e)
+			case blockConstants:
+				d.constantsBlock(e)
+			case blockMetadataKind:
+				d.metadataKindBlock(e)
+			case blockMetadata:
+				d.metadataBlock(e)
+			case blockOperandBundleTags:
+				for _, rec := range records(e) {
+					if rec.Code == operandBundleTagCode {
+						d.bundleTags = append(d.bundleTags, recordString(rec.Ops))
+					}
+				}
+			case blockSyncScopeNames:
+				for _, rec := range records(e) {
+					if rec.Code == syncScopeName {
+						d.syncScopes = append(d.syncScopes, recordString(rec.Ops))
+					}
+				}
+			case blockFunction:
+				d.resolveDeferred()
+				d.functionBlock(e)
+			case blockValueSymtab:
+				d.moduleValueSymtabBlock(e)
+			case blockUselist:
+				// Use-list orders do not affect the LLVM IR module.
+			default:
+				panic(fmt.Errorf("support for module sub-block ID %d not yet implemented", e.ID))
+			}
+		case *bitstream.Record:
+			d.moduleRecord(e)
+		}
+	}
+	d.resolveDeferred()
+	for _, addr := range d.blockAddrs {
+		d.blockAddr(addr)
+	}
+}
+
+// moduleRecord decodes the given record of the module block.
+func (d *decoder) moduleRecord(rec *bitstream.Record) {
+	switch rec.Code {
+	case moduleCodeVersion:
+		if len(rec.Ops) < 1 {
+			panic(fmt.Errorf("invalid VERSION record; missing version"))
+		}
+		d.version = rec.Ops[0]
+	case moduleCodeTriple:
+		d.m.TargetTriple = recordString(rec.Ops)
+	case moduleCodeDataLayout:
+		d.m.DataLayout = recordString(rec.Ops)
+	case moduleCodeAsm:
+		// Module-level inline assembly is stored as a single string with one
+		// line per entry.
+		if asm := recordString(rec.Ops); len(asm) > 0 {
+			d.m.ModuleAsms = strings.Split(strings.TrimSuffix(asm, "\n"), "\n")
+		}
+	case moduleCodeSectionName:
+		d.sectionNames = append(d.sectionNames, recordString(rec.Ops))
+	case moduleCodeGCName:
+		panic(fmt.Errorf("support for garbage collector names not yet implemented"))
+	case moduleCodeComdat:
+		d.comdatRecord(rec)
+	case moduleCodeGlobalVar:
+		d.globalVarRecord(rec)
+	case moduleCodeFunction:
+		d.functionRecord(rec)
+	case moduleCodeAlias:
+		d.aliasRecord(rec)
+	case moduleCodeIFunc:
+		d.ifuncRecord(rec)
+	case moduleCodeSourceFilename:
+		d.m.SourceFilename = recordString(rec.Ops)
+	case moduleCodeVSTOffset, moduleCodeHash:
+		// The offset of the value symbol table and the module hash are not
+		// needed to decode the module.
+	default:
+		panic(fmt.Errorf("support for module record code %d not yet implemented", rec.Code))
+	}
+}
+
+// resolveDeferred resolves the constant operands of global values, which may
+// be forward references to module-level constants.
+func (d *decoder) resolveDeferred() {
+	for _, f := range d.deferred {
+		f()
+	}
+	d.deferred = nil
+}
+
+// blockAddr resolves the basic block of the given block address constant.
+func (d *decoder) blockAddr(addr *blockAddr) {
+	f, ok := addr.c.Func.(*ir.Function)
+	if !ok {
+		panic(fmt.Errorf("invalid block address function type; expected *ir.Function, got %T", addr.c.Func))
+	}
+	if addr.index >= uint64(len(f.Blocks)) {
+		panic(fmt.Errorf("invalid block address; basic block index %d out of range for function %s with %d basic blocks", addr.index, f.Ident(), len(f.Blocks)))
+	}
+	addr.c.Block = f.Blocks[addr.index]
+}
+
+// moduleValueSymtabBlock decodes the given module-level value symbol table.
+func (d *decoder) moduleValueSymtabBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		switch rec.Code {
+		case vstCodeEntry, vstCodeFnEntry:
+			// The names of global values are stored in the string table since
+			// version 2, in which case only function offsets are recorded by
+			// the module-level value symbol table.
+			if d.version >= 2 {
+				continue
+			}
+			ops := rec.Ops
+			if rec.Code == vstCodeFnEntry {
+				// [valueid, offset, namechar x N]
+				if len(ops) < 2 {
+					panic(fmt.Errorf("invalid FNENTRY record; expected at least 2 operands, got %d", len(ops)))
+				}
+				ops = append([]uint64{ops[0]}, ops[2:]...)
+			}
+			if len(ops) < 1 {
+				panic(fmt.Errorf("invalid ENTRY record; missing value ID"))
+			}
+			v, ok := d.moduleValue(ops[0]).(value.Named)
+			if !ok {
+				panic(fmt.Errorf("invalid value symbol table entry; expected named value, got %T", d.moduleValue(ops[0])))
+			}
+			v.SetName(recordString(ops[1:]))
+		}
+	}
+}
+
+// === [ Values ] ==============================================================
+
+// moduleValue returns the module-level value of the given value ID.
+func (d *decoder) moduleValue(id uint64) value.Value {
+	if id >= uint64(len(d.values)) || d.values[id] == nil {
+		panic(fmt.Errorf("invalid value ID %d; no such module-level value", id))
+	}
+	return d.values[id]
+}
+
+// moduleConstant returns the module-level constant of the given value ID.
+func (d *decoder) moduleConstant(id uint64) constant.Constant {
+	v := d.moduleValue(id)
+	c, ok := v.(constant.Constant)
+	if !ok {
+		panic(fmt.Errorf("invalid value type of value ID %d; expected constant.Constant, got %T", id, v))
+	}
+	return c
+}
+
+// === [ Types ] ===============================================================
+
+// typ returns the type of the given type ID.
+func (d *decoder) typ(id uint64) types.Type {
+	if id >= uint64(len(d.types)) || d.types[id] == nil {
+		panic(fmt.Errorf("invalid type ID %d; no such type", id))
+	}
+	return d.types[id]
+}
+
+// === [ Strings ] =============================================================
+
+// recordString returns the string encoded by the given record operands, one
+// character per operand.
+func recordString(ops []uint64) string {
+	buf := make([]byte, len(ops))
+	for i, op := range ops {
+		buf[i] = byte(op)
+	}
+	return string(buf)
+}
+
+// strtabString returns the string of the given offset and size in the string
+// table.
+func (d *decoder) strtabString(offset, size uint64) string {
+	if offset+size > uint64(len(d.strtab)) {
+		panic(fmt.Errorf("invalid string table range [%d, %d); string table of size %d", offset, offset+size, len(d.strtab)))
+	}
+	return string(d.strtab[offset : offset+size])
+}
@@ -0,0 +1,615 @@
+package bitcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+	"github.com/pkg/errors"
+)
+
+// encoder encodes an LLVM IR module into the blocks of a bitcode file.
+type encoder struct {
+	// Module being encoded.
+	m *ir.Module
+
+	// Per module.
+
+	// String table of the bitcode file.
+	strtab []byte
+	// Type table, indexed by type ID.
+	types []types.Type
+	// Type IDs, indexed by type key.
+	typeIDs map[string]uint64
+	// Identified struct types being enumerated, indexed by type key.
+	visiting map[string]bool
+	// Attribute group records, indexed by attribute group ID - 1.
+	attrGroups []*bitstream.Record
+	// Attribute group IDs, indexed by encoded attribute group.
+	attrGroupIDs map[string]uint64
+	// Attribute list records, indexed by attribute list ID - 1.
+	attrLists []*bitstream.Record
+	// Attribute list IDs, indexed by encoded attribute list.
+	attrListIDs map[string]uint64
+	// Section names, indexed by section ID - 1.
+	sectionNames []string
+	// Section IDs, indexed by section name.
+	sectionIDs map[string]uint64
+	// Comdats, indexed by comdat ID - 1.
+	comdats []*ir.Comdat
+	// Comdat IDs, indexed by comdat.
+	comdatIDs map[*ir.Comdat]uint64
+	// Value table, indexed by value ID; global values are followed by the
+	// module-level constants.
+	values []value.Value
+	// Value IDs of module-level values, indexed by value.
+	valueIDs map[value.Value]uint64
+	// Value IDs of simple constants, indexed by constant key.
+	constIDs map[string]uint64
+	// Metadata kind names, indexed by metadata kind ID.
+	mdKinds []string
+	// Metadata kind IDs, indexed by metadata kind name.
+	mdKindIDs map[string]uint64
+	// Index of metadata definitions of the module; used to order the
+	// registration of metadata kinds.
+	mdDefs map[*metadata.Metadata]int
+	// Metadata strings of the module, indexed by metadata ID.
+	mdStrings []string
+	// Metadata string IDs, indexed by metadata string.
+	mdStringIDs map[string]uint64
+	// Module-level metadata nodes and values, indexed by metadata ID - number
+	// of metadata strings.
+	mds []metadata.Node
+	// Indices into mds of metadata nodes, indexed by metadata node.
+	mdNodeIndices map[*metadata.Metadata]uint64
+	// Indices into mds of metadata values, indexed by value ID.
+	mdValueIndices map[uint64]uint64
+	// Operand bundle tags, indexed by operand bundle tag ID.
+	bundleTags []string
+	// Operand bundle tag IDs, indexed by tag.
+	bundleTagIDs map[string]uint64
+	// Synchronization scope names, indexed by synchronization scope ID.
+	syncScopes []string
+	// Synchronization scope IDs, indexed by synchronization scope name.
+	syncScopeIDs map[string]uint64
+
+	// Per function.
+
+	// Function being encoded.
+	f *ir.Function
+	// Value IDs of function-local values, indexed by value.
+	localIDs map[value.Value]uint64
+	// Value ID of the next instruction of the function.
+	nextValueID uint64
+	// Indices of the basic blocks of the function, indexed by basic block.
+	blockIndices map[*ir.BasicBlock]uint64
+	// Function-local metadata values, indexed by metadata ID - number of
+	// module-level metadata.
+	localMDs []*metadata.Value
+	// Indices into localMDs of function-local metadata values, indexed by
+	// value ID.
+	localMDIndices map[uint64]uint64
+}
+
+// encode encodes the given LLVM IR module into the top-level blocks of a
+// bitcode file.
+func encode(m *ir.Module) (blocks []*bitstream.Block, err error) {
+	e := &encoder{
+		m:              m,
+		typeIDs:        make(map[string]uint64),
+		visiting:       make(map[string]bool),
+		attrGroupIDs:   make(map[string]uint64),
+		attrListIDs:    make(map[string]uint64),
+		sectionIDs:     make(map[string]uint64),
+		comdatIDs:      make(map[*ir.Comdat]uint64),
+		valueIDs:       make(map[value.Value]uint64),
+		constIDs:       make(map[string]uint64),
+		mdKindIDs:      make(map[string]uint64),
+		mdDefs:         make(map[*metadata.Metadata]int),
+		mdStringIDs:    make(map[string]uint64),
+		mdNodeIndices:  make(map[*metadata.Metadata]uint64),
+		mdValueIndices: make(map[uint64]uint64),
+		bundleTagIDs:   make(map[string]uint64),
+		syncScopeIDs:   make(map[string]uint64),
+	}
+	// Report unsupported input as an error, rather than crashing the caller.
+	defer func() {
+		if e := recover(); e != nil {
+			err = errors.Errorf("%v", e)
+		}
+	}()
+	e.enumModule()
+	ident := &bitstream.Block{
+		ID: blockIdentification,
+		Entries: []bitstream.Entry{
+			&bitstream.Record{Code: identCodeString, Ops: stringOps(producer)},
+			&bitstream.Record{Code: identCodeEpoch, Ops: []uint64{0}},
+		},
+	}
+	module := e.moduleBlock()
+	strtab := &bitstream.Block{
+		ID: blockStrtab,
+		Entries: []bitstream.Entry{
+			&bitstream.Record{Code: strtabBlob, Blob: append([]byte{}, e.strtab...)},
+		},
+	}
+	return []*bitstream.Block{ident, module, strtab}, nil
+}
+
+// producer is the producer identification string of encoded bitcode files.
+const producer = "llir/llvm"
+
+// === [ Module ] ==============================================================
+
+// moduleBlock encodes the module block of the module.
+func (e *encoder) moduleBlock() *bitstream.Block {
+	// The blocks and records of global values are encoded before the tables
+	// preceding them, as the tables are populated on use.
+	var globals []bitstream.Entry
+	for _, g := range e.m.Globals {
+		globals = append(globals, e.globalVarRecord(g))
+	}
+	for _, f := range e.m.Funcs {
+		globals = append(globals, e.functionRecord(f))
+	}
+	for _, a := range e.m.Aliases {
+		globals = append(globals, e.aliasRecord(a))
+	}
+	for _, i := range e.m.IFuncs {
+		globals = append(globals, e.ifuncRecord(i))
+	}
+	consts := e.constantsBlock()
+	mds := e.metadataBlock()
+	var funcs []bitstream.Entry
+	for _, f := range e.m.Funcs {
+		if len(f.Blocks) > 0 {
+			funcs = append(funcs, e.functionBlock(f))
+		}
+	}
+	block := &bitstream.Block{ID: blockModule}
+	add := func(entries ...bitstream.Entry) {
+		block.Entries = append(block.Entries, entries...)
+	}
+	add(&bitstream.Record{Code: moduleCodeVersion, Ops: []uint64{2}})
+	if len(e.attrGroups) > 0 {
+		add(e.paramAttrGroupBlock(), e.paramAttrBlock())
+	}
+	add(e.typeBlock())
+	if len(e.m.TargetTriple) > 0 {
+		add(&bitstream.Record{Code: moduleCodeTriple, Ops: stringOps(e.m.TargetTriple)})
+	}
+	if len(e.m.DataLayout) > 0 {
+		add(&bitstream.Record{Code: moduleCodeDataLayout, Ops: stringOps(e.m.DataLayout)})
+	}
+	if len(e.m.ModuleAsms) > 0 {
+		// Module-level inline assembly is stored as a single string with one
+		// line per entry.
+		asm := strings.Join(e.m.ModuleAsms, "\n")
+		add(&bitstream.Record{Code: moduleCodeAsm, Ops: stringOps(asm)})
+	}
+	for _, name := range e.sectionNames {
+		add(&bitstream.Record{Code: moduleCodeSectionName, Ops: stringOps(name)})
+	}
+	for _, c := range e.comdats {
+		// [strtab offset, strtab size, selection kind]
+		offset, size := e.addString(c.Name)
+		add(&bitstream.Record{Code: moduleCodeComdat, Ops: []uint64{offset, size, uint64(c.Kind) + 1}})
+	}
+	add(globals...)
+	if len(e.m.SourceFilename) > 0 {
+		add(&bitstream.Record{Code: moduleCodeSourceFilename, Ops: stringOps(e.m.SourceFilename)})
+	}
+	if consts != nil {
+		add(consts)
+	}
+	add(e.metadataKindBlock())
+	if mds != nil {
+		add(mds)
+	}
+	if len(e.bundleTags) > 0 {
+		tags := &bitstream.Block{ID: blockOperandBundleTags}
+		for _, tag := range e.bundleTags {
+			tags.Entries = append(tags.Entries, &bitstream.Record{Code: operandBundleTagCode, Ops: stringOps(tag)})
+		}
+		add(tags)
+	}
+	if len(e.syncScopes) > 0 {
+		scopes := &bitstream.Block{ID: blockSyncScopeNames}
+		for _, name := range e.syncScopes {
+			scopes.Entries = append(scopes.Entries, &bitstream.Record{Code: syncScopeName, Ops: stringOps(name)})
+		}
+		add(scopes)
+	}
+	add(funcs...)
+	return block
+}
+
+// enumModule enumerates the global values, constants and metadata of the
+// module, and registers the metadata kinds, sections and comdats of the module
+// in order of first use.
+func (e *encoder) enumModule() {
+	for _, kind := range fixedMDKinds {
+		e.mdKindID(kind)
+	}
+	// Global values.
+	for _, g := range e.m.Globals {
+		e.addValue(g)
+	}
+	for _, f := range e.m.Funcs {
+		e.addValue(f)
+	}
+	for _, a := range e.m.Aliases {
+		e.addValue(a)
+	}
+	for _, i := range e.m.IFuncs {
+		e.addValue(i)
+	}
+	for _, c := range e.m.Comdats {
+		e.comdatID(c)
+	}
+	for i, md := range e.m.Metadata {
+		e.mdDefs[md] = i
+	}
+	// Constants and metadata.
+	for _, g := range e.m.Globals {
+		e.sectionID(g.Section)
+		e.comdatID(g.Comdat)
+		if g.Init != nil {
+			e.enumConst(g.Init)
+		}
+		e.enumAttachments(g.Metadata)
+	}
+	for _, f := range e.m.Funcs {
+		e.sectionID(f.Section)
+		e.comdatID(f.Comdat)
+		if f.Personality != nil {
+			e.enumConst(f.Personality)
+		}
+		e.enumAttachments(f.Metadata)
+		for _, block := range f.Blocks {
+			for _, inst := range block.Insts {
+				e.enumInst(inst)
+			}
+			e.enumInst(block.Term)
+		}
+	}
+	for _, a := range e.m.Aliases {
+		e.enumConst(a.Aliasee)
+	}
+	for _, i := range e.m.IFuncs {
+		e.enumConst(i.Resolver)
+	}
+	for _, named := range e.m.NamedMetadata {
+		for _, md := range named.Metadata {
+			e.enumMetadata(md)
+		}
+	}
+}
+
+// enumInst enumerates the constants and metadata of the given instruction.
+func (e *encoder) enumInst(inst ir.Instruction) {
+	for _, op := range operands(inst) {
+		e.enumOperand(op)
+	}
+	if inst, ok := inst.(*ir.InstAlloca); ok && inst.NElems == nil {
+		// The number of elements defaults to 1.
+		e.enumConst(allocaNElems)
+	}
+	e.enumAttachments(inst.MDAttachments())
+}
+
+// enumOperand enumerates the constants and metadata of the given instruction
+// operand; local values are ignored.
+func (e *encoder) enumOperand(op value.Value) {
+	switch op := op.(type) {
+	case *ir.Arg:
+		e.enumOperand(op.Value)
+	case *metadata.Value:
+		if isConst(op.X) {
+			e.enumMetadata(op)
+		}
+	case metadata.Node:
+		if isConst(op) {
+			// Constants which are also metadata nodes (e.g. i32 42) are
+			// operands as constants.
+			e.enumConst(op)
+			return
+		}
+		e.enumMetadata(op)
+	default:
+		if isConst(op) {
+			e.enumConst(op)
+		}
+	}
+}
+
+// === [ Global values ] =======================================================
+
+// addValue assigns the next module-level value ID to the given value.
+func (e *encoder) addValue(v value.Value) uint64 {
+	id := uint64(len(e.values))
+	e.values = append(e.values, v)
+	e.valueIDs[v] = id
+	return id
+}
+
+// globalName returns the string table offset and size of the name of the
+// given global value; unnamed global values have an empty name.
+func (e *encoder) globalName(name string) (offset, size uint64) {
+	if isID(name) {
+		return 0, 0
+	}
+	return e.addString(name)
+}
+
+// addString adds the given string to the string table, and returns its offset
+// and size.
+func (e *encoder) addString(s string) (offset, size uint64) {
+	offset = uint64(len(e.strtab))
+	e.strtab = append(e.strtab, s...)
+	return offset, uint64(len(s))
+}
+
+// globalVarRecord encodes the given global variable.
+func (e *encoder) globalVarRecord(g *ir.Global) *bitstream.Record {
+	// [strtab offset, strtab size, type, flags, init ID + 1, linkage,
+	// alignment, section, visibility, TLS model, unnamed_addr, externally
+	// initialized, DLL storage class, comdat, attributes, dso_local]
+	offset, size := e.globalName(g.Name)
+	t := g.Type().(*types.PointerType)
+	// Explicit content type.
+	flags := uint64(t.AddrSpace)<<2 | 2
+	if g.IsConst {
+		flags |= 1
+	}
+	var initID uint64
+	if g.Init != nil {
+		initID = e.valueID(g.Init) + 1
+	}
+	ops := []uint64{
+		offset, size,
+		e.typeID(g.Content),
+		flags,
+		initID,
+		linkageCode(g.Linkage),
+		alignmentCode(g.Align),
+		e.sectionID(g.Section),
+		visibilityCode(g.Visibility),
+		uint64(g.TLSModel),
+		unnamedAddrCode(g.UnnamedAddr),
+		boolCode(g.ExternallyInitialized),
+		uint64(g.DLLStorageClass),
+		e.comdatID(g.Comdat),
+		0,
+		0,
+	}
+	return &bitstream.Record{Code: moduleCodeGlobalVar, Ops: ops}
+}
+
+// functionRecord encodes the given function.
+func (e *encoder) functionRecord(f *ir.Function) *bitstream.Record {
+	// [strtab offset, strtab size, type, calling convention, is prototype,
+	// linkage, attributes, alignment, section, visibility, gc, unnamed_addr,
+	// prologue data, DLL storage class, comdat, prefix data, personality ID +
+	// 1, dso_local, address space]
+	offset, size := e.globalName(f.Name)
+	var personalityID uint64
+	if f.Personality != nil {
+		personalityID = e.valueID(f.Personality) + 1
+	}
+	ops := []uint64{
+		offset, size,
+		e.typeID(f.Sig),
+		callConvCode(f.CallConv),
+		boolCode(len(f.Blocks) == 0),
+		linkageCode(f.Linkage),
+		e.funcAttrList(f),
+		alignmentCode(f.Align),
+		e.sectionID(f.Section),
+		visibilityCode(f.Visibility),
+		0,
+		unnamedAddrCode(f.UnnamedAddr),
+		0,
+		uint64(f.DLLStorageClass),
+		e.comdatID(f.Comdat),
+		0,
+		personalityID,
+		0,
+		uint64(f.Type().(*types.PointerType).AddrSpace),
+	}
+	return &bitstream.Record{Code: moduleCodeFunction, Ops: ops}
+}
+
+// aliasRecord encodes the given alias.
+func (e *encoder) aliasRecord(a *ir.Alias) *bitstream.Record {
+	// [strtab offset, strtab size, type, address space, aliasee ID, linkage,
+	// visibility, DLL storage class, TLS model, unnamed_addr, dso_local]
+	offset, size := e.globalName(a.Name)
+	ops := []uint64{
+		offset, size,
+		e.typeID(a.Typ.Elem),
+		uint64(a.Typ.AddrSpace),
+		e.valueID(a.Aliasee),
+		linkageCode(a.Linkage),
+		visibilityCode(a.Visibility),
+		uint64(a.DLLStorageClass),
+		uint64(a.TLSModel),
+		unnamedAddrCode(a.UnnamedAddr),
+		0,
+	}
+	return &bitstream.Record{Code: moduleCodeAlias, Ops: ops}
+}
+
+// ifuncRecord encodes the given IFunc.
+func (e *encoder) ifuncRecord(i *ir.IFunc) *bitstream.Record {
+	// [strtab offset, strtab size, type, address space, resolver ID, linkage,
+	// visibility]
+	offset, size := e.globalName(i.Name)
+	ops := []uint64{
+		offset, size,
+		e.typeID(i.Typ.Elem),
+		uint64(i.Typ.AddrSpace),
+		e.valueID(i.Resolver),
+		linkageCode(i.Linkage),
+		visibilityCode(i.Visibility),
+	}
+	return &bitstream.Record{Code: moduleCodeIFunc, Ops: ops}
+}
+
+// sectionID returns the section ID of the given section name, where 0 denotes
+// no section.
+func (e *encoder) sectionID(name string) uint64 {
+	if len(name) == 0 {
+		return 0
+	}
+	if id, ok := e.sectionIDs[name]; ok {
+		return id
+	}
+	e.sectionNames = append(e.sectionNames, name)
+	id := uint64(len(e.sectionNames))
+	e.sectionIDs[name] = id
+	return id
+}
+
+// comdatID returns the comdat ID of the given comdat, where 0 denotes no
+// comdat.
+func (e *encoder) comdatID(c *ir.Comdat) uint64 {
+	if c == nil {
+		return 0
+	}
+	if id, ok := e.comdatIDs[c]; ok {
+		return id
+	}
+	e.comdats = append(e.comdats, c)
+	id := uint64(len(e.comdats))
+	e.comdatIDs[c] = id
+	return id
+}
+
+// === [ Strings ] =============================================================
+
+// stringOps returns the record operands of the given string, one character per
+// operand.
+func stringOps(s string) []uint64 {
+	ops := make([]uint64, len(s))
+	for i := 0; i < len(s); i++ {
+		ops[i] = uint64(s[i])
+	}
+	return ops
+}
+
+// isID reports whether the given name is empty or a numeric ID, as assigned to
+// unnamed values.
+func isID(name string) bool {
+	for _, r := range name {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// === [ Enums ] ===============================================================
+
+// linkageCode returns the linkage code of the given LLVM IR linkage type.
+func linkageCode(linkage ir.Linkage) uint64 {
+	switch linkage {
+	case ir.LinkageNone, ir.LinkageExternal:
+		return 0
+	case ir.LinkageAppending:
+		return 2
+	case ir.LinkageInternal:
+		return 3
+	case ir.LinkageExternWeak:
+		return 7
+	case ir.LinkageCommon:
+		return 8
+	case ir.LinkagePrivate:
+		return 9
+	case ir.LinkageAvailableExternally:
+		return 12
+	case ir.LinkageWeak:
+		return 16
+	case ir.LinkageWeakODR:
+		return 17
+	case ir.LinkageLinkOnce:
+		return 18
+	case ir.LinkageLinkOnceODR:
+		return 19
+	default:
+		panic(fmt.Errorf("support for linkage %v not yet implemented", linkage))
+	}
+}
+
+// visibilityCode returns the visibility code of the given LLVM IR visibility
+// style.
+func visibilityCode(vis ir.Visibility) uint64 {
+	switch vis {
+	case ir.VisibilityNone, ir.VisibilityDefault:
+		return 0
+	case ir.VisibilityHidden:
+		return 1
+	case ir.VisibilityProtected:
+		return 2
+	default:
+		panic(fmt.Errorf("support for visibility style %v not yet implemented", vis))
+	}
+}
+
+// unnamedAddrCode returns the unnamed_addr code of the given LLVM IR unnamed
+// address specifier.
+func unnamedAddrCode(unnamed ir.UnnamedAddr) uint64 {
+	switch unnamed {
+	case ir.UnnamedAddrNone:
+		return 0
+	case ir.UnnamedAddrUnnamedAddr:
+		return 1
+	case ir.UnnamedAddrLocalUnnamedAddr:
+		return 2
+	default:
+		panic(fmt.Errorf("support for unnamed address specifier %v not yet implemented", unnamed))
+	}
+}
+
+// alignmentCode returns the encoded alignment of the given alignment in bytes,
+// which is stored as log2(align)+1, where 0 denotes no alignment.
+func alignmentCode(align int) uint64 {
+	if align == 0 {
+		return 0
+	}
+	if align < 0 || align&(align-1) != 0 {
+		panic(fmt.Errorf("invalid alignment %d; expected power of two", align))
+	}
+	var code uint64 = 1
+	for ; align > 1; align >>= 1 {
+		code++
+	}
+	return code
+}
+
+// callConvCode returns the calling convention code of the given LLVM IR
+// calling convention.
+func callConvCode(cc ir.CallConv) uint64 {
+	if cc == ir.CallConvC {
+		// The C calling convention is the default calling convention.
+		return 0
+	}
+	for code, c := range callConvs {
+		if c == cc {
+			return code
+		}
+	}
+	panic(fmt.Errorf("support for calling convention %v not yet implemented", cc))
+}
+
+// boolCode returns the encoding of the given boolean.
+func boolCode(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
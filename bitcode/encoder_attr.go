@@ -0,0 +1,147 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/value"
+)
+
+// funcAttrList returns the attribute list ID of the return, parameter and
+// function attributes of the given function.
+func (e *encoder) funcAttrList(f *ir.Function) uint64 {
+	var groups []uint64
+	groups = e.appendAttrGroup(groups, attrIndexFunc, f.FuncAttrs)
+	groups = e.appendAttrGroup(groups, 0, f.RetAttrs)
+	for i, param := range f.Params() {
+		groups = e.appendAttrGroup(groups, uint64(i+1), param.Attrs)
+	}
+	return e.attrListID(groups)
+}
+
+// callAttrList returns the attribute list ID of the given call-site function
+// and return attributes, and of the parameter attributes of the given
+// arguments.
+func (e *encoder) callAttrList(funcAttrs, retAttrs []attr.Attribute, args []value.Value) uint64 {
+	var groups []uint64
+	groups = e.appendAttrGroup(groups, attrIndexFunc, funcAttrs)
+	groups = e.appendAttrGroup(groups, 0, retAttrs)
+	for i, arg := range args {
+		if arg, ok := arg.(*ir.Arg); ok {
+			groups = e.appendAttrGroup(groups, uint64(i+1), arg.Attrs)
+		}
+	}
+	return e.attrListID(groups)
+}
+
+// appendAttrGroup appends the ID of the attribute group of the given attribute
+// index and attributes to groups, unless there are no attributes.
+func (e *encoder) appendAttrGroup(groups []uint64, index uint64, attrs []attr.Attribute) []uint64 {
+	var ops []uint64
+	for _, a := range attrs {
+		ops = appendAttr(ops, a)
+	}
+	if len(ops) == 0 {
+		return groups
+	}
+	key := fmt.Sprint(index, ops)
+	id, ok := e.attrGroupIDs[key]
+	if !ok {
+		// Attribute group IDs start at 1.
+		id = uint64(len(e.attrGroups) + 1)
+		e.attrGroupIDs[key] = id
+		// [group ID, attribute index, attributes...]
+		rec := &bitstream.Record{Code: paramAttrGroupCodeEntry, Ops: append([]uint64{id, index}, ops...)}
+		e.attrGroups = append(e.attrGroups, rec)
+	}
+	return append(groups, id)
+}
+
+// appendAttr appends the encoding of the given attribute to ops; the
+// attributes of attribute groups are appended in order.
+func appendAttr(ops []uint64, a attr.Attribute) []uint64 {
+	switch a := a.(type) {
+	case *attr.Group:
+		for _, a := range a.Attrs {
+			ops = appendAttr(ops, a)
+		}
+		return ops
+	case attr.Enum:
+		// [kind]
+		return append(ops, attrEncEnum, enumAttrKind(a))
+	case attr.Align:
+		// [kind, value]
+		return append(ops, attrEncInt, attrKindAlign, uint64(a))
+	case attr.AlignStack:
+		return append(ops, attrEncInt, attrKindStackAlign, uint64(a))
+	case attr.Dereferenceable:
+		return append(ops, attrEncInt, attrKindDereferenceable, uint64(a))
+	case attr.DereferenceableOrNull:
+		return append(ops, attrEncInt, attrKindDereferenceableOrNull, uint64(a))
+	case *attr.AllocSize:
+		val := uint64(a.ElemSize) << 32
+		if a.HasNElems {
+			val |= uint64(a.NElems)
+		} else {
+			val |= allocSizeNoNElems
+		}
+		return append(ops, attrEncInt, attrKindAllocSize, val)
+	case *attr.String:
+		// [key..., 0] or [key..., 0, value..., 0]
+		if len(a.Val) == 0 {
+			ops = append(ops, attrEncString)
+			return append(append(ops, stringOps(a.Key)...), 0)
+		}
+		ops = append(ops, attrEncStringVal)
+		ops = append(append(ops, stringOps(a.Key)...), 0)
+		return append(append(ops, stringOps(a.Val)...), 0)
+	default:
+		panic(fmt.Errorf("support for attribute %T not yet implemented", a))
+	}
+}
+
+// enumAttrKind returns the attribute kind of the given enum attribute.
+func enumAttrKind(a attr.Enum) uint64 {
+	for kind, b := range enumAttrs {
+		if a == b {
+			return kind
+		}
+	}
+	panic(fmt.Errorf("support for attribute %v not yet implemented", a))
+}
+
+// attrListID returns the attribute list ID of the given attribute group IDs,
+// where 0 denotes an empty attribute list.
+func (e *encoder) attrListID(groups []uint64) uint64 {
+	if len(groups) == 0 {
+		return 0
+	}
+	key := fmt.Sprint(groups)
+	if id, ok := e.attrListIDs[key]; ok {
+		return id
+	}
+	e.attrLists = append(e.attrLists, &bitstream.Record{Code: paramAttrCodeEntry, Ops: groups})
+	id := uint64(len(e.attrLists))
+	e.attrListIDs[key] = id
+	return id
+}
+
+// paramAttrGroupBlock encodes the attribute group block of the module.
+func (e *encoder) paramAttrGroupBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockParamAttrGroup}
+	for _, rec := range e.attrGroups {
+		block.Entries = append(block.Entries, rec)
+	}
+	return block
+}
+
+// paramAttrBlock encodes the attribute list block of the module.
+func (e *encoder) paramAttrBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockParamAttr}
+	for _, rec := range e.attrLists {
+		block.Entries = append(block.Entries, rec)
+	}
+	return block
+}
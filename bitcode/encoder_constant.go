@@ -0,0 +1,544 @@
+package bitcode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/internal/floats"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// allocaNElems is the number of elements of alloca instructions which do not
+// specify the number of elements.
+var allocaNElems = constant.NewInt(1, types.I32)
+
+// isConst reports whether the given value is a module-level value; i.e. a
+// constant, a global value or an inline assembler expression.
+func isConst(v value.Value) bool {
+	switch v.(type) {
+	case constant.Constant, *ir.InlineAsm:
+		return true
+	default:
+		return false
+	}
+}
+
+// enumConst enumerates the given constant, and returns its value ID. The
+// constant operands of the constant are enumerated first, so that operands
+// precede their users where possible.
+func (e *encoder) enumConst(v value.Value) uint64 {
+	if index, ok := v.(*constant.Index); ok {
+		v = index.Constant
+	}
+	if id, ok := e.valueIDs[v]; ok {
+		return id
+	}
+	switch c := v.(type) {
+	case *ir.Global, *ir.Function, *ir.Alias, *ir.IFunc:
+		panic(fmt.Errorf("invalid reference to global value %s; not defined in module", v.Ident()))
+	case *constant.ExprExtractValue, *constant.ExprInsertValue:
+		// Aggregate expressions have no bitcode representation, as LLVM always
+		// folds them; encode the folded constant in their place.
+		folded := c.(constant.Expr).Simplify()
+		if folded == c {
+			panic(fmt.Errorf("unsupported constant expression %s; unable to fold aggregate expression", c.Ident()))
+		}
+		id := e.enumConst(folded)
+		e.valueIDs[v] = id
+		return id
+	}
+	// Simple constants are uniqued by type and value.
+	key, isSimple := e.constKey(v)
+	if isSimple {
+		if id, ok := e.constIDs[key]; ok {
+			e.valueIDs[v] = id
+			return id
+		}
+	}
+	if _, ok := e.dataElems(v); !ok {
+		for _, op := range operands(v) {
+			e.enumConst(op)
+		}
+	}
+	id := e.addValue(v)
+	if isSimple {
+		e.constIDs[key] = id
+	}
+	return id
+}
+
+// constKey returns a key uniquely identifying the given simple constant, and a
+// boolean indicating if the constant is a simple constant.
+func (e *encoder) constKey(v value.Value) (string, bool) {
+	switch v.(type) {
+	case *constant.Int, *constant.Float, *constant.Null, *constant.Undef, *constant.ZeroInitializer, *constant.NoneToken:
+		return fmt.Sprintf("%d %s", e.typeID(v.Type()), v.Ident()), true
+	default:
+		return "", false
+	}
+}
+
+// valueID returns the value ID of the given module-level value.
+func (e *encoder) valueID(v value.Value) uint64 {
+	if index, ok := v.(*constant.Index); ok {
+		v = index.Constant
+	}
+	id, ok := e.valueIDs[v]
+	if !ok {
+		panic(fmt.Errorf("invalid value %s; no module-level value ID", v.Ident()))
+	}
+	return id
+}
+
+// constantsBlock encodes the module-level constants block, or returns nil if
+// the module has no constants.
+func (e *encoder) constantsBlock() *bitstream.Block {
+	start := len(e.m.Globals) + len(e.m.Funcs) + len(e.m.Aliases) + len(e.m.IFuncs)
+	if start == len(e.values) {
+		return nil
+	}
+	block := &bitstream.Block{ID: blockConstants}
+	var (
+		typeID  uint64
+		hasType bool
+	)
+	for _, v := range e.values[start:] {
+		if id := e.typeID(v.Type()); !hasType || id != typeID {
+			typeID, hasType = id, true
+			block.Entries = append(block.Entries, &bitstream.Record{Code: cstCodeSetType, Ops: []uint64{id}})
+		}
+		block.Entries = append(block.Entries, e.constRecord(v))
+	}
+	return block
+}
+
+// constRecord encodes the given constant.
+func (e *encoder) constRecord(v value.Value) *bitstream.Record {
+	rec := func(code uint64, ops ...uint64) *bitstream.Record {
+		return &bitstream.Record{Code: code, Ops: ops}
+	}
+	if code, ops, ok := e.dataRecord(v); ok {
+		return rec(code, ops...)
+	}
+	switch c := v.(type) {
+	// Simple constants.
+	case *constant.Null, *constant.ZeroInitializer, *constant.NoneToken:
+		return rec(cstCodeNull)
+	case *constant.Undef:
+		return rec(cstCodeUndef)
+	case *constant.Int:
+		return intRecord(c)
+	case *constant.Float:
+		return rec(cstCodeFloat, floatOps(c)...)
+	// Complex constants; empty aggregates are represented as null values.
+	case *constant.Array:
+		if len(c.Elems) == 0 {
+			return rec(cstCodeNull)
+		}
+		return rec(cstCodeAggregate, e.constValueIDs(c.Elems)...)
+	case *constant.Vector:
+		return rec(cstCodeAggregate, e.constValueIDs(c.Elems)...)
+	case *constant.Struct:
+		if len(c.Fields) == 0 {
+			return rec(cstCodeNull)
+		}
+		return rec(cstCodeAggregate, e.constValueIDs(c.Fields)...)
+	// Constant expressions.
+	case *constant.ExprGetElementPtr:
+		return e.constGEPRecord(c)
+	case *constant.ExprSelect:
+		// [cond, true value, false value]
+		return rec(cstCodeCESelect, e.valueID(c.Cond), e.valueID(c.X), e.valueID(c.Y))
+	case *constant.ExprExtractElement:
+		// [vector type, vector, index type, index]
+		return rec(cstCodeCEExtractElt, e.typeID(c.X.Type()), e.valueID(c.X), e.typeID(c.Index.Type()), e.valueID(c.Index))
+	case *constant.ExprInsertElement:
+		// [vector, element, index type, index]
+		return rec(cstCodeCEInsertElt, e.valueID(c.X), e.valueID(c.Elem), e.typeID(c.Index.Type()), e.valueID(c.Index))
+	case *constant.ExprShuffleVector:
+		if types.Equal(c.Type(), c.X.Type()) {
+			// [vector 1, vector 2, mask]
+			return rec(cstCodeCEShuffleVec, e.valueID(c.X), e.valueID(c.Y), e.valueID(c.Mask))
+		}
+		// [operand type, vector 1, vector 2, mask]
+		return rec(cstCodeCEShufVecEx, e.typeID(c.X.Type()), e.valueID(c.X), e.valueID(c.Y), e.valueID(c.Mask))
+	case *constant.ExprICmp:
+		// [operand type, lhs, rhs, predicate]
+		return rec(cstCodeCECmp, e.typeID(c.X.Type()), e.valueID(c.X), e.valueID(c.Y), intPredCode(int(c.Pred)))
+	case *constant.ExprFCmp:
+		return rec(cstCodeCECmp, e.typeID(c.X.Type()), e.valueID(c.X), e.valueID(c.Y), floatPredCode(int(c.Pred)))
+	case *constant.BlockAddress:
+		// [function type, function, basic block index]
+		f, ok := c.Func.(*ir.Function)
+		if !ok {
+			panic(fmt.Errorf("invalid block address function type; expected *ir.Function, got %T", c.Func))
+		}
+		return rec(cstCodeBlockAddress, e.typeID(f.Type()), e.valueID(f), blockIndex(f, c.Block))
+	case *ir.InlineAsm:
+		return e.inlineAsmRecord(c)
+	}
+	if opcode, x, y, flags, ok := constBinopCode(v); ok {
+		// [opcode, lhs, rhs, (flags)]
+		ops := []uint64{opcode, e.valueID(x), e.valueID(y)}
+		if flags != 0 {
+			ops = append(ops, flags)
+		}
+		return rec(cstCodeCEBinop, ops...)
+	}
+	if opcode, from, ok := constCastCode(v); ok {
+		// [opcode, operand type, operand]
+		return rec(cstCodeCECast, opcode, e.typeID(from.Type()), e.valueID(from))
+	}
+	panic(fmt.Errorf("support for constant %T not yet implemented", v))
+}
+
+// constValueIDs returns the value IDs of the given module-level constants.
+func (e *encoder) constValueIDs(cs []constant.Constant) []uint64 {
+	var ids []uint64
+	for _, c := range cs {
+		ids = append(ids, e.valueID(c))
+	}
+	return ids
+}
+
+// constGEPRecord encodes the given getelementptr constant expression.
+func (e *encoder) constGEPRecord(c *constant.ExprGetElementPtr) *bitstream.Record {
+	// [source element type, (flags), (type, index)...]
+	code := uint64(cstCodeCEGEP)
+	ops := []uint64{e.typeID(c.Elem)}
+	inRange := -1
+	for i, index := range c.Indices {
+		if index, ok := index.(*constant.Index); ok && index.InRange {
+			inRange = i
+			break
+		}
+	}
+	switch {
+	case inRange != -1:
+		code = cstCodeCEGEPWithInrangeIndex
+		ops = append(ops, uint64(inRange)<<1|boolCode(c.InBounds))
+	case c.InBounds:
+		code = cstCodeCEInboundsGEP
+	}
+	ops = append(ops, e.typeID(c.Src.Type()), e.valueID(c.Src))
+	for _, index := range c.Indices {
+		ops = append(ops, e.typeID(index.Type()), e.valueID(index))
+	}
+	return &bitstream.Record{Code: code, Ops: ops}
+}
+
+// inlineAsmRecord encodes the given inline assembler expression.
+func (e *encoder) inlineAsmRecord(v *ir.InlineAsm) *bitstream.Record {
+	// [function type, flags, asm size, asm chars..., constraint size,
+	// constraint chars...]
+	sig, ok := v.Typ.Elem.(*types.FuncType)
+	if !ok {
+		panic(fmt.Errorf("invalid inline assembler expression type; expected *types.FuncType, got %T", v.Typ.Elem))
+	}
+	flags := boolCode(v.SideEffect) | boolCode(v.AlignStack)<<1 | boolCode(v.IntelDialect)<<2
+	ops := []uint64{e.typeID(sig), flags, uint64(len(v.Asm))}
+	ops = append(ops, stringOps(v.Asm)...)
+	ops = append(ops, uint64(len(v.Constraint)))
+	ops = append(ops, stringOps(v.Constraint)...)
+	return &bitstream.Record{Code: cstCodeInlineAsm, Ops: ops}
+}
+
+// blockIndex returns the index of the given basic block in the given function.
+func blockIndex(f *ir.Function, block value.Named) uint64 {
+	for i, b := range f.Blocks {
+		if b == block {
+			return uint64(i)
+		}
+	}
+	panic(fmt.Errorf("invalid basic block %s; not present in function %s", block.Ident(), f.Ident()))
+}
+
+// === [ Simple constants ] ====================================================
+
+// encodeSigned encodes the given signed value as a sign rotated value, which
+// stores the sign in the least significant bit.
+func encodeSigned(v int64) uint64 {
+	if v >= 0 {
+		return uint64(v) << 1
+	}
+	if v != math.MinInt64 {
+		return uint64(-v)<<1 | 1
+	}
+	// There is no such thing as -0 with integers; "-0" encodes MinInt64.
+	return 1
+}
+
+// intRecord encodes the given integer constant.
+func intRecord(c *constant.Int) *bitstream.Record {
+	// Two's complement bit pattern of the integer, truncated to the bit width
+	// of its type.
+	bits := uint(c.Typ.Size)
+	mod := new(big.Int).Lsh(big.NewInt(1), bits)
+	x := new(big.Int).Mod(c.X, mod)
+	if bits <= 64 {
+		// [signed value]
+		v := x.Uint64()
+		if bits < 64 && bits > 0 && v>>(bits-1)&1 == 1 {
+			// Sign extend.
+			v |= math.MaxUint64 << bits
+		}
+		return &bitstream.Record{Code: cstCodeInteger, Ops: []uint64{encodeSigned(int64(v))}}
+	}
+	// [signed words...], least significant word first.
+	var ops []uint64
+	word := new(big.Int)
+	mask := new(big.Int).SetUint64(math.MaxUint64)
+	for i := uint(0); i < bits; i += 64 {
+		word.Rsh(x, i).And(word, mask)
+		ops = append(ops, encodeSigned(int64(word.Uint64())))
+	}
+	return &bitstream.Record{Code: cstCodeWideInteger, Ops: ops}
+}
+
+// floatOps returns the bit pattern words of the given floating-point constant.
+func floatOps(c *constant.Float) []uint64 {
+	switch c.Typ.Kind {
+	case types.FloatKindIEEE_16:
+		if c.NaN {
+			return []uint64{0x7E00}
+		}
+		x, _ := c.X.Float64()
+		f, _ := floats.NewFloat16FromFloat64(x)
+		return []uint64{uint64(f.Bits())}
+	case types.FloatKindIEEE_32:
+		if c.NaN {
+			return []uint64{0x7FC00000}
+		}
+		x, _ := c.X.Float32()
+		return []uint64{uint64(math.Float32bits(x))}
+	case types.FloatKindIEEE_64:
+		if c.NaN {
+			return []uint64{0x7FF8000000000000}
+		}
+		x, _ := c.X.Float64()
+		return []uint64{math.Float64bits(x)}
+	case types.FloatKindDoubleExtended_80:
+		// [sign and exponent << 48 | mantissa >> 16, mantissa & 0xFFFF]
+		se, m := uint16(0x7FFF), uint64(0xC000000000000000)
+		if !c.NaN {
+			se, m = floats.NewFloat80FromBig(c.X).Bits()
+		}
+		return []uint64{uint64(se)<<48 | m>>16, m & 0xFFFF}
+	case types.FloatKindIEEE_128:
+		// [low word, high word]
+		hi, lo := uint64(0x7FFF800000000000), uint64(0)
+		if !c.NaN {
+			hi, lo = floats.NewFloat128FromBig(c.X).Bits()
+		}
+		return []uint64{lo, hi}
+	case types.FloatKindDoubleDouble_128:
+		// [high-order double, low-order double]
+		hi, lo := uint64(0x7FF8000000000000), uint64(0)
+		if !c.NaN {
+			hi, lo = floats.NewFloat128PPCFromBig(c.X).Bits()
+		}
+		return []uint64{hi, lo}
+	default:
+		panic(fmt.Errorf("support for floating-point kind %v not yet implemented", c.Typ.Kind))
+	}
+}
+
+// dataElems returns the elements of the given array or vector constant, and a
+// boolean indicating if the constant is encoded as a data sequence; i.e. a
+// character array or a sequence of simple integer or floating-point elements.
+func (e *encoder) dataElems(v value.Value) ([]constant.Constant, bool) {
+	var (
+		elemType types.Type
+		elems    []constant.Constant
+	)
+	switch c := v.(type) {
+	case *constant.Array:
+		elemType, elems = c.Typ.Elem, c.Elems
+	case *constant.Vector:
+		elemType, elems = c.Typ.Elem, c.Elems
+	default:
+		return nil, false
+	}
+	if len(elems) == 0 {
+		return nil, false
+	}
+	switch t := elemType.(type) {
+	case *types.IntType:
+		switch t.Size {
+		case 8, 16, 32, 64:
+		default:
+			return nil, false
+		}
+		for _, elem := range elems {
+			if _, ok := elem.(*constant.Int); !ok {
+				return nil, false
+			}
+		}
+	case *types.FloatType:
+		switch t.Kind {
+		case types.FloatKindIEEE_16, types.FloatKindIEEE_32, types.FloatKindIEEE_64:
+		default:
+			return nil, false
+		}
+		for _, elem := range elems {
+			if _, ok := elem.(*constant.Float); !ok {
+				return nil, false
+			}
+		}
+	default:
+		return nil, false
+	}
+	return elems, true
+}
+
+// dataRecord encodes the given array or vector constant as a data sequence,
+// and reports whether the constant is encoded as a data sequence.
+func (e *encoder) dataRecord(v value.Value) (code uint64, ops []uint64, ok bool) {
+	elems, ok := e.dataElems(v)
+	if !ok {
+		return 0, nil, false
+	}
+	for _, elem := range elems {
+		switch elem := elem.(type) {
+		case *constant.Int:
+			// Zero extended element value.
+			x := new(big.Int).Mod(elem.X, new(big.Int).Lsh(big.NewInt(1), uint(elem.Typ.Size)))
+			ops = append(ops, x.Uint64())
+		case *constant.Float:
+			ops = append(ops, floatOps(elem)[0])
+		}
+	}
+	if t, ok := v.Type().(*types.ArrayType); ok && types.Equal(t.Elem, types.I8) {
+		// Character arrays; C strings are null-terminated and contain no
+		// other null characters.
+		n := len(ops)
+		for i, op := range ops {
+			if op == 0 && i != n-1 {
+				return cstCodeString, ops, true
+			}
+		}
+		if ops[n-1] == 0 {
+			return cstCodeCString, ops[:n-1], true
+		}
+		return cstCodeString, ops, true
+	}
+	return cstCodeData, ops, true
+}
+
+// === [ Constant expressions ] ================================================
+
+// constBinopCode returns the opcode, operands and flags of the given binary
+// constant expression, and a boolean indicating if the constant is a binary
+// constant expression.
+func constBinopCode(v value.Value) (opcode uint64, x, y constant.Constant, flags uint64, ok bool) {
+	switch c := v.(type) {
+	case *constant.ExprAdd:
+		return binopAdd, c.X, c.Y, constOverflowCode(c.OverflowFlags), true
+	case *constant.ExprFAdd:
+		return binopAdd, c.X, c.Y, 0, true
+	case *constant.ExprSub:
+		return binopSub, c.X, c.Y, constOverflowCode(c.OverflowFlags), true
+	case *constant.ExprFSub:
+		return binopSub, c.X, c.Y, 0, true
+	case *constant.ExprMul:
+		return binopMul, c.X, c.Y, constOverflowCode(c.OverflowFlags), true
+	case *constant.ExprFMul:
+		return binopMul, c.X, c.Y, 0, true
+	case *constant.ExprUDiv:
+		return binopUDiv, c.X, c.Y, boolCode(c.Exact), true
+	case *constant.ExprSDiv:
+		return binopSDiv, c.X, c.Y, boolCode(c.Exact), true
+	case *constant.ExprFDiv:
+		return binopSDiv, c.X, c.Y, 0, true
+	case *constant.ExprURem:
+		return binopURem, c.X, c.Y, 0, true
+	case *constant.ExprSRem:
+		return binopSRem, c.X, c.Y, 0, true
+	case *constant.ExprFRem:
+		return binopSRem, c.X, c.Y, 0, true
+	case *constant.ExprShl:
+		return binopShl, c.X, c.Y, constOverflowCode(c.OverflowFlags), true
+	case *constant.ExprLShr:
+		return binopLShr, c.X, c.Y, boolCode(c.Exact), true
+	case *constant.ExprAShr:
+		return binopAShr, c.X, c.Y, boolCode(c.Exact), true
+	case *constant.ExprAnd:
+		return binopAnd, c.X, c.Y, 0, true
+	case *constant.ExprOr:
+		return binopOr, c.X, c.Y, 0, true
+	case *constant.ExprXor:
+		return binopXor, c.X, c.Y, 0, true
+	default:
+		return 0, nil, nil, 0, false
+	}
+}
+
+// constOverflowCode returns the encoding of the given overflow flags.
+func constOverflowCode(flags []constant.OverflowFlag) uint64 {
+	var code uint64
+	for _, flag := range flags {
+		switch flag {
+		case constant.OverflowFlagNUW:
+			code |= overflowNUW
+		case constant.OverflowFlagNSW:
+			code |= overflowNSW
+		}
+	}
+	return code
+}
+
+// constCastCode returns the opcode and operand of the given conversion
+// constant expression, and a boolean indicating if the constant is a
+// conversion constant expression.
+func constCastCode(v value.Value) (opcode uint64, from constant.Constant, ok bool) {
+	switch c := v.(type) {
+	case *constant.ExprTrunc:
+		return castTrunc, c.From, true
+	case *constant.ExprZExt:
+		return castZExt, c.From, true
+	case *constant.ExprSExt:
+		return castSExt, c.From, true
+	case *constant.ExprFPToUI:
+		return castFPToUI, c.From, true
+	case *constant.ExprFPToSI:
+		return castFPToSI, c.From, true
+	case *constant.ExprUIToFP:
+		return castUIToFP, c.From, true
+	case *constant.ExprSIToFP:
+		return castSIToFP, c.From, true
+	case *constant.ExprFPTrunc:
+		return castFPTrunc, c.From, true
+	case *constant.ExprFPExt:
+		return castFPExt, c.From, true
+	case *constant.ExprPtrToInt:
+		return castPtrToInt, c.From, true
+	case *constant.ExprIntToPtr:
+		return castIntToPtr, c.From, true
+	case *constant.ExprBitCast:
+		return castBitCast, c.From, true
+	case *constant.ExprAddrSpaceCast:
+		return castAddrSpaceCast, c.From, true
+	default:
+		return 0, nil, false
+	}
+}
+
+// intPredCode returns the encoding of the given integer predicate.
+func intPredCode(pred int) uint64 {
+	return uint64(pred-1) + predFirstInt
+}
+
+// floatPredCode returns the encoding of the given floating-point predicate.
+func floatPredCode(pred int) uint64 {
+	for code, p := range floatPreds {
+		if int(p) == pred {
+			return uint64(code) + predFirstFloat
+		}
+	}
+	panic(fmt.Errorf("support for floating-point predicate %d not yet implemented", pred))
+}
@@ -0,0 +1,698 @@
+package bitcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// functionBlock encodes the function block of the given function definition.
+func (e *encoder) functionBlock(f *ir.Function) *bitstream.Block {
+	e.f = f
+	e.localIDs = make(map[value.Value]uint64)
+	e.blockIndices = make(map[*ir.BasicBlock]uint64)
+	e.localMDs = nil
+	e.localMDIndices = make(map[uint64]uint64)
+	defer func() {
+		e.f = nil
+	}()
+	// Assign value IDs to the parameters and instructions of the function.
+	id := uint64(len(e.values))
+	for _, param := range f.Params() {
+		e.localIDs[param] = id
+		id++
+	}
+	for i, b := range f.Blocks {
+		e.blockIndices[b] = uint64(i)
+		for _, inst := range blockInsts(b) {
+			if v, ok := inst.(value.Value); ok && !types.Equal(v.Type(), types.Void) {
+				e.localIDs[v] = id
+				id++
+			}
+		}
+	}
+	// Function-local metadata values, as used by call arguments.
+	for _, b := range f.Blocks {
+		for _, inst := range blockInsts(b) {
+			for _, op := range operands(inst) {
+				if arg, ok := op.(*ir.Arg); ok {
+					op = arg.Value
+				}
+				if md, ok := op.(*metadata.Value); ok && !isConst(md.X) {
+					id := e.localID(md.X)
+					if _, ok := e.localMDIndices[id]; !ok {
+						e.localMDIndices[id] = uint64(len(e.localMDs))
+						e.localMDs = append(e.localMDs, md)
+					}
+				}
+			}
+		}
+	}
+	block := &bitstream.Block{ID: blockFunction}
+	add := func(entries ...bitstream.Entry) {
+		block.Entries = append(block.Entries, entries...)
+	}
+	// [n]
+	add(&bitstream.Record{Code: funcCodeDeclareBlocks, Ops: []uint64{uint64(len(f.Blocks))}})
+	if mds := e.funcMetadataBlock(); mds != nil {
+		add(mds)
+	}
+	e.nextValueID = uint64(len(e.values) + len(f.Params()))
+	for _, b := range f.Blocks {
+		for _, inst := range blockInsts(b) {
+			for _, rec := range e.instRecords(inst) {
+				add(rec)
+			}
+			if v, ok := inst.(value.Value); ok && !types.Equal(v.Type(), types.Void) {
+				e.nextValueID++
+			}
+		}
+	}
+	if vst := e.funcValueSymtabBlock(); vst != nil {
+		add(vst)
+	}
+	if attachments := e.metadataAttachmentBlock(); attachments != nil {
+		add(attachments)
+	}
+	return block
+}
+
+// blockInsts returns the instructions of the given basic block, followed by
+// its terminator.
+func blockInsts(b *ir.BasicBlock) []ir.Instruction {
+	insts := make([]ir.Instruction, 0, len(b.Insts)+1)
+	insts = append(insts, b.Insts...)
+	return append(insts, b.Term)
+}
+
+// funcValueSymtabBlock encodes the function-level value symbol table of the
+// function being encoded, or returns nil if the function has no named local
+// values.
+func (e *encoder) funcValueSymtabBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockValueSymtab}
+	add := func(code, id uint64, name string) {
+		// [value ID or basic block index, name chars...]
+		ops := append([]uint64{id}, stringOps(name)...)
+		block.Entries = append(block.Entries, &bitstream.Record{Code: code, Ops: ops})
+	}
+	for _, param := range e.f.Params() {
+		if !isID(param.Name) {
+			add(vstCodeEntry, e.localIDs[param], param.Name)
+		}
+	}
+	for i, b := range e.f.Blocks {
+		if !isID(b.Name) {
+			add(vstCodeBBEntry, uint64(i), b.Name)
+		}
+		for _, inst := range blockInsts(b) {
+			v, ok := inst.(value.Named)
+			if !ok {
+				continue
+			}
+			if id, ok := e.localIDs[v]; ok && !isID(v.GetName()) {
+				add(vstCodeEntry, id, v.GetName())
+			}
+		}
+	}
+	if len(block.Entries) == 0 {
+		return nil
+	}
+	return block
+}
+
+// === [ Values ] ==============================================================
+
+// localID returns the absolute value ID of the given value in the function
+// being encoded.
+func (e *encoder) localID(v value.Value) uint64 {
+	if arg, ok := v.(*ir.Arg); ok {
+		v = arg.Value
+	}
+	if isConst(v) {
+		return e.valueID(v)
+	}
+	id, ok := e.localIDs[v]
+	if !ok {
+		panic(fmt.Errorf("invalid value %s in function %s; no value ID", v.Ident(), e.f.Ident()))
+	}
+	return id
+}
+
+// appendRel appends the value ID of the given value relative to the next
+// value ID to ops. Forward references wrap around as 32-bit values.
+func (e *encoder) appendRel(ops []uint64, v value.Value) []uint64 {
+	if arg, ok := v.(*ir.Arg); ok {
+		v = arg.Value
+	}
+	if _, ok := v.Type().(*types.MetadataType); ok {
+		// Metadata operands are identified by metadata ID.
+		node, ok := v.(metadata.Node)
+		if !ok {
+			panic(fmt.Errorf("invalid metadata operand type; expected metadata.Node, got %T", v))
+		}
+		return append(ops, uint64(uint32(e.nextValueID-e.metadataID(node))))
+	}
+	return append(ops, uint64(uint32(e.nextValueID-e.localID(v))))
+}
+
+// appendTyped appends the relative value ID of the given value to ops,
+// followed by its type ID if the value is forward referenced.
+func (e *encoder) appendTyped(ops []uint64, v value.Value) []uint64 {
+	id := e.localID(v)
+	ops = append(ops, uint64(uint32(e.nextValueID-id)))
+	if id >= e.nextValueID {
+		ops = append(ops, e.typeID(v.Type()))
+	}
+	return ops
+}
+
+// appendSignedRel appends the value ID of the given value relative to the next
+// value ID to ops, as a sign rotated value.
+func (e *encoder) appendSignedRel(ops []uint64, v value.Value) []uint64 {
+	return append(ops, encodeSigned(int64(e.nextValueID)-int64(e.localID(v))))
+}
+
+// blockIndex returns the index of the given basic block in the function being
+// encoded.
+func (e *encoder) blockIndex(b *ir.BasicBlock) uint64 {
+	index, ok := e.blockIndices[b]
+	if !ok {
+		panic(fmt.Errorf("invalid basic block %s; not present in function %s", b.Ident(), e.f.Ident()))
+	}
+	return index
+}
+
+// === [ Instructions ] ========================================================
+
+// instRecords encodes the given instruction, returning its record preceded by
+// the records of its operand bundles.
+func (e *encoder) instRecords(inst ir.Instruction) []*bitstream.Record {
+	rec := func(code uint64, ops ...uint64) []*bitstream.Record {
+		return []*bitstream.Record{{Code: code, Ops: ops}}
+	}
+	if code, ops, ok := e.binopRecord(inst); ok {
+		return rec(code, ops...)
+	}
+	if code, ops, ok := e.castRecord(inst); ok {
+		return rec(code, ops...)
+	}
+	switch inst := inst.(type) {
+	// Vector instructions.
+	case *ir.InstExtractElement:
+		// [vector (typed), index (typed)]
+		ops := e.appendTyped(nil, inst.X)
+		return rec(funcCodeInstExtractElt, e.appendTyped(ops, inst.Index)...)
+	case *ir.InstInsertElement:
+		// [vector (typed), element, index (typed)]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendRel(ops, inst.Elem)
+		return rec(funcCodeInstInsertElt, e.appendTyped(ops, inst.Index)...)
+	case *ir.InstShuffleVector:
+		// [x (typed), y, mask (typed)]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendRel(ops, inst.Y)
+		return rec(funcCodeInstShuffleVec, e.appendTyped(ops, inst.Mask)...)
+	// Aggregate instructions.
+	case *ir.InstExtractValue:
+		// [aggregate (typed), indices...]
+		ops := e.appendTyped(nil, inst.X)
+		return rec(funcCodeInstExtractVal, appendIndices(ops, inst.Indices)...)
+	case *ir.InstInsertValue:
+		// [aggregate (typed), element (typed), indices...]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendTyped(ops, inst.Elem)
+		return rec(funcCodeInstInsertVal, appendIndices(ops, inst.Indices)...)
+	// Memory instructions.
+	case *ir.InstAlloca:
+		return rec(funcCodeInstAlloca, e.allocaOps(inst)...)
+	case *ir.InstLoad:
+		// [source (typed), element type, alignment, volatile, (ordering,
+		// synchronization scope)]
+		ops := e.appendTyped(nil, inst.Src)
+		ops = append(ops, e.typeID(inst.Typ), alignmentCode(inst.Align), boolCode(inst.Volatile))
+		if inst.Ordering != ir.AtomicOrderingNone {
+			ops = append(ops, uint64(inst.Ordering), e.syncScopeID(inst.SyncScope))
+			return rec(funcCodeInstLoadAtomic, ops...)
+		}
+		return rec(funcCodeInstLoad, ops...)
+	case *ir.InstStore:
+		// [destination (typed), source (typed), alignment, volatile,
+		// (ordering, synchronization scope)]
+		ops := e.appendTyped(nil, inst.Dst)
+		ops = e.appendTyped(ops, inst.Src)
+		ops = append(ops, alignmentCode(inst.Align), boolCode(inst.Volatile))
+		if inst.Ordering != ir.AtomicOrderingNone {
+			ops = append(ops, uint64(inst.Ordering), e.syncScopeID(inst.SyncScope))
+			return rec(funcCodeInstStoreAtomic, ops...)
+		}
+		return rec(funcCodeInstStore, ops...)
+	case *ir.InstFence:
+		// [ordering, synchronization scope]
+		return rec(funcCodeInstFence, uint64(inst.Ordering), e.syncScopeID(inst.SyncScope))
+	case *ir.InstCmpXchg:
+		// [pointer (typed), cmp (typed), new, volatile, success ordering,
+		// synchronization scope, failure ordering, weak, (alignment)]
+		ops := e.appendTyped(nil, inst.Ptr)
+		ops = e.appendTyped(ops, inst.Cmp)
+		ops = e.appendRel(ops, inst.New)
+		ops = append(ops, boolCode(inst.Volatile), uint64(inst.SuccessOrdering), e.syncScopeID(inst.SyncScope), uint64(inst.FailureOrdering), boolCode(inst.Weak))
+		if inst.Align != 0 {
+			ops = append(ops, alignmentCode(inst.Align))
+		}
+		return rec(funcCodeInstCmpXchg, ops...)
+	case *ir.InstAtomicRMW:
+		// [pointer (typed), value (typed), operation, volatile, ordering,
+		// synchronization scope, (alignment)]
+		ops := e.appendTyped(nil, inst.Dst)
+		ops = e.appendTyped(ops, inst.X)
+		ops = append(ops, uint64(inst.Op)-1, boolCode(inst.Volatile), uint64(inst.Ordering), e.syncScopeID(inst.SyncScope))
+		if inst.Align != 0 {
+			ops = append(ops, alignmentCode(inst.Align))
+		}
+		return rec(funcCodeInstAtomicRMW, ops...)
+	case *ir.InstGetElementPtr:
+		// [inbounds, element type, source (typed), indices (typed)...]
+		ops := []uint64{boolCode(inst.InBounds), e.typeID(inst.Elem)}
+		ops = e.appendTyped(ops, inst.Src)
+		for _, index := range inst.Indices {
+			ops = e.appendTyped(ops, index)
+		}
+		return rec(funcCodeInstGEP, ops...)
+	// Other instructions.
+	case *ir.InstICmp:
+		// [x (typed), y, predicate]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendRel(ops, inst.Y)
+		return rec(funcCodeInstCmp2, append(ops, intPredCode(int(inst.Pred)))...)
+	case *ir.InstFCmp:
+		// [x (typed), y, predicate, (fast-math flags)]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendRel(ops, inst.Y)
+		ops = append(ops, floatPredCode(int(inst.Pred)))
+		if fmf := fastMathCode(inst.FastMathFlags); fmf != 0 {
+			ops = append(ops, fmf)
+		}
+		return rec(funcCodeInstCmp2, ops...)
+	case *ir.InstPhi:
+		// [type, n x (value, predecessor), (fast-math flags)]
+		ops := []uint64{e.typeID(inst.Typ)}
+		for _, inc := range inst.Incs {
+			ops = e.appendSignedRel(ops, inc.X)
+			ops = append(ops, e.blockIndex(inc.Pred))
+		}
+		if fmf := fastMathCode(inst.FastMathFlags); fmf != 0 {
+			ops = append(ops, fmf)
+		}
+		return rec(funcCodeInstPhi, ops...)
+	case *ir.InstSelect:
+		// [x (typed), y, cond (typed)]
+		ops := e.appendTyped(nil, inst.X)
+		ops = e.appendRel(ops, inst.Y)
+		return rec(funcCodeInstVSelect, e.appendTyped(ops, inst.Cond)...)
+	case *ir.InstCall:
+		return append(e.bundleRecords(inst.OperandBundles), e.callRecord(inst))
+	case *ir.InstVAArg:
+		// [argument list type, argument list, argument type]
+		ops := []uint64{e.typeID(inst.ArgList.Type())}
+		ops = e.appendRel(ops, inst.ArgList)
+		return rec(funcCodeInstVAArg, append(ops, e.typeID(inst.ArgType))...)
+	case *ir.InstLandingPad:
+		// [type, cleanup, n, n x (clause kind, value (typed))]
+		ops := []uint64{e.typeID(inst.Typ), boolCode(inst.Cleanup), uint64(len(inst.Clauses))}
+		for _, clause := range inst.Clauses {
+			ops = append(ops, uint64(clause.Kind)-1)
+			ops = e.appendTyped(ops, clause.X)
+		}
+		return rec(funcCodeInstLandingPad, ops...)
+	case *ir.InstCatchPad:
+		// [parent pad, n, args (typed)...]
+		return rec(funcCodeInstCatchPad, e.padOps(inst.Within, inst.Args)...)
+	case *ir.InstCleanupPad:
+		return rec(funcCodeInstCleanupPad, e.padOps(inst.Within, inst.Args)...)
+	// Terminators.
+	case *ir.TermRet:
+		// [(value (typed))]
+		if inst.X == nil {
+			return rec(funcCodeInstRet)
+		}
+		return rec(funcCodeInstRet, e.appendTyped(nil, inst.X)...)
+	case *ir.TermBr:
+		// [target]
+		return rec(funcCodeInstBr, e.blockIndex(inst.Target))
+	case *ir.TermCondBr:
+		// [true target, false target, cond]
+		ops := []uint64{e.blockIndex(inst.TargetTrue), e.blockIndex(inst.TargetFalse)}
+		return rec(funcCodeInstBr, e.appendRel(ops, inst.Cond)...)
+	case *ir.TermSwitch:
+		// [type, cond, default target, n x (case value ID, target)]
+		ops := []uint64{e.typeID(inst.X.Type())}
+		ops = e.appendRel(ops, inst.X)
+		ops = append(ops, e.blockIndex(inst.TargetDefault))
+		for _, c := range inst.Cases {
+			ops = append(ops, e.valueID(c.X), e.blockIndex(c.Target))
+		}
+		return rec(funcCodeInstSwitch, ops...)
+	case *ir.TermIndirectBr:
+		// [type, address, targets...]
+		ops := []uint64{e.typeID(inst.Addr.Type())}
+		ops = e.appendRel(ops, inst.Addr)
+		for _, target := range inst.ValidTargets {
+			ops = append(ops, e.blockIndex(target))
+		}
+		return rec(funcCodeInstIndirectBr, ops...)
+	case *ir.TermInvoke:
+		return []*bitstream.Record{e.invokeRecord(inst)}
+	case *ir.TermResume:
+		// [value (typed)]
+		return rec(funcCodeInstResume, e.appendTyped(nil, inst.X)...)
+	case *ir.TermUnreachable:
+		return rec(funcCodeInstUnreachable)
+	case *ir.TermCatchSwitch:
+		// [parent pad, n, handlers..., (unwind target)]
+		ops := e.appendRel(nil, inst.Within)
+		ops = append(ops, uint64(len(inst.Handlers)))
+		for _, handler := range inst.Handlers {
+			ops = append(ops, e.blockIndex(handler))
+		}
+		if inst.Unwind != nil {
+			ops = append(ops, e.blockIndex(inst.Unwind))
+		}
+		return rec(funcCodeInstCatchSwitch, ops...)
+	case *ir.TermCatchRet:
+		// [from, target]
+		ops := e.appendRel(nil, inst.From)
+		return rec(funcCodeInstCatchRet, append(ops, e.blockIndex(inst.To))...)
+	case *ir.TermCleanupRet:
+		// [from, (unwind target)]
+		ops := e.appendRel(nil, inst.From)
+		if inst.Unwind != nil {
+			ops = append(ops, e.blockIndex(inst.Unwind))
+		}
+		return rec(funcCodeInstCleanupRet, ops...)
+	default:
+		panic(fmt.Errorf("support for instruction %T not yet implemented", inst))
+	}
+}
+
+// binopRecord encodes the given binary instruction, and reports whether the
+// instruction is a binary instruction.
+func (e *encoder) binopRecord(inst ir.Instruction) (code uint64, ops []uint64, ok bool) {
+	var (
+		opcode uint64
+		x, y   value.Value
+		flags  uint64
+	)
+	switch inst := inst.(type) {
+	case *ir.InstAdd:
+		opcode, x, y, flags = binopAdd, inst.X, inst.Y, overflowCode(inst.OverflowFlags)
+	case *ir.InstFAdd:
+		opcode, x, y, flags = binopAdd, inst.X, inst.Y, fastMathCode(inst.FastMathFlags)
+	case *ir.InstSub:
+		opcode, x, y, flags = binopSub, inst.X, inst.Y, overflowCode(inst.OverflowFlags)
+	case *ir.InstFSub:
+		opcode, x, y, flags = binopSub, inst.X, inst.Y, fastMathCode(inst.FastMathFlags)
+	case *ir.InstMul:
+		opcode, x, y, flags = binopMul, inst.X, inst.Y, overflowCode(inst.OverflowFlags)
+	case *ir.InstFMul:
+		opcode, x, y, flags = binopMul, inst.X, inst.Y, fastMathCode(inst.FastMathFlags)
+	case *ir.InstUDiv:
+		opcode, x, y, flags = binopUDiv, inst.X, inst.Y, boolCode(inst.Exact)
+	case *ir.InstSDiv:
+		opcode, x, y, flags = binopSDiv, inst.X, inst.Y, boolCode(inst.Exact)
+	case *ir.InstFDiv:
+		opcode, x, y, flags = binopSDiv, inst.X, inst.Y, fastMathCode(inst.FastMathFlags)
+	case *ir.InstURem:
+		opcode, x, y = binopURem, inst.X, inst.Y
+	case *ir.InstSRem:
+		opcode, x, y = binopSRem, inst.X, inst.Y
+	case *ir.InstFRem:
+		opcode, x, y, flags = binopSRem, inst.X, inst.Y, fastMathCode(inst.FastMathFlags)
+	case *ir.InstShl:
+		opcode, x, y, flags = binopShl, inst.X, inst.Y, overflowCode(inst.OverflowFlags)
+	case *ir.InstLShr:
+		opcode, x, y, flags = binopLShr, inst.X, inst.Y, boolCode(inst.Exact)
+	case *ir.InstAShr:
+		opcode, x, y, flags = binopAShr, inst.X, inst.Y, boolCode(inst.Exact)
+	case *ir.InstAnd:
+		opcode, x, y = binopAnd, inst.X, inst.Y
+	case *ir.InstOr:
+		opcode, x, y = binopOr, inst.X, inst.Y
+	case *ir.InstXor:
+		opcode, x, y = binopXor, inst.X, inst.Y
+	default:
+		return 0, nil, false
+	}
+	// [x (typed), y, opcode, (flags)]
+	ops = e.appendTyped(nil, x)
+	ops = e.appendRel(ops, y)
+	ops = append(ops, opcode)
+	if flags != 0 {
+		ops = append(ops, flags)
+	}
+	return funcCodeInstBinop, ops, true
+}
+
+// castRecord encodes the given conversion instruction, and reports whether the
+// instruction is a conversion instruction.
+func (e *encoder) castRecord(inst ir.Instruction) (code uint64, ops []uint64, ok bool) {
+	var (
+		opcode uint64
+		from   value.Value
+		to     types.Type
+	)
+	switch inst := inst.(type) {
+	case *ir.InstTrunc:
+		opcode, from, to = castTrunc, inst.From, inst.To
+	case *ir.InstZExt:
+		opcode, from, to = castZExt, inst.From, inst.To
+	case *ir.InstSExt:
+		opcode, from, to = castSExt, inst.From, inst.To
+	case *ir.InstFPTrunc:
+		opcode, from, to = castFPTrunc, inst.From, inst.To
+	case *ir.InstFPExt:
+		opcode, from, to = castFPExt, inst.From, inst.To
+	case *ir.InstFPToUI:
+		opcode, from, to = castFPToUI, inst.From, inst.To
+	case *ir.InstFPToSI:
+		opcode, from, to = castFPToSI, inst.From, inst.To
+	case *ir.InstUIToFP:
+		opcode, from, to = castUIToFP, inst.From, inst.To
+	case *ir.InstSIToFP:
+		opcode, from, to = castSIToFP, inst.From, inst.To
+	case *ir.InstPtrToInt:
+		opcode, from, to = castPtrToInt, inst.From, inst.To
+	case *ir.InstIntToPtr:
+		opcode, from, to = castIntToPtr, inst.From, inst.To
+	case *ir.InstBitCast:
+		opcode, from, to = castBitCast, inst.From, inst.To
+	case *ir.InstAddrSpaceCast:
+		opcode, from, to = castAddrSpaceCast, inst.From, inst.To
+	default:
+		return 0, nil, false
+	}
+	// [from (typed), to type, opcode]
+	ops = e.appendTyped(nil, from)
+	return funcCodeInstCast, append(ops, e.typeID(to), opcode), true
+}
+
+// allocaOps returns the record operands of the given alloca instruction.
+func (e *encoder) allocaOps(inst *ir.InstAlloca) []uint64 {
+	// [element type, operand type, number of elements, alignment and flags,
+	// (address space)]
+	const explicitTypeMask = 1 << 6
+	nelems := inst.NElems
+	if nelems == nil {
+		nelems = allocaNElems
+	}
+	// Alignment is stored in bits 0-4 and 8-10.
+	align := alignmentCode(inst.Align)
+	flags := align&0x1F | (align>>5)<<8 | explicitTypeMask
+	ops := []uint64{e.typeID(inst.Elem), e.typeID(nelems.Type()), e.localID(nelems), flags}
+	// The address space is omitted if it is the default address space of
+	// alloca instructions specified by the data layout.
+	if addrSpace := uint64(inst.Typ.AddrSpace); addrSpace != allocaAddrSpace(e.m.DataLayout) {
+		ops = append(ops, addrSpace)
+	}
+	return ops
+}
+
+// padOps returns the record operands of a catchpad or cleanuppad instruction
+// with the given parent pad and arguments.
+func (e *encoder) padOps(within value.Value, args []value.Value) []uint64 {
+	// [parent pad, n, args (typed)...]
+	ops := e.appendRel(nil, within)
+	ops = append(ops, uint64(len(args)))
+	for _, arg := range args {
+		ops = e.appendTyped(ops, arg)
+	}
+	return ops
+}
+
+// callRecord encodes the given call instruction.
+func (e *encoder) callRecord(inst *ir.InstCall) *bitstream.Record {
+	// [attributes, calling convention and flags, (fast-math flags), function
+	// type, callee (typed), args...]
+	cc := callConvCode(inst.CallConv)<<callConvShift | callExplicitTypeMask
+	switch inst.Tail {
+	case ir.TailTail:
+		cc |= callTailMask
+	case ir.TailMustTail:
+		cc |= callMustTailMask
+	case ir.TailNoTail:
+		cc |= callNoTailMask
+	}
+	ops := []uint64{e.callAttrList(inst.FuncAttrs, inst.RetAttrs, inst.Args), 0}
+	if fmf := fastMathCode(inst.FastMathFlags); fmf != 0 {
+		cc |= callFMFMask
+		ops = append(ops, fmf)
+	}
+	ops[1] = cc
+	ops = append(ops, e.typeID(inst.Sig))
+	ops = e.appendTyped(ops, inst.Callee)
+	ops = e.appendArgs(ops, inst.Sig, inst.Args)
+	return &bitstream.Record{Code: funcCodeInstCall, Ops: ops}
+}
+
+// invokeRecord encodes the given invoke terminator.
+func (e *encoder) invokeRecord(term *ir.TermInvoke) *bitstream.Record {
+	// [attributes, calling convention and flags, normal target, exception
+	// target, function type, callee (typed), args...]
+	const explicitTypeMask = 1 << 13
+	ops := []uint64{
+		e.callAttrList(nil, nil, term.Args),
+		callConvCode(term.CallConv) | explicitTypeMask,
+		e.blockIndex(term.Normal),
+		e.blockIndex(term.Exception),
+		e.typeID(term.Sig),
+	}
+	ops = e.appendTyped(ops, term.Callee)
+	ops = e.appendArgs(ops, term.Sig, term.Args)
+	return &bitstream.Record{Code: funcCodeInstInvoke, Ops: ops}
+}
+
+// appendArgs appends the given call arguments to ops; the arguments of fixed
+// parameters are stored as relative value IDs, and variadic arguments as typed
+// values.
+func (e *encoder) appendArgs(ops []uint64, sig *types.FuncType, args []value.Value) []uint64 {
+	for i, arg := range args {
+		if i < len(sig.Params) {
+			ops = e.appendRel(ops, arg)
+		} else {
+			ops = e.appendTyped(ops, arg)
+		}
+	}
+	return ops
+}
+
+// bundleRecords encodes the given operand bundles.
+func (e *encoder) bundleRecords(bundles []*ir.OperandBundle) []*bitstream.Record {
+	var recs []*bitstream.Record
+	for _, bundle := range bundles {
+		// [tag, values (typed)...]
+		ops := []uint64{e.bundleTagID(bundle.Tag)}
+		for _, input := range bundle.Inputs {
+			ops = e.appendTyped(ops, input)
+		}
+		recs = append(recs, &bitstream.Record{Code: funcCodeOperandBundle, Ops: ops})
+	}
+	return recs
+}
+
+// bundleTagID returns the ID of the given operand bundle tag, registering the
+// tag on first use.
+func (e *encoder) bundleTagID(tag string) uint64 {
+	if id, ok := e.bundleTagIDs[tag]; ok {
+		return id
+	}
+	id := uint64(len(e.bundleTags))
+	e.bundleTags = append(e.bundleTags, tag)
+	e.bundleTagIDs[tag] = id
+	return id
+}
+
+// syncScopeID returns the ID of the given synchronization scope name, where
+// the default system scope is represented by an empty name. The single thread
+// and system scopes are registered first.
+func (e *encoder) syncScopeID(name string) uint64 {
+	if len(e.syncScopes) == 0 {
+		for _, scope := range []string{"singlethread", ""} {
+			e.syncScopeIDs[scope] = uint64(len(e.syncScopes))
+			e.syncScopes = append(e.syncScopes, scope)
+		}
+	}
+	if id, ok := e.syncScopeIDs[name]; ok {
+		return id
+	}
+	id := uint64(len(e.syncScopes))
+	e.syncScopes = append(e.syncScopes, name)
+	e.syncScopeIDs[name] = id
+	return id
+}
+
+// appendIndices appends the given aggregate indices to ops.
+func appendIndices(ops []uint64, indices []int64) []uint64 {
+	for _, index := range indices {
+		ops = append(ops, uint64(index))
+	}
+	return ops
+}
+
+// allocaAddrSpace returns the default address space of alloca instructions,
+// as specified by the given data layout.
+func allocaAddrSpace(layout string) uint64 {
+	for _, spec := range strings.Split(layout, "-") {
+		if !strings.HasPrefix(spec, "A") {
+			continue
+		}
+		if addrSpace, err := strconv.ParseUint(spec[1:], 10, 32); err == nil {
+			return addrSpace
+		}
+	}
+	return 0
+}
+
+// overflowCode returns the encoding of the given overflow flags.
+func overflowCode(flags []ir.OverflowFlag) uint64 {
+	var code uint64
+	for _, flag := range flags {
+		switch flag {
+		case ir.OverflowFlagNUW:
+			code |= overflowNUW
+		case ir.OverflowFlagNSW:
+			code |= overflowNSW
+		}
+	}
+	return code
+}
+
+// fastMathCode returns the encoding of the given fast-math flags.
+func fastMathCode(flags []ir.FastMathFlag) uint64 {
+	var code uint64
+	for _, flag := range flags {
+		switch flag {
+		case ir.FastMathFlagNNaN:
+			code |= fmfNoNaNs
+		case ir.FastMathFlagNInf:
+			code |= fmfNoInfs
+		case ir.FastMathFlagNSZ:
+			code |= fmfNoSignedZeros
+		case ir.FastMathFlagARCP:
+			code |= fmfAllowReciprocal
+		case ir.FastMathFlagContract:
+			code |= fmfAllowContract
+		case ir.FastMathFlagAFN:
+			code |= fmfApproxFunc
+		case ir.FastMathFlagReassoc:
+			code |= fmfAllowReassoc
+		case ir.FastMathFlagFast:
+			code |= fmfFast
+		}
+	}
+	return code
+}
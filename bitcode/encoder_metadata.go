@@ -0,0 +1,298 @@
+package bitcode
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/value"
+)
+
+// fixedMDKinds specifies the metadata kinds with fixed metadata kind IDs, as
+// registered by LLVM.
+var fixedMDKinds = []string{
+	"dbg",
+	"tbaa",
+	"prof",
+	"fpmath",
+	"range",
+	"tbaa.struct",
+	"invariant.load",
+	"alias.scope",
+	"noalias",
+	"nontemporal",
+	"llvm.mem.parallel_loop_access",
+	"nonnull",
+	"dereferenceable",
+	"dereferenceable_or_null",
+	"make.implicit",
+	"unpredictable",
+	"invariant.group",
+	"align",
+	"llvm.loop",
+	"type",
+	"section_prefix",
+	"absolute_symbol",
+	"associated",
+	"callees",
+	"irr_loop",
+	"llvm.access.group",
+	"callback",
+	"llvm.preserve.access.index",
+	"vcall_visibility",
+	"noundef",
+	"annotation",
+}
+
+// mdKindID returns the metadata kind ID of the given metadata kind name,
+// registering the metadata kind on first use.
+func (e *encoder) mdKindID(kind string) uint64 {
+	if id, ok := e.mdKindIDs[kind]; ok {
+		return id
+	}
+	id := uint64(len(e.mdKinds))
+	e.mdKinds = append(e.mdKinds, kind)
+	e.mdKindIDs[kind] = id
+	return id
+}
+
+// attachmentKinds returns the metadata kinds of the given metadata
+// attachments, ordered by metadata kind ID, and registers the metadata kinds
+// not yet registered. Unregistered metadata kinds are registered in order of
+// the metadata definitions of the module they are attached to.
+func (e *encoder) attachmentKinds(mds map[string]*metadata.Metadata) []string {
+	var kinds []string
+	for kind := range mds {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		a, b := kinds[i], kinds[j]
+		aid, aok := e.mdKindIDs[a]
+		bid, bok := e.mdKindIDs[b]
+		switch {
+		case aok && bok:
+			return aid < bid
+		case aok != bok:
+			return aok
+		}
+		adef, aok := e.mdDefs[mds[a]]
+		bdef, bok := e.mdDefs[mds[b]]
+		switch {
+		case aok && bok && adef != bdef:
+			return adef < bdef
+		case aok != bok:
+			return aok
+		}
+		return a < b
+	})
+	for _, kind := range kinds {
+		e.mdKindID(kind)
+	}
+	return kinds
+}
+
+// enumAttachments enumerates the given metadata attachments.
+func (e *encoder) enumAttachments(mds map[string]*metadata.Metadata) {
+	for _, kind := range e.attachmentKinds(mds) {
+		e.enumMetadata(mds[kind])
+	}
+}
+
+// enumMetadata enumerates the given module-level metadata and its operands.
+func (e *encoder) enumMetadata(node metadata.Node) {
+	switch node := node.(type) {
+	case *metadata.String:
+		if _, ok := e.mdStringIDs[node.Val]; !ok {
+			e.mdStringIDs[node.Val] = uint64(len(e.mdStrings))
+			e.mdStrings = append(e.mdStrings, node.Val)
+		}
+	case *metadata.Metadata:
+		if _, ok := e.mdNodeIndices[node]; ok {
+			return
+		}
+		e.mdNodeIndices[node] = uint64(len(e.mds))
+		e.mds = append(e.mds, node)
+		for _, n := range node.Nodes {
+			e.enumMetadata(n)
+		}
+	case *metadata.Value:
+		e.enumMDValue(node.X)
+	default:
+		e.enumMDValue(node)
+	}
+}
+
+// enumMDValue enumerates the given module-level value used as metadata.
+func (e *encoder) enumMDValue(v value.Value) {
+	if !isConst(v) {
+		panic(fmt.Errorf("support for function-local metadata value %s in metadata node not yet implemented", v.Ident()))
+	}
+	id := e.enumConst(v)
+	if _, ok := e.mdValueIndices[id]; !ok {
+		e.mdValueIndices[id] = uint64(len(e.mds))
+		e.mds = append(e.mds, &metadata.Value{X: v})
+	}
+}
+
+// metadataID returns the metadata ID of the given metadata.
+func (e *encoder) metadataID(node metadata.Node) uint64 {
+	switch node := node.(type) {
+	case *metadata.String:
+		if id, ok := e.mdStringIDs[node.Val]; ok {
+			return id
+		}
+	case *metadata.Metadata:
+		if index, ok := e.mdNodeIndices[node]; ok {
+			return uint64(len(e.mdStrings)) + index
+		}
+	case *metadata.Value:
+		return e.mdValueID(node.X)
+	default:
+		return e.mdValueID(node)
+	}
+	panic(fmt.Errorf("invalid metadata %s; no metadata ID", node.Ident()))
+}
+
+// mdValueID returns the metadata ID of the given value used as metadata.
+func (e *encoder) mdValueID(v value.Value) uint64 {
+	if isConst(v) {
+		if index, ok := e.mdValueIndices[e.valueID(v)]; ok {
+			return uint64(len(e.mdStrings)) + index
+		}
+	} else if e.f != nil {
+		if index, ok := e.localMDIndices[e.localID(v)]; ok {
+			return uint64(len(e.mdStrings)+len(e.mds)) + index
+		}
+	}
+	panic(fmt.Errorf("invalid metadata value %s; no metadata ID", v.Ident()))
+}
+
+// attachmentOps returns the record operands of the given metadata
+// attachments.
+func (e *encoder) attachmentOps(mds map[string]*metadata.Metadata) []uint64 {
+	// [n x (kind ID, metadata ID)]
+	var ops []uint64
+	for _, kind := range e.attachmentKinds(mds) {
+		ops = append(ops, e.mdKindIDs[kind], e.metadataID(mds[kind]))
+	}
+	return ops
+}
+
+// metadataKindBlock encodes the metadata kind block of the module.
+func (e *encoder) metadataKindBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockMetadataKind}
+	for id, kind := range e.mdKinds {
+		// [kind ID, name chars...]
+		ops := append([]uint64{uint64(id)}, stringOps(kind)...)
+		block.Entries = append(block.Entries, &bitstream.Record{Code: metadataCodeKind, Ops: ops})
+	}
+	return block
+}
+
+// metadataBlock encodes the module-level metadata block, or returns nil if the
+// module has no metadata.
+func (e *encoder) metadataBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockMetadata}
+	add := func(code uint64, ops ...uint64) {
+		block.Entries = append(block.Entries, &bitstream.Record{Code: code, Ops: ops})
+	}
+	if len(e.mdStrings) > 0 {
+		// [count, offset] blob([n x vbr6 lengths], [chars...])
+		var (
+			lengths []uint64
+			chars   []byte
+		)
+		for _, s := range e.mdStrings {
+			lengths = append(lengths, uint64(len(s)))
+			chars = append(chars, s...)
+		}
+		blob := bitstream.WriteVBRs(lengths, 6)
+		ops := []uint64{uint64(len(e.mdStrings)), uint64(len(blob))}
+		block.Entries = append(block.Entries, &bitstream.Record{Code: metadataCodeStrings, Ops: ops, Blob: append(blob, chars...)})
+	}
+	for _, md := range e.mds {
+		switch md := md.(type) {
+		case *metadata.Metadata:
+			// [n x (metadata ID + 1)]
+			var ops []uint64
+			for _, node := range md.Nodes {
+				ops = append(ops, e.metadataID(node)+1)
+			}
+			add(metadataCodeNode, ops...)
+		case *metadata.Value:
+			// [type, value ID]
+			add(metadataCodeValue, e.typeID(md.X.Type()), e.valueID(md.X))
+		}
+	}
+	for _, named := range e.m.NamedMetadata {
+		// [name chars...]
+		add(metadataCodeName, stringOps(named.Name)...)
+		// [n x metadata ID]
+		var ops []uint64
+		for _, md := range named.Metadata {
+			ops = append(ops, e.metadataID(md))
+		}
+		add(metadataCodeNamedNode, ops...)
+	}
+	// Metadata attachments of global variables and function declarations;
+	// the attachments of function definitions are stored in their function
+	// blocks.
+	for _, g := range e.m.Globals {
+		if len(g.Metadata) > 0 {
+			// [value ID, n x (kind ID, metadata ID)]
+			add(metadataCodeGlobalDeclAttachment, append([]uint64{e.valueID(g)}, e.attachmentOps(g.Metadata)...)...)
+		}
+	}
+	for _, f := range e.m.Funcs {
+		if len(f.Blocks) == 0 && len(f.Metadata) > 0 {
+			add(metadataCodeGlobalDeclAttachment, append([]uint64{e.valueID(f)}, e.attachmentOps(f.Metadata)...)...)
+		}
+	}
+	if len(block.Entries) == 0 {
+		return nil
+	}
+	return block
+}
+
+// funcMetadataBlock encodes the function-local metadata block of the function
+// being encoded, or returns nil if the function has no function-local
+// metadata.
+func (e *encoder) funcMetadataBlock() *bitstream.Block {
+	if len(e.localMDs) == 0 {
+		return nil
+	}
+	block := &bitstream.Block{ID: blockMetadata}
+	for _, md := range e.localMDs {
+		// [type, value ID]
+		ops := []uint64{e.typeID(md.X.Type()), e.localID(md.X)}
+		block.Entries = append(block.Entries, &bitstream.Record{Code: metadataCodeValue, Ops: ops})
+	}
+	return block
+}
+
+// metadataAttachmentBlock encodes the metadata attachment block of the
+// function being encoded, or returns nil if the function and its instructions
+// have no metadata attachments.
+func (e *encoder) metadataAttachmentBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockMetadataAttach}
+	if len(e.f.Metadata) > 0 {
+		// [n x (kind ID, metadata ID)]
+		block.Entries = append(block.Entries, &bitstream.Record{Code: metadataCodeAttachment, Ops: e.attachmentOps(e.f.Metadata)})
+	}
+	var index uint64
+	for _, b := range e.f.Blocks {
+		for _, inst := range blockInsts(b) {
+			if mds := inst.MDAttachments(); len(mds) > 0 {
+				// [instruction index, n x (kind ID, metadata ID)]
+				ops := append([]uint64{index}, e.attachmentOps(mds)...)
+				block.Entries = append(block.Entries, &bitstream.Record{Code: metadataCodeAttachment, Ops: ops})
+			}
+			index++
+		}
+	}
+	if len(block.Entries) == 0 {
+		return nil
+	}
+	return block
+}
@@ -0,0 +1,128 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir/types"
+)
+
+// typeID returns the type ID of the given type, enumerating the type and its
+// subtypes on first use.
+func (e *encoder) typeID(t types.Type) uint64 {
+	key := typeKey(t)
+	if id, ok := e.typeIDs[key]; ok {
+		return id
+	}
+	s, isIdentified := t.(*types.StructType)
+	isIdentified = isIdentified && s.Identified()
+	if isIdentified {
+		if e.visiting[key] {
+			// Recursive reference to an identified struct type; the type ID is
+			// assigned once its subtypes have been enumerated.
+			return 0
+		}
+		e.visiting[key] = true
+	}
+	// Subtypes precede their parent types, with the exception of identified
+	// struct types which may be forward referenced.
+	for _, sub := range subtypes(t) {
+		e.typeID(sub)
+	}
+	if isIdentified {
+		delete(e.visiting, key)
+	}
+	id := uint64(len(e.types))
+	e.types = append(e.types, t)
+	e.typeIDs[key] = id
+	return id
+}
+
+// typeKey returns a key of the given type, which uniquely identifies
+// structurally equal types; identified struct types are identified by name.
+func typeKey(t types.Type) string {
+	if s, ok := t.(*types.StructType); ok && s.Identified() {
+		return s.String()
+	}
+	return t.Def()
+}
+
+// typeBlock encodes the type table block of the module.
+func (e *encoder) typeBlock() *bitstream.Block {
+	block := &bitstream.Block{ID: blockType}
+	add := func(code uint64, ops ...uint64) {
+		block.Entries = append(block.Entries, &bitstream.Record{Code: code, Ops: ops})
+	}
+	add(typeCodeNumEntry, uint64(len(e.types)))
+	for _, t := range e.types {
+		switch t := t.(type) {
+		case *types.VoidType:
+			add(typeCodeVoid)
+		case *types.FloatType:
+			switch t.Kind {
+			case types.FloatKindIEEE_16:
+				add(typeCodeHalf)
+			case types.FloatKindIEEE_32:
+				add(typeCodeFloat)
+			case types.FloatKindIEEE_64:
+				add(typeCodeDouble)
+			case types.FloatKindDoubleExtended_80:
+				add(typeCodeX86FP80)
+			case types.FloatKindIEEE_128:
+				add(typeCodeFP128)
+			case types.FloatKindDoubleDouble_128:
+				add(typeCodePPCFP128)
+			default:
+				panic(fmt.Errorf("support for floating-point kind %v not yet implemented", t.Kind))
+			}
+		case *types.LabelType:
+			add(typeCodeLabel)
+		case *types.MetadataType:
+			add(typeCodeMetadata)
+		case *types.MMXType:
+			add(typeCodeX86MMX)
+		case *types.TokenType:
+			add(typeCodeToken)
+		case *types.IntType:
+			// [width]
+			add(typeCodeInteger, uint64(t.Size))
+		case *types.PointerType:
+			// [pointee type, address space]
+			add(typeCodePointer, e.typeIDs[typeKey(t.Elem)], uint64(t.AddrSpace))
+		case *types.FuncType:
+			// [vararg, return type, param types...]
+			ops := []uint64{boolCode(t.Variadic), e.typeIDs[typeKey(t.Ret)]}
+			for _, param := range t.Params {
+				ops = append(ops, e.typeIDs[typeKey(param.Typ)])
+			}
+			add(typeCodeFunction, ops...)
+		case *types.ArrayType:
+			// [num elements, element type]
+			add(typeCodeArray, uint64(t.Len), e.typeIDs[typeKey(t.Elem)])
+		case *types.VectorType:
+			// [num elements, element type]
+			add(typeCodeVector, uint64(t.Len), e.typeIDs[typeKey(t.Elem)])
+		case *types.StructType:
+			if t.Identified() && !isID(t.Name) {
+				add(typeCodeStructName, stringOps(t.Name)...)
+			}
+			if t.Opaque {
+				add(typeCodeOpaque, 0)
+				break
+			}
+			// [packed, element types...]
+			ops := []uint64{boolCode(t.Packed)}
+			for _, field := range t.Fields {
+				ops = append(ops, e.typeIDs[typeKey(field)])
+			}
+			if t.Identified() {
+				add(typeCodeStructNamed, ops...)
+			} else {
+				add(typeCodeStructAnon, ops...)
+			}
+		default:
+			panic(fmt.Errorf("support for type %T not yet implemented", t))
+		}
+	}
+	return block
+}
@@ -0,0 +1,975 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/irutil"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// fwdValue is a placeholder of a forward referenced value, which is replaced
+// by the definition of the value once the function has been decoded.
+type fwdValue struct {
+	// Value ID of the forward referenced value.
+	id uint64
+	// Type of the forward referenced value.
+	typ types.Type
+}
+
+// Type returns the type of the forward referenced value.
+func (v *fwdValue) Type() types.Type {
+	return v.typ
+}
+
+// Ident returns the identifier associated with the forward referenced value.
+func (v *fwdValue) Ident() string {
+	return fmt.Sprintf("<forward reference to value ID %d>", v.id)
+}
+
+// functionBlock decodes the given function block, which holds the body of the
+// next function with a body.
+func (d *decoder) functionBlock(block *bitstream.Block) {
+	if len(d.bodies) == 0 {
+		panic(fmt.Errorf("invalid function block; no function body pending"))
+	}
+	f := d.bodies[0]
+	d.bodies = d.bodies[1:]
+	d.f = f
+	d.insts = nil
+	d.fwds = make(map[uint64]*fwdValue)
+	d.cur = 0
+	d.bundles = nil
+	// Function-local values and metadata are discarded after decoding the
+	// function.
+	nvalues, nmds := len(d.values), len(d.mds)
+	for _, param := range f.Params() {
+		d.values = append(d.values, param)
+	}
+	d.nextValueID = uint64(len(d.values))
+	for _, e := range block.Entries {
+		switch e := e.(type) {
+		case *bitstream.Block:
+			switch e.ID {
+			case blockConstants:
+				d.constantsBlock(e)
+				d.nextValueID = uint64(len(d.values))
+			case blockMetadata:
+				d.metadataBlock(e)
+			case blockMetadataAttach:
+				d.metadataAttachmentBlock(e)
+			case blockValueSymtab:
+				d.funcValueSymtabBlock(e)
+			case blockUselist:
+				// Use-list orders do not affect the LLVM IR module.
+			default:
+				panic(fmt.Errorf("support for function sub-block ID %d not yet implemented", e.ID))
+			}
+		case *bitstream.Record:
+			d.instRecord(e)
+		}
+	}
+	if len(f.Blocks) == 0 {
+		panic(fmt.Errorf("invalid function body of %s; no basic blocks declared", f.Ident()))
+	}
+	for _, b := range f.Blocks {
+		if b.Term == nil {
+			panic(fmt.Errorf("invalid basic block in function %s; missing terminator", f.Ident()))
+		}
+	}
+	d.resolveFwds()
+	d.values = d.values[:nvalues]
+	d.mds = d.mds[:nmds]
+	d.f = nil
+}
+
+// resolveFwds replaces the placeholders of forward referenced values in the
+// function being decoded with their definitions.
+func (d *decoder) resolveFwds() {
+	if len(d.fwds) == 0 {
+		return
+	}
+	resolve := func(v value.Value) value.Value {
+		fwd, ok := v.(*fwdValue)
+		if !ok {
+			return v
+		}
+		if fwd.id >= uint64(len(d.values)) {
+			panic(fmt.Errorf("invalid forward reference to value ID %d in function %s; no such value", fwd.id, d.f.Ident()))
+		}
+		return d.values[fwd.id]
+	}
+	irutil.WalkFuncBeforeAfter(d.f, func(x interface{}) {
+		switch x := x.(type) {
+		case *value.Value:
+			*x = resolve(*x)
+		case *value.Named:
+			*x = resolve(*x).(value.Named)
+		}
+	}, func(interface{}) {})
+}
+
+// funcValueSymtabBlock decodes the given function-level value symbol table.
+func (d *decoder) funcValueSymtabBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		switch rec.Code {
+		case vstCodeEntry:
+			// [value ID, name chars...]
+			d.expectOps(rec, 1)
+			v := d.value(rec.Ops[0], nil)
+			named, ok := v.(value.Named)
+			if !ok {
+				panic(fmt.Errorf("invalid value symbol table entry; expected named value, got %T", v))
+			}
+			named.SetName(recordString(rec.Ops[1:]))
+		case vstCodeBBEntry:
+			// [basic block index, name chars...]
+			d.expectOps(rec, 1)
+			d.block(rec.Ops[0]).SetName(recordString(rec.Ops[1:]))
+		}
+	}
+}
+
+// === [ Values ] ==============================================================
+
+// value returns the value of the given value ID in the function being decoded.
+// A placeholder of the given type is returned for forward references.
+func (d *decoder) value(id uint64, typ types.Type) value.Value {
+	if id < uint64(len(d.values)) {
+		return d.values[id]
+	}
+	if fwd, ok := d.fwds[id]; ok {
+		return fwd
+	}
+	if typ == nil {
+		panic(fmt.Errorf("invalid forward reference to value ID %d; missing type", id))
+	}
+	fwd := &fwdValue{id: id, typ: typ}
+	d.fwds[id] = fwd
+	return fwd
+}
+
+// relValue returns the value of the leading operand, which holds a value ID
+// relative to the next value ID, and the remaining operands.
+func (d *decoder) relValue(ops []uint64, typ types.Type) (value.Value, []uint64) {
+	rel, ops := next(ops)
+	if _, ok := typ.(*types.MetadataType); ok {
+		return d.metadataOperand(relID(d.nextValueID, rel)), ops
+	}
+	return d.value(relID(d.nextValueID, rel), typ), ops
+}
+
+// signedRelValue returns the value of the leading operand, which holds a sign
+// rotated value ID relative to the next value ID, and the remaining operands.
+func (d *decoder) signedRelValue(ops []uint64, typ types.Type) (value.Value, []uint64) {
+	rel, ops := next(ops)
+	return d.value(uint64(int64(d.nextValueID)-decodeSigned(rel)), typ), ops
+}
+
+// typedValue returns the value of the leading operands, which hold a relative
+// value ID followed by a type ID for forward references, and the remaining
+// operands.
+func (d *decoder) typedValue(ops []uint64) (value.Value, []uint64) {
+	rel, ops := next(ops)
+	id := relID(d.nextValueID, rel)
+	if id < d.nextValueID {
+		return d.value(id, nil), ops
+	}
+	typeID, ops := next(ops)
+	return d.value(id, d.typ(typeID)), ops
+}
+
+// relID returns the absolute value ID of the given value ID relative to the
+// next value ID. Relative IDs of forward references wrap around as 32-bit
+// values.
+func relID(nextValueID, rel uint64) uint64 {
+	return uint64(uint32(nextValueID - rel))
+}
+
+// next returns the leading operand and the remaining operands.
+func next(ops []uint64) (uint64, []uint64) {
+	if len(ops) < 1 {
+		panic(fmt.Errorf("invalid record; missing operand"))
+	}
+	return ops[0], ops[1:]
+}
+
+// block returns the basic block of the given index in the function being
+// decoded.
+func (d *decoder) block(index uint64) *ir.BasicBlock {
+	if index >= uint64(len(d.f.Blocks)) {
+		panic(fmt.Errorf("invalid basic block index %d in function %s; function with %d basic blocks", index, d.f.Ident(), len(d.f.Blocks)))
+	}
+	return d.f.Blocks[index]
+}
+
+// === [ Instructions ] ========================================================
+
+// instRecord decodes the given instruction record of the function being
+// decoded.
+func (d *decoder) instRecord(rec *bitstream.Record) {
+	ops := rec.Ops
+	switch rec.Code {
+	case funcCodeDeclareBlocks:
+		// [n]
+		d.expectOps(rec, 1)
+		if len(d.f.Blocks) != 0 {
+			panic(fmt.Errorf("invalid DECLAREBLOCKS record; basic blocks of function %s already declared", d.f.Ident()))
+		}
+		for i := uint64(0); i < ops[0]; i++ {
+			block := ir.NewBlock("")
+			block.Parent = d.f
+			d.f.Blocks = append(d.f.Blocks, block)
+		}
+	case funcCodeOperandBundle:
+		// [tag, values...]
+		tag, ops := next(ops)
+		if tag >= uint64(len(d.bundleTags)) {
+			panic(fmt.Errorf("invalid operand bundle tag ID %d; no such tag", tag))
+		}
+		bundle := ir.NewOperandBundle(d.bundleTags[tag])
+		for len(ops) > 0 {
+			var input value.Value
+			input, ops = d.typedValue(ops)
+			bundle.Inputs = append(bundle.Inputs, input)
+		}
+		d.bundles = append(d.bundles, bundle)
+	case funcCodeDebugLoc, funcCodeDebugLocAgain:
+		panic(fmt.Errorf("support for debug locations not yet implemented"))
+	// Binary instructions.
+	case funcCodeInstBinop:
+		// [x (typed), y, opcode, flags]
+		x, ops := d.typedValue(ops)
+		y, ops := d.relValue(ops, x.Type())
+		opcode, ops := next(ops)
+		d.appendInst(binop(opcode, x, y, opt(ops, 0)))
+	// Conversion instructions.
+	case funcCodeInstCast:
+		// [from (typed), to type, opcode]
+		from, ops := d.typedValue(ops)
+		to, ops := next(ops)
+		opcode, _ := next(ops)
+		d.appendInst(cast(opcode, from, d.typ(to)))
+	// Vector instructions.
+	case funcCodeInstExtractElt:
+		// [vector (typed), index (typed)]
+		x, ops := d.typedValue(ops)
+		index, _ := d.typedValue(ops)
+		d.appendInst(ir.NewExtractElement(x, index))
+	case funcCodeInstInsertElt:
+		// [vector (typed), element, index (typed)]
+		x, ops := d.typedValue(ops)
+		t, ok := x.Type().(*types.VectorType)
+		if !ok {
+			panic(fmt.Errorf("invalid insertelement vector type; expected *types.VectorType, got %T", x.Type()))
+		}
+		elem, ops := d.relValue(ops, t.Elem)
+		index, _ := d.typedValue(ops)
+		d.appendInst(ir.NewInsertElement(x, elem, index))
+	case funcCodeInstShuffleVec:
+		// [x (typed), y, mask (typed)]
+		x, ops := d.typedValue(ops)
+		y, ops := d.relValue(ops, x.Type())
+		mask, _ := d.typedValue(ops)
+		d.appendInst(ir.NewShuffleVector(x, y, mask))
+	// Aggregate instructions.
+	case funcCodeInstExtractVal:
+		// [aggregate (typed), indices...]
+		x, ops := d.typedValue(ops)
+		d.appendInst(ir.NewExtractValue(x, indices(ops)))
+	case funcCodeInstInsertVal:
+		// [aggregate (typed), element (typed), indices...]
+		x, ops := d.typedValue(ops)
+		elem, ops := d.typedValue(ops)
+		d.appendInst(ir.NewInsertValue(x, elem, indices(ops)))
+	// Memory instructions.
+	case funcCodeInstAlloca:
+		d.allocaRecord(rec)
+	case funcCodeInstLoad, funcCodeInstLoadAtomic:
+		// [source (typed), (element type), alignment, volatile, (ordering,
+		// synchronization scope)]
+		src, ops := d.typedValue(ops)
+		n := 2
+		if rec.Code == funcCodeInstLoadAtomic {
+			n = 4
+		}
+		if len(ops) > n {
+			// Explicit element type; inferred from the type of the source
+			// address.
+			ops = ops[1:]
+		}
+		if len(ops) < n {
+			panic(fmt.Errorf("invalid load record; expected %d operands, got %d", n, len(ops)))
+		}
+		inst := ir.NewLoad(src)
+		inst.Align = alignment(ops[0])
+		inst.Volatile = ops[1] != 0
+		if rec.Code == funcCodeInstLoadAtomic {
+			inst.Ordering = ir.AtomicOrdering(ops[2])
+			inst.SyncScope = d.syncScope(ops[3])
+		}
+		d.appendInst(inst)
+	case funcCodeInstStore, funcCodeInstStoreAtomic:
+		// [destination (typed), source (typed), alignment, volatile, (ordering,
+		// synchronization scope)]
+		dst, ops := d.typedValue(ops)
+		src, ops := d.typedValue(ops)
+		inst := ir.NewStore(src, dst)
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid store record; missing alignment and volatile operands"))
+		}
+		inst.Align = alignment(ops[0])
+		inst.Volatile = ops[1] != 0
+		if rec.Code == funcCodeInstStoreAtomic {
+			if len(ops) < 4 {
+				panic(fmt.Errorf("invalid atomic store record; missing ordering and synchronization scope operands"))
+			}
+			inst.Ordering = ir.AtomicOrdering(ops[2])
+			inst.SyncScope = d.syncScope(ops[3])
+		}
+		d.appendInst(inst)
+	case funcCodeInstFence:
+		// [ordering, synchronization scope]
+		d.expectOps(rec, 2)
+		inst := ir.NewFence(ir.AtomicOrdering(ops[0]))
+		inst.SyncScope = d.syncScope(ops[1])
+		d.appendInst(inst)
+	case funcCodeInstCmpXchg:
+		// [pointer (typed), cmp (typed), new, volatile, success ordering,
+		// synchronization scope, failure ordering, weak, (alignment)]
+		ptr, ops := d.typedValue(ops)
+		cmp, ops := d.typedValue(ops)
+		new, ops := d.relValue(ops, cmp.Type())
+		if len(ops) < 5 {
+			panic(fmt.Errorf("invalid cmpxchg record; expected at least 5 trailing operands, got %d", len(ops)))
+		}
+		inst := ir.NewCmpXchg(ptr, cmp, new, ir.AtomicOrdering(ops[1]), ir.AtomicOrdering(ops[3]))
+		inst.Volatile = ops[0] != 0
+		inst.SyncScope = d.syncScope(ops[2])
+		inst.Weak = ops[4] != 0
+		if len(ops) > 5 {
+			inst.Align = alignment(ops[5])
+		}
+		d.appendInst(inst)
+	case funcCodeInstAtomicRMW, funcCodeInstAtomicRMWOld:
+		// [pointer (typed), value (typed), operation, volatile, ordering,
+		// synchronization scope, (alignment)]
+		dst, ops := d.typedValue(ops)
+		var x value.Value
+		if rec.Code == funcCodeInstAtomicRMWOld {
+			t, ok := dst.Type().(*types.PointerType)
+			if !ok {
+				panic(fmt.Errorf("invalid atomicrmw pointer type; expected *types.PointerType, got %T", dst.Type()))
+			}
+			x, ops = d.relValue(ops, t.Elem)
+		} else {
+			x, ops = d.typedValue(ops)
+		}
+		if len(ops) < 4 {
+			panic(fmt.Errorf("invalid atomicrmw record; expected at least 4 trailing operands, got %d", len(ops)))
+		}
+		inst := ir.NewAtomicRMW(ir.AtomicOp(ops[0]+1), dst, x, ir.AtomicOrdering(ops[2]))
+		inst.Volatile = ops[1] != 0
+		inst.SyncScope = d.syncScope(ops[3])
+		if len(ops) > 4 {
+			inst.Align = alignment(ops[4])
+		}
+		d.appendInst(inst)
+	case funcCodeInstGEP:
+		// [inbounds, element type, source (typed), indices (typed)...]
+		if len(ops) < 2 {
+			panic(fmt.Errorf("invalid getelementptr record; expected at least 2 operands, got %d", len(ops)))
+		}
+		inBounds := ops[0] != 0
+		// The element type is inferred from the type of the source address.
+		src, ops := d.typedValue(ops[2:])
+		var idxs []value.Value
+		for len(ops) > 0 {
+			var index value.Value
+			index, ops = d.typedValue(ops)
+			idxs = append(idxs, index)
+		}
+		inst := ir.NewGetElementPtr(src, idxs...)
+		inst.InBounds = inBounds
+		d.appendInst(inst)
+	// Other instructions.
+	case funcCodeInstCmp, funcCodeInstCmp2:
+		// [x (typed), y, predicate, (fast-math flags)]
+		x, ops := d.typedValue(ops)
+		y, ops := d.relValue(ops, x.Type())
+		pred, ops := next(ops)
+		if isFloatPred(pred) {
+			d.appendInst(ir.NewFCmp(ir.FloatPred(floatPred(pred)), x, y, fastMathFlags(opt(ops, 0))...))
+		} else {
+			d.appendInst(ir.NewICmp(ir.IntPred(intPred(pred)), x, y))
+		}
+	case funcCodeInstPhi:
+		d.phiRecord(rec)
+	case funcCodeInstVSelect:
+		// [x (typed), y, cond (typed)]
+		x, ops := d.typedValue(ops)
+		y, ops := d.relValue(ops, x.Type())
+		cond, _ := d.typedValue(ops)
+		d.appendInst(ir.NewSelect(cond, x, y))
+	case funcCodeInstCall:
+		d.callRecord(rec)
+	case funcCodeInstVAArg:
+		// [argument list type, argument list, argument type]
+		d.expectOps(rec, 3)
+		argList, _ := d.relValue(ops[1:], d.typ(ops[0]))
+		d.appendInst(ir.NewVAArg(argList, d.typ(ops[2])))
+	case funcCodeInstLandingPad:
+		d.landingPadRecord(rec)
+	case funcCodeInstCatchPad, funcCodeInstCleanupPad:
+		// [parent pad, n, args (typed)...]
+		within, ops := d.relValue(ops, types.Token)
+		n, ops := next(ops)
+		var args []value.Value
+		for i := uint64(0); i < n; i++ {
+			var arg value.Value
+			arg, ops = d.typedValue(ops)
+			args = append(args, arg)
+		}
+		if rec.Code == funcCodeInstCatchPad {
+			d.appendInst(ir.NewCatchPad(within, args...))
+		} else {
+			d.appendInst(ir.NewCleanupPad(within, args...))
+		}
+	// Terminators.
+	case funcCodeInstRet:
+		// [(value (typed))]
+		var x value.Value
+		if len(ops) > 0 {
+			x, _ = d.typedValue(ops)
+		}
+		d.setTerm(ir.NewRet(x))
+	case funcCodeInstBr:
+		// [true target, (false target, cond)]
+		d.expectOps(rec, 1)
+		if len(ops) == 1 {
+			d.setTerm(ir.NewBr(d.block(ops[0])))
+			break
+		}
+		d.expectOps(rec, 3)
+		cond, _ := d.relValue(ops[2:], types.I1)
+		d.setTerm(ir.NewCondBr(cond, d.block(ops[0]), d.block(ops[1])))
+	case funcCodeInstSwitch:
+		// [type, cond, default target, n x (case value ID, target)]
+		d.expectOps(rec, 3)
+		typ := d.typ(ops[0])
+		x, ops := d.relValue(ops[1:], typ)
+		targetDefault, ops := next(ops)
+		if len(ops)%2 != 0 {
+			panic(fmt.Errorf("invalid switch record; odd number of case operands"))
+		}
+		var cases []*ir.Case
+		for i := 0; i < len(ops); i += 2 {
+			v := d.value(ops[i], typ)
+			c, ok := v.(*constant.Int)
+			if !ok {
+				panic(fmt.Errorf("invalid switch case value type; expected *constant.Int, got %T", v))
+			}
+			cases = append(cases, ir.NewCase(c, d.block(ops[i+1])))
+		}
+		d.setTerm(ir.NewSwitch(x, d.block(targetDefault), cases...))
+	case funcCodeInstIndirectBr:
+		// [type, address, targets...]
+		d.expectOps(rec, 2)
+		addr, ops := d.relValue(ops[1:], d.typ(ops[0]))
+		var targets []*ir.BasicBlock
+		for _, op := range ops {
+			targets = append(targets, d.block(op))
+		}
+		d.setTerm(ir.NewIndirectBr(addr, targets...))
+	case funcCodeInstInvoke:
+		d.invokeRecord(rec)
+	case funcCodeInstResume:
+		// [value (typed)]
+		x, _ := d.typedValue(ops)
+		d.setTerm(ir.NewResume(x))
+	case funcCodeInstUnreachable:
+		d.setTerm(ir.NewUnreachable())
+	case funcCodeInstCatchSwitch:
+		// [parent pad, n, handlers..., (unwind target)]
+		within, ops := d.relValue(ops, types.Token)
+		n, ops := next(ops)
+		if n > uint64(len(ops)) {
+			panic(fmt.Errorf("invalid catchswitch record; %d handlers out of range", n))
+		}
+		var handlers []*ir.BasicBlock
+		for _, op := range ops[:n] {
+			handlers = append(handlers, d.block(op))
+		}
+		var unwind *ir.BasicBlock
+		if ops = ops[n:]; len(ops) > 0 {
+			unwind = d.block(ops[0])
+		}
+		d.setTerm(ir.NewCatchSwitch(within, handlers, unwind))
+	case funcCodeInstCatchRet:
+		// [from, target]
+		from, ops := d.relValue(ops, types.Token)
+		target, _ := next(ops)
+		d.setTerm(ir.NewCatchRet(from, d.block(target)))
+	case funcCodeInstCleanupRet:
+		// [from, (unwind target)]
+		from, ops := d.relValue(ops, types.Token)
+		var unwind *ir.BasicBlock
+		if len(ops) > 0 {
+			unwind = d.block(ops[0])
+		}
+		d.setTerm(ir.NewCleanupRet(from, unwind))
+	default:
+		panic(fmt.Errorf("support for function record code %d not yet implemented", rec.Code))
+	}
+}
+
+// appendInst appends the given instruction to the current basic block of the
+// function being decoded.
+func (d *decoder) appendInst(inst ir.Instruction) {
+	d.curBlock().AppendInst(inst)
+	d.defineInst(inst)
+}
+
+// setTerm sets the terminator of the current basic block of the function
+// being decoded, and moves on to the next basic block.
+func (d *decoder) setTerm(term ir.Terminator) {
+	d.curBlock().SetTerm(term)
+	d.defineInst(term)
+	d.cur++
+}
+
+// curBlock returns the current basic block of the function being decoded.
+func (d *decoder) curBlock() *ir.BasicBlock {
+	if d.cur >= len(d.f.Blocks) {
+		panic(fmt.Errorf("invalid instruction in function %s; instruction outside of declared basic blocks", d.f.Ident()))
+	}
+	return d.f.Blocks[d.cur]
+}
+
+// defineInst records the given instruction, assigning the next value ID to
+// instructions producing a value.
+func (d *decoder) defineInst(inst ir.Instruction) {
+	d.insts = append(d.insts, inst)
+	if len(d.bundles) > 0 {
+		panic(fmt.Errorf("invalid operand bundles of %T; operand bundles are only valid for call and invoke instructions", inst))
+	}
+	if v, ok := inst.(value.Value); ok && !types.Equal(v.Type(), types.Void) {
+		d.values = append(d.values, v)
+		d.nextValueID++
+	}
+}
+
+// allocaRecord decodes the given alloca record.
+func (d *decoder) allocaRecord(rec *bitstream.Record) {
+	// [element type, operand type, number of elements, alignment and flags,
+	// (address space)]
+	const (
+		inAllocaMask     = 1 << 5
+		explicitTypeMask = 1 << 6
+		swiftErrorMask   = 1 << 7
+	)
+	d.expectOps(rec, 4)
+	ops := rec.Ops
+	elem := d.typ(ops[0])
+	flags := ops[3]
+	if flags&inAllocaMask != 0 {
+		panic(fmt.Errorf("support for inalloca not yet implemented"))
+	}
+	if flags&swiftErrorMask != 0 {
+		panic(fmt.Errorf("support for swifterror not yet implemented"))
+	}
+	if flags&explicitTypeMask == 0 {
+		t, ok := elem.(*types.PointerType)
+		if !ok {
+			panic(fmt.Errorf("invalid alloca type; expected *types.PointerType, got %T", elem))
+		}
+		elem = t.Elem
+	}
+	inst := ir.NewAlloca(elem)
+	// Number of elements; omitted if 1.
+	nelems := d.value(ops[2], d.typ(ops[1]))
+	if c, ok := nelems.(*constant.Int); !ok || c.X.Int64() != 1 {
+		inst.NElems = nelems
+	}
+	// Alignment is stored in bits 0-4 and 8-10.
+	inst.Align = alignment(flags&0x1F | (flags>>8)&0x7<<5)
+	// The address space is omitted if it is the default address space of
+	// alloca instructions specified by the data layout.
+	addrSpace := allocaAddrSpace(d.m.DataLayout)
+	if len(ops) >= 5 {
+		addrSpace = ops[4]
+	}
+	inst.Typ.AddrSpace = int(addrSpace)
+	d.appendInst(inst)
+}
+
+// phiRecord decodes the given phi record.
+func (d *decoder) phiRecord(rec *bitstream.Record) {
+	// [type, n x (value, predecessor), (fast-math flags)]
+	d.expectOps(rec, 1)
+	typ := d.typ(rec.Ops[0])
+	ops := rec.Ops[1:]
+	var flags uint64
+	if len(ops)%2 != 0 {
+		flags = ops[len(ops)-1]
+		ops = ops[:len(ops)-1]
+	}
+	var incs []*ir.Incoming
+	for len(ops) > 0 {
+		var x value.Value
+		x, ops = d.signedRelValue(ops, typ)
+		var pred uint64
+		pred, ops = next(ops)
+		incs = append(incs, ir.NewIncoming(x, d.block(pred)))
+	}
+	if len(incs) == 0 {
+		panic(fmt.Errorf("invalid phi record; no incoming values"))
+	}
+	inst := ir.NewPhi(incs...)
+	inst.Typ = typ
+	inst.FastMathFlags = fastMathFlags(flags)
+	d.appendInst(inst)
+}
+
+// landingPadRecord decodes the given landingpad record.
+func (d *decoder) landingPadRecord(rec *bitstream.Record) {
+	// [type, cleanup, n, n x (clause kind, value (typed))]
+	d.expectOps(rec, 3)
+	typ := d.typ(rec.Ops[0])
+	cleanup := rec.Ops[1] != 0
+	n := rec.Ops[2]
+	ops := rec.Ops[3:]
+	var clauses []*ir.Clause
+	for i := uint64(0); i < n; i++ {
+		var kind uint64
+		kind, ops = next(ops)
+		var x value.Value
+		x, ops = d.typedValue(ops)
+		c, ok := x.(constant.Constant)
+		if !ok {
+			panic(fmt.Errorf("invalid landingpad clause value type; expected constant.Constant, got %T", x))
+		}
+		clauses = append(clauses, ir.NewClause(ir.ClauseKind(kind+1), c))
+	}
+	inst := ir.NewLandingPad(typ, clauses...)
+	inst.Cleanup = cleanup
+	d.appendInst(inst)
+}
+
+// Calling convention flags of call records.
+const (
+	callTailMask         = 1 << 0
+	callConvShift        = 1
+	callConvMask         = 0x3FF
+	callMustTailMask     = 1 << 14
+	callExplicitTypeMask = 1 << 15
+	callNoTailMask       = 1 << 16
+	callFMFMask          = 1 << 17
+)
+
+// callRecord decodes the given call record.
+func (d *decoder) callRecord(rec *bitstream.Record) {
+	// [attributes, calling convention and flags, (fast-math flags), (function
+	// type), callee (typed), args...]
+	d.expectOps(rec, 2)
+	list := d.attrList(rec.Ops[0])
+	cc := rec.Ops[1]
+	ops := rec.Ops[2:]
+	var fmf uint64
+	if cc&callFMFMask != 0 {
+		fmf, ops = next(ops)
+	}
+	var fnty types.Type
+	if cc&callExplicitTypeMask != 0 {
+		var typeID uint64
+		typeID, ops = next(ops)
+		fnty = d.typ(typeID)
+	}
+	callee, ops := d.typedValue(ops)
+	sig := calleeSig(callee, fnty)
+	args := d.args(sig, ops)
+	inst := ir.NewCall(callee, args...)
+	inst.Sig = sig
+	inst.CallConv = callConv(cc >> callConvShift & callConvMask)
+	switch {
+	case cc&callMustTailMask != 0:
+		inst.Tail = ir.TailMustTail
+	case cc&callNoTailMask != 0:
+		inst.Tail = ir.TailNoTail
+	case cc&callTailMask != 0:
+		inst.Tail = ir.TailTail
+	}
+	inst.FastMathFlags = fastMathFlags(fmf)
+	for _, g := range list {
+		switch {
+		case g.index == attrIndexFunc:
+			group := d.funcAttrGroup(g)
+			inst.FuncAttrs = append(inst.FuncAttrs, group)
+			d.callAttrs[inst] = group
+		case g.index == 0:
+			inst.RetAttrs = append(inst.RetAttrs, g.attrs...)
+		default:
+			inst.Args[g.index-1] = argAttrs(inst.Args, g)
+		}
+	}
+	inst.OperandBundles = d.bundles
+	d.bundles = nil
+	d.appendInst(inst)
+}
+
+// invokeRecord decodes the given invoke record.
+func (d *decoder) invokeRecord(rec *bitstream.Record) {
+	// [attributes, calling convention and flags, normal target, exception
+	// target, (function type), callee (typed), args...]
+	const explicitTypeMask = 1 << 13
+	d.expectOps(rec, 4)
+	list := d.attrList(rec.Ops[0])
+	cc := rec.Ops[1]
+	normal, exception := d.block(rec.Ops[2]), d.block(rec.Ops[3])
+	ops := rec.Ops[4:]
+	var fnty types.Type
+	if cc&explicitTypeMask != 0 {
+		var typeID uint64
+		typeID, ops = next(ops)
+		fnty = d.typ(typeID)
+	}
+	callee, ops := d.typedValue(ops)
+	sig := calleeSig(callee, fnty)
+	args := d.args(sig, ops)
+	term := ir.NewInvoke(callee, args, normal, exception)
+	term.Sig = sig
+	term.CallConv = callConv(cc &^ explicitTypeMask)
+	// Return attributes, function attributes and operand bundles of invoke
+	// terminators are not yet supported by the ir package; call-site function
+	// attributes are kept track of for the numbering of attribute groups.
+	for _, g := range list {
+		switch {
+		case g.index == attrIndexFunc:
+			d.callAttrs[term] = d.funcAttrGroup(g)
+		case g.index == 0:
+		default:
+			term.Args[g.index-1] = argAttrs(term.Args, g)
+		}
+	}
+	d.bundles = nil
+	d.setTerm(term)
+}
+
+// calleeSig returns the function signature of the given callee, falling back
+// to the given explicit function type.
+func calleeSig(callee value.Value, fnty types.Type) *types.FuncType {
+	if t, ok := callee.Type().(*types.PointerType); ok {
+		if sig, ok := t.Elem.(*types.FuncType); ok {
+			return sig
+		}
+	}
+	sig, ok := fnty.(*types.FuncType)
+	if !ok {
+		panic(fmt.Errorf("invalid callee type; expected pointer to function type, got %v", callee.Type()))
+	}
+	return sig
+}
+
+// args returns the arguments of the given operands, based on the given
+// function signature.
+func (d *decoder) args(sig *types.FuncType, ops []uint64) []value.Value {
+	var args []value.Value
+	for _, param := range sig.Params {
+		var arg value.Value
+		arg, ops = d.relValue(ops, param.Typ)
+		args = append(args, arg)
+	}
+	if !sig.Variadic && len(ops) > 0 {
+		panic(fmt.Errorf("invalid call arguments; %d trailing operands of non-variadic function", len(ops)))
+	}
+	for len(ops) > 0 {
+		var arg value.Value
+		arg, ops = d.typedValue(ops)
+		args = append(args, arg)
+	}
+	return args
+}
+
+// argAttrs returns the argument of the given attribute group, with its
+// parameter attributes.
+func argAttrs(args []value.Value, g *attrGroup) value.Value {
+	i := g.index - 1
+	if i >= uint64(len(args)) {
+		panic(fmt.Errorf("invalid attribute index %d of call with %d arguments", g.index, len(args)))
+	}
+	if arg, ok := args[i].(*ir.Arg); ok {
+		arg.Attrs = append(arg.Attrs, g.attrs...)
+		return arg
+	}
+	return ir.NewArg(args[i], g.attrs...)
+}
+
+// indices returns the aggregate indices of the given operands.
+func indices(ops []uint64) []int64 {
+	var is []int64
+	for _, op := range ops {
+		is = append(is, int64(op))
+	}
+	return is
+}
+
+// syncScope returns the synchronization scope name of the given ID; the
+// default system scope is represented by an empty name.
+func (d *decoder) syncScope(id uint64) string {
+	if id >= uint64(len(d.syncScopes)) {
+		panic(fmt.Errorf("invalid synchronization scope ID %d; no such scope", id))
+	}
+	return d.syncScopes[id]
+}
+
+// binop returns a new binary instruction based on the given opcode, operands
+// and flags.
+func binop(opcode uint64, x, y value.Value, flags uint64) ir.Instruction {
+	if isFloatType(x.Type()) {
+		fmf := fastMathFlags(flags)
+		switch opcode {
+		case binopAdd:
+			return ir.NewFAdd(x, y, fmf...)
+		case binopSub:
+			return ir.NewFSub(x, y, fmf...)
+		case binopMul:
+			return ir.NewFMul(x, y, fmf...)
+		case binopSDiv:
+			return ir.NewFDiv(x, y, fmf...)
+		case binopSRem:
+			return ir.NewFRem(x, y, fmf...)
+		default:
+			panic(fmt.Errorf("invalid binary opcode %d for floating-point operands", opcode))
+		}
+	}
+	exact := flags&1 != 0
+	switch opcode {
+	case binopAdd:
+		return ir.NewAdd(x, y, overflowFlags(flags)...)
+	case binopSub:
+		return ir.NewSub(x, y, overflowFlags(flags)...)
+	case binopMul:
+		return ir.NewMul(x, y, overflowFlags(flags)...)
+	case binopUDiv:
+		inst := ir.NewUDiv(x, y)
+		inst.Exact = exact
+		return inst
+	case binopSDiv:
+		inst := ir.NewSDiv(x, y)
+		inst.Exact = exact
+		return inst
+	case binopURem:
+		return ir.NewURem(x, y)
+	case binopSRem:
+		return ir.NewSRem(x, y)
+	case binopShl:
+		return ir.NewShl(x, y, overflowFlags(flags)...)
+	case binopLShr:
+		inst := ir.NewLShr(x, y)
+		inst.Exact = exact
+		return inst
+	case binopAShr:
+		inst := ir.NewAShr(x, y)
+		inst.Exact = exact
+		return inst
+	case binopAnd:
+		return ir.NewAnd(x, y)
+	case binopOr:
+		return ir.NewOr(x, y)
+	case binopXor:
+		return ir.NewXor(x, y)
+	default:
+		panic(fmt.Errorf("support for binary opcode %d not yet implemented", opcode))
+	}
+}
+
+// overflowFlags returns the overflow flags of the given encoded flags.
+func overflowFlags(flags uint64) []ir.OverflowFlag {
+	var fs []ir.OverflowFlag
+	if flags&overflowNUW != 0 {
+		fs = append(fs, ir.OverflowFlagNUW)
+	}
+	if flags&overflowNSW != 0 {
+		fs = append(fs, ir.OverflowFlagNSW)
+	}
+	return fs
+}
+
+// Fast-math flags of instruction records.
+const (
+	fmfUnsafeAlgebra   = 1 << 0
+	fmfNoNaNs          = 1 << 1
+	fmfNoInfs          = 1 << 2
+	fmfNoSignedZeros   = 1 << 3
+	fmfAllowReciprocal = 1 << 4
+	fmfAllowContract   = 1 << 5
+	fmfApproxFunc      = 1 << 6
+	fmfAllowReassoc    = 1 << 7
+	// All fast-math flags, except for the legacy unsafe algebra flag.
+	fmfFast = fmfNoNaNs | fmfNoInfs | fmfNoSignedZeros | fmfAllowReciprocal | fmfAllowContract | fmfApproxFunc | fmfAllowReassoc
+)
+
+// fastMathFlags returns the fast-math flags of the given encoded flags, in the
+// order printed by llvm-dis.
+func fastMathFlags(flags uint64) []ir.FastMathFlag {
+	if flags&fmfUnsafeAlgebra != 0 || flags&fmfFast == fmfFast {
+		return []ir.FastMathFlag{ir.FastMathFlagFast}
+	}
+	var fs []ir.FastMathFlag
+	m := []struct {
+		mask uint64
+		flag ir.FastMathFlag
+	}{
+		{mask: fmfAllowReassoc, flag: ir.FastMathFlagReassoc},
+		{mask: fmfNoNaNs, flag: ir.FastMathFlagNNaN},
+		{mask: fmfNoInfs, flag: ir.FastMathFlagNInf},
+		{mask: fmfNoSignedZeros, flag: ir.FastMathFlagNSZ},
+		{mask: fmfAllowReciprocal, flag: ir.FastMathFlagARCP},
+		{mask: fmfAllowContract, flag: ir.FastMathFlagContract},
+		{mask: fmfApproxFunc, flag: ir.FastMathFlagAFN},
+	}
+	for _, f := range m {
+		if flags&f.mask != 0 {
+			fs = append(fs, f.flag)
+		}
+	}
+	return fs
+}
+
+// cast returns a new conversion instruction based on the given opcode, operand
+// and target type.
+func cast(opcode uint64, from value.Value, to types.Type) ir.Instruction {
+	switch opcode {
+	case castTrunc:
+		return ir.NewTrunc(from, to)
+	case castZExt:
+		return ir.NewZExt(from, to)
+	case castSExt:
+		return ir.NewSExt(from, to)
+	case castFPToUI:
+		return ir.NewFPToUI(from, to)
+	case castFPToSI:
+		return ir.NewFPToSI(from, to)
+	case castUIToFP:
+		return ir.NewUIToFP(from, to)
+	case castSIToFP:
+		return ir.NewSIToFP(from, to)
+	case castFPTrunc:
+		return ir.NewFPTrunc(from, to)
+	case castFPExt:
+		return ir.NewFPExt(from, to)
+	case castPtrToInt:
+		return ir.NewPtrToInt(from, to)
+	case castIntToPtr:
+		return ir.NewIntToPtr(from, to)
+	case castBitCast:
+		return ir.NewBitCast(from, to)
+	case castAddrSpaceCast:
+		return ir.NewAddrSpaceCast(from, to)
+	default:
+		panic(fmt.Errorf("support for cast opcode %d not yet implemented", opcode))
+	}
+}
@@ -0,0 +1,425 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+)
+
+// globalName returns the name of the global value of the given record, and the
+// remaining operands of the record. Since version 2, the names of global values
+// are stored in the string table, and are otherwise set by the module-level
+// value symbol table.
+func (d *decoder) globalName(rec *bitstream.Record) (string, []uint64) {
+	if d.version < 2 {
+		return "", rec.Ops
+	}
+	d.expectOps(rec, 2)
+	return d.strtabString(rec.Ops[0], rec.Ops[1]), rec.Ops[2:]
+}
+
+// opt returns the i:th operand of ops, or 0 if not present.
+func opt(ops []uint64, i int) uint64 {
+	if i < len(ops) {
+		return ops[i]
+	}
+	return 0
+}
+
+// === [ Comdats ] =============================================================
+
+// comdatRecord decodes the given comdat record.
+func (d *decoder) comdatRecord(rec *bitstream.Record) {
+	var (
+		name string
+		kind uint64
+	)
+	if d.version >= 2 {
+		// [strtab offset, strtab size, selection kind]
+		d.expectOps(rec, 3)
+		name, kind = d.strtabString(rec.Ops[0], rec.Ops[1]), rec.Ops[2]
+	} else {
+		// [selection kind, name size, name chars...]
+		d.expectOps(rec, 2)
+		kind = rec.Ops[0]
+		n := rec.Ops[1]
+		if 2+n > uint64(len(rec.Ops)) {
+			panic(fmt.Errorf("invalid COMDAT record; name size %d out of range", n))
+		}
+		name = recordString(rec.Ops[2 : 2+n])
+	}
+	if kind < 1 || kind > 5 {
+		panic(fmt.Errorf("support for comdat selection kind %d not yet implemented", kind))
+	}
+	c := d.m.NewComdat(name, ir.SelectionKind(kind-1))
+	d.comdats = append(d.comdats, c)
+}
+
+// comdat returns the comdat of the given comdat ID, where 0 denotes no comdat.
+func (d *decoder) comdat(id uint64) *ir.Comdat {
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.comdats)) {
+		panic(fmt.Errorf("invalid comdat ID %d; no such comdat", id))
+	}
+	return d.comdats[id-1]
+}
+
+// section returns the section name of the given section ID, where 0 denotes no
+// section.
+func (d *decoder) section(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	if id > uint64(len(d.sectionNames)) {
+		panic(fmt.Errorf("invalid section ID %d; no such section", id))
+	}
+	return d.sectionNames[id-1]
+}
+
+// === [ Global variables ] ====================================================
+
+// globalVarRecord decodes the given global variable record.
+func (d *decoder) globalVarRecord(rec *bitstream.Record) {
+	// [type, flags, init ID, linkage, alignment, section, visibility, TLS
+	// model, unnamed_addr, externally initialized, DLL storage class, comdat,
+	// attributes, dso_local, ...]
+	name, ops := d.globalName(rec)
+	if len(ops) < 6 {
+		panic(fmt.Errorf("invalid GLOBALVAR record; expected at least 6 operands, got %d", len(ops)))
+	}
+	g := &ir.Global{
+		Name:     name,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+	t := d.typ(ops[0])
+	flags := ops[1]
+	g.IsConst = flags&1 != 0
+	var addrSpace int
+	if flags&2 != 0 {
+		// Explicit content type.
+		g.Content = t
+		addrSpace = int(flags >> 2)
+	} else {
+		ptr, ok := t.(*types.PointerType)
+		if !ok {
+			panic(fmt.Errorf("invalid global variable type; expected *types.PointerType, got %T", t))
+		}
+		g.Content = ptr.Elem
+		addrSpace = ptr.AddrSpace
+	}
+	g.Typ = types.NewPointer(g.Content)
+	g.Typ.AddrSpace = addrSpace
+	initID := ops[2]
+	g.Linkage = linkage(ops[3])
+	if initID == 0 && g.Linkage == ir.LinkageNone {
+		// External global variable declarations have explicit linkage.
+		g.Linkage = ir.LinkageExternal
+	}
+	g.Align = alignment(ops[4])
+	g.Section = d.section(ops[5])
+	g.Visibility = visibility(opt(ops, 6))
+	g.TLSModel = ir.TLSModel(opt(ops, 7))
+	g.UnnamedAddr = unnamedAddr(opt(ops, 8))
+	g.ExternallyInitialized = opt(ops, 9) != 0
+	g.DLLStorageClass = dllStorageClass(opt(ops, 10))
+	g.Comdat = d.comdat(opt(ops, 11))
+	if initID != 0 {
+		d.deferred = append(d.deferred, func() {
+			g.Init = d.moduleConstant(initID - 1)
+		})
+	}
+	d.m.Globals = append(d.m.Globals, g)
+	d.values = append(d.values, g)
+}
+
+// === [ Functions ] ===========================================================
+
+// functionRecord decodes the given function record.
+func (d *decoder) functionRecord(rec *bitstream.Record) {
+	// [type, calling convention, is prototype, linkage, attributes, alignment,
+	// section, visibility, gc, unnamed_addr, prologue data, DLL storage class,
+	// comdat, prefix data, personality, dso_local, address space, ...]
+	name, ops := d.globalName(rec)
+	if len(ops) < 8 {
+		panic(fmt.Errorf("invalid FUNCTION record; expected at least 8 operands, got %d", len(ops)))
+	}
+	t := d.typ(ops[0])
+	if ptr, ok := t.(*types.PointerType); ok {
+		t = ptr.Elem
+	}
+	sig, ok := t.(*types.FuncType)
+	if !ok {
+		panic(fmt.Errorf("invalid function type; expected *types.FuncType, got %T", t))
+	}
+	// Function types are uniqued by bitcode, whereas each function has its own
+	// parameters.
+	sig = copyFuncType(sig)
+	f := &ir.Function{
+		Parent:   d.m,
+		Name:     name,
+		Sig:      sig,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+	f.Typ = types.NewPointer(sig)
+	f.Typ.AddrSpace = int(opt(ops, 16))
+	f.CallConv = callConv(ops[1])
+	isProto := ops[2] != 0
+	f.Linkage = linkage(ops[3])
+	d.funcAttrs(f, d.attrList(ops[4]))
+	f.Align = alignment(ops[5])
+	f.Section = d.section(ops[6])
+	f.Visibility = visibility(ops[7])
+	if opt(ops, 8) != 0 {
+		panic(fmt.Errorf("support for garbage collector names not yet implemented"))
+	}
+	f.UnnamedAddr = unnamedAddr(opt(ops, 9))
+	if opt(ops, 10) != 0 {
+		panic(fmt.Errorf("support for prologue data not yet implemented"))
+	}
+	f.DLLStorageClass = dllStorageClass(opt(ops, 11))
+	f.Comdat = d.comdat(opt(ops, 12))
+	if opt(ops, 13) != 0 {
+		panic(fmt.Errorf("support for prefix data not yet implemented"))
+	}
+	if personalityID := opt(ops, 14); personalityID != 0 {
+		d.deferred = append(d.deferred, func() {
+			f.Personality = d.moduleConstant(personalityID - 1)
+		})
+	}
+	if !isProto {
+		d.bodies = append(d.bodies, f)
+	}
+	d.m.Funcs = append(d.m.Funcs, f)
+	d.values = append(d.values, f)
+}
+
+// copyFuncType returns a copy of the given function type, with new parameters.
+func copyFuncType(sig *types.FuncType) *types.FuncType {
+	var params []*types.Param
+	for _, param := range sig.Params {
+		params = append(params, types.NewParam("", param.Typ))
+	}
+	t := types.NewFunc(sig.Ret, params...)
+	t.Variadic = sig.Variadic
+	return t
+}
+
+// funcAttrs sets the return, parameter and function attributes of the given
+// function based on the given attribute list.
+func (d *decoder) funcAttrs(f *ir.Function, list []*attrGroup) {
+	params := f.Params()
+	for _, g := range list {
+		switch {
+		case g.index == attrIndexFunc:
+			f.FuncAttrs = append(f.FuncAttrs, d.funcAttrGroup(g))
+		case g.index == 0:
+			f.RetAttrs = append(f.RetAttrs, g.attrs...)
+		case g.index-1 < uint64(len(params)):
+			param := params[g.index-1]
+			param.Attrs = append(param.Attrs, g.attrs...)
+		default:
+			panic(fmt.Errorf("invalid attribute index %d of function %s with %d parameters", g.index, f.Ident(), len(params)))
+		}
+	}
+}
+
+// === [ Aliases ] =============================================================
+
+// aliasRecord decodes the given alias record.
+func (d *decoder) aliasRecord(rec *bitstream.Record) {
+	// [type, address space, aliasee ID, linkage, visibility, DLL storage
+	// class, TLS model, unnamed_addr, dso_local, ...]
+	name, ops := d.globalName(rec)
+	if len(ops) < 4 {
+		panic(fmt.Errorf("invalid ALIAS record; expected at least 4 operands, got %d", len(ops)))
+	}
+	a := &ir.Alias{
+		Name: name,
+		Typ:  types.NewPointer(d.typ(ops[0])),
+	}
+	a.Typ.AddrSpace = int(ops[1])
+	aliaseeID := ops[2]
+	a.Linkage = linkage(ops[3])
+	a.Visibility = visibility(opt(ops, 4))
+	a.DLLStorageClass = dllStorageClass(opt(ops, 5))
+	a.TLSModel = ir.TLSModel(opt(ops, 6))
+	a.UnnamedAddr = unnamedAddr(opt(ops, 7))
+	d.deferred = append(d.deferred, func() {
+		a.Aliasee = d.moduleConstant(aliaseeID)
+	})
+	d.m.Aliases = append(d.m.Aliases, a)
+	d.values = append(d.values, a)
+}
+
+// === [ IFuncs ] ==============================================================
+
+// ifuncRecord decodes the given IFunc record.
+func (d *decoder) ifuncRecord(rec *bitstream.Record) {
+	// [type, address space, resolver ID, linkage, visibility, ...]
+	name, ops := d.globalName(rec)
+	if len(ops) < 4 {
+		panic(fmt.Errorf("invalid IFUNC record; expected at least 4 operands, got %d", len(ops)))
+	}
+	content := d.typ(ops[0])
+	i := &ir.IFunc{
+		Name:    name,
+		Typ:     types.NewPointer(content),
+		Content: content,
+	}
+	i.Typ.AddrSpace = int(ops[1])
+	resolverID := ops[2]
+	i.Linkage = linkage(ops[3])
+	i.Visibility = visibility(opt(ops, 4))
+	d.deferred = append(d.deferred, func() {
+		i.Resolver = d.moduleConstant(resolverID)
+	})
+	d.m.IFuncs = append(d.m.IFuncs, i)
+	d.values = append(d.values, i)
+}
+
+// === [ Enums ] ===============================================================
+
+// linkage returns the LLVM IR linkage type of the given linkage code, which
+// includes the codes of obsolete linkage types.
+func linkage(code uint64) ir.Linkage {
+	switch code {
+	case 0, 5, 6, 15:
+		// external; obsolete dllimport, dllexport and linker_private_weak.
+		return ir.LinkageNone
+	case 2:
+		return ir.LinkageAppending
+	case 3:
+		return ir.LinkageInternal
+	case 7:
+		return ir.LinkageExternWeak
+	case 8:
+		return ir.LinkageCommon
+	case 9, 13, 14:
+		// private; obsolete linker_private and linker_private_weak.
+		return ir.LinkagePrivate
+	case 12:
+		return ir.LinkageAvailableExternally
+	case 1, 16:
+		return ir.LinkageWeak
+	case 10, 17:
+		return ir.LinkageWeakODR
+	case 4, 18:
+		return ir.LinkageLinkOnce
+	case 11, 19:
+		return ir.LinkageLinkOnceODR
+	default:
+		panic(fmt.Errorf("support for linkage code %d not yet implemented", code))
+	}
+}
+
+// visibility returns the LLVM IR visibility style of the given visibility code.
+func visibility(code uint64) ir.Visibility {
+	switch code {
+	case 0:
+		// default visibility is not printed by llvm-dis.
+		return ir.VisibilityNone
+	case 1:
+		return ir.VisibilityHidden
+	case 2:
+		return ir.VisibilityProtected
+	default:
+		panic(fmt.Errorf("support for visibility code %d not yet implemented", code))
+	}
+}
+
+// dllStorageClass returns the LLVM IR DLL storage class of the given DLL
+// storage class code.
+func dllStorageClass(code uint64) ir.DLLStorageClass {
+	switch code {
+	case 0:
+		return ir.DLLStorageClassNone
+	case 1:
+		return ir.DLLStorageClassDLLImport
+	case 2:
+		return ir.DLLStorageClassDLLExport
+	default:
+		panic(fmt.Errorf("support for DLL storage class code %d not yet implemented", code))
+	}
+}
+
+// unnamedAddr returns the LLVM IR unnamed address specifier of the given
+// unnamed_addr code.
+func unnamedAddr(code uint64) ir.UnnamedAddr {
+	switch code {
+	case 0:
+		return ir.UnnamedAddrNone
+	case 1:
+		return ir.UnnamedAddrUnnamedAddr
+	case 2:
+		return ir.UnnamedAddrLocalUnnamedAddr
+	default:
+		panic(fmt.Errorf("support for unnamed_addr code %d not yet implemented", code))
+	}
+}
+
+// alignment returns the alignment in bytes of the given encoded alignment,
+// which is stored as log2(align)+1, where 0 denotes no alignment.
+func alignment(code uint64) int {
+	if code == 0 {
+		return 0
+	}
+	return 1 << (code - 1)
+}
+
+// callConvs maps from calling convention codes to LLVM IR calling
+// conventions.
+var callConvs = map[uint64]ir.CallConv{
+	0:  ir.CallConvNone,
+	8:  ir.CallConvFast,
+	9:  ir.CallConvCold,
+	10: ir.CallConvGHC,
+	11: ir.CallConvHiPE,
+	12: ir.CallConvWebKit_JS,
+	13: ir.CallConvAnyReg,
+	14: ir.CallConvPreserveMost,
+	15: ir.CallConvPreserveAll,
+	16: ir.CallConvSwift,
+	17: ir.CallConvCXX_Fast_TLS,
+	64: ir.CallConvX86_StdCall,
+	65: ir.CallConvX86_FastCall,
+	66: ir.CallConvARM_APCS,
+	67: ir.CallConvARM_AAPCS,
+	68: ir.CallConvARM_AAPCS_VFP,
+	69: ir.CallConvMSP430_Intr,
+	70: ir.CallConvX86_ThisCall,
+	71: ir.CallConvPTX_Kernel,
+	72: ir.CallConvPTX_Device,
+	75: ir.CallConvSPIR_Func,
+	76: ir.CallConvSPIR_Kernel,
+	77: ir.CallConvIntel_OCL_BI,
+	78: ir.CallConvX86_64_SysV,
+	79: ir.CallConvX86_64_Win64,
+	80: ir.CallConvX86_VectorCall,
+	81: ir.CallConvHHVM,
+	82: ir.CallConvHHVM_C,
+	83: ir.CallConvX86_Intr,
+	84: ir.CallConvAVR_Intr,
+	85: ir.CallConvAVR_Signal,
+	86: ir.CallConvAVR_Builtin,
+	87: ir.CallConvAMDGPU_VS,
+	88: ir.CallConvAMDGPU_GS,
+	89: ir.CallConvAMDGPU_PS,
+	90: ir.CallConvAMDGPU_CS,
+	91: ir.CallConvAMDGPU_Kernel,
+	92: ir.CallConvX86_RegCall,
+}
+
+// callConv returns the LLVM IR calling convention of the given calling
+// convention code.
+func callConv(code uint64) ir.CallConv {
+	cc, ok := callConvs[code]
+	if !ok {
+		panic(fmt.Errorf("support for calling convention %d not yet implemented", code))
+	}
+	return cc
+}
@@ -0,0 +1,158 @@
+package bitstream
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Encodings of abbreviation operands.
+const (
+	encFixed = 1
+	encVBR   = 2
+	encArray = 3
+	encChar6 = 4
+	encBlob  = 5
+)
+
+// abbrev is an abbreviation, describing the encoding of record operands.
+type abbrev struct {
+	// Abbreviation operands; the first operand encodes the record code.
+	ops []*abbrevOp
+}
+
+// abbrevOp is an abbreviation operand.
+type abbrevOp struct {
+	// Literal value; valid if enc is 0.
+	lit uint64
+	// Operand encoding; or 0 if literal.
+	enc uint64
+	// Width in bits of fixed and VBR encoded operands.
+	width uint
+}
+
+// parseAbbrev parses the abbreviation following a DEFINE_ABBREV abbreviation
+// ID.
+func (d *decoder) parseAbbrev() (*abbrev, error) {
+	n, err := d.r.vbr(5)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a := &abbrev{}
+	for i := uint64(0); i < n; i++ {
+		isLit, err := d.r.fixed(1)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if isLit == 1 {
+			lit, err := d.r.vbr(8)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			a.ops = append(a.ops, &abbrevOp{lit: lit})
+			continue
+		}
+		enc, err := d.r.fixed(3)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		op := &abbrevOp{enc: enc}
+		switch enc {
+		case encFixed, encVBR:
+			width, err := d.r.vbr(5)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if width == 0 {
+				// Fixed and VBR operands of zero width are literal zeros.
+				op = &abbrevOp{lit: 0}
+			}
+			op.width = uint(width)
+		case encArray, encChar6, encBlob:
+			// no width.
+		default:
+			return nil, errors.Errorf("invalid abbreviation operand encoding %d", enc)
+		}
+		a.ops = append(a.ops, op)
+	}
+	if len(a.ops) == 0 {
+		return nil, errors.New("invalid abbreviation; missing operands")
+	}
+	return a, nil
+}
+
+// parseAbbrevRecord parses a record encoded using the given abbreviation.
+func (d *decoder) parseAbbrevRecord(a *abbrev) (*Record, error) {
+	var vals []uint64
+	rec := &Record{}
+	for i := 0; i < len(a.ops); i++ {
+		op := a.ops[i]
+		switch op.enc {
+		case encArray:
+			if i != len(a.ops)-2 {
+				return nil, errors.New("invalid array operand; must be second to last operand of abbreviation")
+			}
+			n, err := d.r.vbr(6)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			elem := a.ops[i+1]
+			for j := uint64(0); j < n; j++ {
+				v, err := d.scalar(elem)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+				vals = append(vals, v)
+			}
+			i++
+		case encBlob:
+			if i != len(a.ops)-1 {
+				return nil, errors.New("invalid blob operand; must be last operand of abbreviation")
+			}
+			n, err := d.r.vbr(6)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			d.r.align32()
+			blob, err := d.r.bytes(n)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			d.r.align32()
+			rec.Blob = blob
+		default:
+			v, err := d.scalar(op)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			vals = append(vals, v)
+		}
+	}
+	if len(vals) == 0 {
+		return nil, errors.New("invalid abbreviated record; missing record code")
+	}
+	rec.Code = vals[0]
+	rec.Ops = vals[1:]
+	return rec, nil
+}
+
+// scalar reads a scalar operand with the given encoding.
+func (d *decoder) scalar(op *abbrevOp) (uint64, error) {
+	switch op.enc {
+	case 0:
+		return op.lit, nil
+	case encFixed:
+		return d.r.fixed(op.width)
+	case encVBR:
+		return d.r.vbr(op.width)
+	case encChar6:
+		v, err := d.r.fixed(6)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		return uint64(char6[v]), nil
+	default:
+		return 0, errors.Errorf("invalid scalar operand encoding %d", op.enc)
+	}
+}
+
+// char6 maps from 6-bit character encoding to characters.
+const char6 = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789._"
@@ -0,0 +1,241 @@
+// Package bitstream implements access to the generic bitstream container
+// format of LLVM bitcode files.
+//
+// References:
+//    https://llvm.org/docs/BitCodeFormat.html
+package bitstream
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Standard abbreviation IDs.
+const (
+	abbrevEndBlock       = 0
+	abbrevEnterSubblock  = 1
+	abbrevDefine         = 2
+	abbrevUnabbrevRecord = 3
+	// First application defined abbreviation ID.
+	abbrevFirstApp = 4
+)
+
+// BlockInfoID is the block ID of the standard BLOCKINFO block.
+const BlockInfoID = 0
+
+// Record codes of the BLOCKINFO block.
+const (
+	blockInfoSetBID = 1
+)
+
+// Width in bits of abbreviation IDs at the top level of a stream.
+const topLevelWidth = 2
+
+// Entry is a block or record of a bitstream.
+//
+// An Entry has one of the following underlying types.
+//
+//    *bitstream.Block
+//    *bitstream.Record
+type Entry interface {
+	// isEntry ensures that only bitstream entries can be assigned to the
+	// bitstream.Entry interface.
+	isEntry()
+}
+
+// Block is a block of a bitstream, holding records and nested blocks.
+type Block struct {
+	// Block ID.
+	ID uint64
+	// Records and nested blocks, in stream order.
+	Entries []Entry
+}
+
+// Record is a record of a bitstream block.
+type Record struct {
+	// Record code.
+	Code uint64
+	// Record operands.
+	Ops []uint64
+	// Blob operand; or nil if not present.
+	Blob []byte
+}
+
+// isEntry ensures that only bitstream entries can be assigned to the
+// bitstream.Entry interface.
+func (*Block) isEntry() {}
+
+// isEntry ensures that only bitstream entries can be assigned to the
+// bitstream.Entry interface.
+func (*Record) isEntry() {}
+
+// Parse parses the top-level blocks of the given bitstream, which must not
+// include the magic number of the stream. The BLOCKINFO blocks of the stream
+// are interpreted by the parser and omitted from the output.
+func Parse(buf []byte) ([]*Block, error) {
+	d := &decoder{
+		r:         &reader{buf: buf},
+		blockInfo: make(map[uint64][]*abbrev),
+	}
+	var blocks []*Block
+	for !d.r.atEOF() {
+		id, err := d.r.fixed(topLevelWidth)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if id != abbrevEnterSubblock {
+			return nil, errors.Errorf("invalid top-level abbreviation ID %d; expected ENTER_SUBBLOCK", id)
+		}
+		block, err := d.enterBlock()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+// decoder tracks the state of a bitstream during parsing.
+type decoder struct {
+	// Underlying bit reader.
+	r *reader
+	// Abbreviations registered through BLOCKINFO blocks, indexed by block ID.
+	blockInfo map[uint64][]*abbrev
+}
+
+// enterBlock parses the block following an ENTER_SUBBLOCK abbreviation ID. A
+// nil block is returned for BLOCKINFO blocks.
+func (d *decoder) enterBlock() (*Block, error) {
+	id, err := d.r.vbr(8)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	width, err := d.r.vbr(4)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	d.r.align32()
+	// Length of block in 32-bit words; not needed as the block is parsed in
+	// full.
+	if _, err := d.r.fixed(32); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if width < 1 || width > 32 {
+		return nil, errors.Errorf("invalid abbreviation ID width %d of block %d", width, id)
+	}
+	if id == BlockInfoID {
+		return nil, d.parseBlockInfo(uint(width))
+	}
+	return d.parseBlock(id, uint(width))
+}
+
+// parseBlock parses the contents of the block with the given ID up to and
+// including its END_BLOCK.
+func (d *decoder) parseBlock(id uint64, width uint) (*Block, error) {
+	block := &Block{ID: id}
+	abbrevs := append([]*abbrev(nil), d.blockInfo[id]...)
+	for {
+		abbrevID, err := d.r.fixed(width)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		switch abbrevID {
+		case abbrevEndBlock:
+			d.r.align32()
+			return block, nil
+		case abbrevEnterSubblock:
+			sub, err := d.enterBlock()
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if sub != nil {
+				block.Entries = append(block.Entries, sub)
+			}
+		case abbrevDefine:
+			a, err := d.parseAbbrev()
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			abbrevs = append(abbrevs, a)
+		default:
+			rec, err := d.parseRecord(abbrevID, abbrevs)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to parse record of block %d", id)
+			}
+			block.Entries = append(block.Entries, rec)
+		}
+	}
+}
+
+// parseBlockInfo parses the contents of a BLOCKINFO block, registering the
+// abbreviations it defines.
+func (d *decoder) parseBlockInfo(width uint) error {
+	var (
+		cur    uint64
+		hasCur bool
+	)
+	for {
+		abbrevID, err := d.r.fixed(width)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		switch abbrevID {
+		case abbrevEndBlock:
+			d.r.align32()
+			return nil
+		case abbrevEnterSubblock:
+			return errors.New("invalid nested block in BLOCKINFO block")
+		case abbrevDefine:
+			a, err := d.parseAbbrev()
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if !hasCur {
+				return errors.New("abbreviation defined in BLOCKINFO block before SETBID record")
+			}
+			d.blockInfo[cur] = append(d.blockInfo[cur], a)
+		default:
+			rec, err := d.parseRecord(abbrevID, nil)
+			if err != nil {
+				return errors.Wrap(err, "unable to parse record of BLOCKINFO block")
+			}
+			// Block and record names are ignored.
+			if rec.Code == blockInfoSetBID {
+				if len(rec.Ops) < 1 {
+					return errors.New("invalid SETBID record; missing block ID")
+				}
+				cur, hasCur = rec.Ops[0], true
+			}
+		}
+	}
+}
+
+// parseRecord parses a record with the given abbreviation ID, using the
+// abbreviations available in the current block.
+func (d *decoder) parseRecord(abbrevID uint64, abbrevs []*abbrev) (*Record, error) {
+	if abbrevID == abbrevUnabbrevRecord {
+		code, err := d.r.vbr(6)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		n, err := d.r.vbr(6)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rec := &Record{Code: code}
+		for i := uint64(0); i < n; i++ {
+			op, err := d.r.vbr(6)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			rec.Ops = append(rec.Ops, op)
+		}
+		return rec, nil
+	}
+	idx := abbrevID - abbrevFirstApp
+	if idx >= uint64(len(abbrevs)) {
+		return nil, errors.Errorf("undefined abbreviation ID %d", abbrevID)
+	}
+	return d.parseAbbrevRecord(abbrevs[idx])
+}
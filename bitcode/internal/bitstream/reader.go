@@ -0,0 +1,98 @@
+package bitstream
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// reader reads bits in little-endian order from a byte slice.
+type reader struct {
+	// Underlying buffer.
+	buf []byte
+	// Current position in bits.
+	pos uint64
+}
+
+// fixed reads a fixed-width value of n bits.
+func (r *reader) fixed(n uint) (uint64, error) {
+	if n == 0 {
+		return 0, nil
+	}
+	if n > 64 {
+		return 0, errors.Errorf("invalid fixed width %d; exceeds 64 bits", n)
+	}
+	if r.pos+uint64(n) > uint64(len(r.buf))*8 {
+		return 0, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	var x uint64
+	for i := uint(0); i < n; {
+		off := uint(r.pos % 8)
+		m := 8 - off
+		if m > n-i {
+			m = n - i
+		}
+		bits := uint64(r.buf[r.pos/8]>>off) & (1<<m - 1)
+		x |= bits << i
+		i += m
+		r.pos += uint64(m)
+	}
+	return x, nil
+}
+
+// vbr reads a variable bit rate value, encoded in chunks of n bits.
+func (r *reader) vbr(n uint) (uint64, error) {
+	if n < 2 || n > 32 {
+		return 0, errors.Errorf("invalid VBR width %d", n)
+	}
+	hi := uint64(1) << (n - 1)
+	var x uint64
+	for shift := uint(0); ; shift += n - 1 {
+		if shift >= 64 {
+			return 0, errors.New("VBR value overflows 64 bits")
+		}
+		chunk, err := r.fixed(n)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		x |= (chunk &^ hi) << shift
+		if chunk&hi == 0 {
+			return x, nil
+		}
+	}
+}
+
+// align32 skips to the next 32-bit boundary.
+func (r *reader) align32() {
+	r.pos = (r.pos + 31) &^ 31
+}
+
+// bytes reads n bytes; the current position must be byte aligned.
+func (r *reader) bytes(n uint64) ([]byte, error) {
+	start := r.pos / 8
+	if start+n > uint64(len(r.buf)) {
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	r.pos += n * 8
+	return r.buf[start : start+n], nil
+}
+
+// atEOF reports whether the end of the buffer has been reached.
+func (r *reader) atEOF() bool {
+	return r.pos >= uint64(len(r.buf))*8
+}
+
+// ReadVBRs reads n variable bit rate values, encoded in chunks of width bits,
+// from the start of the given bitstream buffer.
+func ReadVBRs(buf []byte, width uint, n uint64) ([]uint64, error) {
+	r := &reader{buf: buf}
+	var xs []uint64
+	for i := uint64(0); i < n; i++ {
+		x, err := r.vbr(width)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		xs = append(xs, x)
+	}
+	return xs, nil
+}
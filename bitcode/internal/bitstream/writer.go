@@ -0,0 +1,152 @@
+package bitstream
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Write encodes the given top-level blocks as a bitstream, which does not
+// include the magic number of the stream.
+//
+// Records are emitted unabbreviated, with the exception of records holding a
+// blob operand, which are preceded by the definition of a matching
+// abbreviation.
+func Write(blocks []*Block) []byte {
+	w := &writer{}
+	for _, block := range blocks {
+		w.writeBlock(block, topLevelWidth)
+	}
+	return w.buf
+}
+
+// WriteVBRs encodes the given values as variable bit rate values in chunks of
+// width bits, padded to a multiple of 32 bits.
+func WriteVBRs(xs []uint64, width uint) []byte {
+	w := &writer{}
+	for _, x := range xs {
+		w.vbr(x, width)
+	}
+	w.align32()
+	return w.buf
+}
+
+// writer writes bits in little-endian order to a byte slice.
+type writer struct {
+	// Underlying buffer.
+	buf []byte
+	// Current position in bits.
+	pos uint64
+}
+
+// fixed writes the n least significant bits of x.
+func (w *writer) fixed(x uint64, n uint) {
+	for i := uint(0); i < n; {
+		off := uint(w.pos % 8)
+		if off == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		m := 8 - off
+		if m > n-i {
+			m = n - i
+		}
+		w.buf[w.pos/8] |= byte((x>>i)&(1<<m-1)) << off
+		i += m
+		w.pos += uint64(m)
+	}
+}
+
+// vbr writes x as a variable bit rate value, encoded in chunks of n bits.
+func (w *writer) vbr(x uint64, n uint) {
+	hi := uint64(1) << (n - 1)
+	for x >= hi {
+		w.fixed(x&(hi-1)|hi, n)
+		x >>= n - 1
+	}
+	w.fixed(x, n)
+}
+
+// align32 pads the stream with zero bits to the next 32-bit boundary.
+func (w *writer) align32() {
+	w.pos = (w.pos + 31) &^ 31
+	for uint64(len(w.buf)) < w.pos/8 {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// writeBlock writes the given block, preceded by an ENTER_SUBBLOCK
+// abbreviation ID of the given width.
+func (w *writer) writeBlock(block *Block, width uint) {
+	// Abbreviations are defined for each record holding a blob operand.
+	nblobs := 0
+	for _, entry := range block.Entries {
+		if rec, ok := entry.(*Record); ok && rec.Blob != nil {
+			nblobs++
+		}
+	}
+	subWidth := uint(bits.Len(uint(abbrevFirstApp + nblobs - 1)))
+	if subWidth < 3 {
+		subWidth = 3
+	}
+	w.fixed(abbrevEnterSubblock, width)
+	w.vbr(block.ID, 8)
+	w.vbr(uint64(subWidth), 4)
+	w.align32()
+	// Placeholder of block length in 32-bit words.
+	lenPos := len(w.buf)
+	w.fixed(0, 32)
+	nabbrevs := 0
+	for _, entry := range block.Entries {
+		switch entry := entry.(type) {
+		case *Block:
+			w.writeBlock(entry, subWidth)
+		case *Record:
+			if entry.Blob == nil {
+				w.writeRecord(entry, subWidth)
+				continue
+			}
+			w.writeBlobRecord(entry, subWidth, abbrevFirstApp+uint64(nabbrevs))
+			nabbrevs++
+		}
+	}
+	w.fixed(abbrevEndBlock, subWidth)
+	w.align32()
+	n := (len(w.buf) - lenPos - 4) / 4
+	binary.LittleEndian.PutUint32(w.buf[lenPos:], uint32(n))
+}
+
+// writeRecord writes the given record as an unabbreviated record.
+func (w *writer) writeRecord(rec *Record, width uint) {
+	w.fixed(abbrevUnabbrevRecord, width)
+	w.vbr(rec.Code, 6)
+	w.vbr(uint64(len(rec.Ops)), 6)
+	for _, op := range rec.Ops {
+		w.vbr(op, 6)
+	}
+}
+
+// writeBlobRecord writes the given record holding a blob operand, preceded by
+// the definition of the abbreviation with the given ID used to encode it.
+func (w *writer) writeBlobRecord(rec *Record, width uint, abbrevID uint64) {
+	// Abbreviation: literal record code, VBR6 operands and blob.
+	w.fixed(abbrevDefine, width)
+	w.vbr(uint64(1+len(rec.Ops)+1), 5)
+	w.fixed(1, 1)
+	w.vbr(rec.Code, 8)
+	for range rec.Ops {
+		w.fixed(0, 1)
+		w.fixed(encVBR, 3)
+		w.vbr(6, 5)
+	}
+	w.fixed(0, 1)
+	w.fixed(encBlob, 3)
+	// Record.
+	w.fixed(abbrevID, width)
+	for _, op := range rec.Ops {
+		w.vbr(op, 6)
+	}
+	w.vbr(uint64(len(rec.Blob)), 6)
+	w.align32()
+	w.buf = append(w.buf, rec.Blob...)
+	w.pos += uint64(len(rec.Blob)) * 8
+	w.align32()
+}
@@ -0,0 +1,211 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// metadataKindBlock decodes the given metadata kind block.
+func (d *decoder) metadataKindBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		if rec.Code == metadataCodeKind {
+			d.metadataKind(rec)
+		}
+	}
+}
+
+// metadataKind decodes the given metadata kind record.
+func (d *decoder) metadataKind(rec *bitstream.Record) {
+	// [kind ID, name chars...]
+	d.expectOps(rec, 1)
+	d.mdKinds[rec.Ops[0]] = recordString(rec.Ops[1:])
+}
+
+// metadataBlock decodes the given metadata block, appending its metadata to the
+// metadata table.
+func (d *decoder) metadataBlock(block *bitstream.Block) {
+	recs := records(block)
+	// Allocate the metadata of the block in advance, as metadata nodes may
+	// refer to metadata defined later on in the block.
+	start := len(d.mds)
+	for _, rec := range recs {
+		switch rec.Code {
+		case metadataCodeStrings:
+			d.metadataStrings(rec)
+		case metadataCodeValue:
+			d.mds = append(d.mds, d.metadataValue(rec))
+		case metadataCodeNode, metadataCodeDistinctNode:
+			// Distinct nodes are decoded as plain nodes, as distinct nodes are
+			// not yet supported by the ir package.
+			d.mds = append(d.mds, &metadata.Metadata{})
+		case metadataCodeName, metadataCodeNamedNode, metadataCodeKind, metadataCodeGlobalDeclAttachment, metadataCodeIndexOffset, metadataCodeIndex:
+			// Handled below.
+		default:
+			panic(fmt.Errorf("support for metadata record code %d not yet implemented", rec.Code))
+		}
+	}
+	id := start
+	var name string
+	for _, rec := range recs {
+		switch rec.Code {
+		case metadataCodeStrings:
+			id += int(rec.Ops[0])
+		case metadataCodeValue:
+			id++
+		case metadataCodeNode, metadataCodeDistinctNode:
+			// [n x (metadata ID + 1)]
+			md := d.mds[id].(*metadata.Metadata)
+			for _, op := range rec.Ops {
+				if op == 0 {
+					panic(fmt.Errorf("support for null metadata operands not yet implemented"))
+				}
+				md.Nodes = append(md.Nodes, d.metadata(op-1))
+			}
+			id++
+		case metadataCodeName:
+			// [name chars...]
+			name = recordString(rec.Ops)
+		case metadataCodeNamedNode:
+			// [n x metadata ID]
+			named := &metadata.Named{Name: name}
+			for _, op := range rec.Ops {
+				named.Metadata = append(named.Metadata, d.metadataNode(op))
+			}
+			d.m.NamedMetadata = append(d.m.NamedMetadata, named)
+		case metadataCodeKind:
+			d.metadataKind(rec)
+		case metadataCodeGlobalDeclAttachment:
+			// [value ID, n x (kind ID, metadata ID)]
+			d.expectOps(rec, 1)
+			v := d.moduleValue(rec.Ops[0])
+			switch v := v.(type) {
+			case *ir.Global:
+				d.attachments(v.Metadata, rec.Ops[1:])
+			case *ir.Function:
+				d.attachments(v.Metadata, rec.Ops[1:])
+			default:
+				panic(fmt.Errorf("invalid metadata attachment value type; expected *ir.Global or *ir.Function, got %T", v))
+			}
+		case metadataCodeIndexOffset, metadataCodeIndex:
+			// The index of metadata offsets is only needed for lazy loading.
+		}
+	}
+}
+
+// metadataStrings decodes the given metadata strings record.
+func (d *decoder) metadataStrings(rec *bitstream.Record) {
+	// [count, offset] blob([n x vbr6 lengths], [chars...])
+	d.expectOps(rec, 2)
+	count, offset := rec.Ops[0], rec.Ops[1]
+	if offset > uint64(len(rec.Blob)) {
+		panic(fmt.Errorf("invalid metadata strings offset %d; blob of size %d", offset, len(rec.Blob)))
+	}
+	lengths, err := bitstream.ReadVBRs(rec.Blob[:offset], 6, count)
+	if err != nil {
+		panic(fmt.Errorf("invalid metadata string lengths; %v", err))
+	}
+	chars := rec.Blob[offset:]
+	for _, n := range lengths {
+		if n > uint64(len(chars)) {
+			panic(fmt.Errorf("invalid metadata string length %d; %d characters remaining", n, len(chars)))
+		}
+		d.mds = append(d.mds, &metadata.String{Val: string(chars[:n])})
+		chars = chars[n:]
+	}
+}
+
+// metadataValue decodes the given metadata value record.
+func (d *decoder) metadataValue(rec *bitstream.Record) metadata.Node {
+	// [type, value ID]
+	d.expectOps(rec, 2)
+	typ := d.typ(rec.Ops[0])
+	switch typ.(type) {
+	case *types.VoidType, *types.MetadataType:
+		panic(fmt.Errorf("invalid metadata value type %v", typ))
+	}
+	var v value.Value
+	if d.f == nil {
+		v = d.moduleValue(rec.Ops[1])
+	} else {
+		// Function-local metadata may refer to values defined later on in the
+		// function.
+		v = d.value(rec.Ops[1], typ)
+	}
+	if c, ok := v.(constant.Constant); ok {
+		if md, ok := c.(metadata.Node); ok {
+			return md
+		}
+	}
+	return &metadata.Value{X: v}
+}
+
+// metadataAttachmentBlock decodes the given metadata attachment block of the
+// function being decoded.
+func (d *decoder) metadataAttachmentBlock(block *bitstream.Block) {
+	for _, rec := range records(block) {
+		if rec.Code != metadataCodeAttachment {
+			continue
+		}
+		// Function attachment: [n x (kind ID, metadata ID)]
+		// Instruction attachment: [instruction index, n x (kind ID, metadata ID)]
+		if len(rec.Ops)%2 == 0 {
+			d.attachments(d.f.Metadata, rec.Ops)
+			continue
+		}
+		index := rec.Ops[0]
+		if index >= uint64(len(d.insts)) {
+			panic(fmt.Errorf("invalid metadata attachment; instruction index %d out of range", index))
+		}
+		d.attachments(d.insts[index].MDAttachments(), rec.Ops[1:])
+	}
+}
+
+// attachments decodes the given metadata attachments, adding them to mds.
+func (d *decoder) attachments(mds map[string]*metadata.Metadata, ops []uint64) {
+	// [n x (kind ID, metadata ID)]
+	if len(ops)%2 != 0 {
+		panic(fmt.Errorf("invalid metadata attachments; odd number of operands"))
+	}
+	for i := 0; i < len(ops); i += 2 {
+		kind, ok := d.mdKinds[ops[i]]
+		if !ok {
+			panic(fmt.Errorf("invalid metadata kind ID %d; no such metadata kind", ops[i]))
+		}
+		mds[kind] = d.metadataNode(ops[i+1])
+	}
+}
+
+// metadata returns the metadata of the given metadata ID.
+func (d *decoder) metadata(id uint64) metadata.Node {
+	if id >= uint64(len(d.mds)) || d.mds[id] == nil {
+		panic(fmt.Errorf("invalid metadata ID %d; no such metadata", id))
+	}
+	return d.mds[id]
+}
+
+// metadataNode returns the metadata node of the given metadata ID.
+func (d *decoder) metadataNode(id uint64) *metadata.Metadata {
+	md := d.metadata(id)
+	node, ok := md.(*metadata.Metadata)
+	if !ok {
+		panic(fmt.Errorf("invalid metadata type of metadata ID %d; expected *metadata.Metadata, got %T", id, md))
+	}
+	return node
+}
+
+// metadataOperand returns the value of a metadata operand of the given
+// metadata ID, as used by call instructions.
+func (d *decoder) metadataOperand(id uint64) value.Value {
+	switch md := d.metadata(id).(type) {
+	case constant.Constant:
+		return &metadata.Value{X: md}
+	default:
+		return md
+	}
+}
@@ -0,0 +1,426 @@
+package bitcode
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// order names and orders the unnamed and module-level entities of the decoded
+// module, as numbered and printed by llvm-dis.
+func (d *decoder) order() {
+	d.nameGlobals()
+	d.orderTypes()
+	d.orderAttrGroups()
+	d.orderComdats()
+	d.orderMetadata()
+}
+
+// nameGlobals assigns IDs to the unnamed global variables, aliases, IFuncs and
+// functions of the module.
+func (d *decoder) nameGlobals() {
+	var globals []value.Named
+	for _, g := range d.m.Globals {
+		globals = append(globals, g)
+	}
+	for _, a := range d.m.Aliases {
+		globals = append(globals, a)
+	}
+	for _, i := range d.m.IFuncs {
+		globals = append(globals, i)
+	}
+	for _, f := range d.m.Funcs {
+		globals = append(globals, f)
+	}
+	id := 0
+	for _, g := range globals {
+		if len(g.GetName()) == 0 {
+			g.SetName(strconv.Itoa(id))
+			id++
+		}
+	}
+}
+
+// orderTypes assigns IDs to the unnamed identified struct types of the module,
+// and records the type definitions of the module; numbered types precede named
+// types.
+func (d *decoder) orderTypes() {
+	finder := newTypeFinder(d)
+	finder.module(d.m)
+	var numbered, named []types.Type
+	for _, t := range finder.structs {
+		if !d.identified[t] {
+			continue
+		}
+		if len(t.Name) == 0 {
+			t.Name = strconv.Itoa(len(numbered))
+			numbered = append(numbered, t)
+		} else {
+			named = append(named, t)
+		}
+	}
+	d.m.Types = append(numbered, named...)
+}
+
+// orderAttrGroups assigns IDs to the attribute groups of function attributes,
+// first of functions and then of call sites.
+func (d *decoder) orderAttrGroups() {
+	d.m.AttrGroups = nil
+	add := func(attrs []attr.Attribute) {
+		for _, a := range attrs {
+			if g, ok := a.(*attr.Group); ok {
+				d.addAttrGroup(g)
+			}
+		}
+	}
+	for _, f := range d.m.Funcs {
+		add(f.FuncAttrs)
+	}
+	for _, f := range d.m.Funcs {
+		for _, block := range f.Blocks {
+			for _, inst := range block.Insts {
+				if g, ok := d.callAttrs[inst]; ok {
+					d.addAttrGroup(g)
+				}
+			}
+			if g, ok := d.callAttrs[block.Term]; ok {
+				d.addAttrGroup(g)
+			}
+		}
+	}
+}
+
+// addAttrGroup assigns the next ID to the given attribute group, unless it
+// already has an ID.
+func (d *decoder) addAttrGroup(g *attr.Group) {
+	if len(g.ID) > 0 {
+		return
+	}
+	g.ID = strconv.Itoa(len(d.m.AttrGroups))
+	d.m.AttrGroups = append(d.m.AttrGroups, g)
+}
+
+// orderComdats records the comdat definitions used by functions and global
+// variables, in order of first use.
+func (d *decoder) orderComdats() {
+	var comdats []*ir.Comdat
+	seen := make(map[*ir.Comdat]bool)
+	add := func(c *ir.Comdat) {
+		if c != nil && !seen[c] {
+			seen[c] = true
+			comdats = append(comdats, c)
+		}
+	}
+	for _, f := range d.m.Funcs {
+		add(f.Comdat)
+	}
+	for _, g := range d.m.Globals {
+		add(g.Comdat)
+	}
+	d.m.Comdats = comdats
+}
+
+// orderMetadata assigns IDs to the metadata nodes of the module in pre-order,
+// and records the metadata definitions of the module in order of their IDs.
+func (d *decoder) orderMetadata() {
+	d.m.Metadata = nil
+	seen := make(map[*metadata.Metadata]bool)
+	var add func(md *metadata.Metadata)
+	add = func(md *metadata.Metadata) {
+		if seen[md] {
+			return
+		}
+		seen[md] = true
+		md.ID = strconv.Itoa(len(d.m.Metadata))
+		d.m.Metadata = append(d.m.Metadata, md)
+		for _, node := range md.Nodes {
+			if n, ok := node.(*metadata.Metadata); ok {
+				add(n)
+			}
+		}
+	}
+	attachments := func(mds map[string]*metadata.Metadata) {
+		for _, kind := range d.attachmentKinds(mds) {
+			add(mds[kind])
+		}
+	}
+	for _, g := range d.m.Globals {
+		attachments(g.Metadata)
+	}
+	for _, named := range d.m.NamedMetadata {
+		for _, md := range named.Metadata {
+			add(md)
+		}
+	}
+	for _, f := range d.m.Funcs {
+		attachments(f.Metadata)
+		for _, block := range f.Blocks {
+			for _, inst := range block.Insts {
+				// Metadata node arguments of intrinsic calls.
+				if call, ok := inst.(*ir.InstCall); ok && isIntrinsic(call.Callee) {
+					for _, arg := range call.ArgValues() {
+						if md, ok := arg.(*metadata.Metadata); ok {
+							add(md)
+						}
+					}
+				}
+				attachments(inst.MDAttachments())
+			}
+			attachments(block.Term.MDAttachments())
+		}
+	}
+}
+
+// attachmentKinds returns the metadata kinds of the given metadata
+// attachments, ordered by metadata kind ID.
+func (d *decoder) attachmentKinds(mds map[string]*metadata.Metadata) []string {
+	kindIDs := make(map[string]uint64)
+	for id, kind := range d.mdKinds {
+		kindIDs[kind] = id
+	}
+	var kinds []string
+	for kind := range mds {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kindIDs[kinds[i]] < kindIDs[kinds[j]]
+	})
+	return kinds
+}
+
+// isIntrinsic reports whether the given callee is an intrinsic function.
+func isIntrinsic(callee value.Value) bool {
+	f, ok := callee.(*ir.Function)
+	return ok && strings.HasPrefix(f.Name, "llvm.")
+}
+
+// === [ Type finder ] =========================================================
+
+// typeFinder locates the struct types of a module, in the order located by the
+// TypeFinder of LLVM.
+type typeFinder struct {
+	d *decoder
+	// Struct types, in order of occurrence.
+	structs []*types.StructType
+	// Visited types, constants and metadata nodes.
+	visitedTypes  map[types.Type]bool
+	visitedValues map[value.Value]bool
+	visitedMDs    map[*metadata.Metadata]bool
+}
+
+// newTypeFinder returns a new type finder.
+func newTypeFinder(d *decoder) *typeFinder {
+	return &typeFinder{
+		d:             d,
+		visitedTypes:  make(map[types.Type]bool),
+		visitedValues: make(map[value.Value]bool),
+		visitedMDs:    make(map[*metadata.Metadata]bool),
+	}
+}
+
+// module locates the struct types of the given module.
+func (finder *typeFinder) module(m *ir.Module) {
+	for _, g := range m.Globals {
+		finder.typ(g.Content)
+		if g.Init != nil {
+			finder.value(g.Init)
+		}
+	}
+	for _, a := range m.Aliases {
+		finder.typ(a.Typ.Elem)
+		finder.value(a.Aliasee)
+	}
+	for _, i := range m.IFuncs {
+		finder.typ(i.Typ.Elem)
+	}
+	for _, f := range m.Funcs {
+		finder.typ(f.Sig)
+		if f.Personality != nil {
+			finder.value(f.Personality)
+		}
+		for _, block := range f.Blocks {
+			for _, inst := range block.Insts {
+				finder.inst(inst)
+			}
+			finder.inst(block.Term)
+		}
+	}
+	for _, named := range m.NamedMetadata {
+		for _, md := range named.Metadata {
+			finder.metadata(md)
+		}
+	}
+}
+
+// inst locates the struct types of the given instruction.
+func (finder *typeFinder) inst(inst ir.Instruction) {
+	if v, ok := inst.(value.Value); ok {
+		finder.typ(v.Type())
+	}
+	for _, op := range operands(inst) {
+		finder.operand(op)
+	}
+	switch inst := inst.(type) {
+	case *ir.InstGetElementPtr:
+		finder.typ(inst.Elem)
+	case *ir.InstAlloca:
+		finder.typ(inst.Elem)
+	}
+	mds := inst.MDAttachments()
+	for _, kind := range finder.d.attachmentKinds(mds) {
+		finder.metadata(mds[kind])
+	}
+}
+
+// operand locates the struct types of the given instruction operand.
+func (finder *typeFinder) operand(op value.Value) {
+	switch op := op.(type) {
+	case *ir.Arg:
+		finder.operand(op.Value)
+	case *metadata.Metadata:
+		finder.metadata(op)
+	case *metadata.Value:
+		finder.value(op.X)
+	default:
+		finder.value(op)
+	}
+}
+
+// typ locates the struct types of the given type.
+func (finder *typeFinder) typ(t types.Type) {
+	if finder.visitedTypes[t] {
+		return
+	}
+	finder.visitedTypes[t] = true
+	worklist := []types.Type{t}
+	for len(worklist) > 0 {
+		t := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		if s, ok := t.(*types.StructType); ok {
+			finder.structs = append(finder.structs, s)
+		}
+		subs := subtypes(t)
+		for i := len(subs) - 1; i >= 0; i-- {
+			if sub := subs[i]; !finder.visitedTypes[sub] {
+				finder.visitedTypes[sub] = true
+				worklist = append(worklist, sub)
+			}
+		}
+	}
+}
+
+// value locates the struct types of the given constant; other values are
+// ignored.
+func (finder *typeFinder) value(v value.Value) {
+	switch v.(type) {
+	case *ir.Global, *ir.Function, *ir.Alias, *ir.IFunc:
+		return
+	case constant.Constant:
+	default:
+		return
+	}
+	if finder.visitedValues[v] {
+		return
+	}
+	finder.visitedValues[v] = true
+	finder.typ(v.Type())
+	if gep, ok := v.(*constant.ExprGetElementPtr); ok {
+		finder.typ(gep.Elem)
+	}
+	for _, op := range operands(v) {
+		finder.value(op)
+	}
+}
+
+// metadata locates the struct types of the given metadata node.
+func (finder *typeFinder) metadata(md *metadata.Metadata) {
+	if finder.visitedMDs[md] {
+		return
+	}
+	finder.visitedMDs[md] = true
+	for _, node := range md.Nodes {
+		switch node := node.(type) {
+		case *metadata.Metadata:
+			finder.metadata(node)
+		case *metadata.Value:
+			finder.value(node.X)
+		case constant.Constant:
+			finder.value(node)
+		}
+	}
+}
+
+// subtypes returns the contained types of the given type.
+func subtypes(t types.Type) []types.Type {
+	switch t := t.(type) {
+	case *types.PointerType:
+		return []types.Type{t.Elem}
+	case *types.FuncType:
+		ts := []types.Type{t.Ret}
+		for _, param := range t.Params {
+			ts = append(ts, param.Typ)
+		}
+		return ts
+	case *types.ArrayType:
+		return []types.Type{t.Elem}
+	case *types.VectorType:
+		return []types.Type{t.Elem}
+	case *types.StructType:
+		return t.Fields
+	default:
+		return nil
+	}
+}
+
+// operands returns the value operands of the given instruction or constant,
+// in order of occurrence.
+func operands(x interface{}) []value.Value {
+	var ops []value.Value
+	v := reflect.ValueOf(x).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Name == "Parent" {
+			continue
+		}
+		f := v.Field(i)
+		if !f.CanInterface() {
+			continue
+		}
+		switch f := f.Interface().(type) {
+		case *ir.BasicBlock:
+			// Basic blocks are not located by the type finder.
+		case value.Value:
+			ops = append(ops, f)
+		case []value.Value:
+			ops = append(ops, f...)
+		case []constant.Constant:
+			for _, c := range f {
+				ops = append(ops, c)
+			}
+		case []*ir.Incoming:
+			for _, inc := range f {
+				ops = append(ops, inc.X)
+			}
+		case []*ir.Case:
+			for _, c := range f {
+				ops = append(ops, c.X)
+			}
+		case []*ir.Clause:
+			for _, c := range f {
+				ops = append(ops, c.X)
+			}
+		case []*ir.OperandBundle:
+			for _, bundle := range f {
+				ops = append(ops, bundle.Inputs...)
+			}
+		}
+	}
+	return ops
+}
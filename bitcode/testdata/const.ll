@@ -0,0 +1,89 @@
+; ModuleID = 'testdata/const.bc'
+source_filename = "const.ll"
+
+%pair = type { i32, float }
+
+@i1t = global i1 true
+@i1f = global i1 false
+@i8 = global i8 -128
+@i32 = global i32 -42
+@i64 = global i64 -9223372036854775808
+@i128 = global i128 -170141183460469231731687303715884105728
+@i256 = global i256 123456789012345678901234567890123456789
+@i7 = global i7 63
+@h = global half 0xH3C00
+@f = global float 1.500000e+00
+@fnan = global float 0x7FF8000000000000
+@finf = global float 0x7FF0000000000000
+@d = global double -0.000000e+00
+@dnan = global double 0x7FF4000000000000
+@x80 = global x86_fp80 0xK4000C000000000000000
+@x80nan = global x86_fp80 0xK7FFFC000000000000000
+@q = global fp128 0xL00000000000000004000800000000000
+@ppc = global ppc_fp128 0xM3FF00000000000000000000000000000
+@str = global [4 x i8] c"abc\00"
+@str2 = global [3 x i8] c"a\0Ab"
+@data = global [3 x i32] [i32 1, i32 2, i32 3]
+@dataf = global [2 x double] [double 1.000000e+00, double 2.000000e+00]
+@vec = global <4 x i32> <i32 1, i32 2, i32 3, i32 4>
+@vecf = global <2 x float> <float 1.000000e+00, float 2.000000e+00>
+@agg = global %pair { i32 1, float 2.000000e+00 }
+@lit = global { i8*, i32 } { i8* null, i32 5 }
+@arr = global [2 x %pair] [%pair { i32 1, float 1.000000e+00 }, %pair zeroinitializer]
+@zero = global [8 x i32] zeroinitializer
+@undef = global i32 undef
+@nullp = global i32* null
+@nested = global [2 x [2 x i8]] [[2 x i8] c"ab", [2 x i8] c"cd"]
+@hvec = global <2 x half> <half 0xH3C00, half 0xH4000>
+@add = global i64 add nuw nsw (i64 ptrtoint (i32* @i32 to i64), i64 1)
+@sub = global i64 sub (i64 ptrtoint (i32* @i32 to i64), i64 2)
+@mul = global i64 mul nsw (i64 ptrtoint (i32* @i32 to i64), i64 3)
+@udiv = global i64 udiv exact (i64 ptrtoint (i32* @i32 to i64), i64 4)
+@sdiv = global i64 sdiv (i64 ptrtoint (i32* @i32 to i64), i64 5)
+@urem = global i64 urem (i64 ptrtoint (i32* @i32 to i64), i64 6)
+@srem = global i64 srem (i64 ptrtoint (i32* @i32 to i64), i64 7)
+@shl = global i64 shl nuw (i64 ptrtoint (i32* @i32 to i64), i64 8)
+@lshr = global i64 lshr exact (i64 ptrtoint (i32* @i32 to i64), i64 9)
+@ashr = global i64 ashr (i64 ptrtoint (i32* @i32 to i64), i64 10)
+@and = global i64 and (i64 ptrtoint (i32* @i32 to i64), i64 11)
+@or = global i64 or (i64 ptrtoint (i32* @i32 to i64), i64 12)
+@xor = global i64 xor (i64 ptrtoint (i32* @i32 to i64), i64 13)
+@fadd = global float fadd (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float), float 1.000000e+00)
+@fsub = global float fsub (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float), float 1.000000e+00)
+@fmul = global float bitcast (i32 ptrtoint (i32* @i32 to i32) to float)
+@fdiv = global float fdiv (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float), float 1.000000e+00)
+@frem = global float frem (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float), float 1.000000e+00)
+@trunc = global i8 ptrtoint (i32* @i32 to i8)
+@zext = global i128 zext (i64 ptrtoint (i32* @i32 to i64) to i128)
+@sext = global i128 sext (i64 ptrtoint (i32* @i32 to i64) to i128)
+@fptoui = global i32 fptoui (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float) to i32)
+@fptosi = global i32 fptosi (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float) to i32)
+@uitofp = global float uitofp (i64 ptrtoint (i32* @i32 to i64) to float)
+@sitofp = global float sitofp (i64 ptrtoint (i32* @i32 to i64) to float)
+@fptrunc = global float fptrunc (double bitcast (i64 ptrtoint (i32* @i32 to i64) to double) to float)
+@fpext = global double fpext (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float) to double)
+@inttoptr = global i8* inttoptr (i64 42 to i8*)
+@bitcast = global i8* bitcast (i32* @i32 to i8*)
+@asc = global i32 addrspace(1)* addrspacecast (i32* @i32 to i32 addrspace(1)*)
+@gep = global i32* getelementptr inbounds ([3 x i32], [3 x i32]* @data, i64 0, i64 1)
+@gepib = global i32* getelementptr inbounds ([3 x i32], [3 x i32]* @data, i64 0, i64 2)
+@gepir = global i32* getelementptr inbounds ([3 x i32], [3 x i32]* @data, i64 0, inrange i64 1)
+@gepst = global float* getelementptr inbounds (%pair, %pair* @agg, i32 0, i32 1)
+@select = global i32 select (i1 icmp eq (i64 ptrtoint (i32* @i32 to i64), i64 0), i32 1, i32 2)
+@icmp = global i1 icmp ult (i32* @i32, i32* @undef)
+@fcmp = global i1 fcmp olt (float bitcast (i32 ptrtoint (i32* @i32 to i32) to float), float 1.000000e+00)
+@ee = global i32 ptrtoint (i32* @i32 to i32)
+@ie = global <2 x i32> <i32 ptrtoint (i32* @i32 to i32), i32 2>
+@sv = global <2 x i32> <i32 ptrtoint (i32* @i32 to i32), i32 1>
+@ba = global i8* blockaddress(@fn, %bb)
+@fwd = global i32* @later
+@later = global i32 0
+@self = global i8* bitcast (i8** @self to i8*)
+
+define void @fn() {
+entry:
+  br label %bb
+
+bb:                                               ; preds = %entry
+  ret void
+}
@@ -0,0 +1,130 @@
+; ModuleID = 'testdata/expr_other.bc'
+source_filename = "expr_other.ll"
+
+define i1 @icmp_1() {
+  ret i1 true
+}
+
+define <2 x i1> @icmp_2() {
+  ret <2 x i1> <i1 true, i1 false>
+}
+
+define i1 @icmp_3() {
+  ret i1 false
+}
+
+define i1 @icmp_4() {
+  ret i1 true
+}
+
+define i1 @icmp_5() {
+  ret i1 false
+}
+
+define i1 @icmp_6() {
+  ret i1 false
+}
+
+define i1 @icmp_7() {
+  ret i1 true
+}
+
+define i1 @icmp_8() {
+  ret i1 true
+}
+
+define i1 @icmp_9() {
+  ret i1 false
+}
+
+define i1 @icmp_10() {
+  ret i1 false
+}
+
+define i1 @icmp_11() {
+  ret i1 true
+}
+
+define i1 @icmp_12() {
+  ret i1 true
+}
+
+define i1 @fcmp_1() {
+  ret i1 true
+}
+
+define <2 x i1> @fcmp_2() {
+  ret <2 x i1> <i1 true, i1 false>
+}
+
+define i1 @fcmp_3() {
+  ret i1 false
+}
+
+define i1 @fcmp_4() {
+  ret i1 false
+}
+
+define i1 @fcmp_5() {
+  ret i1 false
+}
+
+define i1 @fcmp_6() {
+  ret i1 false
+}
+
+define i1 @fcmp_7() {
+  ret i1 true
+}
+
+define i1 @fcmp_8() {
+  ret i1 true
+}
+
+define i1 @fcmp_9() {
+  ret i1 true
+}
+
+define i1 @fcmp_10() {
+  ret i1 true
+}
+
+define i1 @fcmp_11() {
+  ret i1 false
+}
+
+define i1 @fcmp_12() {
+  ret i1 false
+}
+
+define i1 @fcmp_13() {
+  ret i1 false
+}
+
+define i1 @fcmp_14() {
+  ret i1 true
+}
+
+define i1 @fcmp_15() {
+  ret i1 true
+}
+
+define i1 @fcmp_16() {
+  ret i1 true
+}
+
+define i1 @fcmp_17() {
+  ret i1 false
+}
+
+define i1 @fcmp_18() {
+  ret i1 true
+}
+
+define i32 @select_1() {
+  ret i32 42
+}
+
+define <2 x i32> @select_2() {
+  ret <2 x i32> <i32 11, i32 22>
+}
@@ -0,0 +1,24 @@
+; ModuleID = 'testdata/fwd.bc'
+source_filename = "fwd.ll"
+
+declare void @llvm.foo(metadata)
+
+define i32 @f(i32 %n) {
+entry:
+  br label %compute
+
+use:                                              ; preds = %loop
+  %y = mul i32 %x, 2
+  call void @llvm.foo(metadata i32 %y)
+  ret i32 %y
+
+compute:                                          ; preds = %entry
+  %x = add i32 %n, 1
+  br label %loop
+
+loop:                                             ; preds = %loop, %compute
+  %i = phi i32 [ 0, %compute ], [ %next, %loop ]
+  %next = add i32 %i, %x
+  %done = icmp sge i32 %next, %n
+  br i1 %done, label %use, label %loop
+}
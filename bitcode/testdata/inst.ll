@@ -0,0 +1,168 @@
+; ModuleID = 'testdata/inst.bc'
+source_filename = "inst.ll"
+
+%T = type { i32, i8* }
+
+declare i32 @g(i32)
+
+declare void @h(...)
+
+declare i32 @__gxx_personality_v0(...)
+
+declare i8* @mkexc()
+
+define i32 @arith(i32 %a, i32 %b, float %x, float %y, <2 x i32> %v) {
+entry:
+  %0 = add nuw nsw i32 %a, %b
+  %1 = sub nsw i32 %a, %b
+  %2 = mul nuw i32 %a, %b
+  %3 = udiv exact i32 %a, %b
+  %4 = sdiv i32 %a, %b
+  %5 = urem i32 %a, %b
+  %6 = srem i32 %a, %b
+  %7 = shl nuw nsw i32 %a, 1
+  %8 = lshr exact i32 %a, 2
+  %9 = ashr i32 %a, 3
+  %10 = and i32 %a, %b
+  %11 = or i32 %a, %b
+  %12 = xor i32 %a, -1
+  %13 = fadd fast float %x, %y
+  %14 = fsub nnan ninf float %x, %y
+  %15 = fmul nsz arcp float %x, %y
+  %16 = fdiv contract afn float %x, %y
+  %17 = frem reassoc float %x, %y
+  %18 = fadd float %x, 1.000000e+00
+  %19 = add <2 x i32> %v, <i32 1, i32 2>
+  %20 = icmp eq i32 %a, %b
+  %21 = icmp sge i32 %a, 0
+  %22 = fcmp fast olt float %x, %y
+  %23 = fcmp uno float %x, %y
+  %24 = fcmp true float %x, %y
+  %25 = select i1 %20, i32 %a, i32 %b
+  %26 = select i1 %20, float %x, float %y
+  %27 = trunc i32 %a to i8
+  %28 = zext i8 %27 to i64
+  %29 = sext i8 %27 to i64
+  %30 = fptoui float %x to i32
+  %31 = fptosi float %x to i32
+  %32 = uitofp i32 %a to double
+  %33 = sitofp i32 %a to double
+  %34 = fptrunc double %32 to float
+  %35 = fpext float %x to double
+  %36 = inttoptr i64 %28 to i8*
+  %37 = ptrtoint i8* %36 to i64
+  %38 = bitcast i8* %36 to i32*
+  %39 = addrspacecast i32* %38 to i32 addrspace(1)*
+  %40 = extractelement <2 x i32> %v, i32 0
+  %41 = insertelement <2 x i32> %v, i32 %a, i32 1
+  %42 = shufflevector <2 x i32> %v, <2 x i32> undef, <4 x i32> <i32 0, i32 1, i32 1, i32 0>
+  %43 = insertvalue %T undef, i32 %a, 0
+  %44 = extractvalue %T %43, 0
+  %45 = insertvalue { [2 x i32], i8 } zeroinitializer, i32 %b, 0, 1
+  ret i32 %44
+}
+
+define void @memory(i32* %p, i64 %n) {
+  %a = alloca i32, align 4
+  %b = alloca i32, i32 4, align 16
+  %c = alloca %T, i64 %n, align 8
+  %x = load i32, i32* %p, align 4
+  %y = load volatile i32, i32* %p, align 8
+  %z = load atomic i32, i32* %p seq_cst, align 4
+  %w = load atomic volatile i32, i32* %p syncscope("singlethread") acquire, align 4
+  store i32 %x, i32* %a, align 4
+  store volatile i32 %y, i32* %a, align 4
+  store atomic i32 %z, i32* %a release, align 4
+  store atomic volatile i32 %w, i32* %a syncscope("agent") monotonic, align 4
+  fence acquire
+  fence syncscope("singlethread") seq_cst
+  %g = getelementptr %T, %T* %c, i64 1, i32 1
+  %g2 = getelementptr inbounds i32, i32* %p, i64 %n
+  ret void
+}
+
+define i32 @control(i32 %a, i8* %addr) {
+entry:
+  br label %loop
+
+loop:                                             ; preds = %other, %body, %entry
+  %i = phi i32 [ 0, %entry ], [ %next, %body ], [ %next, %other ]
+  %acc = phi i32 [ %a, %entry ], [ %sum, %body ], [ %sum, %other ]
+  %cond = icmp slt i32 %i, 10
+  br i1 %cond, label %body, label %exit
+
+body:                                             ; preds = %loop
+  %sum = add i32 %acc, %i
+  %next = add i32 %i, 1
+  switch i32 %sum, label %loop [
+    i32 1, label %exit
+    i32 2, label %other
+  ]
+
+other:                                            ; preds = %body
+  indirectbr i8* %addr, [label %loop, label %exit]
+
+exit:                                             ; preds = %other, %body, %loop
+  %r = phi i32 [ %acc, %loop ], [ %sum, %body ], [ -1, %other ]
+  ret i32 %r
+
+dead:                                             ; No predecessors!
+  unreachable
+}
+
+define i32 @calls(i32 %a, i8* %p) personality i32 (...)* @__gxx_personality_v0 {
+entry:
+  %0 = call i32 @g(i32 %a)
+  %1 = tail call fastcc i32 @g(i32 signext %a) #0
+  %2 = call i32 @g(i32 %a)
+  %3 = notail call i32 @g(i32 %a) [ "deopt"(i32 %a, i8* %p), "foo"() ]
+  call void (...) @h(i32 1, i8* %p)
+  %4 = call i32 asm sideeffect "mov $1, $0", "=r,r"(i32 %a)
+  %5 = call i32 asm alignstack inteldialect "nop", "=r"()
+  %6 = call noalias i8* @mkexc() #1
+  %7 = va_arg i8* %p, i32
+  %8 = invoke i32 @g(i32 %a)
+          to label %ok unwind label %lpad
+
+ok:                                               ; preds = %entry
+  invoke void (...) @h()
+          to label %ok2 unwind label %lpad
+
+ok2:                                              ; preds = %ok
+  ret i32 %8
+
+lpad:                                             ; preds = %ok, %entry
+  %lp = landingpad { i8*, i32 }
+          cleanup
+          catch i8* null
+          filter [1 x i8*] [i8* bitcast (i32 (i32)* @g to i8*)]
+  resume { i8*, i32 } %lp
+}
+
+define void @funclets() personality i32 (...)* @__gxx_personality_v0 {
+entry:
+  invoke void (...) @h()
+          to label %exit unwind label %dispatch
+
+dispatch:                                         ; preds = %entry
+  %cs = catchswitch within none [label %handler] unwind label %cleanup
+
+handler:                                          ; preds = %dispatch
+  %cp = catchpad within %cs [i8* null, i32 64]
+  catchret from %cp to label %exit
+
+cleanup:                                          ; preds = %dispatch
+  %cl = cleanuppad within none []
+  cleanupret from %cl unwind to caller
+
+exit:                                             ; preds = %handler, %entry
+  ret void
+}
+
+define i32 @mt(i32 %a) {
+  %r = musttail call i32 @g(i32 %a)
+  ret i32 %r
+}
+
+attributes #0 = { nounwind readnone }
+attributes #1 = { nounwind }
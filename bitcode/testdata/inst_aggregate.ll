@@ -0,0 +1,35 @@
+; ModuleID = 'testdata/inst_aggregate.bc'
+source_filename = "inst_aggregate.ll"
+
+define i32 @extractvalue_1() {
+  %result = extractvalue { i8, i32 } { i8 21, i32 42 }, 1
+  ret i32 %result
+}
+
+define i32 @extractvalue_2() {
+  %result = extractvalue { i32, { [2 x i32], i8 } } { i32 0, { [2 x i32], i8 } { [2 x i32] [i32 100, i32 42], i8 11 } }, 1, 0, 1
+  ret i32 %result
+}
+
+define i32 @extractvalue_3() {
+  %result = extractvalue { i8, i32 } { i8 21, i32 42 }, 1, !foo !0, !baz !1
+  ret i32 %result
+}
+
+define { i32, i32 } @insertvalue_1() {
+  %result = insertvalue { i32, i32 } { i32 21, i32 42 }, i32 42, 0
+  ret { i32, i32 } %result
+}
+
+define { i32, { [2 x i32], i32 } } @insertvalue_2() {
+  %result = insertvalue { i32, { [2 x i32], i32 } } { i32 42, { [2 x i32], i32 } { [2 x i32] [i32 100, i32 42], i32 42 } }, i32 42, 1, 0, 0
+  ret { i32, { [2 x i32], i32 } } %result
+}
+
+define { i32, i32 } @insertvalue_3() {
+  %result = insertvalue { i32, i32 } { i32 21, i32 42 }, i32 42, 0, !foo !0, !baz !1
+  ret { i32, i32 } %result
+}
+
+!0 = !{!"bar"}
+!1 = !{!"qux"}
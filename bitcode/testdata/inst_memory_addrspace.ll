@@ -0,0 +1,21 @@
+; ModuleID = 'testdata/inst_memory_addrspace.bc'
+source_filename = "inst_memory_addrspace.ll"
+target datalayout = "A5"
+
+define i32 addrspace(5)* @alloca_1() {
+  %result = alloca i32, align 4, addrspace(5)
+  ret i32 addrspace(5)* %result
+}
+
+define i32 addrspace(5)* @alloca_2() {
+  %result = alloca i32, align 8, addrspace(5)
+  ret i32 addrspace(5)* %result
+}
+
+define i32 addrspace(5)* @alloca_3() {
+  %result = alloca i32, i32 10, align 8, addrspace(5), !foo !0, !baz !1
+  ret i32 addrspace(5)* %result
+}
+
+!0 = !{!"bar"}
+!1 = !{!"qux"}
@@ -0,0 +1,35 @@
+; ModuleID = 'testdata/inst_vector.bc'
+source_filename = "inst_vector.ll"
+
+define i32 @extractelement_1() {
+  %result = extractelement <2 x i32> <i32 21, i32 42>, i32 1
+  ret i32 %result
+}
+
+define i32 @extractelement_2() {
+  %result = extractelement <2 x i32> <i32 21, i32 42>, i32 1, !foo !0, !baz !1
+  ret i32 %result
+}
+
+define <2 x i32> @insertelement_1() {
+  %result = insertelement <2 x i32> <i32 21, i32 42>, i32 42, i32 0
+  ret <2 x i32> %result
+}
+
+define <2 x i32> @insertelement_2() {
+  %result = insertelement <2 x i32> <i32 21, i32 42>, i32 42, i32 0, !foo !0, !baz !1
+  ret <2 x i32> %result
+}
+
+define <2 x i32> @shufflevector_1() {
+  %result = shufflevector <2 x i32> <i32 21, i32 42>, <2 x i32> <i32 42, i32 84>, <2 x i32> <i32 1, i32 2>
+  ret <2 x i32> %result
+}
+
+define <2 x i32> @shufflevector_2() {
+  %result = shufflevector <2 x i32> <i32 21, i32 42>, <2 x i32> <i32 42, i32 84>, <2 x i32> <i32 1, i32 2>, !foo !0, !baz !1
+  ret <2 x i32> %result
+}
+
+!0 = !{!"bar"}
+!1 = !{!"qux"}
@@ -0,0 +1,29 @@
+; ModuleID = 'testdata/metadata.bc'
+source_filename = "metadata.ll"
+
+@g = global i32 0, !foo !0, !bar !1
+@h = global i32 1, !foo !2
+
+define i32 @f(i8* %p) !prof !7 !qux !8 {
+  %a = load i32, i32* @h, align 4, !qux !9
+  %c = load i32, i32* @g, align 4, !range !10, !nontemporal !11
+  ret i32 %a, !baz !12
+}
+
+!named = !{!0, !3, !4}
+!empty = !{}
+!llvm.ident = !{!6}
+
+!0 = !{!"foo", i32 42, !1}
+!1 = distinct !{!1}
+!2 = !{i8 7, float 1.000000e+00, i64 -1}
+!3 = !{}
+!4 = !{!"a", !5}
+!5 = !{!"nested"}
+!6 = !{!"clang version x"}
+!7 = !{!"function_entry_count", i64 10}
+!8 = !{!"dbg"}
+!9 = !{!"sp\00x"}
+!10 = !{i32 0, i32 10}
+!11 = !{i32 1}
+!12 = !{!3, !4}
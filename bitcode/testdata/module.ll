@@ -0,0 +1,95 @@
+; ModuleID = 'testdata/module.bc'
+source_filename = "module.c"
+target datalayout = "e-m:e-p270:32:32-p271:32:32-p272:64:64-i64:64-f80:128-n8:16:32:64-S128"
+target triple = "x86_64-unknown-linux-gnu"
+
+module asm "nop"
+module asm "ret"
+
+%0 = type { i32, %1* }
+%1 = type opaque
+%list = type { i32, %list* }
+
+$any = comdat any
+
+$exact = comdat exactmatch
+
+$largest = comdat largest
+
+$samesize = comdat samesize
+
+@0 = global i32 1
+@g1 = external global i32
+@g2 = private constant [3 x i8] c"foo"
+@g3 = internal global %list zeroinitializer, align 8
+@g4 = weak hidden global i32 2, section ".data.g4", comdat($any)
+@g5 = linkonce_odr protected unnamed_addr global i32 3, comdat($exact)
+@g6 = thread_local(initialexec) global i32 4
+@g7 = dllexport global i32 5, comdat($largest)
+@g8 = local_unnamed_addr externally_initialized global i32 6, comdat($samesize)
+@g9 = common global i32 0, align 4
+@g10 = extern_weak global i32
+@g11 = available_externally global i32 7
+@g12 = global %0 zeroinitializer
+@g14 = thread_local global i32 8
+@g15 = thread_local(localdynamic) global i32 9
+@g16 = thread_local(localexec) global i32 10
+@g17 = addrspace(3) global i32 11
+@g18 = appending global [1 x i32] [i32 1]
+@g19 = linkonce global i32 12
+@g20 = weak_odr global i32 13
+@g21 = external dllimport global i32
+
+@a1 = alias i32, i32* @g4
+@a2 = internal alias i32, i32* @0
+@a3 = weak hidden alias i32, getelementptr inbounds (i32, i32* @g5, i64 1)
+@1 = alias i32, i32* @g9
+
+@i1 = ifunc void (), void ()* ()* @resolver
+@i2 = internal ifunc void (), void ()* ()* @resolver
+
+declare void @f1()
+
+declare fastcc i32 @f2(i32, i8*)
+
+declare void @f3(...)
+
+declare i32 @f4(i32, ...)
+
+declare x86_stdcallcc void @f5()
+
+declare ghccc void @f6()
+
+declare cc11 void @f7()
+
+declare extern_weak void @f8()
+
+declare dllimport void @f9()
+
+define internal void ()* @resolver() {
+  ret void ()* @f1
+}
+
+define private void @f10() section ".text.f10" align 16 {
+  ret void
+}
+
+define linkonce_odr hidden void @f11() comdat($any) {
+  ret void
+}
+
+define weak protected void @f12() unnamed_addr {
+  ret void
+}
+
+define void @f13() local_unnamed_addr {
+  ret void
+}
+
+define void @2() {
+  ret void
+}
+
+define %list* @f14(%0* %x) {
+  ret %list* @g3
+}
@@ -0,0 +1,327 @@
+; ModuleID = 'testdata/term.bc'
+source_filename = "term.ll"
+
+define i32 @ret_1() {
+  ret i32 42
+}
+
+define void @ret_2() {
+  ret void
+}
+
+define i32 @ret_3() {
+  ret i32 42, !foo !0, !baz !1
+}
+
+define void @br_1() {
+  br label %foo
+
+foo:                                              ; preds = %0
+  ret void
+}
+
+define void @br_2() {
+  br label %foo, !foo !0, !baz !1
+
+foo:                                              ; preds = %0
+  ret void
+}
+
+define void @br_3(i1 %cond) {
+  br i1 %cond, label %foo, label %bar
+
+foo:                                              ; preds = %0
+  ret void
+
+bar:                                              ; preds = %0
+  ret void
+}
+
+define void @br_4(i1 %cond) {
+  br i1 %cond, label %foo, label %bar, !foo !0, !baz !1
+
+foo:                                              ; preds = %0
+  ret void
+
+bar:                                              ; preds = %0
+  ret void
+}
+
+define void @switch_1() {
+  switch i32 1, label %default [
+  ]
+
+default:                                          ; preds = %0
+  ret void
+}
+
+define void @switch_2() {
+  switch i32 2, label %default [
+    i32 1, label %case1
+    i32 2, label %case2
+    i32 3, label %case3
+  ]
+
+default:                                          ; preds = %0
+  ret void
+
+case1:                                            ; preds = %0
+  ret void
+
+case2:                                            ; preds = %0
+  ret void
+
+case3:                                            ; preds = %0
+  ret void
+}
+
+define void @switch_3() {
+  switch i32 2, label %default [
+    i32 1, label %case1
+    i32 2, label %case2
+    i32 3, label %case3
+  ], !foo !0, !baz !1
+
+default:                                          ; preds = %0
+  ret void
+
+case1:                                            ; preds = %0
+  ret void
+
+case2:                                            ; preds = %0
+  ret void
+
+case3:                                            ; preds = %0
+  ret void
+}
+
+define void @indirectbr_1(i1 %cond) {
+  %addr = select i1 %cond, i8* blockaddress(@indirectbr_1, %foo), i8* blockaddress(@indirectbr_1, %bar)
+  indirectbr i8* %addr, [label %foo, label %bar]
+
+foo:                                              ; preds = %0
+  ret void
+
+bar:                                              ; preds = %0
+  ret void
+}
+
+define void @indirectbr_2() {
+  indirectbr i8* blockaddress(@indirectbr_2, %foo), [label %foo], !foo !0, !baz !1
+
+foo:                                              ; preds = %0
+  ret void
+}
+
+declare i32 @__gxx_personality_v0(...)
+
+define i32 @f(i32 %x, i32 %y) {
+  ret i32 42
+}
+
+define void @g() {
+  ret void
+}
+
+define i32 @invoke_1() personality i32 (...)* @__gxx_personality_v0 {
+  %result = invoke i32 @f(i32 1, i32 2)
+          to label %normal unwind label %exception
+
+normal:                                           ; preds = %0
+  ret i32 %result
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define void @invoke_2() personality i32 (...)* @__gxx_personality_v0 {
+  invoke void @g()
+          to label %normal unwind label %exception
+
+normal:                                           ; preds = %0
+  ret void
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define i32 @invoke_3() personality i32 (...)* @__gxx_personality_v0 {
+  %result = invoke fastcc i32 @f(i32 1, i32 2)
+          to label %normal unwind label %exception, !foo !0, !baz !1
+
+normal:                                           ; preds = %0
+  ret i32 %result
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define i32 @invoke_4() personality i32 (...)* @__gxx_personality_v0 {
+  br label %entry
+
+use:                                              ; preds = %entry
+  ret i32 %result
+
+entry:                                            ; preds = %0
+  %result = invoke i32 @f(i32 1, i32 2)
+          to label %use unwind label %exception
+
+exception:                                        ; preds = %entry
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define i32 @invoke_5() personality i32 (...)* @__gxx_personality_v0 {
+  %result = invoke i32 asm sideeffect "bswap $0", "=r,r"(i32 42)
+          to label %normal unwind label %exception
+
+normal:                                           ; preds = %0
+  ret i32 %result
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define void @resume_1() personality i32 (...)* @__gxx_personality_v0 {
+  invoke void @g()
+          to label %normal unwind label %exception
+
+normal:                                           ; preds = %0
+  ret void
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x
+}
+
+define void @resume_2() personality i32 (...)* @__gxx_personality_v0 {
+  invoke void @g()
+          to label %normal unwind label %exception
+
+normal:                                           ; preds = %0
+  ret void
+
+exception:                                        ; preds = %0
+  %x = landingpad { i8*, i32 }
+          cleanup
+  resume { i8*, i32 } %x, !foo !0, !baz !1
+}
+
+declare i32 @__CxxFrameHandler3(...)
+
+define void @catchswitch_1() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %dispatch
+
+normal:                                           ; preds = %handler, %0
+  ret void
+
+dispatch:                                         ; preds = %0
+  %cs = catchswitch within none [label %handler] unwind to caller
+
+handler:                                          ; preds = %dispatch
+  %cp = catchpad within %cs []
+  catchret from %cp to label %normal
+}
+
+define void @catchswitch_2() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %dispatch
+
+normal:                                           ; preds = %handler_2, %handler_1, %0
+  ret void
+
+dispatch:                                         ; preds = %0
+  %cs = catchswitch within none [label %handler_1, label %handler_2] unwind label %cleanup
+
+handler_1:                                        ; preds = %dispatch
+  %cp1 = catchpad within %cs [i8* null, i32 64, i8* null]
+  catchret from %cp1 to label %normal
+
+handler_2:                                        ; preds = %dispatch
+  %cp2 = catchpad within %cs []
+  catchret from %cp2 to label %normal
+
+cleanup:                                          ; preds = %dispatch
+  %cleanup_pad = cleanuppad within none []
+  cleanupret from %cleanup_pad unwind to caller
+}
+
+define void @catchswitch_3() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %dispatch
+
+normal:                                           ; preds = %handler, %0
+  ret void
+
+dispatch:                                         ; preds = %0
+  %1 = catchswitch within none [label %handler] unwind to caller, !foo !0, !baz !1
+
+handler:                                          ; preds = %dispatch
+  %2 = catchpad within %1 []
+  catchret from %2 to label %normal
+}
+
+define void @catchret_1() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %dispatch
+
+normal:                                           ; preds = %handler, %0
+  ret void
+
+dispatch:                                         ; preds = %0
+  %cs = catchswitch within none [label %handler] unwind to caller
+
+handler:                                          ; preds = %dispatch
+  %cp = catchpad within %cs []
+  catchret from %cp to label %normal, !foo !0, !baz !1
+}
+
+define void @cleanupret_1() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %cleanup
+
+normal:                                           ; preds = %0
+  ret void
+
+cleanup:                                          ; preds = %0
+  %cp = cleanuppad within none []
+  cleanupret from %cp unwind to caller
+}
+
+define void @cleanupret_2() personality i32 (...)* @__CxxFrameHandler3 {
+  invoke void @g()
+          to label %normal unwind label %cleanup_1
+
+normal:                                           ; preds = %0
+  ret void
+
+cleanup_1:                                        ; preds = %0
+  %cp1 = cleanuppad within none []
+  cleanupret from %cp1 unwind label %cleanup_2, !foo !0, !baz !1
+
+cleanup_2:                                        ; preds = %cleanup_1
+  %cp2 = cleanuppad within none []
+  cleanupret from %cp2 unwind to caller
+}
+
+define void @unreachable_1() {
+  unreachable
+}
+
+define void @unreachable_2() {
+  unreachable, !foo !0, !baz !1
+}
+
+!0 = !{!"bar"}
+!1 = !{!"qux"}
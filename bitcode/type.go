@@ -0,0 +1,156 @@
+package bitcode
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/bitcode/internal/bitstream"
+	"github.com/llir/llvm/ir/types"
+)
+
+// typeBlock decodes the given type table block.
+func (d *decoder) typeBlock(block *bitstream.Block) {
+	// Name of the next identified struct type.
+	var name string
+	// Type ID of the next type.
+	var id uint64
+	for _, rec := range records(block) {
+		if rec.Code == typeCodeNumEntry {
+			if len(rec.Ops) < 1 {
+				panic(fmt.Errorf("invalid NUMENTRY record; missing number of entries"))
+			}
+			d.types = make([]types.Type, rec.Ops[0])
+			continue
+		}
+		if rec.Code == typeCodeStructName {
+			name = recordString(rec.Ops)
+			continue
+		}
+		if id >= uint64(len(d.types)) {
+			d.types = append(d.types, nil)
+		}
+		var t types.Type
+		switch rec.Code {
+		case typeCodeVoid:
+			t = types.Void
+		case typeCodeHalf:
+			t = types.Half
+		case typeCodeFloat:
+			t = types.Float
+		case typeCodeDouble:
+			t = types.Double
+		case typeCodeX86FP80:
+			t = types.X86_FP80
+		case typeCodeFP128:
+			t = types.FP128
+		case typeCodePPCFP128:
+			t = types.PPC_FP128
+		case typeCodeLabel:
+			t = types.Label
+		case typeCodeMetadata:
+			t = types.Metadata
+		case typeCodeX86MMX:
+			t = types.MMX
+		case typeCodeToken:
+			t = types.Token
+		case typeCodeInteger:
+			// [width]
+			d.expectOps(rec, 1)
+			t = types.NewInt(int(rec.Ops[0]))
+		case typeCodePointer:
+			// [pointee type, address space]
+			d.expectOps(rec, 1)
+			typ := types.NewPointer(d.typeRef(rec.Ops[0]))
+			if len(rec.Ops) >= 2 {
+				typ.AddrSpace = int(rec.Ops[1])
+			}
+			t = typ
+		case typeCodeFunction:
+			// [vararg, return type, param types...]
+			d.expectOps(rec, 2)
+			var params []*types.Param
+			for _, op := range rec.Ops[2:] {
+				params = append(params, types.NewParam("", d.typeRef(op)))
+			}
+			typ := types.NewFunc(d.typeRef(rec.Ops[1]), params...)
+			typ.Variadic = rec.Ops[0] != 0
+			t = typ
+		case typeCodeArray:
+			// [num elements, element type]
+			d.expectOps(rec, 2)
+			t = types.NewArray(d.typeRef(rec.Ops[1]), int64(rec.Ops[0]))
+		case typeCodeVector:
+			// [num elements, element type, scalable]
+			d.expectOps(rec, 2)
+			if len(rec.Ops) >= 3 && rec.Ops[2] != 0 {
+				panic(fmt.Errorf("support for scalable vector types not yet implemented"))
+			}
+			t = types.NewVector(d.typeRef(rec.Ops[1]), int64(rec.Ops[0]))
+		case typeCodeStructAnon:
+			// [packed, element types...]
+			d.expectOps(rec, 1)
+			typ := types.NewStruct(d.typeRefs(rec.Ops[1:])...)
+			typ.Packed = rec.Ops[0] != 0
+			t = typ
+		case typeCodeStructNamed:
+			// [packed, element types...]
+			d.expectOps(rec, 1)
+			typ := d.identifiedStruct(id)
+			typ.Name = name
+			typ.Fields = d.typeRefs(rec.Ops[1:])
+			typ.Packed = rec.Ops[0] != 0
+			t, name = typ, ""
+		case typeCodeOpaque:
+			typ := d.identifiedStruct(id)
+			typ.Name = name
+			typ.Opaque = true
+			t, name = typ, ""
+		default:
+			panic(fmt.Errorf("support for type record code %d not yet implemented", rec.Code))
+		}
+		d.types[id] = t
+		id++
+	}
+}
+
+// typeRef returns the type of the given type ID, which may be a forward
+// reference to an identified struct type.
+func (d *decoder) typeRef(id uint64) types.Type {
+	if id >= uint64(len(d.types)) {
+		panic(fmt.Errorf("invalid type ID %d; no such type", id))
+	}
+	if d.types[id] == nil {
+		// Forward references are only valid for identified struct types.
+		d.types[id] = &types.StructType{}
+	}
+	return d.types[id]
+}
+
+// typeRefs returns the types of the given type IDs.
+func (d *decoder) typeRefs(ids []uint64) []types.Type {
+	var ts []types.Type
+	for _, id := range ids {
+		ts = append(ts, d.typeRef(id))
+	}
+	return ts
+}
+
+// identifiedStruct returns the identified struct type of the given type ID,
+// reusing the placeholder created by a forward reference if present.
+func (d *decoder) identifiedStruct(id uint64) *types.StructType {
+	t := &types.StructType{}
+	if id < uint64(len(d.types)) && d.types[id] != nil {
+		var ok bool
+		if t, ok = d.types[id].(*types.StructType); !ok {
+			panic(fmt.Errorf("invalid type of forward referenced type ID %d; expected *types.StructType, got %T", id, d.types[id]))
+		}
+	}
+	d.identified[t] = true
+	return t
+}
+
+// expectOps ensures that the given record has at least n operands.
+func (d *decoder) expectOps(rec *bitstream.Record, n int) {
+	if len(rec.Ops) < n {
+		panic(fmt.Errorf("invalid record with code %d; expected at least %d operands, got %d", rec.Code, n, len(rec.Ops)))
+	}
+}
@@ -1,5 +1,11 @@
 package floats
 
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
 // Float128 represents a 128-bit IEEE 754 quadruple-precision floating-point
 // value, in binary128 format.
 //
@@ -11,10 +17,98 @@ type Float128 struct {
 	//    1 bit:    sign
 	//    15 bits:  exponent
 	//    112 bits: fraction
+	//
+	// The high-order 64 bits are stored in a and the low-order 64 bits in b.
 	a, b uint64
 }
 
-// Bytes returns the IEEE 754 binary representation of f as a byte slice.
+// Bits returns the IEEE 754 binary representation of f, with the high-order 64
+// bits in a and the low-order 64 bits in b.
+func (f Float128) Bits() (a, b uint64) {
+	return f.a, f.b
+}
+
+// Bytes returns the IEEE 754 binary representation of f as a byte slice,
+// containing 32 bytes in hexadecimal format.
 func (f Float128) Bytes() []byte {
-	panic("not yet implemented")
+	return []byte(f.String())
+}
+
+// String returns the IEEE 754 binary representation of f as a string,
+// containing 32 bytes in hexadecimal format.
+func (f Float128) String() string {
+	return fmt.Sprintf("%016X%016X", f.a, f.b)
+}
+
+// IsNaN reports whether f is an IEEE 754 "not-a-number" value.
+func (f Float128) IsNaN() bool {
+	return f.a>>48&0x7FFF == 0x7FFF && (f.a&0xFFFFFFFFFFFF != 0 || f.b != 0)
+}
+
+// Big returns the exact value of f as a big.Float with 113 bits of precision.
+// It panics if f is NaN.
+func (f Float128) Big() *big.Float {
+	if f.IsNaN() {
+		panic(fmt.Errorf("unable to represent binary128 NaN 0x%s as big.Float", f))
+	}
+	// 1 bit: sign
+	neg := f.a>>63 == 1
+	// 15 bits: exponent
+	exp := int(f.a >> 48 & 0x7FFF)
+	// 112 bits: fraction
+	mant := new(big.Int).SetUint64(f.a & 0xFFFFFFFFFFFF)
+	mant.Lsh(mant, 64)
+	mant.Or(mant, new(big.Int).SetUint64(f.b))
+	z := new(big.Float).SetPrec(113)
+	switch exp {
+	case 0x7FFF:
+		return z.SetInf(neg)
+	case 0:
+		// Subnormal values use the minimum exponent and have no implicit
+		// integer part.
+		exp = 1
+	default:
+		// Implicit integer part of normalized values.
+		mant.SetBit(mant, 112, 1)
+	}
+	// Exponent bias 16383.
+	z.SetMantExp(new(big.Float).SetInt(mant), exp-16383-112)
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
+// NewFloat128FromBig returns the nearest 128-bit floating-point value for x.
+func NewFloat128FromBig(x *big.Float) Float128 {
+	neg, exp, mant := round(x, 113, 16383)
+	// Drop the implicit integer part.
+	frac := new(big.Int).SetBit(mant, 112, 0)
+	a := uint64(exp)<<48 | new(big.Int).Rsh(frac, 64).Uint64()
+	if neg {
+		a |= 1 << 63
+	}
+	b := new(big.Int).And(frac, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	return NewFloat128FromBits(a, b)
+}
+
+// NewFloat128FromString returns a new 128-bit floating-point value based on s,
+// which contains 32 bytes in hexadecimal format.
+func NewFloat128FromString(s string) Float128 {
+	return NewFloat128FromBytes([]byte(s))
+}
+
+// NewFloat128FromBytes returns a new 128-bit floating-point value based on b,
+// which contains 32 bytes in hexadecimal format.
+func NewFloat128FromBytes(b []byte) Float128 {
+	if len(b) != 32 {
+		panic(fmt.Errorf("invalid length of float128 hexadecimal representation, expected 32, got %d", len(b)))
+	}
+	return NewFloat128FromBits(unhex64(b[:16]), unhex64(b[16:]))
+}
+
+// NewFloat128FromBits returns a new 128-bit floating-point value based on the
+// high-order 64 bits a and the low-order 64 bits b.
+func NewFloat128FromBits(a, b uint64) Float128 {
+	return Float128{a: a, b: b}
 }
@@ -0,0 +1,109 @@
+package floats
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Float128PPC represents a 128-bit double-double floating-point value, in
+// PowerPC format; the unevaluated sum of two IEEE 754 double-precision values,
+// where the high-order value is the nearest double-precision value of the sum.
+//
+// References:
+//    https://en.wikipedia.org/wiki/Quadruple-precision_floating-point_format#Double-double_arithmetic
+type Float128PPC struct {
+	// High-order double-precision value, in binary64 format.
+	hi uint64
+	// Low-order double-precision value, in binary64 format.
+	lo uint64
+}
+
+// Bits returns the binary representation of f, with the high-order binary64
+// value in hi and the low-order binary64 value in lo.
+func (f Float128PPC) Bits() (hi, lo uint64) {
+	return f.hi, f.lo
+}
+
+// Bytes returns the binary representation of f as a byte slice, containing 32
+// bytes in hexadecimal format.
+func (f Float128PPC) Bytes() []byte {
+	return []byte(f.String())
+}
+
+// String returns the binary representation of f as a string, containing 32
+// bytes in hexadecimal format.
+func (f Float128PPC) String() string {
+	return fmt.Sprintf("%016X%016X", f.hi, f.lo)
+}
+
+// IsNaN reports whether f is an IEEE 754 "not-a-number" value.
+func (f Float128PPC) IsNaN() bool {
+	return math.IsNaN(math.Float64frombits(f.hi)) || math.IsNaN(math.Float64frombits(f.lo))
+}
+
+// Big returns the exact value of f as a big.Float with at least 106 bits of
+// precision. It panics if f is NaN.
+func (f Float128PPC) Big() *big.Float {
+	if f.IsNaN() {
+		panic(fmt.Errorf("unable to represent double-double NaN 0x%s as big.Float", f))
+	}
+	hi := math.Float64frombits(f.hi)
+	lo := math.Float64frombits(f.lo)
+	z := new(big.Float).SetPrec(106)
+	if math.IsInf(hi, 0) || lo == 0 {
+		return z.SetFloat64(hi)
+	}
+	// Use sufficient precision to represent the sum exactly.
+	_, hiExp := math.Frexp(hi)
+	_, loExp := math.Frexp(lo)
+	diff := hiExp - loExp
+	if diff < 0 {
+		diff = -diff
+	}
+	if prec := uint(diff + 54); prec > z.Prec() {
+		z.SetPrec(prec)
+	}
+	return z.Add(big.NewFloat(hi), big.NewFloat(lo))
+}
+
+// NewFloat128PPCFromBig returns the nearest double-double floating-point value
+// for x.
+func NewFloat128PPCFromBig(x *big.Float) Float128PPC {
+	hi, _ := x.Float64()
+	if math.IsInf(hi, 0) || hi == 0 {
+		return NewFloat128PPCFromBits(math.Float64bits(hi), 0)
+	}
+	// The difference between x and its nearest double-precision value is
+	// exactly representable with two more bits of precision than the largest
+	// precision of the two.
+	prec := x.Prec()
+	if prec < 53 {
+		prec = 53
+	}
+	rem := new(big.Float).SetPrec(prec+2).Sub(x, big.NewFloat(hi))
+	lo, _ := rem.Float64()
+	return NewFloat128PPCFromBits(math.Float64bits(hi), math.Float64bits(lo))
+}
+
+// NewFloat128PPCFromString returns a new double-double floating-point value
+// based on s, which contains 32 bytes in hexadecimal format.
+func NewFloat128PPCFromString(s string) Float128PPC {
+	return NewFloat128PPCFromBytes([]byte(s))
+}
+
+// NewFloat128PPCFromBytes returns a new double-double floating-point value
+// based on b, which contains 32 bytes in hexadecimal format.
+func NewFloat128PPCFromBytes(b []byte) Float128PPC {
+	if len(b) != 32 {
+		panic(fmt.Errorf("invalid length of double-double hexadecimal representation, expected 32, got %d", len(b)))
+	}
+	return NewFloat128PPCFromBits(unhex64(b[:16]), unhex64(b[16:]))
+}
+
+// NewFloat128PPCFromBits returns a new double-double floating-point value
+// based on the binary64 representation of the high-order value hi and the
+// low-order value lo.
+func NewFloat128PPCFromBits(hi, lo uint64) Float128PPC {
+	return Float128PPC{hi: hi, lo: lo}
+}
@@ -8,6 +8,7 @@ package floats
 import (
 	"fmt"
 	"math"
+	"math/big"
 )
 
 // Float16 represents a 16-bit IEEE 754 half-precision floating-point value, in
@@ -302,3 +303,63 @@ func unhex(b byte) uint64 {
 	}
 	panic(fmt.Errorf("invalid byte; expected hexadecimal, got %q", b))
 }
+
+// unhex64 returns the numeric value represented by the 16 hexadecimal digits of
+// b.
+func unhex64(b []byte) uint64 {
+	var x uint64
+	for _, c := range b {
+		x = x<<4 | unhex(c)
+	}
+	return x
+}
+
+// round returns the sign, biased exponent and significand of the value nearest
+// to x in the binary floating-point format with prec bits of precision and the
+// given exponent bias. Ties are rounded to even. The significand includes the
+// integer part; the biased exponent is 0 for zero and subnormal values, and all
+// ones for infinite values and values which overflow the format.
+func round(x *big.Float, prec uint, bias int) (neg bool, exp int, mant *big.Int) {
+	neg = x.Signbit()
+	if x.IsInf() {
+		return neg, 2*bias + 1, new(big.Int).Lsh(big.NewInt(1), prec-1)
+	}
+	if x.Sign() == 0 {
+		return neg, 0, new(big.Int)
+	}
+	// |x| = m * 2^e, where 1 <= m < 2.
+	a := new(big.Float).Abs(x)
+	e := a.MantExp(nil) - 1
+	if min := 1 - bias; e < min {
+		// Subnormal value.
+		e = min
+	}
+	// Scale |x| so that the integer part holds the significand, and round the
+	// remaining fraction to nearest even.
+	s := new(big.Float).SetMantExp(a, int(prec)-1-e)
+	mant, _ = s.Int(nil)
+	rem := new(big.Float).Sub(s, new(big.Float).SetInt(mant))
+	switch rem.Cmp(big.NewFloat(0.5)) {
+	case 1:
+		mant.Add(mant, big.NewInt(1))
+	case 0:
+		if mant.Bit(0) == 1 {
+			mant.Add(mant, big.NewInt(1))
+		}
+	}
+	if mant.BitLen() > int(prec) {
+		// Rounding carried into the next binade.
+		mant.Rsh(mant, 1)
+		e++
+	}
+	if mant.BitLen() < int(prec) {
+		// Zero or subnormal value.
+		return neg, 0, mant
+	}
+	exp = e + bias
+	if exp >= 2*bias+1 {
+		// Overflow to infinity.
+		return neg, 2*bias + 1, new(big.Int).Lsh(big.NewInt(1), prec-1)
+	}
+	return neg, exp, mant
+}
@@ -3,6 +3,7 @@ package floats
 import (
 	"fmt"
 	"math"
+	"math/big"
 )
 
 // Float80 represents an 80-bit IEEE 754 extended precision floating-point
@@ -41,6 +42,37 @@ func (f Float80) String() string {
 	return fmt.Sprintf("%04X%016X", f.se, f.m)
 }
 
+// IsNaN reports whether f is an IEEE 754 "not-a-number" value.
+func (f Float80) IsNaN() bool {
+	return f.se&0x7FFF == 0x7FFF && f.m&0x7FFFFFFFFFFFFFFF != 0
+}
+
+// Big returns the exact value of f as a big.Float with 64 bits of precision. It
+// panics if f is NaN.
+func (f Float80) Big() *big.Float {
+	if f.IsNaN() {
+		panic(fmt.Errorf("unable to represent binary80 NaN 0x%s as big.Float", f))
+	}
+	// 1 bit: sign
+	neg := f.se>>15 == 1
+	// 15 bits: exponent
+	exp := int(f.se & 0x7FFF)
+	z := new(big.Float).SetPrec(64)
+	switch exp {
+	case 0x7FFF:
+		return z.SetInf(neg)
+	case 0:
+		// Subnormal values use the minimum exponent.
+		exp = 1
+	}
+	// 64 bits: integer part and fraction, with exponent bias 16383.
+	z.SetMantExp(new(big.Float).SetUint64(f.m), exp-16383-63)
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
 // Float64 returns the float64 representation of f.
 func (f Float80) Float64() float64 {
 	se := uint64(f.se)
@@ -138,6 +170,17 @@ func NewFloat80FromFloat64(x float64) Float80 {
 	return NewFloat80FromBits(se, m)
 }
 
+// NewFloat80FromBig returns the nearest 80-bit floating-point value for x.
+func NewFloat80FromBig(x *big.Float) Float80 {
+	neg, exp, mant := round(x, 64, 16383)
+	se := uint16(exp)
+	if neg {
+		se |= 0x8000
+	}
+	// The integer part is explicitly stored in binary80.
+	return NewFloat80FromBits(se, mant.Uint64())
+}
+
 // NewFloat80FromString returns a new 80-bit floating-point value based on s,
 // which contains 20 bytes in hexadecimal format.
 func NewFloat80FromString(s string) Float80 {
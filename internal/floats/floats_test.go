@@ -2,6 +2,7 @@ package floats
 
 import (
 	"math"
+	"math/big"
 	"testing"
 )
 
@@ -199,3 +200,115 @@ func TestNewFloat80FromFloat64(t *testing.T) {
 		}
 	}
 }
+
+func TestFloat80Big(t *testing.T) {
+	golden := []struct {
+		in   string
+		want string
+	}{
+		{in: "0", want: "00000000000000000000"},                            // +0
+		{in: "-0", want: "80000000000000000000"},                           // -0
+		{in: "1", want: "3FFF8000000000000000"},                            // 1
+		{in: "-3", want: "C000C000000000000000"},                           // -3
+		{in: "0.1", want: "3FFBCCCCCCCCCCCCCCCD"},                          // 0.1
+		{in: "1.18973149535723176505e+4932", want: "7FFEFFFFFFFFFFFFFFFF"}, // max normal
+		{in: "3.36210314311209350626e-4932", want: "00018000000000000000"}, // min positive normal
+		{in: "3.64519953188247460253e-4951", want: "00000000000000000001"}, // min positive subnormal
+		{in: "1e5000", want: "7FFF8000000000000000"},                       // overflow
+		{in: "+Inf", want: "7FFF8000000000000000"},                         // +inf
+		{in: "-Inf", want: "FFFF8000000000000000"},                         // -inf
+	}
+	for _, g := range golden {
+		x := parseBig(t, g.in)
+		f := NewFloat80FromBig(x)
+		if got := f.String(); got != g.want {
+			t.Errorf("binary80 mismatch for %s; expected 0x%s, got 0x%s", g.in, g.want, got)
+			continue
+		}
+		// Round-trip through big.Float.
+		if got := NewFloat80FromBig(f.Big()).String(); got != g.want {
+			t.Errorf("binary80 round-trip mismatch for 0x%s; got 0x%s", g.want, got)
+		}
+	}
+	if !NewFloat80FromString("7FFFC000000000000000").IsNaN() {
+		t.Errorf("expected binary80 0x7FFFC000000000000000 to be NaN")
+	}
+}
+
+// === [ float128 ] ============================================================
+
+func TestFloat128Big(t *testing.T) {
+	golden := []struct {
+		in   string
+		want string
+	}{
+		{in: "0", want: "00000000000000000000000000000000"},                                           // +0
+		{in: "-0", want: "80000000000000000000000000000000"},                                          // -0
+		{in: "1", want: "3FFF0000000000000000000000000000"},                                           // 1
+		{in: "-2", want: "C0000000000000000000000000000000"},                                          // -2
+		{in: "0.1", want: "3FFB999999999999999999999999999A"},                                         // 0.1
+		{in: "1.18973149535723176508575932662800702e+4932", want: "7FFEFFFFFFFFFFFFFFFFFFFFFFFFFFFF"}, // max normal
+		{in: "3.36210314311209350626267781732175260e-4932", want: "00010000000000000000000000000000"}, // min positive normal
+		{in: "6.47517511943802511092443895822764655e-4966", want: "00000000000000000000000000000001"}, // min positive subnormal
+		{in: "1e5000", want: "7FFF0000000000000000000000000000"},                                      // overflow
+		{in: "-Inf", want: "FFFF0000000000000000000000000000"},                                        // -inf
+	}
+	for _, g := range golden {
+		x := parseBig(t, g.in)
+		f := NewFloat128FromBig(x)
+		if got := f.String(); got != g.want {
+			t.Errorf("binary128 mismatch for %s; expected 0x%s, got 0x%s", g.in, g.want, got)
+			continue
+		}
+		// Round-trip through big.Float.
+		if got := NewFloat128FromBig(f.Big()).String(); got != g.want {
+			t.Errorf("binary128 round-trip mismatch for 0x%s; got 0x%s", g.want, got)
+		}
+	}
+	if !NewFloat128FromString("7FFF8000000000000000000000000000").IsNaN() {
+		t.Errorf("expected binary128 0x7FFF8000000000000000000000000000 to be NaN")
+	}
+}
+
+// === [ double-double ] =======================================================
+
+func TestFloat128PPCBig(t *testing.T) {
+	golden := []struct {
+		in   string
+		want string
+	}{
+		{in: "0", want: "00000000000000000000000000000000"},    // +0
+		{in: "-0", want: "80000000000000000000000000000000"},   // -0
+		{in: "1", want: "3FF00000000000000000000000000000"},    // 1
+		{in: "0.1", want: "3FB999999999999ABC5999999999999A"},  // 0.1
+		{in: "-Inf", want: "FFF00000000000000000000000000000"}, // -inf
+	}
+	for _, g := range golden {
+		x := parseBig(t, g.in)
+		f := NewFloat128PPCFromBig(x)
+		if got := f.String(); got != g.want {
+			t.Errorf("double-double mismatch for %s; expected 0x%s, got 0x%s", g.in, g.want, got)
+			continue
+		}
+		// Round-trip through big.Float.
+		if got := NewFloat128PPCFromBig(f.Big()).String(); got != g.want {
+			t.Errorf("double-double round-trip mismatch for 0x%s; got 0x%s", g.want, got)
+		}
+	}
+	// 1/3 = 0x3FD5555555555555 + 0x3C75555555555555, which requires more than
+	// 53 bits of precision to represent as the sum of the two parts.
+	f := NewFloat128PPCFromString("3FD55555555555553C75555555555555")
+	if got := NewFloat128PPCFromBig(f.Big()).String(); got != "3FD55555555555553C75555555555555" {
+		t.Errorf("double-double round-trip mismatch for 0x3FD55555555555553C75555555555555; got 0x%s", got)
+	}
+}
+
+// parseBig parses the given decimal floating-point string with sufficient
+// precision to round correctly to any of the supported formats.
+func parseBig(t *testing.T, s string) *big.Float {
+	x, ok := new(big.Float).SetPrec(256).SetString(s)
+	if !ok {
+		t.Fatalf("unable to parse floating-point value %q", s)
+	}
+	return x
+}
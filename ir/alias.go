@@ -0,0 +1,117 @@
+// === [ Aliases ] =============================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#aliases
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+// An Alias represents an LLVM IR alias, which introduces a new name for a
+// global variable, function, alias or constant expression (the aliasee).
+//
+// Aliases may be referenced from instructions (e.g. call), and are thus
+// considered LLVM IR values of pointer type.
+type Alias struct {
+	// Alias name.
+	Name string
+	// Alias type.
+	Typ *types.PointerType
+	// Aliasee.
+	//
+	// Aliasee may have one of the following underlying types.
+	//
+	//    *ir.Global
+	//    *ir.Function
+	//    *ir.Alias
+	//    constant.Expr
+	Aliasee constant.Constant
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
+	// Thread local storage model.
+	TLSModel TLSModel
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
+	// Source position of the alias name; or the zero value if unknown.
+	Pos Position
+}
+
+// NewAlias returns a new alias based on the given alias name and aliasee.
+func NewAlias(name string, aliasee constant.Constant) *Alias {
+	typ, ok := aliasee.Type().(*types.PointerType)
+	if !ok {
+		panic(fmt.Errorf("invalid aliasee type; expected *types.PointerType, got %T", aliasee.Type()))
+	}
+	return &Alias{
+		Name:    name,
+		Typ:     typ,
+		Aliasee: aliasee,
+	}
+}
+
+// Type returns the type of the alias.
+func (alias *Alias) Type() types.Type {
+	return alias.Typ
+}
+
+// Ident returns the identifier associated with the alias.
+func (alias *Alias) Ident() string {
+	return enc.Global(alias.Name)
+}
+
+// GetName returns the name of the alias.
+func (alias *Alias) GetName() string {
+	return alias.Name
+}
+
+// SetName sets the name of the alias.
+func (alias *Alias) SetName(name string) {
+	alias.Name = name
+}
+
+// Immutable ensures that only constants can be assigned to the
+// constant.Constant interface.
+func (*Alias) Immutable() {}
+
+// MetadataNode ensures that only metadata nodes can be assigned to the
+// metadata.Node interface.
+func (*Alias) MetadataNode() {}
+
+// String returns the LLVM syntax representation of the alias.
+func (alias *Alias) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s =", alias.Ident())
+	if alias.Linkage != LinkageNone {
+		fmt.Fprintf(buf, " %s", alias.Linkage)
+	}
+	if alias.Visibility != VisibilityNone {
+		fmt.Fprintf(buf, " %s", alias.Visibility)
+	}
+	if alias.DLLStorageClass != DLLStorageClassNone {
+		fmt.Fprintf(buf, " %s", alias.DLLStorageClass)
+	}
+	if alias.TLSModel != TLSModelNone {
+		fmt.Fprintf(buf, " %s", alias.TLSModel)
+	}
+	if alias.UnnamedAddr != UnnamedAddrNone {
+		fmt.Fprintf(buf, " %s", alias.UnnamedAddr)
+	}
+	fmt.Fprintf(buf, " alias %s, ", alias.Typ.Elem)
+	// The type of constant expressions is implied, and therefore omitted.
+	if _, ok := alias.Aliasee.(constant.Expr); !ok {
+		fmt.Fprintf(buf, "%s ", alias.Aliasee.Type())
+	}
+	buf.WriteString(alias.Aliasee.Ident())
+	return buf.String()
+}
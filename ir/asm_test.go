@@ -19,6 +19,7 @@ func TestRoundTrip(t *testing.T) {
 		// Top-level declarations.
 		{path: "../asm/testdata/module.ll"},
 		{path: "../asm/testdata/global.ll"},
+		{path: "../asm/testdata/alias.ll"},
 		{path: "../asm/testdata/func.ll"},
 		{path: "../asm/testdata/metadata.ll"},
 		// Types.
@@ -39,6 +40,7 @@ func TestRoundTrip(t *testing.T) {
 		{path: "../asm/testdata/inst_vector.ll"},
 		{path: "../asm/testdata/inst_aggregate.ll"},
 		{path: "../asm/testdata/inst_memory.ll"},
+		{path: "../asm/testdata/inst_memory_addrspace.ll"},
 		{path: "../asm/testdata/inst_conversion.ll"},
 		{path: "../asm/testdata/inst_other.ll"},
 		// Terminators.
@@ -0,0 +1,296 @@
+// === [ Attributes ] ==========================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#parameter-attributes
+//    http://llvm.org/docs/LangRef.html#function-attributes
+//    http://llvm.org/docs/LangRef.html#attribute-groups
+
+// Package attr provides access to LLVM IR parameter, return and function
+// attributes.
+package attr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+)
+
+// An Attribute represents an LLVM IR parameter, return or function attribute.
+//
+// Attribute may have one of the following underlying types.
+//
+//    attr.Enum                    (https://godoc.org/github.com/llir/llvm/ir/attr#Enum)
+//    attr.Align                   (https://godoc.org/github.com/llir/llvm/ir/attr#Align)
+//    attr.AlignStack              (https://godoc.org/github.com/llir/llvm/ir/attr#AlignStack)
+//    attr.Dereferenceable         (https://godoc.org/github.com/llir/llvm/ir/attr#Dereferenceable)
+//    attr.DereferenceableOrNull   (https://godoc.org/github.com/llir/llvm/ir/attr#DereferenceableOrNull)
+//    *attr.AllocSize              (https://godoc.org/github.com/llir/llvm/ir/attr#AllocSize)
+//    *attr.String                 (https://godoc.org/github.com/llir/llvm/ir/attr#String)
+//    *attr.Group                  (https://godoc.org/github.com/llir/llvm/ir/attr#Group)
+type Attribute interface {
+	fmt.Stringer
+	// IsAttribute ensures that only attributes can be assigned to the
+	// attr.Attribute interface.
+	IsAttribute()
+}
+
+// --- [ Enum attributes ] -----------------------------------------------------
+
+// Enum represents the set of attributes without value.
+type Enum uint
+
+// Enum attributes.
+const (
+	AlwaysInline                Enum = iota + 1 // alwaysinline
+	ArgMemOnly                                  // argmemonly
+	Builtin                                     // builtin
+	ByVal                                       // byval
+	Cold                                        // cold
+	Convergent                                  // convergent
+	InAlloca                                    // inalloca
+	InReg                                       // inreg
+	InaccessibleMemOrArgMemOnly                 // inaccessiblemem_or_argmemonly
+	InaccessibleMemOnly                         // inaccessiblememonly
+	InlineHint                                  // inlinehint
+	JumpTable                                   // jumptable
+	MinSize                                     // minsize
+	Naked                                       // naked
+	Nest                                        // nest
+	NoAlias                                     // noalias
+	NoBuiltin                                   // nobuiltin
+	NoCapture                                   // nocapture
+	NoDuplicate                                 // noduplicate
+	NoImplicitFloat                             // noimplicitfloat
+	NoInline                                    // noinline
+	NoRecurse                                   // norecurse
+	NoRedZone                                   // noredzone
+	NoReturn                                    // noreturn
+	NoUnwind                                    // nounwind
+	NonLazyBind                                 // nonlazybind
+	NonNull                                     // nonnull
+	OptNone                                     // optnone
+	OptSize                                     // optsize
+	ReadNone                                    // readnone
+	ReadOnly                                    // readonly
+	Returned                                    // returned
+	ReturnsTwice                                // returns_twice
+	SExt                                        // signext
+	SRet                                        // sret
+	SSP                                         // ssp
+	SSPReq                                      // sspreq
+	SSPStrong                                   // sspstrong
+	SafeStack                                   // safestack
+	SanitizeAddress                             // sanitize_address
+	SanitizeMemory                              // sanitize_memory
+	SanitizeThread                              // sanitize_thread
+	SwiftError                                  // swifterror
+	SwiftSelf                                   // swiftself
+	UWTable                                     // uwtable
+	WriteOnly                                   // writeonly
+	ZExt                                        // zeroext
+)
+
+// String returns the LLVM syntax representation of the attribute.
+func (a Enum) String() string {
+	m := map[Enum]string{
+		AlwaysInline:                "alwaysinline",
+		ArgMemOnly:                  "argmemonly",
+		Builtin:                     "builtin",
+		ByVal:                       "byval",
+		Cold:                        "cold",
+		Convergent:                  "convergent",
+		InAlloca:                    "inalloca",
+		InReg:                       "inreg",
+		InaccessibleMemOrArgMemOnly: "inaccessiblemem_or_argmemonly",
+		InaccessibleMemOnly:         "inaccessiblememonly",
+		InlineHint:                  "inlinehint",
+		JumpTable:                   "jumptable",
+		MinSize:                     "minsize",
+		Naked:                       "naked",
+		Nest:                        "nest",
+		NoAlias:                     "noalias",
+		NoBuiltin:                   "nobuiltin",
+		NoCapture:                   "nocapture",
+		NoDuplicate:                 "noduplicate",
+		NoImplicitFloat:             "noimplicitfloat",
+		NoInline:                    "noinline",
+		NoRecurse:                   "norecurse",
+		NoRedZone:                   "noredzone",
+		NoReturn:                    "noreturn",
+		NoUnwind:                    "nounwind",
+		NonLazyBind:                 "nonlazybind",
+		NonNull:                     "nonnull",
+		OptNone:                     "optnone",
+		OptSize:                     "optsize",
+		ReadNone:                    "readnone",
+		ReadOnly:                    "readonly",
+		Returned:                    "returned",
+		ReturnsTwice:                "returns_twice",
+		SExt:                        "signext",
+		SRet:                        "sret",
+		SSP:                         "ssp",
+		SSPReq:                      "sspreq",
+		SSPStrong:                   "sspstrong",
+		SafeStack:                   "safestack",
+		SanitizeAddress:             "sanitize_address",
+		SanitizeMemory:              "sanitize_memory",
+		SanitizeThread:              "sanitize_thread",
+		SwiftError:                  "swifterror",
+		SwiftSelf:                   "swiftself",
+		UWTable:                     "uwtable",
+		WriteOnly:                   "writeonly",
+		ZExt:                        "zeroext",
+	}
+	if s, ok := m[a]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown attribute %d", uint(a))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (Enum) IsAttribute() {}
+
+// --- [ Integer attributes ] --------------------------------------------------
+
+// Align is an attribute specifying the alignment in bytes of a parameter or
+// return value.
+type Align int64
+
+// String returns the LLVM syntax representation of the attribute.
+func (a Align) String() string {
+	return fmt.Sprintf("align %d", int64(a))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (Align) IsAttribute() {}
+
+// AlignStack is an attribute specifying the alignment in bytes of the stack of
+// a function.
+type AlignStack int64
+
+// String returns the LLVM syntax representation of the attribute.
+func (a AlignStack) String() string {
+	return fmt.Sprintf("alignstack(%d)", int64(a))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (AlignStack) IsAttribute() {}
+
+// Dereferenceable is an attribute specifying the number of bytes known to be
+// dereferenceable through a pointer parameter or return value.
+type Dereferenceable int64
+
+// String returns the LLVM syntax representation of the attribute.
+func (a Dereferenceable) String() string {
+	return fmt.Sprintf("dereferenceable(%d)", int64(a))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (Dereferenceable) IsAttribute() {}
+
+// DereferenceableOrNull is an attribute specifying the number of bytes known to
+// be dereferenceable through a pointer parameter or return value, unless null.
+type DereferenceableOrNull int64
+
+// String returns the LLVM syntax representation of the attribute.
+func (a DereferenceableOrNull) String() string {
+	return fmt.Sprintf("dereferenceable_or_null(%d)", int64(a))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (DereferenceableOrNull) IsAttribute() {}
+
+// AllocSize is an attribute specifying which parameters of an allocation
+// function hold the size of the allocated memory.
+type AllocSize struct {
+	// Index of the element size parameter.
+	ElemSize int
+	// Index of the number of elements parameter; valid if HasNElems is set.
+	NElems int
+	// Specifies whether the number of elements parameter is present.
+	HasNElems bool
+}
+
+// String returns the LLVM syntax representation of the attribute.
+func (a *AllocSize) String() string {
+	if a.HasNElems {
+		return fmt.Sprintf("allocsize(%d, %d)", a.ElemSize, a.NElems)
+	}
+	return fmt.Sprintf("allocsize(%d)", a.ElemSize)
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (*AllocSize) IsAttribute() {}
+
+// --- [ String attributes ] ---------------------------------------------------
+
+// String is a target-dependent attribute, specified as a key and optional
+// value.
+type String struct {
+	// Attribute key.
+	Key string
+	// Attribute value; or empty if not present.
+	Val string
+}
+
+// String returns the LLVM syntax representation of the attribute.
+func (a *String) String() string {
+	if len(a.Val) > 0 {
+		return fmt.Sprintf(`"%s"="%s"`, enc.EscapeString(a.Key), enc.EscapeString(a.Val))
+	}
+	return fmt.Sprintf(`"%s"`, enc.EscapeString(a.Key))
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (*String) IsAttribute() {}
+
+// --- [ Attribute groups ] ----------------------------------------------------
+
+// A Group represents an attribute group definition. Attribute groups are
+// referenced from the attributes of functions by their ID.
+type Group struct {
+	// Attribute group ID.
+	ID string
+	// Function attributes of the attribute group.
+	Attrs []Attribute
+}
+
+// String returns the LLVM syntax representation of a reference to the
+// attribute group.
+func (g *Group) String() string {
+	return g.Ident()
+}
+
+// Ident returns the identifier associated with the attribute group.
+func (g *Group) Ident() string {
+	return "#" + g.ID
+}
+
+// Def returns the LLVM syntax representation of the definition of the
+// attribute group.
+func (g *Group) Def() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{")
+	for _, a := range g.Attrs {
+		// The stack alignment is specified using "=" within attribute groups.
+		if a, ok := a.(AlignStack); ok {
+			fmt.Fprintf(buf, " alignstack=%d", int64(a))
+			continue
+		}
+		fmt.Fprintf(buf, " %s", a)
+	}
+	buf.WriteString(" }")
+	return buf.String()
+}
+
+// IsAttribute ensures that only attributes can be assigned to the
+// attr.Attribute interface.
+func (*Group) IsAttribute() {}
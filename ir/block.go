@@ -3,8 +3,9 @@
 package ir
 
 import (
-	"bytes"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/llir/llvm/internal/enc"
 	"github.com/llir/llvm/ir/types"
@@ -26,6 +27,8 @@ type BasicBlock struct {
 	Insts []Instruction
 	// Terminator of the basic block.
 	Term Terminator
+	// Source position of the basic block; or the zero value if unknown.
+	Pos Position
 }
 
 // NewBlock returns a new basic block based on the given label name. An empty
@@ -58,18 +61,42 @@ func (block *BasicBlock) SetName(name string) {
 
 // String returns the LLVM syntax representation of the basic block.
 func (block *BasicBlock) String() string {
-	buf := &bytes.Buffer{}
-	if isLocalID(block.Name) {
+	buf := &strings.Builder{}
+	// Writes to a strings.Builder never fail.
+	block.WriteTo(buf)
+	return buf.String()
+}
 
-		fmt.Fprintf(buf, "; <label>:%s\n", enc.EscapeIdent(block.Name))
+// WriteTo writes the LLVM syntax representation of the basic block to w, one
+// instruction at a time. It implements io.WriterTo, and the output is
+// identical to the output of String.
+func (block *BasicBlock) WriteTo(w io.Writer) (n int64, err error) {
+	p := &printer{w: w}
+	block.print(p)
+	return p.n, p.err
+}
+
+// print writes the LLVM syntax representation of the basic block to p.
+func (block *BasicBlock) print(p *printer) {
+	var label string
+	if isLocalID(block.Name) && !p.cfg.NumericLabels {
+		label = fmt.Sprintf("; <label>:%s", enc.EscapeIdent(block.Name))
 	} else {
-		fmt.Fprintf(buf, "%s:\n", enc.EscapeIdent(block.Name))
+		label = fmt.Sprintf("%s:", enc.EscapeIdent(block.Name))
+	}
+	if preds := p.preds(block); len(preds) > 0 {
+		var idents []string
+		for _, pred := range preds {
+			idents = append(idents, pred.Ident())
+		}
+		label = padToColumn(label, predsColumn) + "; preds = " + strings.Join(idents, ", ")
 	}
+	p.WriteString(label + "\n")
 	for _, inst := range block.Insts {
-		fmt.Fprintf(buf, "\t%s\n", inst)
+		p.printInst(inst)
+		p.WriteString("\n")
 	}
-	fmt.Fprintf(buf, "\t%s", block.Term)
-	return buf.String()
+	p.printInst(block.Term)
 }
 
 // AppendInst appends the given instruction to the basic block.
@@ -87,49 +114,49 @@ func (block *BasicBlock) SetTerm(term Terminator) {
 // --- [ Binary instructions ] -------------------------------------------------
 
 // NewAdd appends a new add instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewAdd(x, y value.Value) *InstAdd {
-	inst := NewAdd(x, y)
+// operands and optional overflow flags.
+func (block *BasicBlock) NewAdd(x, y value.Value, flags ...OverflowFlag) *InstAdd {
+	inst := NewAdd(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
 
 // NewFAdd appends a new fadd instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewFAdd(x, y value.Value) *InstFAdd {
-	inst := NewFAdd(x, y)
+// operands and optional fast-math flags.
+func (block *BasicBlock) NewFAdd(x, y value.Value, flags ...FastMathFlag) *InstFAdd {
+	inst := NewFAdd(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
 
 // NewSub appends a new sub instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewSub(x, y value.Value) *InstSub {
-	inst := NewSub(x, y)
+// operands and optional overflow flags.
+func (block *BasicBlock) NewSub(x, y value.Value, flags ...OverflowFlag) *InstSub {
+	inst := NewSub(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
 
 // NewFSub appends a new fsub instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewFSub(x, y value.Value) *InstFSub {
-	inst := NewFSub(x, y)
+// operands and optional fast-math flags.
+func (block *BasicBlock) NewFSub(x, y value.Value, flags ...FastMathFlag) *InstFSub {
+	inst := NewFSub(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
 
 // NewMul appends a new mul instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewMul(x, y value.Value) *InstMul {
-	inst := NewMul(x, y)
+// operands and optional overflow flags.
+func (block *BasicBlock) NewMul(x, y value.Value, flags ...OverflowFlag) *InstMul {
+	inst := NewMul(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
 
 // NewFMul appends a new fmul instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewFMul(x, y value.Value) *InstFMul {
-	inst := NewFMul(x, y)
+// operands and optional fast-math flags.
+func (block *BasicBlock) NewFMul(x, y value.Value, flags ...FastMathFlag) *InstFMul {
+	inst := NewFMul(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
@@ -151,9 +178,9 @@ func (block *BasicBlock) NewSDiv(x, y value.Value) *InstSDiv {
 }
 
 // NewFDiv appends a new fdiv instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewFDiv(x, y value.Value) *InstFDiv {
-	inst := NewFDiv(x, y)
+// operands and optional fast-math flags.
+func (block *BasicBlock) NewFDiv(x, y value.Value, flags ...FastMathFlag) *InstFDiv {
+	inst := NewFDiv(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
@@ -175,9 +202,9 @@ func (block *BasicBlock) NewSRem(x, y value.Value) *InstSRem {
 }
 
 // NewFRem appends a new frem instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewFRem(x, y value.Value) *InstFRem {
-	inst := NewFRem(x, y)
+// operands and optional fast-math flags.
+func (block *BasicBlock) NewFRem(x, y value.Value, flags ...FastMathFlag) *InstFRem {
+	inst := NewFRem(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
@@ -185,9 +212,9 @@ func (block *BasicBlock) NewFRem(x, y value.Value) *InstFRem {
 // --- [ Bitwise instructions ] ------------------------------------------------
 
 // NewShl appends a new shl instruction to the basic block based on the given
-// operands.
-func (block *BasicBlock) NewShl(x, y value.Value) *InstShl {
-	inst := NewShl(x, y)
+// operands and optional overflow flags.
+func (block *BasicBlock) NewShl(x, y value.Value, flags ...OverflowFlag) *InstShl {
+	inst := NewShl(x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
@@ -302,6 +329,32 @@ func (block *BasicBlock) NewStore(src, dst value.Value) *InstStore {
 	return inst
 }
 
+// NewFence appends a new fence instruction to the basic block based on the
+// given atomic memory ordering constraints.
+func (block *BasicBlock) NewFence(ordering AtomicOrdering) *InstFence {
+	inst := NewFence(ordering)
+	block.AppendInst(inst)
+	return inst
+}
+
+// NewCmpXchg appends a new cmpxchg instruction to the basic block based on the
+// given address, value to compare against, new value to store, and atomic
+// memory ordering constraints on success and failure.
+func (block *BasicBlock) NewCmpXchg(ptr, cmp, new value.Value, success, failure AtomicOrdering) *InstCmpXchg {
+	inst := NewCmpXchg(ptr, cmp, new, success, failure)
+	block.AppendInst(inst)
+	return inst
+}
+
+// NewAtomicRMW appends a new atomicrmw instruction to the basic block based on
+// the given atomic operation, destination address, operand and atomic memory
+// ordering constraints.
+func (block *BasicBlock) NewAtomicRMW(op AtomicOp, dst, x value.Value, ordering AtomicOrdering) *InstAtomicRMW {
+	inst := NewAtomicRMW(op, dst, x, ordering)
+	block.AppendInst(inst)
+	return inst
+}
+
 // NewGetElementPtr appends a new getelementptr instruction to the basic block
 // based on the given source address and element indices.
 func (block *BasicBlock) NewGetElementPtr(src value.Value, indices ...value.Value) *InstGetElementPtr {
@@ -427,9 +480,9 @@ func (block *BasicBlock) NewICmp(pred IntPred, x, y value.Value) *InstICmp {
 }
 
 // NewFCmp appends a new fcmp instruction to the basic block based on the given
-// floating-point condition code and operands.
-func (block *BasicBlock) NewFCmp(pred FloatPred, x, y value.Value) *InstFCmp {
-	inst := NewFCmp(pred, x, y)
+// floating-point condition code, operands and optional fast-math flags.
+func (block *BasicBlock) NewFCmp(pred FloatPred, x, y value.Value, flags ...FastMathFlag) *InstFCmp {
+	inst := NewFCmp(pred, x, y, flags...)
 	block.AppendInst(inst)
 	return inst
 }
@@ -460,12 +513,46 @@ func (block *BasicBlock) NewSelect(cond, x, y value.Value) *InstSelect {
 //    *constant.ExprBitCast
 //    *ir.InstBitCast
 //    *ir.InstLoad
-func (block *BasicBlock) NewCall(callee value.Named, args ...value.Value) *InstCall {
+//    *ir.InlineAsm
+func (block *BasicBlock) NewCall(callee value.Value, args ...value.Value) *InstCall {
 	inst := NewCall(callee, args...)
 	block.AppendInst(inst)
 	return inst
 }
 
+// NewVAArg appends a new va_arg instruction to the basic block based on the
+// given variable argument list and argument type.
+func (block *BasicBlock) NewVAArg(argList value.Value, argType types.Type) *InstVAArg {
+	inst := NewVAArg(argList, argType)
+	block.AppendInst(inst)
+	return inst
+}
+
+// NewLandingPad appends a new landingpad instruction to the basic block based on
+// the given result type and exception clauses.
+func (block *BasicBlock) NewLandingPad(typ types.Type, clauses ...*Clause) *InstLandingPad {
+	inst := NewLandingPad(typ, clauses...)
+	block.AppendInst(inst)
+	return inst
+}
+
+// NewCatchPad appends a new catchpad instruction to the basic block based on the
+// given parent catchswitch terminator and exception arguments.
+func (block *BasicBlock) NewCatchPad(within value.Value, args ...value.Value) *InstCatchPad {
+	inst := NewCatchPad(within, args...)
+	block.AppendInst(inst)
+	return inst
+}
+
+// NewCleanupPad appends a new cleanuppad instruction to the basic block based on
+// the given parent exception pad and exception arguments. The none token
+// constant is used as parent if the cleanuppad is not within a funclet.
+func (block *BasicBlock) NewCleanupPad(within value.Value, args ...value.Value) *InstCleanupPad {
+	inst := NewCleanupPad(within, args...)
+	block.AppendInst(inst)
+	return inst
+}
+
 // --- [ Terminators ] ---------------------------------------------------------
 
 // NewRet sets the terminator of the basic block to a new ret terminator based
@@ -501,6 +588,67 @@ func (block *BasicBlock) NewSwitch(x value.Value, targetDefault *BasicBlock, cas
 	return term
 }
 
+// NewIndirectBr sets the terminator of the basic block to a new indirectbr
+// terminator based on the given target address (derived from a blockaddress
+// constant) and set of valid target basic blocks.
+func (block *BasicBlock) NewIndirectBr(addr value.Value, validTargets ...*BasicBlock) *TermIndirectBr {
+	term := NewIndirectBr(addr, validTargets...)
+	block.SetTerm(term)
+	return term
+}
+
+// NewInvoke sets the terminator of the basic block to a new invoke terminator
+// based on the given callee, function arguments, and target branches of normal
+// return and exception.
+//
+// The callee value may have one of the following underlying types.
+//    *ir.Function
+//    *types.Param
+//    *constant.ExprBitCast
+//    *ir.InstBitCast
+//    *ir.InstLoad
+//    *ir.InlineAsm
+func (block *BasicBlock) NewInvoke(callee value.Value, args []value.Value, normal, exception *BasicBlock) *TermInvoke {
+	term := NewInvoke(callee, args, normal, exception)
+	block.SetTerm(term)
+	return term
+}
+
+// NewResume sets the terminator of the basic block to a new resume terminator
+// based on the given exception value to propagate.
+func (block *BasicBlock) NewResume(x value.Value) *TermResume {
+	term := NewResume(x)
+	block.SetTerm(term)
+	return term
+}
+
+// NewCatchSwitch sets the terminator of the basic block to a new catchswitch
+// terminator based on the given parent exception pad, exception handlers and
+// unwind target. A nil unwind target indicates that the catchswitch unwinds to
+// the caller.
+func (block *BasicBlock) NewCatchSwitch(within value.Value, handlers []*BasicBlock, unwind *BasicBlock) *TermCatchSwitch {
+	term := NewCatchSwitch(within, handlers, unwind)
+	block.SetTerm(term)
+	return term
+}
+
+// NewCatchRet sets the terminator of the basic block to a new catchret
+// terminator based on the given exiting catchpad and target branch.
+func (block *BasicBlock) NewCatchRet(from value.Value, to *BasicBlock) *TermCatchRet {
+	term := NewCatchRet(from, to)
+	block.SetTerm(term)
+	return term
+}
+
+// NewCleanupRet sets the terminator of the basic block to a new cleanupret
+// terminator based on the given exiting cleanuppad and unwind target. A nil
+// unwind target indicates that the cleanupret unwinds to the caller.
+func (block *BasicBlock) NewCleanupRet(from value.Value, unwind *BasicBlock) *TermCleanupRet {
+	term := NewCleanupRet(from, unwind)
+	block.SetTerm(term)
+	return term
+}
+
 // NewUnreachable sets the terminator of the basic block to a new unreachable
 // terminator.
 func (block *BasicBlock) NewUnreachable() *TermUnreachable {
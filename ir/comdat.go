@@ -0,0 +1,74 @@
+// === [ Comdats ] =============================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#comdats
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+)
+
+// A Comdat represents an LLVM IR comdat definition. Global variables and
+// functions of the same comdat are grouped together by the linker, which uses
+// the selection kind of the comdat to pick one group among duplicates.
+type Comdat struct {
+	// Comdat name.
+	Name string
+	// Selection kind.
+	Kind SelectionKind
+}
+
+// NewComdat returns a new comdat definition based on the given comdat name and
+// selection kind.
+func NewComdat(name string, kind SelectionKind) *Comdat {
+	return &Comdat{Name: name, Kind: kind}
+}
+
+// Ident returns the identifier associated with the comdat.
+func (c *Comdat) Ident() string {
+	return enc.Comdat(c.Name)
+}
+
+// String returns the LLVM syntax representation of the comdat definition.
+func (c *Comdat) String() string {
+	return fmt.Sprintf("%s = comdat %s", c.Ident(), c.Kind)
+}
+
+// SelectionKind represents the set of comdat selection kinds.
+type SelectionKind uint
+
+// Comdat selection kinds.
+const (
+	SelectionKindAny          SelectionKind = iota // any
+	SelectionKindExactMatch                        // exactmatch
+	SelectionKindLargest                           // largest
+	SelectionKindNoDuplicates                      // noduplicates
+	SelectionKindSameSize                          // samesize
+)
+
+// String returns the LLVM syntax representation of the selection kind.
+func (kind SelectionKind) String() string {
+	m := map[SelectionKind]string{
+		SelectionKindAny:          "any",
+		SelectionKindExactMatch:   "exactmatch",
+		SelectionKindLargest:      "largest",
+		SelectionKindNoDuplicates: "noduplicates",
+		SelectionKindSameSize:     "samesize",
+	}
+	if s, ok := m[kind]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown selection kind %d", uint(kind))
+}
+
+// comdatString returns the LLVM syntax representation of the comdat of the
+// given global variable or function name.
+func comdatString(c *Comdat, name string) string {
+	if c.Name == name {
+		return "comdat"
+	}
+	return fmt.Sprintf("comdat(%s)", c.Ident())
+}
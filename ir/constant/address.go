@@ -0,0 +1,55 @@
+// === [ Addresses of basic blocks ] ===========================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#addresses-of-basic-blocks
+
+package constant
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// --- [ blockaddress ] --------------------------------------------------------
+
+// BlockAddress represents a block address constant; the address of a basic
+// block within a given function.
+type BlockAddress struct {
+	// Parent function.
+	//
+	// Func has the underlying type *ir.Function.
+	Func value.Named
+	// Basic block of the parent function.
+	//
+	// Block has the underlying type *ir.BasicBlock.
+	Block value.Named
+}
+
+// NewBlockAddress returns a new block address constant based on the given
+// parent function and basic block.
+//
+// The parent function has the underlying type *ir.Function, and the basic block
+// has the underlying type *ir.BasicBlock.
+func NewBlockAddress(f, block value.Named) *BlockAddress {
+	return &BlockAddress{Func: f, Block: block}
+}
+
+// Type returns the type of the constant.
+func (c *BlockAddress) Type() types.Type {
+	return types.NewPointer(types.I8)
+}
+
+// Ident returns the string representation of the constant.
+func (c *BlockAddress) Ident() string {
+	return fmt.Sprintf("blockaddress(%s, %s)", c.Func.Ident(), c.Block.Ident())
+}
+
+// Immutable ensures that only constants can be assigned to the
+// constant.Constant interface.
+func (*BlockAddress) Immutable() {}
+
+// MetadataNode ensures that only metadata nodes can be assigned to the
+// ir.MetadataNode interface.
+func (*BlockAddress) MetadataNode() {}
@@ -153,6 +153,9 @@ func (c *Struct) Type() types.Type {
 // Ident returns the string representation of the constant.
 func (c *Struct) Ident() string {
 	buf := &bytes.Buffer{}
+	if c.Typ.Packed {
+		buf.WriteString("<")
+	}
 	buf.WriteString("{")
 	if len(c.Fields) > 0 {
 		// Use same output format as Clang.
@@ -171,6 +174,9 @@ func (c *Struct) Ident() string {
 		buf.WriteString(" ")
 	}
 	buf.WriteString("}")
+	if c.Typ.Packed {
+		buf.WriteString(">")
+	}
 	return buf.String()
 }
 
@@ -20,9 +20,10 @@ import (
 //
 // http://llvm.org/docs/LangRef.html#simple-constants
 //
-//    *constant.Int     (https://godoc.org/github.com/llir/llvm/ir/constant#Int)
-//    *constant.Float   (https://godoc.org/github.com/llir/llvm/ir/constant#Float)
-//    *constant.Null    (https://godoc.org/github.com/llir/llvm/ir/constant#Null)
+//    *constant.Int         (https://godoc.org/github.com/llir/llvm/ir/constant#Int)
+//    *constant.Float       (https://godoc.org/github.com/llir/llvm/ir/constant#Float)
+//    *constant.Null        (https://godoc.org/github.com/llir/llvm/ir/constant#Null)
+//    *constant.NoneToken   (https://godoc.org/github.com/llir/llvm/ir/constant#NoneToken)
 //
 // Complex constants
 //
@@ -33,15 +34,23 @@ import (
 //    *constant.Struct            (https://godoc.org/github.com/llir/llvm/ir/constant#Struct)
 //    *constant.ZeroInitializer   (https://godoc.org/github.com/llir/llvm/ir/constant#ZeroInitializer)
 //
-// Global variable and function addresses
+// Global variable, function, alias and IFunc addresses
 //
 //    *ir.Global     (https://godoc.org/github.com/llir/llvm/ir#Global)
 //    *ir.Function   (https://godoc.org/github.com/llir/llvm/ir#Function)
+//    *ir.Alias      (https://godoc.org/github.com/llir/llvm/ir#Alias)
+//    *ir.IFunc      (https://godoc.org/github.com/llir/llvm/ir#IFunc)
 //
 // Undefined value constants
 //
 //    *constant.Undef   (https://godoc.org/github.com/llir/llvm/ir/constant#Undef)
 //
+// Addresses of basic blocks
+//
+// http://llvm.org/docs/LangRef.html#addresses-of-basic-blocks
+//
+//    *constant.BlockAddress   (https://godoc.org/github.com/llir/llvm/ir/constant#BlockAddress)
+//
 // Constant expressions
 //
 // http://llvm.org/docs/LangRef.html#constant-expressions
@@ -60,4 +69,6 @@ var (
 	True = NewInt(1, types.I1)
 	// False represents the `false` constant.
 	False = NewInt(0, types.I1)
+	// None represents the `none` token constant.
+	None = &NoneToken{}
 )
@@ -11,12 +11,15 @@ var (
 	_ constant.Constant = &constant.Int{}
 	_ constant.Constant = &constant.Float{}
 	_ constant.Constant = &constant.Null{}
+	_ constant.Constant = &constant.NoneToken{}
 	// Complex constants.
 	_ constant.Constant = &constant.Vector{}
 	_ constant.Constant = &constant.Array{}
 	_ constant.Constant = &constant.Struct{}
 	_ constant.Constant = &constant.ZeroInitializer{}
 	_ constant.Constant = &constant.Undef{}
+	// Addresses of basic blocks.
+	_ constant.Constant = &constant.BlockAddress{}
 )
 
 // Validate that the relevant types satisfy the constant.Expr interface.
@@ -82,6 +85,7 @@ var (
 	_ metadata.Node = &constant.Struct{}
 	_ metadata.Node = &constant.ZeroInitializer{}
 	_ metadata.Node = &constant.Undef{}
+	_ metadata.Node = &constant.BlockAddress{}
 	// Binary expressions.
 	_ metadata.Node = &constant.ExprAdd{}
 	_ metadata.Node = &constant.ExprFAdd{}
@@ -65,7 +65,15 @@ func (*ExprExtractValue) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprExtractValue) Simplify() Constant {
-	panic("not yet implemented")
+	c := simplify(expr.X)
+	for _, index := range expr.Indices {
+		elems, ok := aggregateElems(c)
+		if !ok || index < 0 || index >= int64(len(elems)) {
+			return expr
+		}
+		c = simplify(elems[index])
+	}
+	return c
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -122,7 +130,10 @@ func (*ExprInsertValue) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprInsertValue) Simplify() Constant {
-	panic("not yet implemented")
+	if c, ok := insertValue(simplify(expr.X), simplify(expr.Elem), expr.Indices); ok {
+		return c
+	}
+	return expr
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -153,3 +164,31 @@ func aggregateElemType(t types.Type, indices []int64) (types.Type, error) {
 		return nil, errors.Errorf("invalid aggregate value type; expected *types.ArrayType or *types.StructType, got %T", t)
 	}
 }
+
+// insertValue returns a copy of the given aggregate constant with the element
+// at the specified indices replaced by elem. The boolean return value indicates
+// success.
+func insertValue(agg, elem Constant, indices []int64) (Constant, bool) {
+	if len(indices) == 0 {
+		return elem, true
+	}
+	elems, ok := aggregateElems(agg)
+	index := indices[0]
+	if !ok || index < 0 || index >= int64(len(elems)) {
+		return nil, false
+	}
+	e, ok := insertValue(simplify(elems[index]), elem, indices[1:])
+	if !ok {
+		return nil, false
+	}
+	elems = append([]Constant(nil), elems...)
+	elems[index] = e
+	switch t := agg.Type().(type) {
+	case *types.ArrayType:
+		return &Array{Typ: t, Elems: elems}, true
+	case *types.StructType:
+		return &Struct{Typ: t, Fields: elems}, true
+	default:
+		return nil, false
+	}
+}
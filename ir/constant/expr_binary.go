@@ -8,6 +8,7 @@
 package constant
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/llir/llvm/ir/types"
@@ -22,13 +23,17 @@ import (
 type ExprAdd struct {
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
-// NewAdd returns a new add expression based on the given operands.
-func NewAdd(x, y Constant) *ExprAdd {
+// NewAdd returns a new add expression based on the given
+// operands and optional overflow flags.
+func NewAdd(x, y Constant, flags ...OverflowFlag) *ExprAdd {
 	return &ExprAdd{
-		X: x,
-		Y: y,
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
 	}
 }
 
@@ -39,11 +44,15 @@ func (expr *ExprAdd) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprAdd) Ident() string {
-	return fmt.Sprintf("add (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("add")
+	buf.WriteString(overflowFlagsString(expr.OverflowFlags))
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -52,7 +61,7 @@ func (*ExprAdd) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprAdd) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -85,11 +94,14 @@ func (expr *ExprFAdd) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprFAdd) Ident() string {
-	return fmt.Sprintf("fadd (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("fadd")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -98,7 +110,7 @@ func (*ExprFAdd) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFAdd) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -114,13 +126,17 @@ func (*ExprFAdd) MetadataNode() {}
 type ExprSub struct {
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
-// NewSub returns a new sub expression based on the given operands.
-func NewSub(x, y Constant) *ExprSub {
+// NewSub returns a new sub expression based on the given
+// operands and optional overflow flags.
+func NewSub(x, y Constant, flags ...OverflowFlag) *ExprSub {
 	return &ExprSub{
-		X: x,
-		Y: y,
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
 	}
 }
 
@@ -131,11 +147,15 @@ func (expr *ExprSub) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprSub) Ident() string {
-	return fmt.Sprintf("sub (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("sub")
+	buf.WriteString(overflowFlagsString(expr.OverflowFlags))
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -144,7 +164,7 @@ func (*ExprSub) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSub) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -177,11 +197,14 @@ func (expr *ExprFSub) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprFSub) Ident() string {
-	return fmt.Sprintf("fsub (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("fsub")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -190,7 +213,7 @@ func (*ExprFSub) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFSub) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -206,13 +229,17 @@ func (*ExprFSub) MetadataNode() {}
 type ExprMul struct {
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
-// NewMul returns a new mul expression based on the given operands.
-func NewMul(x, y Constant) *ExprMul {
+// NewMul returns a new mul expression based on the given
+// operands and optional overflow flags.
+func NewMul(x, y Constant, flags ...OverflowFlag) *ExprMul {
 	return &ExprMul{
-		X: x,
-		Y: y,
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
 	}
 }
 
@@ -223,11 +250,15 @@ func (expr *ExprMul) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprMul) Ident() string {
-	return fmt.Sprintf("mul (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("mul")
+	buf.WriteString(overflowFlagsString(expr.OverflowFlags))
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -236,7 +267,7 @@ func (*ExprMul) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprMul) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -269,11 +300,14 @@ func (expr *ExprFMul) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprFMul) Ident() string {
-	return fmt.Sprintf("fmul (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("fmul")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -282,7 +316,7 @@ func (*ExprFMul) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFMul) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -298,6 +332,9 @@ func (*ExprFMul) MetadataNode() {}
 type ExprUDiv struct {
 	// Operands.
 	X, Y Constant
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 }
 
 // NewUDiv returns a new udiv expression based on the given operands.
@@ -315,11 +352,17 @@ func (expr *ExprUDiv) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprUDiv) Ident() string {
-	return fmt.Sprintf("udiv (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("udiv")
+	if expr.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -328,7 +371,7 @@ func (*ExprUDiv) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprUDiv) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -344,6 +387,9 @@ func (*ExprUDiv) MetadataNode() {}
 type ExprSDiv struct {
 	// Operands.
 	X, Y Constant
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 }
 
 // NewSDiv returns a new sdiv expression based on the given operands.
@@ -361,11 +407,17 @@ func (expr *ExprSDiv) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprSDiv) Ident() string {
-	return fmt.Sprintf("sdiv (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("sdiv")
+	if expr.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -374,7 +426,7 @@ func (*ExprSDiv) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSDiv) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -407,11 +459,14 @@ func (expr *ExprFDiv) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprFDiv) Ident() string {
-	return fmt.Sprintf("fdiv (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("fdiv")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -420,7 +475,7 @@ func (*ExprFDiv) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFDiv) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -453,11 +508,14 @@ func (expr *ExprURem) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprURem) Ident() string {
-	return fmt.Sprintf("urem (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("urem")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -466,7 +524,7 @@ func (*ExprURem) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprURem) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -499,11 +557,14 @@ func (expr *ExprSRem) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprSRem) Ident() string {
-	return fmt.Sprintf("srem (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("srem")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -512,7 +573,7 @@ func (*ExprSRem) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSRem) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -545,11 +606,14 @@ func (expr *ExprFRem) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprFRem) Ident() string {
-	return fmt.Sprintf("frem (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("frem")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -558,7 +622,7 @@ func (*ExprFRem) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFRem) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
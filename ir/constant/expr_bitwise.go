@@ -8,6 +8,7 @@
 package constant
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/llir/llvm/ir/types"
@@ -22,13 +23,17 @@ import (
 type ExprShl struct {
 	// Operands.
 	X, Y Constant
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 }
 
-// NewShl returns a new shl expression based on the given operands.
-func NewShl(x, y Constant) *ExprShl {
+// NewShl returns a new shl expression based on the given
+// operands and optional overflow flags.
+func NewShl(x, y Constant, flags ...OverflowFlag) *ExprShl {
 	return &ExprShl{
-		X: x,
-		Y: y,
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
 	}
 }
 
@@ -39,11 +44,15 @@ func (expr *ExprShl) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprShl) Ident() string {
-	return fmt.Sprintf("shl (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("shl")
+	buf.WriteString(overflowFlagsString(expr.OverflowFlags))
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -52,7 +61,7 @@ func (*ExprShl) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprShl) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -68,6 +77,9 @@ func (*ExprShl) MetadataNode() {}
 type ExprLShr struct {
 	// Operands.
 	X, Y Constant
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 }
 
 // NewLShr returns a new lshr expression based on the given operands.
@@ -85,11 +97,17 @@ func (expr *ExprLShr) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprLShr) Ident() string {
-	return fmt.Sprintf("lshr (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("lshr")
+	if expr.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -98,7 +116,7 @@ func (*ExprLShr) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprLShr) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -114,6 +132,9 @@ func (*ExprLShr) MetadataNode() {}
 type ExprAShr struct {
 	// Operands.
 	X, Y Constant
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 }
 
 // NewAShr returns a new ashr expression based on the given operands.
@@ -131,11 +152,17 @@ func (expr *ExprAShr) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprAShr) Ident() string {
-	return fmt.Sprintf("ashr (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("ashr")
+	if expr.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -144,7 +171,7 @@ func (*ExprAShr) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprAShr) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -177,11 +204,14 @@ func (expr *ExprAnd) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprAnd) Ident() string {
-	return fmt.Sprintf("and (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("and")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -190,7 +220,7 @@ func (*ExprAnd) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprAnd) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -223,11 +253,14 @@ func (expr *ExprOr) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprOr) Ident() string {
-	return fmt.Sprintf("or (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("or")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -236,7 +269,7 @@ func (*ExprOr) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprOr) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -269,11 +302,14 @@ func (expr *ExprXor) Type() types.Type {
 
 // Ident returns the string representation of the constant expression.
 func (expr *ExprXor) Ident() string {
-	return fmt.Sprintf("xor (%s %s, %s %s)",
+	buf := &bytes.Buffer{}
+	buf.WriteString("xor")
+	fmt.Fprintf(buf, " (%s %s, %s %s)",
 		expr.X.Type(),
 		expr.X.Ident(),
 		expr.Y.Type(),
 		expr.Y.Ident())
+	return buf.String()
 }
 
 // Immutable ensures that only constants can be assigned to the
@@ -282,7 +318,7 @@ func (*ExprXor) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprXor) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyBinary(expr, expr.X, expr.Y)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
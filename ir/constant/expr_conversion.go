@@ -53,7 +53,7 @@ func (*ExprTrunc) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprTrunc) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -100,7 +100,7 @@ func (*ExprZExt) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprZExt) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -147,7 +147,7 @@ func (*ExprSExt) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSExt) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -194,7 +194,7 @@ func (*ExprFPTrunc) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFPTrunc) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -241,7 +241,7 @@ func (*ExprFPExt) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFPExt) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -288,7 +288,7 @@ func (*ExprFPToUI) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFPToUI) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -335,7 +335,7 @@ func (*ExprFPToSI) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFPToSI) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -382,7 +382,7 @@ func (*ExprUIToFP) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprUIToFP) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -429,7 +429,7 @@ func (*ExprSIToFP) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSIToFP) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -476,7 +476,7 @@ func (*ExprPtrToInt) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprPtrToInt) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -523,7 +523,7 @@ func (*ExprIntToPtr) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprIntToPtr) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -570,7 +570,7 @@ func (*ExprBitCast) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprBitCast) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -617,7 +617,7 @@ func (*ExprAddrSpaceCast) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprAddrSpaceCast) Simplify() Constant {
-	panic("not yet implemented")
+	return simplifyConversion(expr, expr.From, expr.To)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
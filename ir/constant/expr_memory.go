@@ -27,6 +27,9 @@ type ExprGetElementPtr struct {
 	Src Constant
 	// Element indices.
 	Indices []Constant
+	// In-bounds address computation; the result is a poison value if the
+	// address is outside of the allocated object pointed to by Src.
+	InBounds bool
 }
 
 // NewGetElementPtr returns a new getelementptr expression based on the given
@@ -53,6 +56,9 @@ func NewGetElementPtr(src Constant, indices ...Constant) *ExprGetElementPtr {
 		case *types.ArrayType:
 			e = t.Elem
 		case *types.StructType:
+			if idx, ok := index.(*Index); ok {
+				index = idx.Constant
+			}
 			idx, ok := index.(*Int)
 			if !ok {
 				panic(fmt.Errorf("invalid index type for structure element; expected *constant.Int, got %T", index))
@@ -79,12 +85,20 @@ func (expr *ExprGetElementPtr) Type() types.Type {
 // Ident returns the string representation of the constant expression.
 func (expr *ExprGetElementPtr) Ident() string {
 	buf := &bytes.Buffer{}
-	fmt.Fprintf(buf, "getelementptr (%s, %s %s",
+	buf.WriteString("getelementptr")
+	if expr.InBounds {
+		buf.WriteString(" inbounds")
+	}
+	fmt.Fprintf(buf, " (%s, %s %s",
 		expr.Elem,
 		expr.Src.Type(),
 		expr.Src.Ident())
 	for _, index := range expr.Indices {
-		fmt.Fprintf(buf, ", %s %s",
+		buf.WriteString(", ")
+		if idx, ok := index.(*Index); ok && idx.InRange {
+			buf.WriteString("inrange ")
+		}
+		fmt.Fprintf(buf, "%s %s",
 			index.Type(),
 			index.Ident())
 	}
@@ -98,9 +112,43 @@ func (*ExprGetElementPtr) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprGetElementPtr) Simplify() Constant {
-	panic("not yet implemented")
+	// Fold getelementptr expressions with null source address and zero indices
+	// into a null pointer of the result type.
+	if _, ok := simplify(expr.Src).(*Null); !ok {
+		return expr
+	}
+	for _, index := range expr.Indices {
+		if idx, ok := index.(*Index); ok {
+			index = idx.Constant
+		}
+		index, ok := simplify(index).(*Int)
+		if !ok || index.X.Sign() != 0 {
+			return expr
+		}
+	}
+	return NewNull(expr.Typ)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
 // ir.MetadataNode interface.
 func (*ExprGetElementPtr) MetadataNode() {}
+
+// ~~~ [ Index ] ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+
+// Index represents an element index of a getelementptr expression.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#getelementptr-instruction
+type Index struct {
+	// Element index.
+	Constant
+	// States that loads and stores through the resulting pointer stay within
+	// the bounds of the element selected by the index; at most one index of a
+	// getelementptr expression may be marked inrange.
+	InRange bool
+}
+
+// NewIndex returns a new element index based on the given constant.
+func NewIndex(index Constant) *Index {
+	return &Index{Constant: index}
+}
@@ -62,7 +62,20 @@ func (*ExprICmp) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprICmp) Simplify() Constant {
-	panic("not yet implemented")
+	x, y := simplify(expr.X), simplify(expr.Y)
+	if _, ok := expr.Typ.(*types.VectorType); ok {
+		f := func(x, y Constant) (Constant, bool) {
+			return foldICmp(expr.Pred, x, y)
+		}
+		if c, ok := foldVectors(x, y, f); ok {
+			return c
+		}
+		return expr
+	}
+	if c, ok := foldICmp(expr.Pred, x, y); ok {
+		return c
+	}
+	return expr
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -157,7 +170,20 @@ func (*ExprFCmp) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprFCmp) Simplify() Constant {
-	panic("not yet implemented")
+	x, y := simplify(expr.X), simplify(expr.Y)
+	if _, ok := expr.Typ.(*types.VectorType); ok {
+		f := func(x, y Constant) (Constant, bool) {
+			return foldFCmp(expr.Pred, x, y)
+		}
+		if c, ok := foldVectors(x, y, f); ok {
+			return c
+		}
+		return expr
+	}
+	if c, ok := foldFCmp(expr.Pred, x, y); ok {
+		return c
+	}
+	return expr
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -260,7 +286,39 @@ func (*ExprSelect) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprSelect) Simplify() Constant {
-	panic("not yet implemented")
+	cond := simplify(expr.Cond)
+	if cond, ok := cond.(*Int); ok {
+		if cond.X.Sign() != 0 {
+			return simplify(expr.X)
+		}
+		return simplify(expr.Y)
+	}
+	// Select element-wise based on vector condition.
+	conds, ok := vectorElems(cond)
+	if !ok {
+		return expr
+	}
+	xs, ok := vectorElems(simplify(expr.X))
+	if !ok || len(xs) != len(conds) {
+		return expr
+	}
+	ys, ok := vectorElems(simplify(expr.Y))
+	if !ok || len(ys) != len(conds) {
+		return expr
+	}
+	var elems []Constant
+	for i := range conds {
+		cond, ok := simplify(conds[i]).(*Int)
+		if !ok {
+			return expr
+		}
+		if cond.X.Sign() != 0 {
+			elems = append(elems, xs[i])
+		} else {
+			elems = append(elems, ys[i])
+		}
+	}
+	return NewVector(elems...)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -60,7 +60,15 @@ func (*ExprExtractElement) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprExtractElement) Simplify() Constant {
-	panic("not yet implemented")
+	elems, ok := vectorElems(simplify(expr.X))
+	if !ok {
+		return expr
+	}
+	i, ok := vectorIndex(simplify(expr.Index), len(elems))
+	if !ok {
+		return expr
+	}
+	return simplify(elems[i])
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -114,7 +122,17 @@ func (*ExprInsertElement) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprInsertElement) Simplify() Constant {
-	panic("not yet implemented")
+	elems, ok := vectorElems(simplify(expr.X))
+	if !ok {
+		return expr
+	}
+	i, ok := vectorIndex(simplify(expr.Index), len(elems))
+	if !ok {
+		return expr
+	}
+	elems = append([]Constant(nil), elems...)
+	elems[i] = simplify(expr.Elem)
+	return NewVector(elems...)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -168,7 +186,32 @@ func (*ExprShuffleVector) Immutable() {}
 
 // Simplify returns a simplified version of the constant expression.
 func (expr *ExprShuffleVector) Simplify() Constant {
-	panic("not yet implemented")
+	xs, ok := vectorElems(simplify(expr.X))
+	if !ok {
+		return expr
+	}
+	ys, ok := vectorElems(simplify(expr.Y))
+	if !ok {
+		return expr
+	}
+	mask, ok := vectorElems(simplify(expr.Mask))
+	if !ok {
+		return expr
+	}
+	xs = append(xs[:len(xs):len(xs)], ys...)
+	var elems []Constant
+	for _, m := range mask {
+		if _, ok := m.(*Undef); ok {
+			elems = append(elems, NewUndef(xs[0].Type()))
+			continue
+		}
+		i, ok := vectorIndex(simplify(m), len(xs))
+		if !ok {
+			return expr
+		}
+		elems = append(elems, xs[i])
+	}
+	return NewVector(elems...)
 }
 
 // MetadataNode ensures that only metadata nodes can be assigned to the
@@ -5,6 +5,11 @@
 
 package constant
 
+import (
+	"bytes"
+	"fmt"
+)
+
 // An Expr represents an LLVM IR constant expression.
 //
 // Expr may have one of the following underlying types.
@@ -88,3 +93,53 @@ type Expr interface {
 	// Simplify returns a simplified version of the constant expression.
 	Simplify() Constant
 }
+
+// OverflowFlag represents the set of overflow flags of integer arithmetic
+// expressions.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#add-instruction
+type OverflowFlag uint
+
+// Overflow flags.
+const (
+	OverflowFlagNUW OverflowFlag = iota + 1 // nuw: no unsigned wrap
+	OverflowFlagNSW                         // nsw: no signed wrap
+)
+
+// String returns the LLVM syntax representation of the overflow flag.
+func (flag OverflowFlag) String() string {
+	m := map[OverflowFlag]string{
+		OverflowFlagNUW: "nuw",
+		OverflowFlagNSW: "nsw",
+	}
+	if s, ok := m[flag]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown overflow flag %d", uint(flag))
+}
+
+// overflowFlagsString returns the string representation of the given overflow
+// flags, each prefixed by a space. Flags are printed once each, in the order
+// used by LLVM (nuw before nsw).
+func overflowFlagsString(flags []OverflowFlag) string {
+	buf := &bytes.Buffer{}
+	present := make(map[OverflowFlag]bool)
+	for _, flag := range flags {
+		present[flag] = true
+	}
+	for _, flag := range []OverflowFlag{OverflowFlagNUW, OverflowFlagNSW} {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	// Unknown flags are printed last, in their original order.
+	for _, flag := range flags {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	return buf.String()
+}
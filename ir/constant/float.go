@@ -7,7 +7,9 @@ package constant
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/llir/llvm/internal/floats"
@@ -20,8 +22,11 @@ import (
 type Float struct {
 	// Floating-point type.
 	Typ *types.FloatType
-	// Floating-point value.
+	// Floating-point value; or nil if NaN.
 	X *big.Float
+	// Not-a-Number (NaN) value; NaN values are not representable by
+	// *big.Float.
+	NaN bool
 }
 
 // NewFloat returns a new floating-point constant based on the given
@@ -31,6 +36,9 @@ func NewFloat(x float64, typ types.Type) *Float {
 	if !ok {
 		panic(fmt.Errorf("invalid floating-point constant type; expected *types.FloatType, got %T", typ))
 	}
+	if math.IsNaN(x) {
+		return &Float{Typ: t, NaN: true}
+	}
 	return &Float{Typ: t, X: big.NewFloat(x)}
 }
 
@@ -49,45 +57,64 @@ func NewFloatFromString(s string, typ types.Type) *Float {
 	case strings.HasPrefix(s, "0xK"):
 		//   HexFP80Constant   0xK[0-9A-Fa-f]+    // 20 hex digits
 
-		// TODO: Implement support for the 0xK floating-point representation format.
-		c.X = &big.Float{}
-		c.X.SetString("0.0") // TODO: Remove placehold zero value.
-		//panic(fmt.Errorf("support for floating-point constant 0xK representation not yet implemented; unable to parse floating-point constant %q", s))
-		s = s[len("0xK"):]
+		f := floats.NewFloat80FromString(s[len("0xK"):])
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
 		return c
 	case strings.HasPrefix(s, "0xL"):
 		//   HexFP128Constant  0xL[0-9A-Fa-f]+    // 32 hex digits
 
-		// TODO: Implement support for the 0xL floating-point representation format.
-		c.X = &big.Float{}
-		c.X.SetString("0.0") // TODO: Remove placehold zero value.
-		//panic(fmt.Errorf("support for floating-point constant 0xL representation not yet implemented; unable to parse floating-point constant %q", s))
-		s = s[len("0xL"):]
+		// The low-order 64 bits precede the high-order 64 bits.
+		hex := s[len("0xL"):]
+		if len(hex) != 32 {
+			panic(fmt.Errorf("invalid length of fp128 hexadecimal representation, expected 32, got %d; unable to parse floating-point constant %q", len(hex), s))
+		}
+		f := floats.NewFloat128FromString(hex[16:] + hex[:16])
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
 		return c
 	case strings.HasPrefix(s, "0xM"):
 		//   HexPPC128Constant 0xM[0-9A-Fa-f]+    // 32 hex digits
 
-		// TODO: Implement support for the 0xM floating-point representation format.
-		c.X = &big.Float{}
-		c.X.SetString("0.0") // TODO: Remove placehold zero value.
-		//panic(fmt.Errorf("support for floating-point constant 0xM representation not yet implemented; unable to parse floating-point constant %q", s))
-		s = s[len("0xM"):]
+		f := floats.NewFloat128PPCFromString(s[len("0xM"):])
+		if f.IsNaN() {
+			c.NaN = true
+			return c
+		}
+		c.X = f.Big()
 		return c
 	case strings.HasPrefix(s, "0xH"):
 		//   HexHalfConstant   0xH[0-9A-Fa-f]+    // 4 hex digits
 
 		str := s[len("0xH"):]
-		x := floats.NewFloat16FromString(str)
-		c.X = big.NewFloat(x.Float64())
+		x := floats.NewFloat16FromString(str).Float64()
+		if math.IsNaN(x) {
+			c.NaN = true
+			return c
+		}
+		c.X = big.NewFloat(x)
 		return c
 	case strings.HasPrefix(s, "0x"):
 		//   HexFPConstant     0x[0-9A-Fa-f]+     // 16 hex digits
 
-		// TODO: Implement support for the 0x floating-point representation format.
-		c.X = &big.Float{}
-		c.X.SetString("0.0") // TODO: Remove placehold zero value.
-		//panic(fmt.Errorf("support for floating-point constant 0x representation not yet implemented; unable to parse floating-point constant %q", s))
-		s = s[len("0x"):]
+		// Both float and double constants are represented in the IEEE 754
+		// binary64 format.
+		bits, err := strconv.ParseUint(s[len("0x"):], 16, 64)
+		if err != nil {
+			panic(fmt.Errorf("unable to parse floating-point constant %q; %v", s, err))
+		}
+		x := math.Float64frombits(bits)
+		if math.IsNaN(x) {
+			c.NaN = true
+			return c
+		}
+		c.X = big.NewFloat(x)
 		return c
 	}
 
@@ -114,44 +141,50 @@ func (c *Float) Ident() string {
 	switch kind {
 	case types.FloatKindIEEE_128:
 		// The IEEE 128-bit format is represented by 0xL followed by 32
-		// hexadecimal digits.
-
-		// TODO: Implement.
-		panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
+		// hexadecimal digits, with the low-order 64 bits preceding the
+		// high-order 64 bits.
+		f := floats.NewFloat128FromBits(0x7FFF800000000000, 0)
+		if !c.NaN {
+			f = floats.NewFloat128FromBig(c.X)
+		}
+		hex := f.String()
+		return "0xL" + hex[16:] + hex[:16]
 	case types.FloatKindDoubleExtended_80:
 		// The 80-bit format used by x86 is represented as 0xK followed by 20
 		// hexadecimal digits.
-
-		// TODO: Represent precise float80 using c.X.MantExp and
-		// floats.NewFloat80FromBits.
-		f := floats.NewFloat80FromFloat64(c.Float64())
+		f := floats.NewFloat80FromBits(0x7FFF, 0xC000000000000000)
+		if !c.NaN {
+			f = floats.NewFloat80FromBig(c.X)
+		}
 		return "0xK" + f.String()
 	case types.FloatKindDoubleDouble_128:
 		// The 128-bit format used by PowerPC (two adjacent doubles) is
 		// represented by 0xM followed by 32 hexadecimal digits.
-
-		// TODO: Implement.
-		panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
+		f := floats.NewFloat128PPCFromBits(0x7FF8000000000000, 0)
+		if !c.NaN {
+			f = floats.NewFloat128PPCFromBig(c.X)
+		}
+		return "0xM" + f.String()
 	}
 
-	// TODO: Handle NaN, special values (e.g. Pi). Print those in hexadecimal
-	// representation.
-
-	// Use hexadecimal representation for +Inf and -Inf.
-	printHex := c.X.IsInf()
-	if printHex {
+	// Use hexadecimal representation for NaN, +Inf and -Inf.
+	if c.NaN || c.X.IsInf() {
 		switch kind {
 		case types.FloatKindIEEE_16:
-			// TODO: Implement.
-			x, _ := c.X.Float64()
-			f16, _ := floats.NewFloat16FromFloat64(x)
+			// The IEEE 16-bit format is represented by 0xH followed by 4
+			// hexadecimal digits.
+			if c.NaN {
+				return "0xH7E00"
+			}
+			f16, _ := floats.NewFloat16FromFloat64(c.Float64())
 			return "0xH" + f16.String()
-		case types.FloatKindIEEE_32:
-			// TODO: Implement.
-			panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
-		case types.FloatKindIEEE_64:
-			// TODO: Implement.
-			panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
+		case types.FloatKindIEEE_32, types.FloatKindIEEE_64:
+			// Both float and double constants are represented by 0x followed by
+			// the 16 hexadecimal digits of the IEEE 754 binary64 format.
+			if c.NaN {
+				return "0x7FF8000000000000"
+			}
+			return fmt.Sprintf("0x%016X", math.Float64bits(c.Float64()))
 		default:
 			panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
 		}
@@ -184,6 +217,9 @@ func (*Float) MetadataNode() {}
 
 // Float64 returns the float64 representation of the floating-point constant.
 func (c *Float) Float64() float64 {
+	if c.NaN {
+		return math.NaN()
+	}
 	x, _ := c.X.Float64()
 	return x
 }
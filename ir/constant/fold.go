@@ -0,0 +1,757 @@
+// === [ Constant folding ] ====================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#constant-expressions
+
+package constant
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/llir/llvm/internal/floats"
+	"github.com/llir/llvm/ir/types"
+)
+
+// simplify returns a simplified version of the given constant, if it is a
+// constant expression.
+func simplify(c Constant) Constant {
+	if expr, ok := c.(Expr); ok {
+		return expr.Simplify()
+	}
+	return c
+}
+
+// --- [ Binary and bitwise expressions ] --------------------------------------
+
+// simplifyBinary returns a simplified version of the given binary or bitwise
+// expression with operands x and y. The expression is returned unchanged if it
+// cannot be folded.
+func simplifyBinary(expr Expr, x, y Constant) Constant {
+	x, y = simplify(x), simplify(y)
+	switch x := x.(type) {
+	case *Int:
+		if y, ok := y.(*Int); ok {
+			if c, ok := foldIntBinary(expr, x, y); ok {
+				return c
+			}
+		}
+	case *Float:
+		if y, ok := y.(*Float); ok {
+			if c, ok := foldFloatBinary(expr, x, y); ok {
+				return c
+			}
+		}
+	default:
+		// Fold vector operands element-wise.
+		f := func(x, y Constant) (Constant, bool) {
+			c := newBinary(expr, x, y).Simplify()
+			_, isExpr := c.(Expr)
+			return c, !isExpr
+		}
+		if c, ok := foldVectors(x, y, f); ok {
+			return c
+		}
+	}
+	return expr
+}
+
+// newBinary returns a new binary or bitwise expression of the same kind as
+// expr, based on the given operands.
+func newBinary(expr Expr, x, y Constant) Expr {
+	switch expr.(type) {
+	// Binary expressions.
+	case *ExprAdd:
+		return NewAdd(x, y)
+	case *ExprFAdd:
+		return NewFAdd(x, y)
+	case *ExprSub:
+		return NewSub(x, y)
+	case *ExprFSub:
+		return NewFSub(x, y)
+	case *ExprMul:
+		return NewMul(x, y)
+	case *ExprFMul:
+		return NewFMul(x, y)
+	case *ExprUDiv:
+		return NewUDiv(x, y)
+	case *ExprSDiv:
+		return NewSDiv(x, y)
+	case *ExprFDiv:
+		return NewFDiv(x, y)
+	case *ExprURem:
+		return NewURem(x, y)
+	case *ExprSRem:
+		return NewSRem(x, y)
+	case *ExprFRem:
+		return NewFRem(x, y)
+	// Bitwise expressions.
+	case *ExprShl:
+		return NewShl(x, y)
+	case *ExprLShr:
+		return NewLShr(x, y)
+	case *ExprAShr:
+		return NewAShr(x, y)
+	case *ExprAnd:
+		return NewAnd(x, y)
+	case *ExprOr:
+		return NewOr(x, y)
+	case *ExprXor:
+		return NewXor(x, y)
+	default:
+		panic(fmt.Errorf("support for binary expression %T not yet implemented", expr))
+	}
+}
+
+// foldIntBinary folds the given binary or bitwise expression on integer
+// operands. The boolean return value indicates success.
+func foldIntBinary(expr Expr, x, y *Int) (Constant, bool) {
+	size := x.Typ.Size
+	z := &big.Int{}
+	switch expr.(type) {
+	// Binary expressions.
+	case *ExprAdd:
+		z.Add(x.X, y.X)
+	case *ExprSub:
+		z.Sub(x.X, y.X)
+	case *ExprMul:
+		z.Mul(x.X, y.X)
+	case *ExprUDiv, *ExprURem:
+		b := unsignedInt(y.X, size)
+		if b.Sign() == 0 {
+			// Division by zero is undefined behaviour.
+			return nil, false
+		}
+		a := unsignedInt(x.X, size)
+		if _, ok := expr.(*ExprUDiv); ok {
+			z.Quo(a, b)
+		} else {
+			z.Rem(a, b)
+		}
+	case *ExprSDiv, *ExprSRem:
+		a, b := signedInt(x.X, size), signedInt(y.X, size)
+		if b.Sign() == 0 {
+			// Division by zero is undefined behaviour.
+			return nil, false
+		}
+		if b.Cmp(big.NewInt(-1)) == 0 && a.Cmp(minSignedInt(size)) == 0 {
+			// Signed overflow is undefined behaviour.
+			return nil, false
+		}
+		// Both sdiv and srem round towards zero, which match the semantics of
+		// Quo and Rem.
+		if _, ok := expr.(*ExprSDiv); ok {
+			z.Quo(a, b)
+		} else {
+			z.Rem(a, b)
+		}
+	// Bitwise expressions.
+	case *ExprShl, *ExprLShr, *ExprAShr:
+		n := unsignedInt(y.X, size)
+		if n.Cmp(big.NewInt(int64(size))) >= 0 {
+			// Shift amounts equal to or larger than the bit width produce a
+			// poison value.
+			return nil, false
+		}
+		shift := uint(n.Uint64())
+		switch expr.(type) {
+		case *ExprShl:
+			z.Lsh(x.X, shift)
+		case *ExprLShr:
+			z.Rsh(unsignedInt(x.X, size), shift)
+		case *ExprAShr:
+			z.Rsh(signedInt(x.X, size), shift)
+		}
+	case *ExprAnd:
+		z.And(x.X, y.X)
+	case *ExprOr:
+		z.Or(x.X, y.X)
+	case *ExprXor:
+		z.Xor(x.X, y.X)
+	default:
+		return nil, false
+	}
+	return newWrappedInt(z, x.Typ), true
+}
+
+// foldFloatBinary folds the given binary expression on floating-point
+// operands. The boolean return value indicates success.
+func foldFloatBinary(expr Expr, x, y *Float) (Constant, bool) {
+	if x.NaN || y.NaN {
+		// Expressions on NaN operands are left unfolded, as are expressions
+		// producing NaN values.
+		return nil, false
+	}
+	kind := x.Typ.Kind
+	switch kind {
+	case types.FloatKindIEEE_16, types.FloatKindIEEE_32:
+		// Evaluate using native single precision arithmetic. Half precision
+		// results are rounded twice, which is innocuous as single precision has
+		// more than twice the number of significand bits of half precision.
+		a, _ := x.X.Float32()
+		b, _ := y.X.Float32()
+		var z float32
+		switch expr.(type) {
+		case *ExprFAdd:
+			z = a + b
+		case *ExprFSub:
+			z = a - b
+		case *ExprFMul:
+			z = a * b
+		case *ExprFDiv:
+			z = a / b
+		case *ExprFRem:
+			z = float32(math.Mod(float64(a), float64(b)))
+		default:
+			return nil, false
+		}
+		if z != z {
+			// NaN values are not representable by *big.Float.
+			return nil, false
+		}
+		return &Float{Typ: x.Typ, X: roundFloat(big.NewFloat(float64(z)), kind)}, true
+	case types.FloatKindIEEE_64:
+		// Evaluate using native double precision arithmetic.
+		a, b := x.Float64(), y.Float64()
+		var z float64
+		switch expr.(type) {
+		case *ExprFAdd:
+			z = a + b
+		case *ExprFSub:
+			z = a - b
+		case *ExprFMul:
+			z = a * b
+		case *ExprFDiv:
+			z = a / b
+		case *ExprFRem:
+			z = math.Mod(a, b)
+		default:
+			return nil, false
+		}
+		if math.IsNaN(z) {
+			// NaN values are not representable by *big.Float.
+			return nil, false
+		}
+		return &Float{Typ: x.Typ, X: big.NewFloat(z)}, true
+	default:
+		// Evaluate using arbitrary precision arithmetic, limited to finite
+		// operands.
+		if x.X.IsInf() || y.X.IsInf() {
+			return nil, false
+		}
+		z := new(big.Float).SetPrec(floatPrec(kind))
+		switch expr.(type) {
+		case *ExprFAdd:
+			z.Add(x.X, y.X)
+		case *ExprFSub:
+			z.Sub(x.X, y.X)
+		case *ExprFMul:
+			z.Mul(x.X, y.X)
+		case *ExprFDiv:
+			if y.X.Sign() == 0 {
+				return nil, false
+			}
+			z.Quo(x.X, y.X)
+		case *ExprFRem:
+			if y.X.Sign() == 0 {
+				return nil, false
+			}
+			z = floatRem(x.X, y.X)
+		default:
+			return nil, false
+		}
+		return &Float{Typ: x.Typ, X: roundFloat(z, kind)}, true
+	}
+}
+
+// floatRem returns the remainder of the truncated division x/y of the given
+// finite operands, with the sign of x; as computed by fmod. The remainder is
+// exactly representable in the precision of the operands, and is therefore
+// computed exactly using rational arithmetic.
+func floatRem(x, y *big.Float) *big.Float {
+	a, _ := x.Rat(nil)
+	b, _ := y.Rat(nil)
+	q := new(big.Rat).Quo(a, b)
+	// Truncate quotient towards zero.
+	n := new(big.Int).Quo(q.Num(), q.Denom())
+	r := new(big.Rat).Sub(a, new(big.Rat).Mul(new(big.Rat).SetInt(n), b))
+	z := new(big.Float).SetRat(r)
+	if r.Sign() == 0 && x.Signbit() {
+		z.Neg(z)
+	}
+	return z
+}
+
+// --- [ Conversion expressions ] ----------------------------------------------
+
+// simplifyConversion returns a simplified version of the given conversion
+// expression, which converts from to the type to. The expression is returned
+// unchanged if it cannot be folded.
+func simplifyConversion(expr Expr, from Constant, to types.Type) Constant {
+	from = simplify(from)
+	if c, ok := foldConversion(expr, from, to); ok {
+		return c
+	}
+	// Fold vector operands element-wise.
+	toType, ok := to.(*types.VectorType)
+	if !ok {
+		return expr
+	}
+	elems, ok := vectorElems(from)
+	if !ok || int64(len(elems)) != toType.Len {
+		return expr
+	}
+	var cs []Constant
+	for _, elem := range elems {
+		c, ok := foldConversion(expr, simplify(elem), toType.Elem)
+		if !ok {
+			return expr
+		}
+		cs = append(cs, c)
+	}
+	return NewVector(cs...)
+}
+
+// foldConversion folds the given conversion expression on a scalar constant.
+// The boolean return value indicates success.
+func foldConversion(expr Expr, from Constant, to types.Type) (Constant, bool) {
+	switch expr.(type) {
+	case *ExprTrunc, *ExprZExt, *ExprSExt:
+		x, ok := from.(*Int)
+		if !ok {
+			return nil, false
+		}
+		t, ok := to.(*types.IntType)
+		if !ok {
+			return nil, false
+		}
+		switch expr.(type) {
+		case *ExprZExt:
+			return newWrappedInt(unsignedInt(x.X, x.Typ.Size), t), true
+		case *ExprSExt:
+			return newWrappedInt(signedInt(x.X, x.Typ.Size), t), true
+		}
+		return newWrappedInt(x.X, t), true
+	case *ExprFPTrunc, *ExprFPExt:
+		x, ok := from.(*Float)
+		if !ok || x.NaN {
+			return nil, false
+		}
+		t, ok := to.(*types.FloatType)
+		if !ok {
+			return nil, false
+		}
+		return &Float{Typ: t, X: roundFloat(x.X, t.Kind)}, true
+	case *ExprFPToUI, *ExprFPToSI:
+		x, ok := from.(*Float)
+		if !ok || x.NaN || x.X.IsInf() {
+			return nil, false
+		}
+		t, ok := to.(*types.IntType)
+		if !ok {
+			return nil, false
+		}
+		// Round towards zero.
+		z, _ := x.X.Int(nil)
+		// Values out of range of the target type produce a poison value.
+		if _, ok := expr.(*ExprFPToUI); ok {
+			if z.Sign() < 0 || z.BitLen() > t.Size {
+				return nil, false
+			}
+		} else if z.Cmp(minSignedInt(t.Size)) < 0 || z.Cmp(maxSignedInt(t.Size)) > 0 {
+			return nil, false
+		}
+		return newWrappedInt(z, t), true
+	case *ExprUIToFP, *ExprSIToFP:
+		x, ok := from.(*Int)
+		if !ok {
+			return nil, false
+		}
+		t, ok := to.(*types.FloatType)
+		if !ok {
+			return nil, false
+		}
+		var z *big.Int
+		if _, ok := expr.(*ExprUIToFP); ok {
+			z = unsignedInt(x.X, x.Typ.Size)
+		} else {
+			z = signedInt(x.X, x.Typ.Size)
+		}
+		return &Float{Typ: t, X: roundFloat(new(big.Float).SetInt(z), t.Kind)}, true
+	case *ExprPtrToInt:
+		if _, ok := from.(*Null); !ok {
+			return nil, false
+		}
+		t, ok := to.(*types.IntType)
+		if !ok {
+			return nil, false
+		}
+		return NewInt(0, t), true
+	case *ExprIntToPtr:
+		x, ok := from.(*Int)
+		if !ok || unsignedInt(x.X, x.Typ.Size).Sign() != 0 {
+			return nil, false
+		}
+		t, ok := to.(*types.PointerType)
+		if !ok {
+			return nil, false
+		}
+		return NewNull(t), true
+	case *ExprBitCast:
+		if from.Type().Equal(to) {
+			return from, true
+		}
+		switch x := from.(type) {
+		case *Null:
+			if t, ok := to.(*types.PointerType); ok && t.AddrSpace == x.Typ.AddrSpace {
+				return NewNull(t), true
+			}
+		case *Int:
+			// Reinterpret the bits of an integer as a floating-point value.
+			t, ok := to.(*types.FloatType)
+			if !ok {
+				return nil, false
+			}
+			bits := unsignedInt(x.X, x.Typ.Size).Uint64()
+			var f float64
+			switch {
+			case t.Kind == types.FloatKindIEEE_32 && x.Typ.Size == 32:
+				f = float64(math.Float32frombits(uint32(bits)))
+			case t.Kind == types.FloatKindIEEE_64 && x.Typ.Size == 64:
+				f = math.Float64frombits(bits)
+			default:
+				return nil, false
+			}
+			if math.IsNaN(f) {
+				return nil, false
+			}
+			return &Float{Typ: t, X: big.NewFloat(f)}, true
+		case *Float:
+			// Reinterpret the bits of a floating-point value as an integer. The
+			// payload of NaN values is not retained.
+			t, ok := to.(*types.IntType)
+			if !ok || x.NaN {
+				return nil, false
+			}
+			var bits uint64
+			switch {
+			case x.Typ.Kind == types.FloatKindIEEE_32 && t.Size == 32:
+				f, _ := x.X.Float32()
+				bits = uint64(math.Float32bits(f))
+			case x.Typ.Kind == types.FloatKindIEEE_64 && t.Size == 64:
+				bits = math.Float64bits(x.Float64())
+			default:
+				return nil, false
+			}
+			return newWrappedInt(new(big.Int).SetUint64(bits), t), true
+		}
+	}
+	return nil, false
+}
+
+// --- [ Comparison expressions ] ----------------------------------------------
+
+// foldICmp folds the integer comparison of x and y, based on the given
+// predicate. The boolean return value indicates success.
+func foldICmp(pred IntPred, x, y Constant) (Constant, bool) {
+	var a, b *big.Int
+	var size int
+	switch x := x.(type) {
+	case *Int:
+		y, ok := y.(*Int)
+		if !ok {
+			return nil, false
+		}
+		a, b, size = x.X, y.X, x.Typ.Size
+	case *Null:
+		// Null pointers compare equal.
+		if _, ok := y.(*Null); !ok {
+			return nil, false
+		}
+		a, b, size = &big.Int{}, &big.Int{}, 1
+	default:
+		return nil, false
+	}
+	var cmp int
+	switch pred {
+	case IntEQ, IntNE, IntUGT, IntUGE, IntULT, IntULE:
+		cmp = unsignedInt(a, size).Cmp(unsignedInt(b, size))
+	case IntSGT, IntSGE, IntSLT, IntSLE:
+		cmp = signedInt(a, size).Cmp(signedInt(b, size))
+	default:
+		return nil, false
+	}
+	var z bool
+	switch pred {
+	case IntEQ:
+		z = cmp == 0
+	case IntNE:
+		z = cmp != 0
+	case IntUGT, IntSGT:
+		z = cmp > 0
+	case IntUGE, IntSGE:
+		z = cmp >= 0
+	case IntULT, IntSLT:
+		z = cmp < 0
+	case IntULE, IntSLE:
+		z = cmp <= 0
+	}
+	return newBool(z), true
+}
+
+// foldFCmp folds the floating-point comparison of x and y, based on the given
+// predicate. The boolean return value indicates success.
+func foldFCmp(pred FloatPred, x, y Constant) (Constant, bool) {
+	switch pred {
+	case FloatFalse:
+		return newBool(false), true
+	case FloatTrue:
+		return newBool(true), true
+	}
+	a, ok := x.(*Float)
+	if !ok {
+		return nil, false
+	}
+	b, ok := y.(*Float)
+	if !ok {
+		return nil, false
+	}
+	if a.NaN || b.NaN {
+		// Comparisons with NaN operands are unordered.
+		switch pred {
+		case FloatUEQ, FloatUGT, FloatUGE, FloatULT, FloatULE, FloatUNE, FloatUNO:
+			return newBool(true), true
+		}
+		return newBool(false), true
+	}
+	cmp := a.X.Cmp(b.X)
+	var z bool
+	switch pred {
+	case FloatOEQ, FloatUEQ:
+		z = cmp == 0
+	case FloatOGT, FloatUGT:
+		z = cmp > 0
+	case FloatOGE, FloatUGE:
+		z = cmp >= 0
+	case FloatOLT, FloatULT:
+		z = cmp < 0
+	case FloatOLE, FloatULE:
+		z = cmp <= 0
+	case FloatONE, FloatUNE:
+		z = cmp != 0
+	case FloatORD:
+		z = true
+	case FloatUNO:
+		z = false
+	default:
+		return nil, false
+	}
+	return newBool(z), true
+}
+
+// ### [ Helper functions ] ####################################################
+
+// newBool returns a new boolean constant of type i1.
+func newBool(x bool) *Int {
+	if x {
+		return NewInt(1, types.I1)
+	}
+	return NewInt(0, types.I1)
+}
+
+// newWrappedInt returns a new integer constant of the given type, with the
+// value x wrapped in two's complement to the bit width of the type.
+func newWrappedInt(x *big.Int, typ *types.IntType) *Int {
+	// Boolean values are represented as 0 and 1, all other integer values are
+	// represented as signed integers.
+	if typ.Size == 1 {
+		return &Int{Typ: typ, X: unsignedInt(x, typ.Size)}
+	}
+	return &Int{Typ: typ, X: signedInt(x, typ.Size)}
+}
+
+// unsignedInt returns the unsigned interpretation of x in two's complement
+// with the given bit width.
+func unsignedInt(x *big.Int, size int) *big.Int {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(size))
+	mask.Sub(mask, big.NewInt(1))
+	return new(big.Int).And(x, mask)
+}
+
+// signedInt returns the signed interpretation of x in two's complement with
+// the given bit width.
+func signedInt(x *big.Int, size int) *big.Int {
+	z := unsignedInt(x, size)
+	if z.Bit(size-1) == 1 {
+		z.Sub(z, new(big.Int).Lsh(big.NewInt(1), uint(size)))
+	}
+	return z
+}
+
+// minSignedInt returns the minimum signed integer of the given bit width.
+func minSignedInt(size int) *big.Int {
+	return new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(size-1)))
+}
+
+// maxSignedInt returns the maximum signed integer of the given bit width.
+func maxSignedInt(size int) *big.Int {
+	z := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+	return z.Sub(z, big.NewInt(1))
+}
+
+// floatPrec returns the precision in bits of the significand of the given
+// floating-point kind.
+func floatPrec(kind types.FloatKind) uint {
+	switch kind {
+	case types.FloatKindIEEE_16:
+		return 11
+	case types.FloatKindIEEE_32:
+		return 24
+	case types.FloatKindIEEE_64:
+		return 53
+	case types.FloatKindIEEE_128:
+		return 113
+	case types.FloatKindDoubleExtended_80:
+		return 64
+	case types.FloatKindDoubleDouble_128:
+		return 106
+	default:
+		panic(fmt.Errorf("support for floating-point kind %v not yet implemented", kind))
+	}
+}
+
+// roundFloat returns x rounded to the nearest value representable by the given
+// floating-point kind.
+func roundFloat(x *big.Float, kind types.FloatKind) *big.Float {
+	switch kind {
+	case types.FloatKindIEEE_16:
+		f, _ := x.Float32()
+		h, _ := floats.NewFloat16FromFloat32(f)
+		return big.NewFloat(h.Float64())
+	case types.FloatKindIEEE_32:
+		f, _ := x.Float32()
+		return big.NewFloat(float64(f))
+	case types.FloatKindIEEE_64:
+		f, _ := x.Float64()
+		return big.NewFloat(f)
+	case types.FloatKindIEEE_128:
+		return floats.NewFloat128FromBig(x).Big()
+	case types.FloatKindDoubleExtended_80:
+		return floats.NewFloat80FromBig(x).Big()
+	default:
+		return floats.NewFloat128PPCFromBig(x).Big()
+	}
+}
+
+// foldVectors folds the given vector operands element-wise using f. The boolean
+// return value indicates success.
+func foldVectors(x, y Constant, f func(x, y Constant) (Constant, bool)) (Constant, bool) {
+	xs, ok := vectorElems(x)
+	if !ok {
+		return nil, false
+	}
+	ys, ok := vectorElems(y)
+	if !ok || len(xs) != len(ys) {
+		return nil, false
+	}
+	var elems []Constant
+	for i := range xs {
+		elem, ok := f(simplify(xs[i]), simplify(ys[i]))
+		if !ok {
+			return nil, false
+		}
+		elems = append(elems, elem)
+	}
+	return NewVector(elems...), true
+}
+
+// vectorIndex returns the integer value of the given vector index constant, if
+// it is within bounds of a vector of length n. The boolean return value
+// indicates success.
+func vectorIndex(index Constant, n int) (int, bool) {
+	i, ok := index.(*Int)
+	if !ok {
+		return 0, false
+	}
+	x := unsignedInt(i.X, i.Typ.Size)
+	if !x.IsInt64() || x.Int64() >= int64(n) {
+		return 0, false
+	}
+	return int(x.Int64()), true
+}
+
+// vectorElems returns the elements of the given vector constant. The boolean
+// return value indicates success.
+func vectorElems(c Constant) ([]Constant, bool) {
+	switch c := c.(type) {
+	case *Vector:
+		return c.Elems, true
+	case *ZeroInitializer:
+		if t, ok := c.Typ.(*types.VectorType); ok {
+			return repeat(zeroValue(t.Elem), t.Len), true
+		}
+	case *Undef:
+		if t, ok := c.Typ.(*types.VectorType); ok {
+			return repeat(NewUndef(t.Elem), t.Len), true
+		}
+	}
+	return nil, false
+}
+
+// aggregateElems returns the elements of the given array or struct constant.
+// The boolean return value indicates success.
+func aggregateElems(c Constant) ([]Constant, bool) {
+	switch c := c.(type) {
+	case *Array:
+		return c.Elems, true
+	case *Struct:
+		return c.Fields, true
+	case *ZeroInitializer:
+		switch t := c.Typ.(type) {
+		case *types.ArrayType:
+			return repeat(zeroValue(t.Elem), t.Len), true
+		case *types.StructType:
+			var fields []Constant
+			for _, field := range t.Fields {
+				fields = append(fields, zeroValue(field))
+			}
+			return fields, true
+		}
+	case *Undef:
+		switch t := c.Typ.(type) {
+		case *types.ArrayType:
+			return repeat(NewUndef(t.Elem), t.Len), true
+		case *types.StructType:
+			var fields []Constant
+			for _, field := range t.Fields {
+				fields = append(fields, NewUndef(field))
+			}
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// zeroValue returns the zero value of the given type.
+func zeroValue(t types.Type) Constant {
+	switch t := t.(type) {
+	case *types.IntType:
+		return NewInt(0, t)
+	case *types.FloatType:
+		return NewFloat(0, t)
+	case *types.PointerType:
+		return NewNull(t)
+	default:
+		return NewZeroInitializer(t)
+	}
+}
+
+// repeat returns a slice containing n copies of the given constant.
+func repeat(c Constant, n int64) []Constant {
+	cs := make([]Constant, n)
+	for i := range cs {
+		cs[i] = c
+	}
+	return cs
+}
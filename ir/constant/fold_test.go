@@ -0,0 +1,128 @@
+package constant_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+func TestSimplify(t *testing.T) {
+	i8 := func(x int64) *constant.Int {
+		return constant.NewInt(x, types.I8)
+	}
+	i32 := func(x int64) *constant.Int {
+		return constant.NewInt(x, types.I32)
+	}
+	f32 := func(x float64) *constant.Float {
+		return constant.NewFloat(x, types.Float)
+	}
+	f64 := func(x float64) *constant.Float {
+		return constant.NewFloat(x, types.Double)
+	}
+	fp := func(s string, typ *types.FloatType) *constant.Float {
+		return constant.NewFloatFromString(s, typ)
+	}
+	i8ptr := types.NewPointer(types.I8)
+	// Global address, which cannot be folded.
+	g := constant.NewPtrToInt(&global{typ: i8ptr}, types.I64)
+	golden := []struct {
+		want string
+		expr constant.Expr
+	}{
+		// Binary expressions.
+		{want: "-128", expr: constant.NewAdd(i8(127), i8(1))},
+		{want: "-1", expr: constant.NewSub(i8(0), i8(1))},
+		{want: "0", expr: constant.NewMul(i8(16), i8(16))},
+		{want: "127", expr: constant.NewUDiv(i8(-2), i8(2))},
+		{want: "-1", expr: constant.NewSDiv(i8(-2), i8(2))},
+		{want: "udiv (i8 1, i8 0)", expr: constant.NewUDiv(i8(1), i8(0))},
+		{want: "sdiv (i8 -128, i8 -1)", expr: constant.NewSDiv(i8(-128), i8(-1))},
+		{want: "3", expr: constant.NewURem(i8(-1), i8(7))},
+		{want: "-1", expr: constant.NewSRem(i8(-1), i8(5))},
+		{want: "true", expr: constant.NewAdd(constant.False, constant.True)},
+		{want: "false", expr: constant.NewAdd(constant.True, constant.True)},
+		{want: "3.5", expr: constant.NewFAdd(f64(1.25), f64(2.25))},
+		{want: "-0.5", expr: constant.NewFSub(f32(1), f32(1.5))},
+		{want: "0.1", expr: constant.NewFDiv(f64(1), f64(10))},
+		{want: "0.10000000149011612", expr: constant.NewFDiv(f32(1), f32(10))},
+		{want: "1.0", expr: constant.NewFRem(f64(7), f64(3))},
+		{want: "fdiv (double 0.0, double 0.0)", expr: constant.NewFDiv(f64(0), f64(0))},
+		// Overflow to infinity.
+		{want: "0x7FF0000000000000", expr: constant.NewFMul(f64(1e308), f64(10))},
+		{want: "0xFFF0000000000000", expr: constant.NewFMul(f64(-1e308), f64(10))},
+		{want: "0x7FF0000000000000", expr: constant.NewFMul(f32(math.MaxFloat32), f32(10))},
+		{want: "0xL00000000000000007FFF000000000000", expr: constant.NewFAdd(fp("0xLFFFFFFFFFFFFFFFF7FFEFFFFFFFFFFFF", types.FP128), fp("0xLFFFFFFFFFFFFFFFF7FFEFFFFFFFFFFFF", types.FP128))},
+		{want: "0xK7FFF8000000000000000", expr: constant.NewFMul(fp("0xK7FFEFFFFFFFFFFFFFFFF", types.X86_FP80), fp("2.0", types.X86_FP80))},
+		// Remainder of extended precision operands.
+		{want: "0xL00000000000000003FFF000000000000", expr: constant.NewFRem(fp("7.0", types.FP128), fp("3.0", types.FP128))},
+		{want: "0xK3FFF8000000000000000", expr: constant.NewFRem(fp("7.0", types.X86_FP80), fp("3.0", types.X86_FP80))},
+		{want: "0xKBFFF8000000000000000", expr: constant.NewFRem(fp("-7.0", types.X86_FP80), fp("3.0", types.X86_FP80))},
+		{want: "0xK80000000000000000000", expr: constant.NewFRem(fp("-3.0", types.X86_FP80), fp("3.0", types.X86_FP80))},
+		{want: "0xM3FF00000000000000000000000000000", expr: constant.NewFRem(fp("7.0", types.PPC_FP128), fp("3.0", types.PPC_FP128))},
+		{want: "frem (fp128 0xL00000000000000003FFF000000000000, fp128 0xL00000000000000000000000000000000)", expr: constant.NewFRem(fp("1.0", types.FP128), fp("0.0", types.FP128))},
+		// Bitwise expressions.
+		{want: "-128", expr: constant.NewShl(i8(1), i8(7))},
+		{want: "shl (i8 1, i8 8)", expr: constant.NewShl(i8(1), i8(8))},
+		{want: "127", expr: constant.NewLShr(i8(-1), i8(1))},
+		{want: "-1", expr: constant.NewAShr(i8(-1), i8(1))},
+		{want: "15", expr: constant.NewAnd(i8(-1), i8(15))},
+		{want: "-16", expr: constant.NewXor(i8(-1), i8(15))},
+		{want: "-1", expr: constant.NewOr(i8(-16), i8(15))},
+		// Nested expressions.
+		{want: "6", expr: constant.NewMul(constant.NewAdd(i32(1), i32(2)), i32(2))},
+		// Vector expressions.
+		{want: "<i32 4, i32 6>", expr: constant.NewAdd(constant.NewVector(i32(1), i32(2)), constant.NewVector(i32(3), i32(4)))},
+		{want: "2", expr: constant.NewExtractElement(constant.NewVector(i32(1), i32(2)), i32(1))},
+		{want: "<i32 1, i32 5>", expr: constant.NewInsertElement(constant.NewVector(i32(1), i32(2)), i32(5), i32(1))},
+		{want: "<i32 4, i32 1>", expr: constant.NewShuffleVector(constant.NewVector(i32(1), i32(2)), constant.NewVector(i32(3), i32(4)), constant.NewVector(i32(3), i32(0)))},
+		// Aggregate expressions.
+		{want: "2", expr: constant.NewExtractValue(constant.NewStruct(i32(1), constant.NewArray(i8(1), i8(2))), []int64{1, 1})},
+		{want: "{ i32 1, [2 x i8] [i8 1, i8 5] }", expr: constant.NewInsertValue(constant.NewStruct(i32(1), constant.NewArray(i8(1), i8(2))), i8(5), []int64{1, 1})},
+		{want: "0", expr: constant.NewExtractValue(constant.NewZeroInitializer(types.NewArray(types.I32, 2)), []int64{1})},
+		// Memory expressions.
+		{want: "null", expr: constant.NewGetElementPtr(constant.NewNull(i8ptr), i32(0))},
+		// Conversion expressions.
+		{want: "-1", expr: constant.NewTrunc(i32(255), types.I8)},
+		{want: "255", expr: constant.NewZExt(i8(-1), types.I32)},
+		{want: "-1", expr: constant.NewSExt(i8(-1), types.I32)},
+		{want: "1.5", expr: constant.NewFPTrunc(f64(1.5), types.Float)},
+		{want: "0.10000000149011612", expr: constant.NewFPTrunc(f64(0.1), types.Float)},
+		{want: "-2", expr: constant.NewFPToSI(f64(-2.5), types.I32)},
+		{want: "fptoui (double -2.5 to i32)", expr: constant.NewFPToUI(f64(-2.5), types.I32)},
+		{want: "255.0", expr: constant.NewUIToFP(i8(-1), types.Double)},
+		{want: "-1.0", expr: constant.NewSIToFP(i8(-1), types.Double)},
+		{want: "null", expr: constant.NewIntToPtr(i32(0), i8ptr)},
+		{want: "1.0", expr: constant.NewBitCast(i32(0x3F800000), types.Float)},
+		{want: "1065353216", expr: constant.NewBitCast(f32(1), types.I32)},
+		{want: "ptrtoint (i8* @g to i64)", expr: g},
+		{want: "add (i64 ptrtoint (i8* @g to i64), i64 1)", expr: constant.NewAdd(g, constant.NewInt(1, types.I64))},
+		// Other expressions.
+		{want: "true", expr: constant.NewICmp(constant.IntUGT, i8(-1), i8(1))},
+		{want: "false", expr: constant.NewICmp(constant.IntSGT, i8(-1), i8(1))},
+		{want: "true", expr: constant.NewICmp(constant.IntEQ, constant.NewNull(i8ptr), constant.NewNull(i8ptr))},
+		{want: "<i1 true, i1 false>", expr: constant.NewICmp(constant.IntSLT, constant.NewVector(i32(1), i32(2)), constant.NewVector(i32(2), i32(1)))},
+		{want: "true", expr: constant.NewFCmp(constant.FloatOLT, f64(1), f64(2))},
+		{want: "false", expr: constant.NewFCmp(constant.FloatFalse, f64(1), f64(1))},
+		{want: "true", expr: constant.NewFCmp(constant.FloatUNO, f64(math.NaN()), f64(1))},
+		{want: "false", expr: constant.NewFCmp(constant.FloatOEQ, f64(math.NaN()), f64(math.NaN()))},
+		{want: "2", expr: constant.NewSelect(constant.False, i32(1), i32(2))},
+		{want: "<i32 1, i32 4>", expr: constant.NewSelect(constant.NewVector(constant.True, constant.False), constant.NewVector(i32(1), i32(2)), constant.NewVector(i32(3), i32(4)))},
+	}
+	for i, g := range golden {
+		got := g.expr.Simplify().Ident()
+		if got != g.want {
+			t.Errorf("i=%d; expected %q, got %q", i, g.want, got)
+		}
+	}
+}
+
+// global is a dummy global variable address constant.
+type global struct {
+	typ types.Type
+}
+
+func (g *global) Type() types.Type { return g.typ }
+func (g *global) Ident() string    { return "@g" }
+func (g *global) Immutable()       {}
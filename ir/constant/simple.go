@@ -136,3 +136,23 @@ func (*Null) Immutable() {}
 // MetadataNode ensures that only metadata nodes can be assigned to the
 // ir.MetadataNode interface.
 func (*Null) MetadataNode() {}
+
+// --- [ none token ] ----------------------------------------------------------
+
+// NoneToken represents a none token constant.
+type NoneToken struct {
+}
+
+// Type returns the type of the constant.
+func (*NoneToken) Type() types.Type {
+	return types.Token
+}
+
+// Ident returns the string representation of the constant.
+func (*NoneToken) Ident() string {
+	return "none"
+}
+
+// Immutable ensures that only constants can be assigned to the
+// constant.Constant interface.
+func (*NoneToken) Immutable() {}
@@ -8,11 +8,14 @@ package ir
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
@@ -35,13 +38,35 @@ type Function struct {
 	Typ *types.PointerType
 	// Function type.
 	Sig *types.FuncType
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
 	// Calling convention.
 	CallConv CallConv
+	// Return attributes.
+	RetAttrs []attr.Attribute
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
+	// Function attributes.
+	FuncAttrs []attr.Attribute
+	// Section name; or empty if not present.
+	Section string
+	// Comdat; or nil if not present.
+	Comdat *Comdat
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Personality function used for exception handling; or nil if not present.
+	Personality constant.Constant
 	// Basic blocks of the function; or nil if defined externally.
 	Blocks []*BasicBlock
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// function.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the function name; or the zero value if unknown.
+	Pos Position
 	// mu prevents races on assignIDs.
 	mu sync.Mutex
 }
@@ -89,20 +114,49 @@ func (*Function) MetadataNode() {}
 
 // String returns the LLVM syntax representation of the function.
 func (f *Function) String() string {
+	buf := &strings.Builder{}
+	// Writes to a strings.Builder never fail.
+	f.WriteTo(buf)
+	return buf.String()
+}
+
+// WriteTo writes the LLVM syntax representation of the function to w, one
+// basic block at a time. It implements io.WriterTo, and the output is
+// identical to the output of String.
+func (f *Function) WriteTo(w io.Writer) (n int64, err error) {
+	p := &printer{w: w}
+	f.print(p)
+	return p.n, p.err
+}
+
+// print writes the LLVM syntax representation of the function to p.
+func (f *Function) print(p *printer) {
 	// Assign unique local IDs to unnamed function parameters, basic blocks and
 	// local variables.
 	f.mu.Lock()
 	assignIDs(f)
 	f.mu.Unlock()
 
-	// Calling convention.
-	callconv := ""
+	// Linkage, visibility, DLL storage class and calling convention.
+	header := &bytes.Buffer{}
+	if f.Linkage != LinkageNone {
+		fmt.Fprintf(header, " %s", f.Linkage)
+	}
+	if f.Visibility != VisibilityNone {
+		fmt.Fprintf(header, " %s", f.Visibility)
+	}
+	if f.DLLStorageClass != DLLStorageClassNone {
+		fmt.Fprintf(header, " %s", f.DLLStorageClass)
+	}
 	if f.CallConv != CallConvNone {
-		callconv = fmt.Sprintf(" %s", f.CallConv)
+		fmt.Fprintf(header, " %s", f.CallConv)
 	}
 
 	// Function signature.
 	sig := &bytes.Buffer{}
+	for _, a := range f.RetAttrs {
+		fmt.Fprintf(sig, "%s ", a)
+	}
 	fmt.Fprintf(sig, "%s %s(",
 		f.Sig.Ret,
 		f.Ident())
@@ -111,14 +165,14 @@ func (f *Function) String() string {
 		if i != 0 {
 			sig.WriteString(", ")
 		}
+		sig.WriteString(param.Type().String())
+		for _, a := range param.Attrs {
+			fmt.Fprintf(sig, " %s", a)
+		}
 		// Use same output format as Clang. Don't output local ID for unnamed
 		// function parameters.
 		if len(param.Name) > 0 && !isLocalID(param.Name) {
-			fmt.Fprintf(sig, "%s %s",
-				param.Type(),
-				param.Ident())
-		} else {
-			sig.WriteString(param.Type().String())
+			fmt.Fprintf(sig, " %s", param.Ident())
 		}
 	}
 	if f.Sig.Variadic {
@@ -128,23 +182,41 @@ func (f *Function) String() string {
 		sig.WriteString("...")
 	}
 	sig.WriteString(")")
+	if f.UnnamedAddr != UnnamedAddrNone {
+		fmt.Fprintf(sig, " %s", f.UnnamedAddr)
+	}
+	for _, a := range f.FuncAttrs {
+		fmt.Fprintf(sig, " %s", a)
+	}
+	if len(f.Section) > 0 {
+		fmt.Fprintf(sig, ` section "%s"`, enc.EscapeString(f.Section))
+	}
+	if f.Comdat != nil {
+		fmt.Fprintf(sig, " %s", comdatString(f.Comdat, f.Name))
+	}
+	if f.Align != 0 {
+		fmt.Fprintf(sig, " align %d", f.Align)
+	}
+	if f.Personality != nil {
+		fmt.Fprintf(sig, " personality %s %s", f.Personality.Type(), f.Personality.Ident())
+	}
 
 	// Metadata.
 	md := metadataString(f.Metadata, "")
 
 	// Function definition.
 	if len(f.Blocks) > 0 {
-		buf := &bytes.Buffer{}
-		fmt.Fprintf(buf, "define%s %s%s {\n", callconv, sig, md)
+		fmt.Fprintf(p, "define%s %s%s {\n", header, sig, md)
 		for _, block := range f.Blocks {
-			fmt.Fprintln(buf, block)
+			block.print(p)
+			p.WriteString("\n")
 		}
-		buf.WriteString("}")
-		return buf.String()
+		p.WriteString("}")
+		return
 	}
 
 	// External function declaration.
-	return fmt.Sprintf("declare%s%s %s", md, callconv, sig)
+	fmt.Fprintf(p, "declare%s%s %s", md, header, sig)
 }
 
 // Params returns the parameters of the function.
@@ -231,6 +303,13 @@ func assignIDs(f *Function) {
 			// Assign local IDs to unnamed local variables.
 			setName(n)
 		}
+		// Assign local IDs to unnamed local variables produced by terminators
+		// (e.g. invoke).
+		if n, ok := block.Term.(value.Named); ok {
+			if !n.Type().Equal(types.Void) {
+				setName(n)
+			}
+		}
 	}
 }
 
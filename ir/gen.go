@@ -20,40 +20,49 @@ import (
 func main() {
 	binaryInsts := []*Instruction{
 		{
-			Name: "Add",
-			Desc: "an addition",
+			Name:     "Add",
+			Desc:     "an addition",
+			Overflow: true,
 		},
 		{
-			Name: "FAdd",
-			Desc: "a floating-point addition",
+			Name:     "FAdd",
+			Desc:     "a floating-point addition",
+			FastMath: true,
 		},
 		{
-			Name: "Sub",
-			Desc: "a subtraction",
+			Name:     "Sub",
+			Desc:     "a subtraction",
+			Overflow: true,
 		},
 		{
-			Name: "FSub",
-			Desc: "a floating-point subtraction",
+			Name:     "FSub",
+			Desc:     "a floating-point subtraction",
+			FastMath: true,
 		},
 		{
-			Name: "Mul",
-			Desc: "a multiplication",
+			Name:     "Mul",
+			Desc:     "a multiplication",
+			Overflow: true,
 		},
 		{
-			Name: "FMul",
-			Desc: "a floating-point multiplication",
+			Name:     "FMul",
+			Desc:     "a floating-point multiplication",
+			FastMath: true,
 		},
 		{
-			Name: "UDiv",
-			Desc: "an unsigned division",
+			Name:  "UDiv",
+			Desc:  "an unsigned division",
+			Exact: true,
 		},
 		{
-			Name: "SDiv",
-			Desc: "a signed division",
+			Name:  "SDiv",
+			Desc:  "a signed division",
+			Exact: true,
 		},
 		{
-			Name: "FDiv",
-			Desc: "a floating-point division",
+			Name:     "FDiv",
+			Desc:     "a floating-point division",
+			FastMath: true,
 		},
 		{
 			Name: "URem",
@@ -64,22 +73,26 @@ func main() {
 			Desc: "a signed remainder",
 		},
 		{
-			Name: "FRem",
-			Desc: "a floating-point remainder",
+			Name:     "FRem",
+			Desc:     "a floating-point remainder",
+			FastMath: true,
 		},
 	}
 	bitwiseInsts := []*Instruction{
 		{
-			Name: "Shl",
-			Desc: "a shift left",
+			Name:     "Shl",
+			Desc:     "a shift left",
+			Overflow: true,
 		},
 		{
-			Name: "LShr",
-			Desc: "a logical shift right",
+			Name:  "LShr",
+			Desc:  "a logical shift right",
+			Exact: true,
 		},
 		{
-			Name: "AShr",
-			Desc: "an arithmetic shift right",
+			Name:  "AShr",
+			Desc:  "an arithmetic shift right",
+			Exact: true,
 		},
 		{
 			Name: "And",
@@ -222,6 +235,12 @@ type Instruction struct {
 	Name string
 	// Instruction description; e.g. `a shift left`.
 	Desc string
+	// Overflow flags (nuw and nsw) are supported by the instruction.
+	Overflow bool
+	// Exact flag is supported by the instruction.
+	Exact bool
+	// Fast-math flags are supported by the instruction.
+	FastMath bool
 }
 
 // gen generates a source file containing the instructions of the given
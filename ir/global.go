@@ -35,9 +35,30 @@ type Global struct {
 	Init constant.Constant
 	// Immutability of the global variable.
 	IsConst bool
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// DLL storage class.
+	DLLStorageClass DLLStorageClass
+	// Thread local storage model.
+	TLSModel TLSModel
+	// Unnamed address specifier.
+	UnnamedAddr UnnamedAddr
+	// Externally initialized.
+	ExternallyInitialized bool
+	// Section name; or empty if not present.
+	Section string
+	// Comdat; or nil if not present.
+	Comdat *Comdat
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// global.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the global variable name; or the zero value if
+	// unknown.
+	Pos Position
 }
 
 // NewGlobalDecl returns a new external global variable declaration based on the
@@ -96,30 +117,59 @@ func (*Global) MetadataNode() {}
 
 // String returns the LLVM syntax representation of the global variable.
 func (global *Global) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s =", global.Ident())
+	linkage := global.Linkage
+	if global.Init == nil && linkage == LinkageNone {
+		// External global variable declaration.
+		linkage = LinkageExternal
+	}
+	if linkage != LinkageNone {
+		fmt.Fprintf(buf, " %s", linkage)
+	}
+	if global.Visibility != VisibilityNone {
+		fmt.Fprintf(buf, " %s", global.Visibility)
+	}
+	if global.DLLStorageClass != DLLStorageClassNone {
+		fmt.Fprintf(buf, " %s", global.DLLStorageClass)
+	}
+	if global.TLSModel != TLSModelNone {
+		fmt.Fprintf(buf, " %s", global.TLSModel)
+	}
+	if global.UnnamedAddr != UnnamedAddrNone {
+		fmt.Fprintf(buf, " %s", global.UnnamedAddr)
+	}
+	if global.Typ.AddrSpace != 0 {
+		fmt.Fprintf(buf, " addrspace(%d)", global.Typ.AddrSpace)
+	}
+	if global.ExternallyInitialized {
+		buf.WriteString(" externally_initialized")
+	}
 	imm := "global"
 	if global.IsConst {
 		imm = "constant"
 	}
-	md := metadataString(global.Metadata, ",")
-	addrspace := &bytes.Buffer{}
-	if global.Typ.AddrSpace != 0 {
-		fmt.Fprintf(addrspace, " addrspace(%d)", global.Typ.AddrSpace)
-	}
 	if global.Init != nil {
 		// Global variable definition.
-		return fmt.Sprintf("%s =%s %s %s %s%s",
-			global.Ident(),
-			addrspace,
+		fmt.Fprintf(buf, " %s %s %s",
 			imm,
 			global.Init.Type(),
-			global.Init.Ident(),
-			md)
+			global.Init.Ident())
+	} else {
+		// External global variable declaration.
+		fmt.Fprintf(buf, " %s %s",
+			imm,
+			global.Content)
+	}
+	if len(global.Section) > 0 {
+		fmt.Fprintf(buf, `, section "%s"`, enc.EscapeString(global.Section))
+	}
+	if global.Comdat != nil {
+		fmt.Fprintf(buf, ", %s", comdatString(global.Comdat, global.Name))
+	}
+	if global.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", global.Align)
 	}
-	// External global variable declaration.
-	return fmt.Sprintf("%s = external%s %s %s%s",
-		global.Ident(),
-		addrspace,
-		imm,
-		global.Content,
-		md)
+	buf.WriteString(metadataString(global.Metadata, ","))
+	return buf.String()
 }
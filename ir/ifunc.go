@@ -0,0 +1,101 @@
+// === [ IFuncs ] ==============================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#ifuncs
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+// An IFunc represents an LLVM IR indirect function, the address of which is
+// determined at load time by invoking a resolver function.
+//
+// IFuncs may be referenced from instructions (e.g. call), and are thus
+// considered LLVM IR values of pointer type.
+type IFunc struct {
+	// IFunc name.
+	Name string
+	// IFunc type.
+	Typ *types.PointerType
+	// Content type.
+	Content types.Type
+	// Resolver.
+	//
+	// Resolver may have one of the following underlying types.
+	//
+	//    *ir.Function
+	//    constant.Expr
+	Resolver constant.Constant
+	// Linkage type.
+	Linkage Linkage
+	// Visibility style.
+	Visibility Visibility
+	// Source position of the IFunc name; or the zero value if unknown.
+	Pos Position
+}
+
+// NewIFunc returns a new IFunc based on the given IFunc name, content type and
+// resolver.
+func NewIFunc(name string, content types.Type, resolver constant.Constant) *IFunc {
+	typ := types.NewPointer(content)
+	return &IFunc{
+		Name:     name,
+		Typ:      typ,
+		Content:  content,
+		Resolver: resolver,
+	}
+}
+
+// Type returns the type of the IFunc.
+func (ifunc *IFunc) Type() types.Type {
+	return ifunc.Typ
+}
+
+// Ident returns the identifier associated with the IFunc.
+func (ifunc *IFunc) Ident() string {
+	return enc.Global(ifunc.Name)
+}
+
+// GetName returns the name of the IFunc.
+func (ifunc *IFunc) GetName() string {
+	return ifunc.Name
+}
+
+// SetName sets the name of the IFunc.
+func (ifunc *IFunc) SetName(name string) {
+	ifunc.Name = name
+}
+
+// Immutable ensures that only constants can be assigned to the
+// constant.Constant interface.
+func (*IFunc) Immutable() {}
+
+// MetadataNode ensures that only metadata nodes can be assigned to the
+// metadata.Node interface.
+func (*IFunc) MetadataNode() {}
+
+// String returns the LLVM syntax representation of the IFunc.
+func (ifunc *IFunc) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s =", ifunc.Ident())
+	if ifunc.Linkage != LinkageNone {
+		fmt.Fprintf(buf, " %s", ifunc.Linkage)
+	}
+	if ifunc.Visibility != VisibilityNone {
+		fmt.Fprintf(buf, " %s", ifunc.Visibility)
+	}
+	fmt.Fprintf(buf, " ifunc %s, ", ifunc.Content)
+	// The type of constant expressions is implied, and therefore omitted.
+	if _, ok := ifunc.Resolver.(constant.Expr); !ok {
+		fmt.Fprintf(buf, "%s ", ifunc.Resolver.Type())
+	}
+	buf.WriteString(ifunc.Resolver.Ident())
+	return buf.String()
+}
@@ -0,0 +1,71 @@
+// === [ Inline assembler expressions ] ========================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#inline-assembler-expressions
+
+package ir
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+)
+
+// InlineAsm represents an inline assembler expression, which may be used as the
+// callee of call and invoke instructions.
+//
+// Examples:
+//
+//    asm sideeffect "nop", ""
+//    asm "bswap $0", "=r,r"
+type InlineAsm struct {
+	// Type of the inline assembler expression; pointer to the function type of
+	// the callee.
+	Typ *types.PointerType
+	// Assembly instructions.
+	Asm string
+	// Constraints.
+	Constraint string
+	// Inline assembly has side effects which are not visible through the
+	// constraint list.
+	SideEffect bool
+	// Inline assembly must be executed with an aligned stack.
+	AlignStack bool
+	// Inline assembly uses the Intel dialect, rather than AT&T.
+	IntelDialect bool
+}
+
+// NewInlineAsm returns a new inline assembler expression based on the given
+// function signature, assembly instructions and constraints.
+func NewInlineAsm(sig *types.FuncType, asm, constraint string) *InlineAsm {
+	return &InlineAsm{
+		Typ:        types.NewPointer(sig),
+		Asm:        asm,
+		Constraint: constraint,
+	}
+}
+
+// Type returns the type of the inline assembler expression.
+func (asm *InlineAsm) Type() types.Type {
+	return asm.Typ
+}
+
+// Ident returns the identifier associated with the inline assembler
+// expression.
+func (asm *InlineAsm) Ident() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("asm")
+	if asm.SideEffect {
+		buf.WriteString(" sideeffect")
+	}
+	if asm.AlignStack {
+		buf.WriteString(" alignstack")
+	}
+	if asm.IntelDialect {
+		buf.WriteString(" inteldialect")
+	}
+	fmt.Fprintf(buf, ` "%s", "%s"`, enc.EscapeString(asm.Asm), enc.EscapeString(asm.Constraint))
+	return buf.String()
+}
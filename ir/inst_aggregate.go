@@ -36,6 +36,8 @@ type InstExtractValue struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewExtractValue returns a new extractvalue instruction based on the given
@@ -76,17 +78,21 @@ func (inst *InstExtractValue) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstExtractValue) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstExtractValue) bareString() string {
 	indices := &bytes.Buffer{}
 	for _, index := range inst.Indices {
 		fmt.Fprintf(indices, ", %d", index)
 	}
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = extractvalue %s %s%s%s",
+	return fmt.Sprintf("%s = extractvalue %s %s%s",
 		inst.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
-		indices,
-		md)
+		indices)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -99,6 +105,21 @@ func (inst *InstExtractValue) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstExtractValue) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstExtractValue) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstExtractValue) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ insertvalue ] ---------------------------------------------------------
 
 // InstInsertValue represents an insertvalue instruction.
@@ -119,6 +140,8 @@ type InstInsertValue struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewInsertValue returns a new insertvalue instruction based on the given
@@ -155,19 +178,23 @@ func (inst *InstInsertValue) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstInsertValue) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstInsertValue) bareString() string {
 	indices := &bytes.Buffer{}
 	for _, index := range inst.Indices {
 		fmt.Fprintf(indices, ", %d", index)
 	}
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = insertvalue %s %s, %s %s%s%s",
+	return fmt.Sprintf("%s = insertvalue %s %s, %s %s%s",
 		inst.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
 		inst.Elem.Type(),
 		inst.Elem.Ident(),
-		indices,
-		md)
+		indices)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -180,6 +207,21 @@ func (inst *InstInsertValue) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstInsertValue) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstInsertValue) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstInsertValue) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // ### [ Helper functions ] ####################################################
 
 // aggregateElemType returns the element type of the given aggregate type, based
@@ -8,6 +8,7 @@
 package ir
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/llir/llvm/internal/enc"
@@ -29,17 +30,23 @@ type InstAdd struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewAdd returns a new add instruction based on the given operands.
-func NewAdd(x, y value.Value) *InstAdd {
+// NewAdd returns a new add instruction based on the given
+// operands and optional overflow flags.
+func NewAdd(x, y value.Value, flags ...OverflowFlag) *InstAdd {
 	return &InstAdd{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -66,13 +73,20 @@ func (inst *InstAdd) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstAdd) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = add %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAdd) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = add", inst.Ident())
+	buf.WriteString(overflowFlagsString(inst.OverflowFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -85,6 +99,21 @@ func (inst *InstAdd) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstAdd) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAdd) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAdd) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fadd ] ----------------------------------------------------------------
 
 // InstFAdd represents a floating-point addition instruction.
@@ -98,17 +127,23 @@ type InstFAdd struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewFAdd returns a new fadd instruction based on the given operands.
-func NewFAdd(x, y value.Value) *InstFAdd {
+// NewFAdd returns a new fadd instruction based on the given
+// operands and optional fast-math flags.
+func NewFAdd(x, y value.Value, flags ...FastMathFlag) *InstFAdd {
 	return &InstFAdd{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -135,13 +170,20 @@ func (inst *InstFAdd) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFAdd) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fadd %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFAdd) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = fadd", inst.Ident())
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -154,6 +196,21 @@ func (inst *InstFAdd) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFAdd) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFAdd) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFAdd) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ sub ] -----------------------------------------------------------------
 
 // InstSub represents a subtraction instruction.
@@ -167,17 +224,23 @@ type InstSub struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewSub returns a new sub instruction based on the given operands.
-func NewSub(x, y value.Value) *InstSub {
+// NewSub returns a new sub instruction based on the given
+// operands and optional overflow flags.
+func NewSub(x, y value.Value, flags ...OverflowFlag) *InstSub {
 	return &InstSub{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -204,13 +267,20 @@ func (inst *InstSub) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSub) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = sub %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSub) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = sub", inst.Ident())
+	buf.WriteString(overflowFlagsString(inst.OverflowFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -223,6 +293,21 @@ func (inst *InstSub) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSub) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSub) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSub) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fsub ] ----------------------------------------------------------------
 
 // InstFSub represents a floating-point subtraction instruction.
@@ -236,17 +321,23 @@ type InstFSub struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewFSub returns a new fsub instruction based on the given operands.
-func NewFSub(x, y value.Value) *InstFSub {
+// NewFSub returns a new fsub instruction based on the given
+// operands and optional fast-math flags.
+func NewFSub(x, y value.Value, flags ...FastMathFlag) *InstFSub {
 	return &InstFSub{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -273,13 +364,20 @@ func (inst *InstFSub) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFSub) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fsub %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFSub) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = fsub", inst.Ident())
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -292,6 +390,21 @@ func (inst *InstFSub) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFSub) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFSub) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFSub) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ mul ] -----------------------------------------------------------------
 
 // InstMul represents a multiplication instruction.
@@ -305,17 +418,23 @@ type InstMul struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewMul returns a new mul instruction based on the given operands.
-func NewMul(x, y value.Value) *InstMul {
+// NewMul returns a new mul instruction based on the given
+// operands and optional overflow flags.
+func NewMul(x, y value.Value, flags ...OverflowFlag) *InstMul {
 	return &InstMul{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -342,13 +461,20 @@ func (inst *InstMul) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstMul) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = mul %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstMul) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = mul", inst.Ident())
+	buf.WriteString(overflowFlagsString(inst.OverflowFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -361,6 +487,21 @@ func (inst *InstMul) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstMul) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstMul) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstMul) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fmul ] ----------------------------------------------------------------
 
 // InstFMul represents a floating-point multiplication instruction.
@@ -374,17 +515,23 @@ type InstFMul struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewFMul returns a new fmul instruction based on the given operands.
-func NewFMul(x, y value.Value) *InstFMul {
+// NewFMul returns a new fmul instruction based on the given
+// operands and optional fast-math flags.
+func NewFMul(x, y value.Value, flags ...FastMathFlag) *InstFMul {
 	return &InstFMul{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -411,13 +558,20 @@ func (inst *InstFMul) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFMul) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fmul %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFMul) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = fmul", inst.Ident())
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -430,6 +584,21 @@ func (inst *InstFMul) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFMul) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFMul) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFMul) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ udiv ] ----------------------------------------------------------------
 
 // InstUDiv represents an unsigned division instruction.
@@ -443,9 +612,14 @@ type InstUDiv struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewUDiv returns a new udiv instruction based on the given operands.
@@ -480,13 +654,22 @@ func (inst *InstUDiv) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstUDiv) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = udiv %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstUDiv) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = udiv", inst.Ident())
+	if inst.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -499,6 +682,21 @@ func (inst *InstUDiv) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstUDiv) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstUDiv) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstUDiv) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ sdiv ] ----------------------------------------------------------------
 
 // InstSDiv represents a signed division instruction.
@@ -512,9 +710,14 @@ type InstSDiv struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewSDiv returns a new sdiv instruction based on the given operands.
@@ -549,13 +752,22 @@ func (inst *InstSDiv) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSDiv) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = sdiv %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSDiv) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = sdiv", inst.Ident())
+	if inst.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -568,6 +780,21 @@ func (inst *InstSDiv) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSDiv) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSDiv) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSDiv) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fdiv ] ----------------------------------------------------------------
 
 // InstFDiv represents a floating-point division instruction.
@@ -581,17 +808,23 @@ type InstFDiv struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewFDiv returns a new fdiv instruction based on the given operands.
-func NewFDiv(x, y value.Value) *InstFDiv {
+// NewFDiv returns a new fdiv instruction based on the given
+// operands and optional fast-math flags.
+func NewFDiv(x, y value.Value, flags ...FastMathFlag) *InstFDiv {
 	return &InstFDiv{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -618,13 +851,20 @@ func (inst *InstFDiv) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFDiv) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fdiv %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFDiv) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = fdiv", inst.Ident())
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -637,6 +877,21 @@ func (inst *InstFDiv) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFDiv) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFDiv) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFDiv) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ urem ] ----------------------------------------------------------------
 
 // InstURem represents an unsigned remainder instruction.
@@ -653,6 +908,8 @@ type InstURem struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewURem returns a new urem instruction based on the given operands.
@@ -687,13 +944,19 @@ func (inst *InstURem) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstURem) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = urem %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstURem) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = urem", inst.Ident())
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -706,6 +969,21 @@ func (inst *InstURem) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstURem) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstURem) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstURem) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ srem ] ----------------------------------------------------------------
 
 // InstSRem represents a signed remainder instruction.
@@ -722,6 +1000,8 @@ type InstSRem struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewSRem returns a new srem instruction based on the given operands.
@@ -756,13 +1036,19 @@ func (inst *InstSRem) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSRem) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = srem %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSRem) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = srem", inst.Ident())
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -775,6 +1061,21 @@ func (inst *InstSRem) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSRem) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSRem) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSRem) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ frem ] ----------------------------------------------------------------
 
 // InstFRem represents a floating-point remainder instruction.
@@ -788,17 +1089,23 @@ type InstFRem struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewFRem returns a new frem instruction based on the given operands.
-func NewFRem(x, y value.Value) *InstFRem {
+// NewFRem returns a new frem instruction based on the given
+// operands and optional fast-math flags.
+func NewFRem(x, y value.Value, flags ...FastMathFlag) *InstFRem {
 	return &InstFRem{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -825,13 +1132,20 @@ func (inst *InstFRem) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFRem) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = frem %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFRem) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = frem", inst.Ident())
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -843,3 +1157,18 @@ func (inst *InstFRem) GetParent() *BasicBlock {
 func (inst *InstFRem) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFRem) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFRem) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFRem) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
@@ -8,6 +8,7 @@
 package ir
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/llir/llvm/internal/enc"
@@ -29,17 +30,23 @@ type InstShl struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Overflow flags.
+	OverflowFlags []OverflowFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
-// NewShl returns a new shl instruction based on the given operands.
-func NewShl(x, y value.Value) *InstShl {
+// NewShl returns a new shl instruction based on the given
+// operands and optional overflow flags.
+func NewShl(x, y value.Value, flags ...OverflowFlag) *InstShl {
 	return &InstShl{
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		X:             x,
+		Y:             y,
+		OverflowFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -66,13 +73,20 @@ func (inst *InstShl) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstShl) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = shl %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstShl) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = shl", inst.Ident())
+	buf.WriteString(overflowFlagsString(inst.OverflowFlags))
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -85,6 +99,21 @@ func (inst *InstShl) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstShl) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstShl) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstShl) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ lshr ] ----------------------------------------------------------------
 
 // InstLShr represents a logical shift right instruction.
@@ -98,9 +127,14 @@ type InstLShr struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewLShr returns a new lshr instruction based on the given operands.
@@ -135,13 +169,22 @@ func (inst *InstLShr) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstLShr) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = lshr %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstLShr) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = lshr", inst.Ident())
+	if inst.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -154,6 +197,21 @@ func (inst *InstLShr) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstLShr) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstLShr) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstLShr) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ ashr ] ----------------------------------------------------------------
 
 // InstAShr represents an arithmetic shift right instruction.
@@ -167,9 +225,14 @@ type InstAShr struct {
 	Name string
 	// Operands.
 	X, Y value.Value
+	// Exact operation; the result is a poison value if any bits of the exact
+	// result are discarded.
+	Exact bool
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewAShr returns a new ashr instruction based on the given operands.
@@ -204,13 +267,22 @@ func (inst *InstAShr) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstAShr) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = ashr %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAShr) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = ashr", inst.Ident())
+	if inst.Exact {
+		buf.WriteString(" exact")
+	}
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -223,6 +295,21 @@ func (inst *InstAShr) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstAShr) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAShr) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAShr) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ and ] -----------------------------------------------------------------
 
 // InstAnd represents an AND instruction.
@@ -239,6 +326,8 @@ type InstAnd struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewAnd returns a new and instruction based on the given operands.
@@ -273,13 +362,19 @@ func (inst *InstAnd) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstAnd) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = and %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAnd) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = and", inst.Ident())
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -292,6 +387,21 @@ func (inst *InstAnd) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstAnd) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAnd) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAnd) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ or ] ------------------------------------------------------------------
 
 // InstOr represents an OR instruction.
@@ -308,6 +418,8 @@ type InstOr struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewOr returns a new or instruction based on the given operands.
@@ -342,13 +454,19 @@ func (inst *InstOr) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstOr) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = or %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstOr) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = or", inst.Ident())
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -361,6 +479,21 @@ func (inst *InstOr) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstOr) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstOr) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstOr) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ xor ] -----------------------------------------------------------------
 
 // InstXor represents an exclusive-OR instruction.
@@ -377,6 +510,8 @@ type InstXor struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewXor returns a new xor instruction based on the given operands.
@@ -411,13 +546,19 @@ func (inst *InstXor) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstXor) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = xor %s %s, %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstXor) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = xor", inst.Ident())
+	fmt.Fprintf(buf, " %s %s, %s",
 		inst.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -429,3 +570,18 @@ func (inst *InstXor) GetParent() *BasicBlock {
 func (inst *InstXor) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
+
+// GetPos returns the source position of the instruction.
+func (inst *InstXor) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstXor) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstXor) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
@@ -34,6 +34,8 @@ type InstTrunc struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewTrunc returns a new trunc instruction based on the given source value and target type.
@@ -68,13 +70,17 @@ func (inst *InstTrunc) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstTrunc) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = trunc %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstTrunc) bareString() string {
+	return fmt.Sprintf("%s = trunc %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -87,6 +93,21 @@ func (inst *InstTrunc) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstTrunc) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstTrunc) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstTrunc) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ zext ] ----------------------------------------------------------------
 
 // InstZExt represents a zero extension instruction.
@@ -105,6 +126,8 @@ type InstZExt struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewZExt returns a new zext instruction based on the given source value and target type.
@@ -139,13 +162,17 @@ func (inst *InstZExt) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstZExt) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = zext %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstZExt) bareString() string {
+	return fmt.Sprintf("%s = zext %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -158,6 +185,21 @@ func (inst *InstZExt) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstZExt) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstZExt) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstZExt) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ sext ] ----------------------------------------------------------------
 
 // InstSExt represents a sign extension instruction.
@@ -176,6 +218,8 @@ type InstSExt struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewSExt returns a new sext instruction based on the given source value and target type.
@@ -210,13 +254,17 @@ func (inst *InstSExt) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSExt) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = sext %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSExt) bareString() string {
+	return fmt.Sprintf("%s = sext %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -229,6 +277,21 @@ func (inst *InstSExt) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSExt) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSExt) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSExt) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fptrunc ] -------------------------------------------------------------
 
 // InstFPTrunc represents a floating-point truncation instruction.
@@ -247,6 +310,8 @@ type InstFPTrunc struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewFPTrunc returns a new fptrunc instruction based on the given source value and target type.
@@ -281,13 +346,17 @@ func (inst *InstFPTrunc) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFPTrunc) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fptrunc %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFPTrunc) bareString() string {
+	return fmt.Sprintf("%s = fptrunc %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -300,6 +369,21 @@ func (inst *InstFPTrunc) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFPTrunc) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFPTrunc) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFPTrunc) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fpext ] ---------------------------------------------------------------
 
 // InstFPExt represents a floating-point extension instruction.
@@ -318,6 +402,8 @@ type InstFPExt struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewFPExt returns a new fpext instruction based on the given source value and target type.
@@ -352,13 +438,17 @@ func (inst *InstFPExt) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFPExt) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fpext %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFPExt) bareString() string {
+	return fmt.Sprintf("%s = fpext %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -371,6 +461,21 @@ func (inst *InstFPExt) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFPExt) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFPExt) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFPExt) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fptoui ] --------------------------------------------------------------
 
 // InstFPToUI represents a floating-point to unsigned integer conversion instruction.
@@ -389,6 +494,8 @@ type InstFPToUI struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewFPToUI returns a new fptoui instruction based on the given source value and target type.
@@ -423,13 +530,17 @@ func (inst *InstFPToUI) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFPToUI) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fptoui %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFPToUI) bareString() string {
+	return fmt.Sprintf("%s = fptoui %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -442,6 +553,21 @@ func (inst *InstFPToUI) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFPToUI) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFPToUI) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFPToUI) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fptosi ] --------------------------------------------------------------
 
 // InstFPToSI represents a floating-point to signed integer conversion instruction.
@@ -460,6 +586,8 @@ type InstFPToSI struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewFPToSI returns a new fptosi instruction based on the given source value and target type.
@@ -494,13 +622,17 @@ func (inst *InstFPToSI) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFPToSI) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fptosi %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFPToSI) bareString() string {
+	return fmt.Sprintf("%s = fptosi %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -513,6 +645,21 @@ func (inst *InstFPToSI) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFPToSI) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFPToSI) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFPToSI) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ uitofp ] --------------------------------------------------------------
 
 // InstUIToFP represents an unsigned integer to floating-point conversion instruction.
@@ -531,6 +678,8 @@ type InstUIToFP struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewUIToFP returns a new uitofp instruction based on the given source value and target type.
@@ -565,13 +714,17 @@ func (inst *InstUIToFP) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstUIToFP) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = uitofp %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstUIToFP) bareString() string {
+	return fmt.Sprintf("%s = uitofp %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -584,6 +737,21 @@ func (inst *InstUIToFP) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstUIToFP) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstUIToFP) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstUIToFP) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ sitofp ] --------------------------------------------------------------
 
 // InstSIToFP represents a signed integer to floating-point conversion instruction.
@@ -602,6 +770,8 @@ type InstSIToFP struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewSIToFP returns a new sitofp instruction based on the given source value and target type.
@@ -636,13 +806,17 @@ func (inst *InstSIToFP) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSIToFP) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = sitofp %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSIToFP) bareString() string {
+	return fmt.Sprintf("%s = sitofp %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -655,6 +829,21 @@ func (inst *InstSIToFP) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSIToFP) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSIToFP) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSIToFP) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ ptrtoint ] ------------------------------------------------------------
 
 // InstPtrToInt represents a pointer to integer conversion instruction.
@@ -673,6 +862,8 @@ type InstPtrToInt struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewPtrToInt returns a new ptrtoint instruction based on the given source value and target type.
@@ -707,13 +898,17 @@ func (inst *InstPtrToInt) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstPtrToInt) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = ptrtoint %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstPtrToInt) bareString() string {
+	return fmt.Sprintf("%s = ptrtoint %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -726,6 +921,21 @@ func (inst *InstPtrToInt) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstPtrToInt) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstPtrToInt) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstPtrToInt) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ inttoptr ] ------------------------------------------------------------
 
 // InstIntToPtr represents an integer to pointer conversion instruction.
@@ -744,6 +954,8 @@ type InstIntToPtr struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewIntToPtr returns a new inttoptr instruction based on the given source value and target type.
@@ -778,13 +990,17 @@ func (inst *InstIntToPtr) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstIntToPtr) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = inttoptr %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstIntToPtr) bareString() string {
+	return fmt.Sprintf("%s = inttoptr %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -797,6 +1013,21 @@ func (inst *InstIntToPtr) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstIntToPtr) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstIntToPtr) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstIntToPtr) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ bitcast ] -------------------------------------------------------------
 
 // InstBitCast represents a bitcast instruction.
@@ -815,6 +1046,8 @@ type InstBitCast struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewBitCast returns a new bitcast instruction based on the given source value and target type.
@@ -849,13 +1082,17 @@ func (inst *InstBitCast) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstBitCast) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = bitcast %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstBitCast) bareString() string {
+	return fmt.Sprintf("%s = bitcast %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -868,6 +1105,21 @@ func (inst *InstBitCast) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstBitCast) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstBitCast) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstBitCast) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ addrspacecast ] -------------------------------------------------------
 
 // InstAddrSpaceCast represents an address space cast instruction.
@@ -886,6 +1138,8 @@ type InstAddrSpaceCast struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewAddrSpaceCast returns a new addrspacecast instruction based on the given source value and target type.
@@ -920,13 +1174,17 @@ func (inst *InstAddrSpaceCast) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstAddrSpaceCast) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = addrspacecast %s %s to %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAddrSpaceCast) bareString() string {
+	return fmt.Sprintf("%s = addrspacecast %s %s to %s",
 		inst.Ident(),
 		inst.From.Type(),
 		inst.From.Ident(),
-		inst.To,
-		md)
+		inst.To)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -938,3 +1196,18 @@ func (inst *InstAddrSpaceCast) GetParent() *BasicBlock {
 func (inst *InstAddrSpaceCast) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAddrSpaceCast) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAddrSpaceCast) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAddrSpaceCast) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
@@ -20,6 +20,9 @@ import (
 
 // InstAlloca represents an alloca instruction.
 //
+// The allocated memory resides in the address space of the pointer type Typ;
+// e.g. inst.Typ.AddrSpace = 5 to allocate in address space 5.
+//
 // References:
 //    http://llvm.org/docs/LangRef.html#alloca-instruction
 type InstAlloca struct {
@@ -27,18 +30,25 @@ type InstAlloca struct {
 	Parent *BasicBlock
 	// Name of the local variable associated with the instruction.
 	Name string
-	// Type of the instruction.
+	// Type of the instruction; the address space of the pointer type specifies
+	// the address space of the allocated memory.
 	Typ *types.PointerType
 	// Element type.
 	Elem types.Type
 	// Number of elements; or nil if one element.
 	NElems value.Value
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewAlloca returns a new alloca instruction based on the given element type.
+// The memory is allocated in the default address space; set Typ.AddrSpace of
+// the returned instruction to allocate memory in a different address space.
 func NewAlloca(elem types.Type) *InstAlloca {
 	typ := types.NewPointer(elem)
 	return &InstAlloca{
@@ -71,19 +81,24 @@ func (inst *InstAlloca) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstAlloca) String() string {
-	md := metadataString(inst.Metadata, ",")
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAlloca) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = alloca %s", inst.Ident(), inst.Elem)
 	if inst.NElems != nil {
-		return fmt.Sprintf("%s = alloca %s, %s %s%s",
-			inst.Ident(),
-			inst.Elem,
-			inst.NElems.Type(),
-			inst.NElems.Ident(),
-			md)
-	}
-	return fmt.Sprintf("%s = alloca %s%s",
-		inst.Ident(),
-		inst.Elem,
-		md)
+		fmt.Fprintf(buf, ", %s %s", inst.NElems.Type(), inst.NElems.Ident())
+	}
+	if inst.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", inst.Align)
+	}
+	if inst.Typ.AddrSpace != 0 {
+		fmt.Fprintf(buf, ", addrspace(%d)", inst.Typ.AddrSpace)
+	}
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -96,6 +111,21 @@ func (inst *InstAlloca) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstAlloca) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAlloca) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAlloca) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ load ] ----------------------------------------------------------------
 
 // InstLoad represents a load instruction.
@@ -111,9 +141,20 @@ type InstLoad struct {
 	Typ types.Type
 	// Source address.
 	Src value.Value
+	// Atomic memory ordering constraints; or AtomicOrderingNone if not atomic.
+	Ordering AtomicOrdering
+	// Synchronization scope of atomic load; or the empty string if within the
+	// scope of the entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewLoad returns a new load instruction based on the given source address.
@@ -152,13 +193,46 @@ func (inst *InstLoad) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstLoad) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = load %s, %s %s%s",
-		inst.Ident(),
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstLoad) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = load", inst.Ident())
+	atomic := inst.Ordering != AtomicOrderingNone
+	if atomic {
+		buf.WriteString(" atomic")
+	}
+	if inst.Volatile {
+		buf.WriteString(" volatile")
+	}
+	fmt.Fprintf(buf, " %s, %s %s",
 		inst.Type(),
 		inst.Src.Type(),
-		inst.Src.Ident(),
-		md)
+		inst.Src.Ident())
+	if atomic {
+		fmt.Fprintf(buf, "%s %s", syncScopeString(inst.SyncScope), inst.Ordering)
+	}
+	if inst.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", inst.Align)
+	}
+	return buf.String()
+}
+
+// IsNonTemporal reports whether the load instruction is marked as
+// non-temporal through !nontemporal metadata.
+func (inst *InstLoad) IsNonTemporal() bool {
+	_, ok := inst.Metadata["nontemporal"]
+	return ok
+}
+
+// IsInvariant reports whether the memory location read by the load
+// instruction is marked as invariant through !invariant.load metadata.
+func (inst *InstLoad) IsInvariant() bool {
+	_, ok := inst.Metadata["invariant.load"]
+	return ok
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -171,6 +245,21 @@ func (inst *InstLoad) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstLoad) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstLoad) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstLoad) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ store ] ---------------------------------------------------------------
 
 // InstStore represents a store instruction.
@@ -184,9 +273,20 @@ type InstStore struct {
 	Src value.Value
 	// Destination address.
 	Dst value.Value
+	// Atomic memory ordering constraints; or AtomicOrderingNone if not atomic.
+	Ordering AtomicOrdering
+	// Synchronization scope of atomic store; or the empty string if within the
+	// scope of the entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewStore returns a new store instruction based on the given source value and
@@ -201,13 +301,40 @@ func NewStore(src, dst value.Value) *InstStore {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstStore) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("store %s %s, %s %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstStore) bareString() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("store")
+	atomic := inst.Ordering != AtomicOrderingNone
+	if atomic {
+		buf.WriteString(" atomic")
+	}
+	if inst.Volatile {
+		buf.WriteString(" volatile")
+	}
+	fmt.Fprintf(buf, " %s %s, %s %s",
 		inst.Src.Type(),
 		inst.Src.Ident(),
 		inst.Dst.Type(),
-		inst.Dst.Ident(),
-		md)
+		inst.Dst.Ident())
+	if atomic {
+		fmt.Fprintf(buf, "%s %s", syncScopeString(inst.SyncScope), inst.Ordering)
+	}
+	if inst.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", inst.Align)
+	}
+	return buf.String()
+}
+
+// IsNonTemporal reports whether the store instruction is marked as
+// non-temporal through !nontemporal metadata.
+func (inst *InstStore) IsNonTemporal() bool {
+	_, ok := inst.Metadata["nontemporal"]
+	return ok
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -220,12 +347,423 @@ func (inst *InstStore) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstStore) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstStore) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstStore) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ fence ] ---------------------------------------------------------------
 
+// InstFence represents a fence instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#fence-instruction
+type InstFence struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Atomic memory ordering constraints.
+	Ordering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewFence returns a new fence instruction based on the given atomic memory
+// ordering constraints.
+func NewFence(ordering AtomicOrdering) *InstFence {
+	return &InstFence{
+		Ordering: ordering,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstFence) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFence) bareString() string {
+	return fmt.Sprintf("fence%s %s",
+		syncScopeString(inst.SyncScope),
+		inst.Ordering)
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstFence) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstFence) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstFence) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFence) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFence) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
+// AtomicOrdering represents the set of atomic memory ordering constraints.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#ordering
+type AtomicOrdering uint
+
+// Atomic memory ordering constraints.
+const (
+	AtomicOrderingNone                   AtomicOrdering = iota // not atomic.
+	AtomicOrderingUnordered                                    // unordered
+	AtomicOrderingMonotonic                                    // monotonic
+	AtomicOrderingAcquire                                      // acquire
+	AtomicOrderingRelease                                      // release
+	AtomicOrderingAcquireRelease                               // acq_rel
+	AtomicOrderingSequentiallyConsistent                       // seq_cst
+)
+
+// String returns the LLVM syntax representation of the atomic memory ordering
+// constraints.
+func (ordering AtomicOrdering) String() string {
+	m := map[AtomicOrdering]string{
+		AtomicOrderingUnordered:              "unordered",
+		AtomicOrderingMonotonic:              "monotonic",
+		AtomicOrderingAcquire:                "acquire",
+		AtomicOrderingRelease:                "release",
+		AtomicOrderingAcquireRelease:         "acq_rel",
+		AtomicOrderingSequentiallyConsistent: "seq_cst",
+	}
+	if s, ok := m[ordering]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown atomic memory ordering %d", uint(ordering))
+}
+
+// syncScopeString returns the string representation of the given
+// synchronization scope, prefixed by a space; or the empty string if within
+// the scope of the entire system.
+func syncScopeString(syncScope string) string {
+	if len(syncScope) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` syncscope("%s")`, enc.EscapeString(syncScope))
+}
+
 // --- [ cmpxchg ] -------------------------------------------------------------
 
+// InstCmpXchg represents a cmpxchg instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cmpxchg-instruction
+type InstCmpXchg struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Type of the instruction; a structure of the compared value type and i1.
+	Typ *types.StructType
+	// Address to read from, compare against and store to.
+	Ptr value.Value
+	// Value to compare against.
+	Cmp value.Value
+	// New value to store.
+	New value.Value
+	// Atomic memory ordering constraints on success.
+	SuccessOrdering AtomicOrdering
+	// Atomic memory ordering constraints on failure.
+	FailureOrdering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Weak cmpxchg, which may fail spuriously.
+	Weak bool
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCmpXchg returns a new cmpxchg instruction based on the given address,
+// value to compare against, new value to store, and atomic memory ordering
+// constraints on success and failure.
+func NewCmpXchg(ptr, cmp, new value.Value, success, failure AtomicOrdering) *InstCmpXchg {
+	return &InstCmpXchg{
+		Typ:             types.NewStruct(cmp.Type(), types.I1),
+		Ptr:             ptr,
+		Cmp:             cmp,
+		New:             new,
+		SuccessOrdering: success,
+		FailureOrdering: failure,
+		Metadata:        make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCmpXchg) Type() types.Type {
+	return inst.Typ
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstCmpXchg) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstCmpXchg) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstCmpXchg) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstCmpXchg) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstCmpXchg) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = cmpxchg", inst.Ident())
+	if inst.Weak {
+		buf.WriteString(" weak")
+	}
+	if inst.Volatile {
+		buf.WriteString(" volatile")
+	}
+	fmt.Fprintf(buf, " %s %s, %s %s, %s %s%s %s %s",
+		inst.Ptr.Type(),
+		inst.Ptr.Ident(),
+		inst.Cmp.Type(),
+		inst.Cmp.Ident(),
+		inst.New.Type(),
+		inst.New.Ident(),
+		syncScopeString(inst.SyncScope),
+		inst.SuccessOrdering,
+		inst.FailureOrdering)
+	if inst.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", inst.Align)
+	}
+	return buf.String()
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstCmpXchg) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstCmpXchg) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCmpXchg) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstCmpXchg) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstCmpXchg) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ atomicrmw ] -----------------------------------------------------------
 
+// InstAtomicRMW represents an atomicrmw instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#atomicrmw-instruction
+type InstAtomicRMW struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Atomic operation.
+	Op AtomicOp
+	// Destination address.
+	Dst value.Value
+	// Operand.
+	X value.Value
+	// Atomic memory ordering constraints.
+	Ordering AtomicOrdering
+	// Synchronization scope; or the empty string if within the scope of the
+	// entire system.
+	SyncScope string
+	// Volatile memory access.
+	Volatile bool
+	// Alignment in bytes; or 0 if not present.
+	Align int
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewAtomicRMW returns a new atomicrmw instruction based on the given atomic
+// operation, destination address, operand and atomic memory ordering
+// constraints.
+func NewAtomicRMW(op AtomicOp, dst, x value.Value, ordering AtomicOrdering) *InstAtomicRMW {
+	return &InstAtomicRMW{
+		Op:       op,
+		Dst:      dst,
+		X:        x,
+		Ordering: ordering,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstAtomicRMW) Type() types.Type {
+	return inst.X.Type()
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstAtomicRMW) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstAtomicRMW) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstAtomicRMW) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstAtomicRMW) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstAtomicRMW) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = atomicrmw", inst.Ident())
+	if inst.Volatile {
+		buf.WriteString(" volatile")
+	}
+	fmt.Fprintf(buf, " %s %s %s, %s %s%s %s",
+		inst.Op,
+		inst.Dst.Type(),
+		inst.Dst.Ident(),
+		inst.X.Type(),
+		inst.X.Ident(),
+		syncScopeString(inst.SyncScope),
+		inst.Ordering)
+	if inst.Align != 0 {
+		fmt.Fprintf(buf, ", align %d", inst.Align)
+	}
+	return buf.String()
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstAtomicRMW) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstAtomicRMW) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstAtomicRMW) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstAtomicRMW) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstAtomicRMW) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
+// AtomicOp represents the set of atomic operations of atomicrmw instructions.
+type AtomicOp uint
+
+// Atomic operations.
+const (
+	AtomicOpXChg AtomicOp = iota + 1 // xchg
+	AtomicOpAdd                      // add
+	AtomicOpSub                      // sub
+	AtomicOpAnd                      // and
+	AtomicOpNAnd                     // nand
+	AtomicOpOr                       // or
+	AtomicOpXor                      // xor
+	AtomicOpMax                      // max
+	AtomicOpMin                      // min
+	AtomicOpUMax                     // umax
+	AtomicOpUMin                     // umin
+)
+
+// String returns the LLVM syntax representation of the atomic operation.
+func (op AtomicOp) String() string {
+	m := map[AtomicOp]string{
+		AtomicOpXChg: "xchg",
+		AtomicOpAdd:  "add",
+		AtomicOpSub:  "sub",
+		AtomicOpAnd:  "and",
+		AtomicOpNAnd: "nand",
+		AtomicOpOr:   "or",
+		AtomicOpXor:  "xor",
+		AtomicOpMax:  "max",
+		AtomicOpMin:  "min",
+		AtomicOpUMax: "umax",
+		AtomicOpUMin: "umin",
+	}
+	if s, ok := m[op]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown atomic operation %d", uint(op))
+}
+
 // --- [ getelementptr ] -------------------------------------------------------
 
 // InstGetElementPtr represents a getelementptr instruction.
@@ -245,9 +783,14 @@ type InstGetElementPtr struct {
 	Src value.Value
 	// Element indices.
 	Indices []value.Value
+	// In-bounds address computation; the result is a poison value if the
+	// address is outside of the allocated object pointed to by Src.
+	InBounds bool
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewGetElementPtr returns a new getelementptr instruction based on the given
@@ -316,20 +859,27 @@ func (inst *InstGetElementPtr) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstGetElementPtr) String() string {
-	indices := &bytes.Buffer{}
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstGetElementPtr) bareString() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s = getelementptr", inst.Ident())
+	if inst.InBounds {
+		buf.WriteString(" inbounds")
+	}
+	fmt.Fprintf(buf, " %s, %s %s",
+		inst.Elem,
+		inst.Src.Type(),
+		inst.Src.Ident())
 	for _, index := range inst.Indices {
-		fmt.Fprintf(indices, ", %s %s",
+		fmt.Fprintf(buf, ", %s %s",
 			index.Type(),
 			index.Ident())
 	}
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = getelementptr %s, %s %s%s%s",
-		inst.Ident(),
-		inst.Elem,
-		inst.Src.Type(),
-		inst.Src.Ident(),
-		indices,
-		md)
+	return buf.String()
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -341,3 +891,18 @@ func (inst *InstGetElementPtr) GetParent() *BasicBlock {
 func (inst *InstGetElementPtr) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
+
+// GetPos returns the source position of the instruction.
+func (inst *InstGetElementPtr) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstGetElementPtr) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstGetElementPtr) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
@@ -0,0 +1,60 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
+)
+
+func TestMemoryMetadata(t *testing.T) {
+	src := constant.NewNull(types.NewPointer(types.I32))
+	md := &metadata.Metadata{Nodes: []metadata.Node{constant.NewInt(1, types.I32)}}
+
+	// Without metadata attachments.
+	load := ir.NewLoad(src)
+	store := ir.NewStore(constant.NewInt(42, types.I32), src)
+	if load.IsNonTemporal() {
+		t.Errorf("load without !nontemporal reported as non-temporal")
+	}
+	if load.IsInvariant() {
+		t.Errorf("load without !invariant.load reported as invariant")
+	}
+	if store.IsNonTemporal() {
+		t.Errorf("store without !nontemporal reported as non-temporal")
+	}
+
+	// With metadata attachments.
+	load.Metadata["nontemporal"] = md
+	store.Metadata["nontemporal"] = md
+	if !load.IsNonTemporal() {
+		t.Errorf("load with !nontemporal not reported as non-temporal")
+	}
+	if load.IsInvariant() {
+		t.Errorf("load without !invariant.load reported as invariant")
+	}
+	if !store.IsNonTemporal() {
+		t.Errorf("store with !nontemporal not reported as non-temporal")
+	}
+	load.Metadata["invariant.load"] = &metadata.Metadata{}
+	if !load.IsInvariant() {
+		t.Errorf("load with !invariant.load not reported as invariant")
+	}
+}
+
+func TestAllocaAddrSpace(t *testing.T) {
+	inst := ir.NewAlloca(types.I32)
+	inst.SetName("x")
+	if got, want := inst.String(), "%x = alloca i32"; got != want {
+		t.Errorf("alloca mismatch; expected %q, got %q", want, got)
+	}
+	inst.Typ.AddrSpace = 5
+	if got, want := inst.String(), "%x = alloca i32, addrspace(5)"; got != want {
+		t.Errorf("alloca mismatch; expected %q, got %q", want, got)
+	}
+	if got, want := inst.Type().String(), "i32 addrspace(5)*"; got != want {
+		t.Errorf("alloca type mismatch; expected %q, got %q", want, got)
+	}
+}
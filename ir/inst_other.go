@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
@@ -35,6 +37,8 @@ type InstICmp struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewICmp returns a new icmp instruction based on the given integer predicate
@@ -76,14 +80,18 @@ func (inst *InstICmp) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstICmp) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = icmp %s %s %s, %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstICmp) bareString() string {
+	return fmt.Sprintf("%s = icmp %s %s %s, %s",
 		inst.Ident(),
 		inst.Pred,
 		inst.X.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -96,6 +104,21 @@ func (inst *InstICmp) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstICmp) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstICmp) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstICmp) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // IntPred represents the set of integer predicates of the icmp instruction.
 type IntPred int
 
@@ -150,24 +173,29 @@ type InstFCmp struct {
 	Pred FloatPred
 	// Operands.
 	X, Y value.Value
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewFCmp returns a new fcmp instruction based on the given floating-point
-// predicate and operands.
-func NewFCmp(pred FloatPred, x, y value.Value) *InstFCmp {
+// predicate, operands and optional fast-math flags.
+func NewFCmp(pred FloatPred, x, y value.Value, flags ...FastMathFlag) *InstFCmp {
 	var typ types.Type = types.I1
 	if t, ok := x.Type().(*types.VectorType); ok {
 		typ = types.NewVector(types.I1, t.Len)
 	}
 	return &InstFCmp{
-		Typ:      typ,
-		Pred:     pred,
-		X:        x,
-		Y:        y,
-		Metadata: make(map[string]*metadata.Metadata),
+		Typ:           typ,
+		Pred:          pred,
+		X:             x,
+		Y:             y,
+		FastMathFlags: flags,
+		Metadata:      make(map[string]*metadata.Metadata),
 	}
 }
 
@@ -194,14 +222,19 @@ func (inst *InstFCmp) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstFCmp) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = fcmp %s %s %s, %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstFCmp) bareString() string {
+	return fmt.Sprintf("%s = fcmp%s %s %s %s, %s",
 		inst.Ident(),
+		fastMathFlagsString(inst.FastMathFlags),
 		inst.Pred,
 		inst.X.Type(),
 		inst.X.Ident(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -214,6 +247,21 @@ func (inst *InstFCmp) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstFCmp) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstFCmp) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstFCmp) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // FloatPred represents the set of floating-point predicates of the fcmp
 // instruction.
 type FloatPred int
@@ -280,9 +328,13 @@ type InstPhi struct {
 	Typ types.Type
 	// Incoming values.
 	Incs []*Incoming
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewPhi returns a new phi instruction based on the given incoming values.
@@ -321,6 +373,12 @@ func (inst *InstPhi) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstPhi) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstPhi) bareString() string {
 	incs := &bytes.Buffer{}
 	for i, inc := range inst.Incs {
 		if i != 0 {
@@ -330,12 +388,11 @@ func (inst *InstPhi) String() string {
 			inc.X.Ident(),
 			inc.Pred.Ident())
 	}
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = phi %s %s%s",
+	return fmt.Sprintf("%s = phi%s %s %s",
 		inst.Ident(),
+		fastMathFlagsString(inst.FastMathFlags),
 		inst.Type(),
-		incs,
-		md)
+		incs)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -348,6 +405,21 @@ func (inst *InstPhi) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstPhi) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstPhi) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstPhi) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // Incoming represents an incoming value of a phi instruction.
 type Incoming struct {
 	// Incoming value.
@@ -383,6 +455,8 @@ type InstSelect struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewSelect returns a new select instruction based on the given selection
@@ -419,16 +493,20 @@ func (inst *InstSelect) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstSelect) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = select %s %s, %s %s, %s %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstSelect) bareString() string {
+	return fmt.Sprintf("%s = select %s %s, %s %s, %s %s",
 		inst.Ident(),
 		inst.Cond.Type(),
 		inst.Cond.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
 		inst.Y.Type(),
-		inst.Y.Ident(),
-		md)
+		inst.Y.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -441,6 +519,21 @@ func (inst *InstSelect) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstSelect) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstSelect) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstSelect) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ call ] ----------------------------------------------------------------
 
 // InstCall represents a call instruction.
@@ -461,16 +554,30 @@ type InstCall struct {
 	//    *constant.ExprBitCast
 	//    *ir.InstBitCast
 	//    *ir.InstLoad
+	//    *ir.InlineAsm
 	Callee value.Value
 	// Callee signature.
 	Sig *types.FuncType
-	// Function arguments.
+	// Function arguments; arguments with parameter attributes are wrapped in an
+	// *ir.Arg. Use ArgValues to access the underlying argument values.
 	Args []value.Value
 	// Calling convention.
 	CallConv CallConv
+	// Tail call kind.
+	Tail Tail
+	// Fast-math flags.
+	FastMathFlags []FastMathFlag
+	// Return attributes.
+	RetAttrs []attr.Attribute
+	// Function attributes.
+	FuncAttrs []attr.Attribute
+	// Operand bundles.
+	OperandBundles []*OperandBundle
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewCall returns a new call instruction based on the given callee and function
@@ -483,6 +590,7 @@ type InstCall struct {
 //    *constant.ExprBitCast
 //    *ir.InstBitCast
 //    *ir.InstLoad
+//    *ir.InlineAsm
 func NewCall(callee value.Value, args ...value.Value) *InstCall {
 	typ, ok := callee.Type().(*types.PointerType)
 	if !ok {
@@ -523,37 +631,52 @@ func (inst *InstCall) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstCall) String() string {
-	ident := &bytes.Buffer{}
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstCall) bareString() string {
+	buf := &bytes.Buffer{}
 	if !inst.Type().Equal(types.Void) {
-		fmt.Fprintf(ident, "%s = ", inst.Ident())
+		fmt.Fprintf(buf, "%s = ", inst.Ident())
 	}
-	// Print callee signature instead of return type for variadic callees.
-	callconv := &bytes.Buffer{}
+	if inst.Tail != TailNone {
+		fmt.Fprintf(buf, "%s ", inst.Tail)
+	}
+	buf.WriteString("call")
+	buf.WriteString(fastMathFlagsString(inst.FastMathFlags))
 	if inst.CallConv != CallConvNone {
-		fmt.Fprintf(callconv, " %s", inst.CallConv)
+		fmt.Fprintf(buf, " %s", inst.CallConv)
+	}
+	for _, a := range inst.RetAttrs {
+		fmt.Fprintf(buf, " %s", a)
 	}
+	// Print callee signature instead of return type for variadic callees.
 	sig := inst.Sig
 	ret := sig.Ret.String()
 	if sig.Variadic {
 		ret = sig.String()
 	}
-	args := &bytes.Buffer{}
+	fmt.Fprintf(buf, " %s %s(", ret, inst.Callee.Ident())
 	for i, arg := range inst.Args {
 		if i != 0 {
-			args.WriteString(", ")
+			buf.WriteString(", ")
 		}
-		fmt.Fprintf(args, "%s %s",
-			arg.Type(),
-			arg.Ident())
+		buf.WriteString(argString(arg))
 	}
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%scall%s %s %s(%s)%s",
-		ident,
-		callconv,
-		ret,
-		inst.Callee.Ident(),
-		args,
-		md)
+	buf.WriteString(")")
+	for _, a := range inst.FuncAttrs {
+		fmt.Fprintf(buf, " %s", a)
+	}
+	buf.WriteString(operandBundlesString(inst.OperandBundles))
+	return buf.String()
+}
+
+// ArgValues returns the function arguments of the call instruction, with *ir.Arg
+// wrappers replaced by their underlying argument values.
+func (inst *InstCall) ArgValues() []value.Value {
+	return argValues(inst.Args)
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -566,10 +689,585 @@ func (inst *InstCall) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstCall) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstCall) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstCall) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
+// Tail represents the set of tail call kinds.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#call-instruction
+type Tail uint
+
+// Tail call kinds.
+const (
+	TailNone     Tail = iota // no tail call marker.
+	TailTail                 // tail
+	TailMustTail             // musttail
+	TailNoTail               // notail
+)
+
+// String returns the LLVM syntax representation of the tail call kind.
+func (tail Tail) String() string {
+	m := map[Tail]string{
+		TailTail:     "tail",
+		TailMustTail: "musttail",
+		TailNoTail:   "notail",
+	}
+	if s, ok := m[tail]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown tail call kind %d", uint(tail))
+}
+
+// Arg represents a function argument with parameter attributes at the call
+// site. It wraps the argument value in the Args field of call instructions and
+// invoke terminators.
+type Arg struct {
+	// Argument value.
+	value.Value
+	// Parameter attributes.
+	Attrs []attr.Attribute
+}
+
+// NewArg returns a new function argument based on the given value and
+// parameter attributes.
+func NewArg(x value.Value, attrs ...attr.Attribute) *Arg {
+	return &Arg{
+		Value: x,
+		Attrs: attrs,
+	}
+}
+
+// argString returns the string representation of the given function argument,
+// including its parameter attributes.
+func argString(arg value.Value) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(arg.Type().String())
+	if arg, ok := arg.(*Arg); ok {
+		for _, a := range arg.Attrs {
+			fmt.Fprintf(buf, " %s", a)
+		}
+	}
+	fmt.Fprintf(buf, " %s", arg.Ident())
+	return buf.String()
+}
+
+// argValues returns the given function arguments, with *ir.Arg wrappers
+// replaced by their underlying argument values.
+func argValues(args []value.Value) []value.Value {
+	vs := make([]value.Value, len(args))
+	for i, arg := range args {
+		if a, ok := arg.(*Arg); ok {
+			arg = a.Value
+		}
+		vs[i] = arg
+	}
+	return vs
+}
+
+// OperandBundle represents a tagged operand bundle of a call instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#operand-bundles
+type OperandBundle struct {
+	// Operand bundle tag (e.g. "deopt" or "funclet").
+	Tag string
+	// Operand bundle inputs.
+	Inputs []value.Value
+}
+
+// NewOperandBundle returns a new operand bundle based on the given tag and
+// inputs.
+func NewOperandBundle(tag string, inputs ...value.Value) *OperandBundle {
+	return &OperandBundle{
+		Tag:    tag,
+		Inputs: inputs,
+	}
+}
+
+// String returns the LLVM syntax representation of the operand bundle.
+func (bundle *OperandBundle) String() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `"%s"(`, enc.EscapeString(bundle.Tag))
+	for i, input := range bundle.Inputs {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%s %s", input.Type(), input.Ident())
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// operandBundlesString returns the string representation of the given operand
+// bundles, prefixed by a space; or the empty string if no operand bundles are
+// present.
+func operandBundlesString(bundles []*OperandBundle) string {
+	if len(bundles) == 0 {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(" [ ")
+	for i, bundle := range bundles {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(bundle.String())
+	}
+	buf.WriteString(" ]")
+	return buf.String()
+}
+
 // --- [ va_arg ] --------------------------------------------------------------
 
+// InstVAArg represents a va_arg instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#va_arg-instruction
+type InstVAArg struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Variable argument list.
+	ArgList value.Value
+	// Argument type.
+	ArgType types.Type
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewVAArg returns a new va_arg instruction based on the given variable
+// argument list and argument type.
+func NewVAArg(argList value.Value, argType types.Type) *InstVAArg {
+	return &InstVAArg{
+		ArgList:  argList,
+		ArgType:  argType,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstVAArg) Type() types.Type {
+	return inst.ArgType
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstVAArg) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstVAArg) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstVAArg) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstVAArg) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstVAArg) bareString() string {
+	return fmt.Sprintf("%s = va_arg %s %s, %s",
+		inst.Ident(),
+		inst.ArgList.Type(),
+		inst.ArgList.Ident(),
+		inst.ArgType)
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstVAArg) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstVAArg) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstVAArg) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstVAArg) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstVAArg) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ landingpad ] ----------------------------------------------------------
 
+// InstLandingPad represents a landingpad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#landingpad-instruction
+type InstLandingPad struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Result type.
+	Typ types.Type
+	// Specifies whether the landing pad is a cleanup.
+	Cleanup bool
+	// Exception clauses.
+	Clauses []*Clause
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewLandingPad returns a new landingpad instruction based on the given result
+// type and exception clauses.
+func NewLandingPad(typ types.Type, clauses ...*Clause) *InstLandingPad {
+	return &InstLandingPad{
+		Typ:      typ,
+		Clauses:  clauses,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstLandingPad) Type() types.Type {
+	return inst.Typ
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstLandingPad) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstLandingPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstLandingPad) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstLandingPad) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstLandingPad) bareString() string {
+	clauses := &bytes.Buffer{}
+	if inst.Cleanup {
+		clauses.WriteString("\n\t\tcleanup")
+	}
+	for _, c := range inst.Clauses {
+		fmt.Fprintf(clauses, "\n\t\t%s", c)
+	}
+	return fmt.Sprintf("%s = landingpad %s%s",
+		inst.Ident(),
+		inst.Type(),
+		clauses)
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstLandingPad) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstLandingPad) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstLandingPad) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstLandingPad) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstLandingPad) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
+// Clause represents an exception clause of a landingpad instruction.
+type Clause struct {
+	// Clause kind.
+	Kind ClauseKind
+	// Clause operand; a type info object of a catch clause, or an array of type
+	// info objects of a filter clause.
+	X constant.Constant
+}
+
+// NewClause returns a new exception clause based on the given clause kind and
+// operand.
+func NewClause(kind ClauseKind, x constant.Constant) *Clause {
+	return &Clause{
+		Kind: kind,
+		X:    x,
+	}
+}
+
+// String returns the LLVM syntax representation of the exception clause.
+func (c *Clause) String() string {
+	return fmt.Sprintf("%s %s %s",
+		c.Kind,
+		c.X.Type(),
+		c.X.Ident())
+}
+
+// ClauseKind represents the set of exception clause kinds.
+type ClauseKind uint
+
+// Exception clause kinds.
+const (
+	ClauseKindCatch  ClauseKind = iota + 1 // catch
+	ClauseKindFilter                       // filter
+)
+
+// String returns the LLVM syntax representation of the exception clause kind.
+func (kind ClauseKind) String() string {
+	m := map[ClauseKind]string{
+		ClauseKindCatch:  "catch",
+		ClauseKindFilter: "filter",
+	}
+	if s, ok := m[kind]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown exception clause kind %d", uint(kind))
+}
+
 // --- [ catchpad ] ------------------------------------------------------------
 
+// InstCatchPad represents a catchpad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchpad-instruction
+type InstCatchPad struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Parent catchswitch terminator.
+	Within value.Value
+	// Exception arguments.
+	Args []value.Value
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCatchPad returns a new catchpad instruction based on the given parent
+// catchswitch terminator and exception arguments.
+func NewCatchPad(within value.Value, args ...value.Value) *InstCatchPad {
+	return &InstCatchPad{
+		Within:   within,
+		Args:     args,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCatchPad) Type() types.Type {
+	return types.Token
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstCatchPad) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstCatchPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstCatchPad) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstCatchPad) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstCatchPad) bareString() string {
+	return fmt.Sprintf("%s = catchpad within %s [%s]",
+		inst.Ident(),
+		inst.Within.Ident(),
+		exceptionArgsString(inst.Args))
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstCatchPad) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstCatchPad) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCatchPad) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstCatchPad) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstCatchPad) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ cleanuppad ] ----------------------------------------------------------
+
+// InstCleanupPad represents a cleanuppad instruction.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cleanuppad-instruction
+type InstCleanupPad struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the instruction.
+	Name string
+	// Parent exception pad; or the none token constant if not within a
+	// funclet.
+	Within value.Value
+	// Exception arguments.
+	Args []value.Value
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCleanupPad returns a new cleanuppad instruction based on the given parent
+// exception pad and exception arguments. The none token constant is used as
+// parent if the cleanuppad is not within a funclet.
+func NewCleanupPad(within value.Value, args ...value.Value) *InstCleanupPad {
+	return &InstCleanupPad{
+		Within:   within,
+		Args:     args,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCleanupPad) Type() types.Type {
+	return types.Token
+}
+
+// Ident returns the identifier associated with the instruction.
+func (inst *InstCleanupPad) Ident() string {
+	return enc.Local(inst.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// instruction.
+func (inst *InstCleanupPad) GetName() string {
+	return inst.Name
+}
+
+// SetName sets the name of the local variable associated with the instruction.
+func (inst *InstCleanupPad) SetName(name string) {
+	inst.Name = name
+}
+
+// String returns the LLVM syntax representation of the instruction.
+func (inst *InstCleanupPad) String() string {
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstCleanupPad) bareString() string {
+	return fmt.Sprintf("%s = cleanuppad within %s [%s]",
+		inst.Ident(),
+		inst.Within.Ident(),
+		exceptionArgsString(inst.Args))
+}
+
+// GetParent returns the parent basic block of the instruction.
+func (inst *InstCleanupPad) GetParent() *BasicBlock {
+	return inst.Parent
+}
+
+// SetParent sets the parent basic block of the instruction.
+func (inst *InstCleanupPad) SetParent(parent *BasicBlock) {
+	inst.Parent = parent
+}
+
+// GetPos returns the source position of the instruction.
+func (inst *InstCleanupPad) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstCleanupPad) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstCleanupPad) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
+// exceptionArgsString returns the LLVM syntax representation of the given
+// exception arguments of a catchpad or cleanuppad instruction.
+func exceptionArgsString(args []value.Value) string {
+	buf := &bytes.Buffer{}
+	for i, arg := range args {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%s %s",
+			arg.Type(),
+			arg.Ident())
+	}
+	return buf.String()
+}
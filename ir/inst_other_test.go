@@ -0,0 +1,36 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/attr"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+func TestArgValues(t *testing.T) {
+	f := ir.NewFunction("f", types.Void, ir.NewParam("x", types.I32), ir.NewParam("y", types.I32))
+	x, y := constant.NewInt(1, types.I32), constant.NewInt(2, types.I32)
+	args := []value.Value{ir.NewArg(x, attr.ZExt), y}
+	want := []value.Value{x, y}
+
+	call := ir.NewCall(f, args...)
+	invoke := ir.NewInvoke(f, args, &ir.BasicBlock{}, &ir.BasicBlock{})
+	for _, got := range [][]value.Value{call.ArgValues(), invoke.ArgValues()} {
+		if len(got) != len(want) {
+			t.Errorf("number of arguments mismatch; expected %d, got %d", len(want), len(got))
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("argument %d mismatch; expected %v, got %v", i, want[i], got[i])
+			}
+		}
+	}
+	// The arguments of the call site are left untouched.
+	if _, ok := call.Args[0].(*ir.Arg); !ok {
+		t.Errorf("invalid argument type; expected *ir.Arg, got %T", call.Args[0])
+	}
+}
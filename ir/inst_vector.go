@@ -34,6 +34,8 @@ type InstExtractElement struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewExtractElement returns a new extractelement instruction based on the given
@@ -74,14 +76,18 @@ func (inst *InstExtractElement) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstExtractElement) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = extractelement %s %s, %s %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstExtractElement) bareString() string {
+	return fmt.Sprintf("%s = extractelement %s %s, %s %s",
 		inst.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
 		inst.Index.Type(),
-		inst.Index.Ident(),
-		md)
+		inst.Index.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -94,6 +100,21 @@ func (inst *InstExtractElement) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstExtractElement) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstExtractElement) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstExtractElement) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ insertelement ] -------------------------------------------------------
 
 // InstInsertElement represents an insertelement instruction.
@@ -114,6 +135,8 @@ type InstInsertElement struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewInsertElement returns a new insertelement instruction based on the given
@@ -150,16 +173,20 @@ func (inst *InstInsertElement) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstInsertElement) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = insertelement %s %s, %s %s, %s %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstInsertElement) bareString() string {
+	return fmt.Sprintf("%s = insertelement %s %s, %s %s, %s %s",
 		inst.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
 		inst.Elem.Type(),
 		inst.Elem.Ident(),
 		inst.Index.Type(),
-		inst.Index.Ident(),
-		md)
+		inst.Index.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -172,6 +199,21 @@ func (inst *InstInsertElement) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
 
+// GetPos returns the source position of the instruction.
+func (inst *InstInsertElement) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstInsertElement) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstInsertElement) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
+
 // --- [ shufflevector ] -------------------------------------------------------
 
 // InstShuffleVector represents an shufflevector instruction.
@@ -192,6 +234,8 @@ type InstShuffleVector struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the instruction; or the zero value if unknown.
+	Pos Position
 }
 
 // NewShuffleVector returns a new shufflevector instruction based on the given
@@ -228,16 +272,20 @@ func (inst *InstShuffleVector) SetName(name string) {
 
 // String returns the LLVM syntax representation of the instruction.
 func (inst *InstShuffleVector) String() string {
-	md := metadataString(inst.Metadata, ",")
-	return fmt.Sprintf("%s = shufflevector %s %s, %s %s, %s %s%s",
+	return inst.bareString() + metadataString(inst.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the instruction, without
+// metadata attachments.
+func (inst *InstShuffleVector) bareString() string {
+	return fmt.Sprintf("%s = shufflevector %s %s, %s %s, %s %s",
 		inst.Ident(),
 		inst.X.Type(),
 		inst.X.Ident(),
 		inst.Y.Type(),
 		inst.Y.Ident(),
 		inst.Mask.Type(),
-		inst.Mask.Ident(),
-		md)
+		inst.Mask.Ident())
 }
 
 // GetParent returns the parent basic block of the instruction.
@@ -249,3 +297,18 @@ func (inst *InstShuffleVector) GetParent() *BasicBlock {
 func (inst *InstShuffleVector) SetParent(parent *BasicBlock) {
 	inst.Parent = parent
 }
+
+// GetPos returns the source position of the instruction.
+func (inst *InstShuffleVector) GetPos() Position {
+	return inst.Pos
+}
+
+// SetPos sets the source position of the instruction.
+func (inst *InstShuffleVector) SetPos(pos Position) {
+	inst.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the instruction.
+func (inst *InstShuffleVector) MDAttachments() map[string]*metadata.Metadata {
+	return inst.Metadata
+}
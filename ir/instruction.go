@@ -5,7 +5,12 @@
 
 package ir
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/llir/llvm/ir/metadata"
+)
 
 // An Instruction represents a non-branching LLVM IR instruction.
 //
@@ -65,6 +70,9 @@ import "fmt"
 //    *ir.InstAlloca          (https://godoc.org/github.com/llir/llvm/ir#InstAlloca)
 //    *ir.InstLoad            (https://godoc.org/github.com/llir/llvm/ir#InstLoad)
 //    *ir.InstStore           (https://godoc.org/github.com/llir/llvm/ir#InstStore)
+//    *ir.InstFence           (https://godoc.org/github.com/llir/llvm/ir#InstFence)
+//    *ir.InstCmpXchg         (https://godoc.org/github.com/llir/llvm/ir#InstCmpXchg)
+//    *ir.InstAtomicRMW       (https://godoc.org/github.com/llir/llvm/ir#InstAtomicRMW)
 //    *ir.InstGetElementPtr   (https://godoc.org/github.com/llir/llvm/ir#InstGetElementPtr)
 //
 // Conversion instructions
@@ -89,15 +97,165 @@ import "fmt"
 //
 // http://llvm.org/docs/LangRef.html#other-operations
 //
-//    *ir.InstICmp     (https://godoc.org/github.com/llir/llvm/ir#InstICmp)
-//    *ir.InstFCmp     (https://godoc.org/github.com/llir/llvm/ir#InstFCmp)
-//    *ir.InstPhi      (https://godoc.org/github.com/llir/llvm/ir#InstPhi)
-//    *ir.InstSelect   (https://godoc.org/github.com/llir/llvm/ir#InstSelect)
-//    *ir.InstCall     (https://godoc.org/github.com/llir/llvm/ir#InstCall)
+//    *ir.InstICmp         (https://godoc.org/github.com/llir/llvm/ir#InstICmp)
+//    *ir.InstFCmp         (https://godoc.org/github.com/llir/llvm/ir#InstFCmp)
+//    *ir.InstPhi          (https://godoc.org/github.com/llir/llvm/ir#InstPhi)
+//    *ir.InstSelect       (https://godoc.org/github.com/llir/llvm/ir#InstSelect)
+//    *ir.InstCall         (https://godoc.org/github.com/llir/llvm/ir#InstCall)
+//    *ir.InstVAArg        (https://godoc.org/github.com/llir/llvm/ir#InstVAArg)
+//    *ir.InstLandingPad   (https://godoc.org/github.com/llir/llvm/ir#InstLandingPad)
+//    *ir.InstCatchPad     (https://godoc.org/github.com/llir/llvm/ir#InstCatchPad)
+//    *ir.InstCleanupPad   (https://godoc.org/github.com/llir/llvm/ir#InstCleanupPad)
 type Instruction interface {
 	fmt.Stringer
 	// GetParent returns the parent basic block of the instruction.
 	GetParent() *BasicBlock
 	// SetParent sets the parent basic block of the instruction.
 	SetParent(parent *BasicBlock)
+	// GetPos returns the source position of the instruction.
+	GetPos() Position
+	// SetPos sets the source position of the instruction.
+	SetPos(pos Position)
+	// MDAttachments returns the metadata attachments of the instruction, as a
+	// map from metadata identifier (e.g. !dbg) to metadata.
+	MDAttachments() map[string]*metadata.Metadata
+}
+
+// OverflowFlag represents the set of overflow flags of integer arithmetic
+// instructions.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#add-instruction
+type OverflowFlag uint
+
+// Overflow flags.
+const (
+	OverflowFlagNUW OverflowFlag = iota + 1 // nuw: no unsigned wrap
+	OverflowFlagNSW                         // nsw: no signed wrap
+)
+
+// String returns the LLVM syntax representation of the overflow flag.
+func (flag OverflowFlag) String() string {
+	m := map[OverflowFlag]string{
+		OverflowFlagNUW: "nuw",
+		OverflowFlagNSW: "nsw",
+	}
+	if s, ok := m[flag]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown overflow flag %d", uint(flag))
+}
+
+// overflowFlagsString returns the string representation of the given overflow
+// flags, each prefixed by a space. Flags are printed once each, in the order
+// used by LLVM (nuw before nsw).
+func overflowFlagsString(flags []OverflowFlag) string {
+	buf := &bytes.Buffer{}
+	present := make(map[OverflowFlag]bool)
+	for _, flag := range flags {
+		present[flag] = true
+	}
+	for _, flag := range []OverflowFlag{OverflowFlagNUW, OverflowFlagNSW} {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	// Unknown flags are printed last, in their original order.
+	for _, flag := range flags {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	return buf.String()
+}
+
+// FastMathFlag represents the set of fast-math flags of floating-point
+// instructions.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#fast-math-flags
+type FastMathFlag uint
+
+// Fast-math flags.
+const (
+	FastMathFlagNNaN     FastMathFlag = iota + 1 // nnan: no NaNs
+	FastMathFlagNInf                             // ninf: no infinities
+	FastMathFlagNSZ                              // nsz: no signed zeros
+	FastMathFlagARCP                             // arcp: allow reciprocal
+	FastMathFlagContract                         // contract: allow floating-point contraction
+	FastMathFlagAFN                              // afn: approximate functions
+	FastMathFlagReassoc                          // reassoc: allow reassociation
+	FastMathFlagFast                             // fast: all fast-math flags
+)
+
+// String returns the LLVM syntax representation of the fast-math flag.
+func (flag FastMathFlag) String() string {
+	m := map[FastMathFlag]string{
+		FastMathFlagNNaN:     "nnan",
+		FastMathFlagNInf:     "ninf",
+		FastMathFlagNSZ:      "nsz",
+		FastMathFlagARCP:     "arcp",
+		FastMathFlagContract: "contract",
+		FastMathFlagAFN:      "afn",
+		FastMathFlagReassoc:  "reassoc",
+		FastMathFlagFast:     "fast",
+	}
+	if s, ok := m[flag]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown fast-math flag %d", uint(flag))
+}
+
+// fastMathFlagsString returns the string representation of the given
+// fast-math flags, each prefixed by a space. Flags are printed once each, in
+// the order used by LLVM; and as the single flag fast if all fast-math flags
+// are set.
+func fastMathFlagsString(flags []FastMathFlag) string {
+	buf := &bytes.Buffer{}
+	present := make(map[FastMathFlag]bool)
+	for _, flag := range flags {
+		present[flag] = true
+	}
+	order := []FastMathFlag{
+		FastMathFlagReassoc,
+		FastMathFlagNNaN,
+		FastMathFlagNInf,
+		FastMathFlagNSZ,
+		FastMathFlagARCP,
+		FastMathFlagContract,
+		FastMathFlagAFN,
+	}
+	fast := present[FastMathFlagFast]
+	if !fast {
+		fast = true
+		for _, flag := range order {
+			if !present[flag] {
+				fast = false
+				break
+			}
+		}
+	}
+	if fast {
+		buf.WriteString(" fast")
+		delete(present, FastMathFlagFast)
+		for _, flag := range order {
+			delete(present, flag)
+		}
+	}
+	for _, flag := range order {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	// Unknown flags are printed last, in their original order.
+	for _, flag := range flags {
+		if present[flag] {
+			fmt.Fprintf(buf, " %s", flag)
+			delete(present, flag)
+		}
+	}
+	return buf.String()
 }
@@ -0,0 +1,64 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+func TestFlagsString(t *testing.T) {
+	x, y := constant.NewInt(30, types.I32), constant.NewInt(12, types.I32)
+	fx, fy := constant.NewFloat(30, types.Double), constant.NewFloat(12, types.Double)
+	golden := []struct {
+		in interface {
+			SetName(name string)
+			String() string
+		}
+		want string
+	}{
+		// Overflow flags.
+		{
+			in:   ir.NewAdd(x, y, ir.OverflowFlagNSW, ir.OverflowFlagNUW),
+			want: "%x = add nuw nsw i32 30, 12",
+		},
+		{
+			in:   ir.NewShl(x, y, ir.OverflowFlagNSW, ir.OverflowFlagNSW),
+			want: "%x = shl nsw i32 30, 12",
+		},
+		// Fast-math flags.
+		{
+			in:   ir.NewFAdd(fx, fy, ir.FastMathFlagAFN, ir.FastMathFlagNSZ, ir.FastMathFlagReassoc, ir.FastMathFlagNSZ),
+			want: "%x = fadd reassoc nsz afn double 30.0, 12.0",
+		},
+		{
+			in:   ir.NewFAdd(fx, fy, ir.FastMathFlagNNaN, ir.FastMathFlagFast, ir.FastMathFlagNInf),
+			want: "%x = fadd fast double 30.0, 12.0",
+		},
+		{
+			in: ir.NewFAdd(fx, fy,
+				ir.FastMathFlagAFN,
+				ir.FastMathFlagContract,
+				ir.FastMathFlagARCP,
+				ir.FastMathFlagNSZ,
+				ir.FastMathFlagNInf,
+				ir.FastMathFlagNNaN,
+				ir.FastMathFlagReassoc,
+			),
+			want: "%x = fadd fast double 30.0, 12.0",
+		},
+	}
+	for _, g := range golden {
+		g.in.SetName("x")
+		if got := g.in.String(); got != g.want {
+			t.Errorf("instruction mismatch; expected %q, got %q", g.want, got)
+		}
+	}
+
+	// Overflow flags of constant expressions.
+	c := constant.NewMul(x, y, constant.OverflowFlagNSW, constant.OverflowFlagNUW, constant.OverflowFlagNUW)
+	if got, want := c.Ident(), "mul nuw nsw (i32 30, i32 12)"; got != want {
+		t.Errorf("constant expression mismatch; expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,68 @@
+// === [ Intrinsic functions ] =================================================
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#intrinsic-functions
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/types"
+)
+
+// --- [ Variable argument handling intrinsics ] -------------------------------
+
+// VAStart returns the llvm.va_start intrinsic function of the module, which
+// initializes the variable argument list pointed to by its i8* argument. The
+// intrinsic is declared in the module on first use.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#llvm-va-start-intrinsic
+func (m *Module) VAStart() *Function {
+	argList := types.NewPointer(types.I8)
+	return m.intrinsic("llvm.va_start", types.Void, NewParam("", argList))
+}
+
+// VAEnd returns the llvm.va_end intrinsic function of the module, which
+// destroys the variable argument list pointed to by its i8* argument. The
+// intrinsic is declared in the module on first use.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#llvm-va-end-intrinsic
+func (m *Module) VAEnd() *Function {
+	argList := types.NewPointer(types.I8)
+	return m.intrinsic("llvm.va_end", types.Void, NewParam("", argList))
+}
+
+// VACopy returns the llvm.va_copy intrinsic function of the module, which
+// copies the variable argument list pointed to by its second i8* argument to
+// the one pointed to by its first. The intrinsic is declared in the module on
+// first use.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#llvm-va-copy-intrinsic
+func (m *Module) VACopy() *Function {
+	argList := types.NewPointer(types.I8)
+	return m.intrinsic("llvm.va_copy", types.Void, NewParam("", argList), NewParam("", argList))
+}
+
+// ### [ Helper functions ] ####################################################
+
+// intrinsic returns the function of the module with the given intrinsic name,
+// appending a new function declaration based on the given return type and
+// parameters if not yet present. It panics if a function of the given name is
+// already present with a different signature.
+func (m *Module) intrinsic(name string, ret types.Type, params ...*types.Param) *Function {
+	sig := types.NewFunc(ret, params...)
+	for _, f := range m.Funcs {
+		if f.Name == name {
+			if !f.Sig.Equal(sig) {
+				panic(fmt.Errorf("invalid signature of intrinsic function %s; expected `%v`, got `%v`", enc.Global(name), sig, f.Sig))
+			}
+			return f
+		}
+	}
+	return m.NewFunction(name, ret, params...)
+}
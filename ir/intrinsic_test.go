@@ -0,0 +1,70 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
+)
+
+func TestIntrinsic(t *testing.T) {
+	i8Ptr := types.NewPointer(types.I8)
+	i32 := types.I32
+
+	// Create a variadic function which returns its first variadic argument.
+	m := ir.NewModule()
+	first := m.NewFunction("first", i32, ir.NewParam("n", i32))
+	first.Sig.Variadic = true
+	entry := first.NewBlock("")
+	ap := entry.NewAlloca(i8Ptr)
+	ap.SetName("ap")
+	ap2 := entry.NewBitCast(ap, i8Ptr)
+	ap2.SetName("ap2")
+	entry.NewCall(m.VAStart(), ap2)
+	x := entry.NewVAArg(ap, i32)
+	x.SetName("x")
+	entry.NewCall(m.VAEnd(), ap2)
+	entry.NewRet(x)
+
+	// Intrinsics are declared once, on first use.
+	if m.VAStart() != m.VAStart() {
+		t.Errorf("llvm.va_start declared more than once")
+	}
+	const want = `define i32 @first(i32 %n, ...) {
+; <label>:0
+	%ap = alloca i8*
+	%ap2 = bitcast i8** %ap to i8*
+	call void @llvm.va_start(i8* %ap2)
+	%x = va_arg i8** %ap, i32
+	call void @llvm.va_end(i8* %ap2)
+	ret i32 %x
+}
+
+declare void @llvm.va_start(i8*)
+
+declare void @llvm.va_end(i8*)
+`
+	if got := m.String(); got != want {
+		t.Errorf("module mismatch; expected `%v`, got `%v`", want, got)
+	}
+
+	// Reuse intrinsics declared by the user with a matching signature.
+	m = ir.NewModule()
+	vaCopy := m.NewFunction("llvm.va_copy", types.Void, ir.NewParam("dst", i8Ptr), ir.NewParam("src", i8Ptr))
+	if got := m.VACopy(); got != vaCopy {
+		t.Errorf("llvm.va_copy mismatch; expected %p, got %p", vaCopy, got)
+	}
+	if len(m.Funcs) != 1 {
+		t.Errorf("number of functions mismatch; expected 1, got %d", len(m.Funcs))
+	}
+
+	// Reject intrinsics declared by the user with a mismatching signature.
+	m = ir.NewModule()
+	m.NewFunction("llvm.va_end", i32, ir.NewParam("", i8Ptr))
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("expected panic for llvm.va_end with mismatching signature")
+		}
+	}()
+	m.VAEnd()
+}
@@ -11,6 +11,8 @@ import (
 var (
 	_ constant.Constant = &ir.Global{}
 	_ constant.Constant = &ir.Function{}
+	_ constant.Constant = &ir.Alias{}
+	_ constant.Constant = &ir.IFunc{}
 )
 
 // Validate that the relevant types satisfy the ir.Instruction interface.
@@ -46,6 +48,9 @@ var (
 	_ ir.Instruction = &ir.InstAlloca{}
 	_ ir.Instruction = &ir.InstLoad{}
 	_ ir.Instruction = &ir.InstStore{}
+	_ ir.Instruction = &ir.InstFence{}
+	_ ir.Instruction = &ir.InstCmpXchg{}
+	_ ir.Instruction = &ir.InstAtomicRMW{}
 	_ ir.Instruction = &ir.InstGetElementPtr{}
 	// Conversion instructions
 	_ ir.Instruction = &ir.InstTrunc{}
@@ -67,6 +72,10 @@ var (
 	_ ir.Instruction = &ir.InstPhi{}
 	_ ir.Instruction = &ir.InstSelect{}
 	_ ir.Instruction = &ir.InstCall{}
+	_ ir.Instruction = &ir.InstVAArg{}
+	_ ir.Instruction = &ir.InstLandingPad{}
+	_ ir.Instruction = &ir.InstCatchPad{}
+	_ ir.Instruction = &ir.InstCleanupPad{}
 )
 
 // Validate that the relevant types satisfy the ir.Terminator interface.
@@ -76,6 +85,12 @@ var (
 	_ ir.Terminator = &ir.TermBr{}
 	_ ir.Terminator = &ir.TermCondBr{}
 	_ ir.Terminator = &ir.TermSwitch{}
+	_ ir.Terminator = &ir.TermIndirectBr{}
+	_ ir.Terminator = &ir.TermInvoke{}
+	_ ir.Terminator = &ir.TermResume{}
+	_ ir.Terminator = &ir.TermCatchSwitch{}
+	_ ir.Terminator = &ir.TermCatchRet{}
+	_ ir.Terminator = &ir.TermCleanupRet{}
 	_ ir.Terminator = &ir.TermUnreachable{}
 )
 
@@ -83,6 +98,8 @@ var (
 var (
 	_ value.Named = &ir.Global{}
 	_ value.Named = &ir.Function{}
+	_ value.Named = &ir.Alias{}
+	_ value.Named = &ir.IFunc{}
 	_ value.Named = &ir.BasicBlock{}
 	// Binary instructions
 	_ value.Named = &ir.InstAdd{}
@@ -114,6 +131,8 @@ var (
 	// Memory instructions
 	_ value.Named = &ir.InstAlloca{}
 	_ value.Named = &ir.InstLoad{}
+	_ value.Named = &ir.InstCmpXchg{}
+	_ value.Named = &ir.InstAtomicRMW{}
 	_ value.Named = &ir.InstGetElementPtr{}
 	// Conversion instructions
 	_ value.Named = &ir.InstTrunc{}
@@ -135,6 +154,13 @@ var (
 	_ value.Named = &ir.InstPhi{}
 	_ value.Named = &ir.InstSelect{}
 	_ value.Named = &ir.InstCall{}
+	_ value.Named = &ir.InstVAArg{}
+	_ value.Named = &ir.InstLandingPad{}
+	_ value.Named = &ir.InstCatchPad{}
+	_ value.Named = &ir.InstCleanupPad{}
+	// Terminators
+	_ value.Named = &ir.TermInvoke{}
+	_ value.Named = &ir.TermCatchSwitch{}
 )
 
 // Validate that the relevant types satisfy the ir.MetadataNode interface.
@@ -78,7 +78,7 @@ type walker struct {
 // traversal.
 func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{})) {
 	switch x.(type) {
-	case []*ir.Global, []*ir.Function, []types.Type, []*types.Param, []value.Value, []constant.Constant, []*ir.BasicBlock, []ir.Instruction, []*ir.Incoming, []*ir.Case:
+	case []*ir.Global, []*ir.Alias, []*ir.IFunc, []*ir.Function, []types.Type, []*types.Param, []value.Value, []constant.Constant, []*ir.BasicBlock, []ir.Instruction, []*ir.Incoming, []*ir.Clause, []*ir.Case, []*ir.OperandBundle:
 		// unhashable type.
 	case *ir.Function:
 		if w.funcScope {
@@ -119,6 +119,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	// pointers to struct pointers
 	case **ir.Global:
 		w.walkBeforeAfter(*n, before, after)
+	case **ir.Alias:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.IFunc:
+		w.walkBeforeAfter(*n, before, after)
 	case **ir.Function:
 		w.walkBeforeAfter(*n, before, after)
 	// Types
@@ -136,10 +140,14 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **types.VectorType:
 		w.walkBeforeAfter(*n, before, after)
+	case **types.MMXType:
+		w.walkBeforeAfter(*n, before, after)
 	case **types.LabelType:
 		w.walkBeforeAfter(*n, before, after)
 	case **types.MetadataType:
 		w.walkBeforeAfter(*n, before, after)
+	case **types.TokenType:
+		w.walkBeforeAfter(*n, before, after)
 	case **types.ArrayType:
 		w.walkBeforeAfter(*n, before, after)
 	case **types.StructType:
@@ -151,6 +159,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **constant.Null:
 		w.walkBeforeAfter(*n, before, after)
+	case **constant.NoneToken:
+		w.walkBeforeAfter(*n, before, after)
 	case **constant.Vector:
 		w.walkBeforeAfter(*n, before, after)
 	case **constant.Array:
@@ -161,6 +171,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **constant.Undef:
 		w.walkBeforeAfter(*n, before, after)
+	case **constant.BlockAddress:
+		w.walkBeforeAfter(*n, before, after)
 	// Constant expressions
 	case **constant.ExprAdd:
 		w.walkBeforeAfter(*n, before, after)
@@ -210,6 +222,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **constant.ExprGetElementPtr:
 		w.walkBeforeAfter(*n, before, after)
+	case **constant.Index:
+		w.walkBeforeAfter(*n, before, after)
 	case **constant.ExprTrunc:
 		w.walkBeforeAfter(*n, before, after)
 	case **constant.ExprZExt:
@@ -298,6 +312,12 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ir.InstStore:
 		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstFence:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstCmpXchg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstAtomicRMW:
+		w.walkBeforeAfter(*n, before, after)
 	case **ir.InstGetElementPtr:
 		w.walkBeforeAfter(*n, before, after)
 	case **ir.InstTrunc:
@@ -338,6 +358,20 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ir.InstCall:
 		w.walkBeforeAfter(*n, before, after)
+	case **ir.Arg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.OperandBundle:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstVAArg:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstLandingPad:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.Clause:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstCatchPad:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.InstCleanupPad:
+		w.walkBeforeAfter(*n, before, after)
 	// Terminators
 	case **ir.TermRet:
 		w.walkBeforeAfter(*n, before, after)
@@ -349,8 +383,23 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case **ir.Case:
 		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermIndirectBr:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermInvoke:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermResume:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermCatchSwitch:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermCatchRet:
+		w.walkBeforeAfter(*n, before, after)
+	case **ir.TermCleanupRet:
+		w.walkBeforeAfter(*n, before, after)
 	case **ir.TermUnreachable:
 		w.walkBeforeAfter(*n, before, after)
+	// Inline assembler expressions
+	case **ir.InlineAsm:
+		w.walkBeforeAfter(*n, before, after)
 	// Metadata
 	case **metadata.Metadata:
 		w.walkBeforeAfter(*n, before, after)
@@ -366,6 +415,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case *[]*ir.Global:
 		w.walkBeforeAfter(*n, before, after)
+	case *[]*ir.Alias:
+		w.walkBeforeAfter(*n, before, after)
+	case *[]*ir.IFunc:
+		w.walkBeforeAfter(*n, before, after)
 	case *[]value.Value:
 		w.walkBeforeAfter(*n, before, after)
 	case *[]constant.Constant:
@@ -380,6 +433,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(*n, before, after)
 	case *[]*ir.Incoming:
 		w.walkBeforeAfter(*n, before, after)
+	case *[]*ir.Clause:
+		w.walkBeforeAfter(*n, before, after)
+	case *[]*ir.OperandBundle:
+		w.walkBeforeAfter(*n, before, after)
 
 	// These are ordered and grouped to match ../../ll.bnf
 	case *ir.Module:
@@ -389,6 +446,12 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Globals != nil {
 			w.walkBeforeAfter(&n.Globals, before, after)
 		}
+		if n.Aliases != nil {
+			w.walkBeforeAfter(&n.Aliases, before, after)
+		}
+		if n.IFuncs != nil {
+			w.walkBeforeAfter(&n.IFuncs, before, after)
+		}
 		if n.Funcs != nil {
 			w.walkBeforeAfter(&n.Funcs, before, after)
 		}
@@ -401,12 +464,29 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Init != nil {
 			w.walkBeforeAfter(&n.Init, before, after)
 		}
+	case []*ir.Alias:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ir.Alias:
+		w.walkBeforeAfter(&n.Typ, before, after)
+		w.walkBeforeAfter(&n.Aliasee, before, after)
+	case []*ir.IFunc:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ir.IFunc:
+		w.walkBeforeAfter(&n.Content, before, after)
+		w.walkBeforeAfter(&n.Resolver, before, after)
 	case []*ir.Function:
 		for i := range n {
 			w.walkBeforeAfter(&n[i], before, after)
 		}
 	case *ir.Function:
 		w.walkBeforeAfter(&n.Sig, before, after)
+		if n.Personality != nil {
+			w.walkBeforeAfter(&n.Personality, before, after)
+		}
 		if n.Blocks != nil {
 			w.walkBeforeAfter(&n.Blocks, before, after)
 		}
@@ -436,10 +516,14 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Elem, before, after)
 	case *types.VectorType:
 		w.walkBeforeAfter(&n.Elem, before, after)
+	case *types.MMXType:
+		// nothing to do.
 	case *types.LabelType:
 		// nothing to do.
 	case *types.MetadataType:
 		// nothing to do.
+	case *types.TokenType:
+		// nothing to do.
 	case *types.ArrayType:
 		w.walkBeforeAfter(&n.Elem, before, after)
 	case *types.StructType:
@@ -461,6 +545,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Typ, before, after)
 	case *constant.Null:
 		w.walkBeforeAfter(&n.Typ, before, after)
+	case *constant.NoneToken:
+		// nothing to do.
 	case *constant.Vector:
 		w.walkBeforeAfter(&n.Typ, before, after)
 		if n.Elems != nil {
@@ -480,6 +566,10 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		w.walkBeforeAfter(&n.Typ, before, after)
 	case *constant.Undef:
 		w.walkBeforeAfter(&n.Typ, before, after)
+	case *constant.BlockAddress:
+		// Block is not traversed, as it may reside outside of the scope of the
+		// function currently being traversed.
+		w.walkBeforeAfter(&n.Func, before, after)
 	// Constant expressions
 	case *constant.ExprAdd:
 		w.walkBeforeAfter(&n.X, before, after)
@@ -560,6 +650,8 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Indices != nil {
 			w.walkBeforeAfter(&n.Indices, before, after)
 		}
+	case *constant.Index:
+		w.walkBeforeAfter(&n.Constant, before, after)
 	case *constant.ExprTrunc:
 		w.walkBeforeAfter(&n.From, before, after)
 		w.walkBeforeAfter(&n.To, before, after)
@@ -709,6 +801,15 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	case *ir.InstStore:
 		w.walkBeforeAfter(&n.Src, before, after)
 		w.walkBeforeAfter(&n.Dst, before, after)
+	case *ir.InstFence:
+		// nothing to do.
+	case *ir.InstCmpXchg:
+		w.walkBeforeAfter(&n.Ptr, before, after)
+		w.walkBeforeAfter(&n.Cmp, before, after)
+		w.walkBeforeAfter(&n.New, before, after)
+	case *ir.InstAtomicRMW:
+		w.walkBeforeAfter(&n.Dst, before, after)
+		w.walkBeforeAfter(&n.X, before, after)
 	case *ir.InstGetElementPtr:
 		w.walkBeforeAfter(&n.Elem, before, after)
 		w.walkBeforeAfter(&n.Src, before, after)
@@ -782,6 +883,43 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 		if n.Args != nil {
 			w.walkBeforeAfter(&n.Args, before, after)
 		}
+		if n.OperandBundles != nil {
+			w.walkBeforeAfter(&n.OperandBundles, before, after)
+		}
+	case *ir.Arg:
+		w.walkBeforeAfter(&n.Value, before, after)
+	case []*ir.OperandBundle:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ir.OperandBundle:
+		if n.Inputs != nil {
+			w.walkBeforeAfter(&n.Inputs, before, after)
+		}
+	case *ir.InstVAArg:
+		w.walkBeforeAfter(&n.ArgList, before, after)
+		w.walkBeforeAfter(&n.ArgType, before, after)
+	case *ir.InstLandingPad:
+		w.walkBeforeAfter(&n.Typ, before, after)
+		if n.Clauses != nil {
+			w.walkBeforeAfter(&n.Clauses, before, after)
+		}
+	case []*ir.Clause:
+		for i := range n {
+			w.walkBeforeAfter(&n[i], before, after)
+		}
+	case *ir.Clause:
+		w.walkBeforeAfter(&n.X, before, after)
+	case *ir.InstCatchPad:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
+	case *ir.InstCleanupPad:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
 	// Terminators
 	case *ir.TermRet:
 		if n.X != nil {
@@ -806,9 +944,44 @@ func (w *walker) walkBeforeAfter(x interface{}, before, after func(interface{}))
 	case *ir.Case:
 		w.walkBeforeAfter(&n.X, before, after)
 		w.walkBeforeAfter(&n.Target, before, after)
+	case *ir.TermIndirectBr:
+		w.walkBeforeAfter(&n.Addr, before, after)
+		if n.ValidTargets != nil {
+			w.walkBeforeAfter(&n.ValidTargets, before, after)
+		}
+	case *ir.TermInvoke:
+		w.walkBeforeAfter(&n.Callee, before, after)
+		w.walkBeforeAfter(&n.Sig, before, after)
+		if n.Args != nil {
+			w.walkBeforeAfter(&n.Args, before, after)
+		}
+		w.walkBeforeAfter(&n.Normal, before, after)
+		w.walkBeforeAfter(&n.Exception, before, after)
+	case *ir.TermResume:
+		w.walkBeforeAfter(&n.X, before, after)
+	case *ir.TermCatchSwitch:
+		w.walkBeforeAfter(&n.Within, before, after)
+		if n.Handlers != nil {
+			w.walkBeforeAfter(&n.Handlers, before, after)
+		}
+		if n.Unwind != nil {
+			w.walkBeforeAfter(&n.Unwind, before, after)
+		}
+	case *ir.TermCatchRet:
+		w.walkBeforeAfter(&n.From, before, after)
+		w.walkBeforeAfter(&n.To, before, after)
+	case *ir.TermCleanupRet:
+		w.walkBeforeAfter(&n.From, before, after)
+		if n.Unwind != nil {
+			w.walkBeforeAfter(&n.Unwind, before, after)
+		}
 	case *ir.TermUnreachable:
 		// nothing to do.
 
+	// Inline assembler expressions
+	case *ir.InlineAsm:
+		w.walkBeforeAfter(&n.Typ, before, after)
+
 	// Metadata
 	case *metadata.Metadata:
 		for i := range n.Nodes {
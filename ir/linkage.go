@@ -0,0 +1,154 @@
+package ir
+
+import "fmt"
+
+// Linkage represents the set of linkage types.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#linkage
+type Linkage uint
+
+// Linkage types.
+const (
+	LinkageNone                Linkage = iota // no linkage specified (external).
+	LinkageAppending                          // appending
+	LinkageAvailableExternally                // available_externally
+	LinkageCommon                             // common
+	LinkageInternal                           // internal
+	LinkageLinkOnce                           // linkonce
+	LinkageLinkOnceODR                        // linkonce_odr
+	LinkagePrivate                            // private
+	LinkageWeak                               // weak
+	LinkageWeakODR                            // weak_odr
+	LinkageExternal                           // external
+	LinkageExternWeak                         // extern_weak
+)
+
+// String returns the LLVM syntax representation of the linkage type.
+func (linkage Linkage) String() string {
+	m := map[Linkage]string{
+		LinkageAppending:           "appending",
+		LinkageAvailableExternally: "available_externally",
+		LinkageCommon:              "common",
+		LinkageInternal:            "internal",
+		LinkageLinkOnce:            "linkonce",
+		LinkageLinkOnceODR:         "linkonce_odr",
+		LinkagePrivate:             "private",
+		LinkageWeak:                "weak",
+		LinkageWeakODR:             "weak_odr",
+		LinkageExternal:            "external",
+		LinkageExternWeak:          "extern_weak",
+	}
+	if s, ok := m[linkage]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown linkage type %d", uint(linkage))
+}
+
+// Visibility represents the set of visibility styles.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#visibility-styles
+type Visibility uint
+
+// Visibility styles.
+const (
+	VisibilityNone      Visibility = iota // no visibility style specified.
+	VisibilityDefault                     // default
+	VisibilityHidden                      // hidden
+	VisibilityProtected                   // protected
+)
+
+// String returns the LLVM syntax representation of the visibility style.
+func (vis Visibility) String() string {
+	m := map[Visibility]string{
+		VisibilityDefault:   "default",
+		VisibilityHidden:    "hidden",
+		VisibilityProtected: "protected",
+	}
+	if s, ok := m[vis]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown visibility style %d", uint(vis))
+}
+
+// DLLStorageClass represents the set of DLL storage classes.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#dll-storage-classes
+type DLLStorageClass uint
+
+// DLL storage classes.
+const (
+	DLLStorageClassNone      DLLStorageClass = iota // no DLL storage class specified.
+	DLLStorageClassDLLImport                        // dllimport
+	DLLStorageClassDLLExport                        // dllexport
+)
+
+// String returns the LLVM syntax representation of the DLL storage class.
+func (dll DLLStorageClass) String() string {
+	m := map[DLLStorageClass]string{
+		DLLStorageClassDLLImport: "dllimport",
+		DLLStorageClassDLLExport: "dllexport",
+	}
+	if s, ok := m[dll]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown DLL storage class %d", uint(dll))
+}
+
+// TLSModel represents the set of thread local storage models.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#thread-local-storage-models
+type TLSModel uint
+
+// Thread local storage models.
+const (
+	TLSModelNone         TLSModel = iota // not thread local.
+	TLSModelGeneric                      // thread_local
+	TLSModelLocalDynamic                 // thread_local(localdynamic)
+	TLSModelInitialExec                  // thread_local(initialexec)
+	TLSModelLocalExec                    // thread_local(localexec)
+)
+
+// String returns the LLVM syntax representation of the thread local storage
+// model.
+func (tls TLSModel) String() string {
+	m := map[TLSModel]string{
+		TLSModelGeneric:      "thread_local",
+		TLSModelLocalDynamic: "thread_local(localdynamic)",
+		TLSModelInitialExec:  "thread_local(initialexec)",
+		TLSModelLocalExec:    "thread_local(localexec)",
+	}
+	if s, ok := m[tls]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown thread local storage model %d", uint(tls))
+}
+
+// UnnamedAddr represents the set of unnamed address specifiers.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#global-variables
+type UnnamedAddr uint
+
+// Unnamed address specifiers.
+const (
+	UnnamedAddrNone             UnnamedAddr = iota // no unnamed address specified.
+	UnnamedAddrLocalUnnamedAddr                    // local_unnamed_addr
+	UnnamedAddrUnnamedAddr                         // unnamed_addr
+)
+
+// String returns the LLVM syntax representation of the unnamed address
+// specifier.
+func (unnamed UnnamedAddr) String() string {
+	m := map[UnnamedAddr]string{
+		UnnamedAddrLocalUnnamedAddr: "local_unnamed_addr",
+		UnnamedAddrUnnamedAddr:      "unnamed_addr",
+	}
+	if s, ok := m[unnamed]; ok {
+		return s
+	}
+	return fmt.Sprintf("unknown unnamed address specifier %d", uint(unnamed))
+}
@@ -9,28 +9,42 @@
 package ir
 
 import (
-	"bytes"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/attr"
 	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
 	"github.com/llir/llvm/ir/types"
 )
 
 // A Module represents an LLVM IR module, which consists of top-level type
-// definitions, global variables, functions, and metadata.
+// definitions, global variables, aliases, IFuncs, functions, and metadata.
 type Module struct {
+	// Source filename; or empty if not present.
+	SourceFilename string
 	// Data layout.
 	DataLayout string
 	// Target triple.
 	TargetTriple string
+	// Module-level inline assembly, one entry per line.
+	ModuleAsms []string
 	// Type definitions.
 	Types []types.Type
+	// Comdat definitions of the module.
+	Comdats []*Comdat
 	// Global variables of the module.
 	Globals []*Global
+	// Aliases of the module.
+	Aliases []*Alias
+	// IFuncs of the module.
+	IFuncs []*IFunc
 	// Functions of the module.
 	Funcs []*Function
+	// Attribute group definitions of the module.
+	AttrGroups []*attr.Group
 	// Named metadata of the module.
 	NamedMetadata []*metadata.Named
 	// Metadata of the module.
@@ -44,47 +58,107 @@ func NewModule() *Module {
 
 // String returns the LLVM syntax representation of the module.
 func (m *Module) String() string {
-	buf := &bytes.Buffer{}
+	buf := &strings.Builder{}
+	// Writes to a strings.Builder never fail.
+	m.WriteTo(buf)
+	return buf.String()
+}
+
+// WriteTo writes the LLVM syntax representation of the module to w, without
+// building the representation of the entire module in memory. It implements
+// io.WriterTo, and the output is identical to the output of String.
+func (m *Module) WriteTo(w io.Writer) (n int64, err error) {
+	p := &printer{w: w}
+	m.print(p)
+	return p.n, p.err
+}
+
+// print writes the LLVM syntax representation of the module to p.
+func (m *Module) print(p *printer) {
+	if len(m.SourceFilename) > 0 {
+		fmt.Fprintf(p, "source_filename = \"%s\"\n", enc.EscapeString(m.SourceFilename))
+	}
 	if len(m.DataLayout) > 0 {
-		fmt.Fprintf(buf, "target datalayout = %q\n", m.DataLayout)
+		fmt.Fprintf(p, "target datalayout = %q\n", m.DataLayout)
 	}
 	if len(m.TargetTriple) > 0 {
-		fmt.Fprintf(buf, "target triple = %q\n", m.TargetTriple)
+		fmt.Fprintf(p, "target triple = %q\n", m.TargetTriple)
+	}
+	for i, asm := range m.ModuleAsms {
+		// Group module-level inline assembly.
+		if i == 0 && p.n > 0 {
+			p.WriteString("\n")
+		}
+		fmt.Fprintf(p, "module asm \"%s\"\n", enc.EscapeString(asm))
 	}
 	for _, typ := range m.Types {
-		if len(buf.Bytes()) > 0 {
-			buf.WriteString("\n")
+		if p.n > 0 {
+			p.WriteString("\n")
 		}
 		name := enc.Local(typ.GetName())
-		fmt.Fprintf(buf, "%s = type %s\n", name, typ.Def())
+		fmt.Fprintf(p, "%s = type %s\n", name, typ.Def())
+	}
+	for i, c := range m.Comdats {
+		// Group comdat definitions.
+		if i == 0 && p.n > 0 {
+			p.WriteString("\n")
+		}
+		fmt.Fprintln(p, c)
+	}
+	// Assign unique local IDs to unnamed basic blocks of functions in advance,
+	// as they may be referred to by block address constants of preceding
+	// global variables and functions.
+	for _, f := range m.Funcs {
+		f.mu.Lock()
+		assignIDs(f)
+		f.mu.Unlock()
 	}
 	for _, global := range m.Globals {
-		if len(buf.Bytes()) > 0 {
-			buf.WriteString("\n")
+		if p.n > 0 {
+			p.WriteString("\n")
 		}
-		fmt.Fprintln(buf, global)
+		fmt.Fprintln(p, global)
+	}
+	for _, alias := range m.Aliases {
+		if p.n > 0 {
+			p.WriteString("\n")
+		}
+		fmt.Fprintln(p, alias)
+	}
+	for _, ifunc := range m.IFuncs {
+		if p.n > 0 {
+			p.WriteString("\n")
+		}
+		fmt.Fprintln(p, ifunc)
 	}
 	for _, f := range m.Funcs {
-		if len(buf.Bytes()) > 0 {
-			buf.WriteString("\n")
+		if p.n > 0 {
+			p.WriteString("\n")
 		}
-		fmt.Fprintln(buf, f)
+		f.print(p)
+		p.WriteString("\n")
+	}
+	for i, g := range m.AttrGroups {
+		// Group attribute group definitions.
+		if i == 0 && p.n > 0 {
+			p.WriteString("\n")
+		}
+		fmt.Fprintf(p, "attributes %s = %s\n", g.Ident(), g.Def())
 	}
 	for _, md := range m.NamedMetadata {
-		if len(buf.Bytes()) > 0 {
-			buf.WriteString("\n")
+		if p.n > 0 {
+			p.WriteString("\n")
 		}
 		name := enc.Metadata(md.Name)
-		fmt.Fprintf(buf, "%s = %s\n", name, md.Def())
+		fmt.Fprintf(p, "%s = %s\n", name, md.Def())
 	}
 	for _, md := range m.Metadata {
-		if len(buf.Bytes()) > 0 {
-			buf.WriteString("\n")
+		if p.n > 0 {
+			p.WriteString("\n")
 		}
 		id := enc.Metadata(md.ID)
-		fmt.Fprintf(buf, "%s = %s\n", id, md.Def())
+		fmt.Fprintf(p, "%s = %s\n", id, md.Def())
 	}
-	return buf.String()
 }
 
 // AppendFunction appends the given function to the module.
@@ -101,6 +175,14 @@ func (m *Module) NewType(name string, typ types.Type) types.Type {
 	return typ
 }
 
+// NewComdat appends a new comdat definition to the module based on the given
+// comdat name and selection kind.
+func (m *Module) NewComdat(name string, kind SelectionKind) *Comdat {
+	c := NewComdat(name, kind)
+	m.Comdats = append(m.Comdats, c)
+	return c
+}
+
 // NewGlobalDecl appends a new external global variable declaration to the
 // module based on the given global variable name and content type.
 func (m *Module) NewGlobalDecl(name string, content types.Type) *Global {
@@ -117,6 +199,22 @@ func (m *Module) NewGlobalDef(name string, init constant.Constant) *Global {
 	return global
 }
 
+// NewAlias appends a new alias to the module based on the given alias name and
+// aliasee.
+func (m *Module) NewAlias(name string, aliasee constant.Constant) *Alias {
+	alias := NewAlias(name, aliasee)
+	m.Aliases = append(m.Aliases, alias)
+	return alias
+}
+
+// NewIFunc appends a new IFunc to the module based on the given IFunc name,
+// content type and resolver.
+func (m *Module) NewIFunc(name string, content types.Type, resolver constant.Constant) *IFunc {
+	ifunc := NewIFunc(name, content, resolver)
+	m.IFuncs = append(m.IFuncs, ifunc)
+	return ifunc
+}
+
 // NewFunction appends a new function to the module based on the given function
 // name, return type and parameters.
 func (m *Module) NewFunction(name string, ret types.Type, params ...*types.Param) *Function {
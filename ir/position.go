@@ -0,0 +1,44 @@
+package ir
+
+import "fmt"
+
+// A Position describes a source position within an LLVM IR assembly file.
+//
+// Positions are only recorded by the LLVM IR assembly parser when requested;
+// the zero value of Position denotes an unknown position.
+type Position struct {
+	// File name; or empty if unknown.
+	Filename string
+	// Byte offset, starting at 0.
+	Offset int
+	// Line number, starting at 1.
+	Line int
+	// Column number, starting at 1 (byte count).
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+// String returns the string representation of the position, in one of the
+// following forms.
+//
+//    file:line:col   valid position with file name
+//    line:col        valid position without file name
+//    file            invalid position with file name
+//    -               invalid position without file name
+func (pos Position) String() string {
+	s := pos.Filename
+	if pos.IsValid() {
+		if len(s) > 0 {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if len(s) == 0 {
+		s = "-"
+	}
+	return s
+}
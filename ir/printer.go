@@ -0,0 +1,181 @@
+package ir
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// predsColumn specifies the column at which predecessor comments of basic
+// block labels are aligned, as used by LLVM.
+const predsColumn = 50
+
+// PrintConfig specifies how to print the LLVM syntax representation of
+// modules, functions and basic blocks. The zero value produces output
+// identical to the output of String.
+type PrintConfig struct {
+	// Annotate the labels of basic blocks with the predecessors of the basic
+	// blocks (e.g. "; preds = %a, %b"), in order of the predecessors in the
+	// parent function.
+	Preds bool
+	// Print the labels of unnamed basic blocks as "N:" rather than as
+	// "; <label>:N" comment headers.
+	NumericLabels bool
+	// Comments of instructions and terminators, printed on the lines preceding
+	// the instructions; each line of a comment is printed as a separate
+	// comment line.
+	Comments map[Instruction]string
+	// Column at which to align the metadata attachments of instructions and
+	// terminators (e.g. "!dbg !12"); or 0 to print the metadata attachments
+	// directly after the instructions. Tabs advance to the next multiple of 8
+	// columns.
+	MetadataColumn int
+}
+
+// Fprint writes the LLVM syntax representation of the given module, function
+// or basic block to w, as specified by the printer configuration. It returns
+// the number of bytes written and any write error encountered.
+func (cfg *PrintConfig) Fprint(w io.Writer, node interface{}) (n int64, err error) {
+	p := &printer{w: w, cfg: *cfg}
+	switch node := node.(type) {
+	case *Module:
+		node.print(p)
+	case *Function:
+		node.print(p)
+	case *BasicBlock:
+		node.print(p)
+	default:
+		return 0, errors.Errorf("support for printing %T not yet implemented", node)
+	}
+	return p.n, p.err
+}
+
+// bareStringer is implemented by the instructions and terminators of this
+// package, which may be printed without their metadata attachments.
+type bareStringer interface {
+	// bareString returns the LLVM syntax representation of the instruction,
+	// without metadata attachments.
+	bareString() string
+}
+
+// printer is an io.Writer which keeps track of the number of bytes written to
+// the underlying writer, and of the first error encountered; writes after an
+// error are skipped.
+type printer struct {
+	// Underlying writer.
+	w io.Writer
+	// Number of bytes written.
+	n int64
+	// First error encountered; or nil if no error.
+	err error
+	// Printer configuration.
+	cfg PrintConfig
+	// Function of the cached predecessors; or nil if not yet computed.
+	predsFunc *Function
+	// Cached predecessors of the basic blocks of predsFunc.
+	predsCache map[*BasicBlock][]*BasicBlock
+}
+
+// Write writes b to the underlying writer, unless a previous write failed.
+func (p *printer) Write(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	p.err = err
+	return n, err
+}
+
+// WriteString writes s to the underlying writer, unless a previous write
+// failed.
+func (p *printer) WriteString(s string) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	n, err := io.WriteString(p.w, s)
+	p.n += int64(n)
+	p.err = err
+	return n, err
+}
+
+// printInst writes the given instruction or terminator to p, indented by a
+// tab and preceded by its comment lines, if any.
+func (p *printer) printInst(inst Instruction) {
+	if comment, ok := p.cfg.Comments[inst]; ok {
+		for _, line := range strings.Split(comment, "\n") {
+			if len(line) == 0 {
+				p.WriteString("\t;\n")
+				continue
+			}
+			fmt.Fprintf(p, "\t; %s\n", line)
+		}
+	}
+	mds := inst.MDAttachments()
+	bare, ok := inst.(bareStringer)
+	if p.cfg.MetadataColumn == 0 || len(mds) == 0 || !ok {
+		p.WriteString("\t" + inst.String())
+		return
+	}
+	// Align the metadata attachments, keeping the comma directly after the
+	// instruction.
+	s := padToColumn("\t"+bare.bareString()+",", p.cfg.MetadataColumn)
+	p.WriteString(s + strings.TrimPrefix(metadataString(mds, ","), ", "))
+}
+
+// preds returns the predecessors of the given basic block, or nil if
+// predecessor comments are disabled.
+func (p *printer) preds(block *BasicBlock) []*BasicBlock {
+	if !p.cfg.Preds || block.Parent == nil {
+		return nil
+	}
+	if p.predsFunc != block.Parent {
+		p.predsFunc = block.Parent
+		p.predsCache = make(map[*BasicBlock][]*BasicBlock)
+		for _, pred := range block.Parent.Blocks {
+			if pred.Term == nil {
+				continue
+			}
+			for _, succ := range pred.Term.Succs() {
+				// Skip duplicate edges (e.g. switch cases with the same target).
+				if !containsBlock(p.predsCache[succ], pred) {
+					p.predsCache[succ] = append(p.predsCache[succ], pred)
+				}
+			}
+		}
+	}
+	return p.predsCache[block]
+}
+
+// ### [ Helper functions ] ####################################################
+
+// containsBlock reports whether the given basic block is in blocks.
+func containsBlock(blocks []*BasicBlock, block *BasicBlock) bool {
+	for _, b := range blocks {
+		if b == block {
+			return true
+		}
+	}
+	return false
+}
+
+// padToColumn pads the last line of s with spaces up to the given column, using
+// at least one space. Tabs advance to the next multiple of 8 columns.
+func padToColumn(s string, column int) string {
+	line := s[strings.LastIndex(s, "\n")+1:]
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			col += 8 - col%8
+		} else {
+			col++
+		}
+	}
+	n := column - col
+	if n < 1 {
+		n = 1
+	}
+	return s + strings.Repeat(" ", n)
+}
@@ -0,0 +1,142 @@
+package ir_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/llir/llvm/asm"
+	"github.com/llir/llvm/ir"
+)
+
+func TestPrintConfigDefault(t *testing.T) {
+	golden := []struct {
+		path string
+	}{
+		{path: "../asm/testdata/module.ll"},
+		{path: "../asm/testdata/alias.ll"},
+		{path: "../asm/testdata/func.ll"},
+		{path: "../asm/testdata/metadata.ll"},
+		{path: "../asm/testdata/term.ll"},
+	}
+	for _, g := range golden {
+		m, err := asm.ParseFile(g.path)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", g.path, err)
+			continue
+		}
+		buf := &bytes.Buffer{}
+		cfg := &ir.PrintConfig{}
+		if _, err := cfg.Fprint(buf, m); err != nil {
+			t.Errorf("%q: unable to print module; %v", g.path, err)
+			continue
+		}
+		want, got := m.String(), buf.String()
+		if want != got {
+			t.Errorf("%q: output mismatch; expected %q, got %q", g.path, want, got)
+		}
+	}
+}
+
+func TestPrintConfig(t *testing.T) {
+	const src = `
+define i32 @f(i32 %x) {
+entry:
+	%0 = icmp eq i32 %x, 0
+	br i1 %0, label %1, label %exit
+
+1:
+	%y = add i32 %x, 1, !foo !0, !bar !0
+	switch i32 %y, label %2 [
+		i32 1, label %exit
+	]
+
+2:
+	br label %exit
+
+exit:
+	%r = phi i32 [ 0, %entry ], [ %y, %1 ], [ %x, %2 ]
+	ret i32 %r
+}
+
+!0 = !{}
+`
+	m, err := asm.ParseString(src)
+	if err != nil {
+		t.Fatalf("unable to parse module; %v", err)
+	}
+	f := m.Funcs[0]
+	golden := []struct {
+		cfg  *ir.PrintConfig
+		want string
+	}{
+		// Predecessor comments, with duplicate edges omitted; the predecessors
+		// include conditional, unconditional and switch branches.
+		{
+			cfg: &ir.PrintConfig{Preds: true},
+			want: `define i32 @f(i32 %x) {
+entry:
+	%0 = icmp eq i32 %x, 0
+	br i1 %0, label %1, label %exit
+; <label>:1                                       ; preds = %entry
+	%y = add i32 %x, 1, !bar !0, !foo !0
+	switch i32 %y, label %2 [
+		i32 1, label %exit
+	]
+; <label>:2                                       ; preds = %1
+	br label %exit
+exit:                                             ; preds = %entry, %1, %2
+	%r = phi i32 [ 0, %entry ], [ %y, %1 ], [ %x, %2 ]
+	ret i32 %r
+}`,
+		},
+		// Numeric labels, instruction comments and aligned metadata.
+		{
+			cfg: &ir.PrintConfig{
+				NumericLabels: true,
+				Comments: map[ir.Instruction]string{
+					f.Blocks[1].Insts[0]: "increment x",
+					f.Blocks[3].Term:     "return the\n\nresult",
+				},
+				MetadataColumn: 40,
+			},
+			want: `define i32 @f(i32 %x) {
+entry:
+	%0 = icmp eq i32 %x, 0
+	br i1 %0, label %1, label %exit
+1:
+	; increment x
+	%y = add i32 %x, 1,             !bar !0, !foo !0
+	switch i32 %y, label %2 [
+		i32 1, label %exit
+	]
+2:
+	br label %exit
+exit:
+	%r = phi i32 [ 0, %entry ], [ %y, %1 ], [ %x, %2 ]
+	; return the
+	;
+	; result
+	ret i32 %r
+}`,
+		},
+	}
+	for _, g := range golden {
+		buf := &bytes.Buffer{}
+		if _, err := g.cfg.Fprint(buf, f); err != nil {
+			t.Errorf("unable to print function; %v", err)
+			continue
+		}
+		if got := buf.String(); g.want != got {
+			t.Errorf("output mismatch; expected %q, got %q", g.want, got)
+		}
+		// Output must remain valid LLVM IR.
+		if _, err := asm.ParseString(buf.String() + "\n!0 = !{}\n"); err != nil {
+			t.Errorf("unable to parse output; %v", err)
+		}
+	}
+	// Printing of unsupported nodes.
+	cfg := &ir.PrintConfig{}
+	if _, err := cfg.Fprint(&bytes.Buffer{}, f.Sig); err == nil {
+		t.Errorf("expected error when printing %T", f.Sig)
+	}
+}
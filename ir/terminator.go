@@ -9,8 +9,10 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/llir/llvm/internal/enc"
 	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/metadata"
+	"github.com/llir/llvm/ir/types"
 	"github.com/llir/llvm/ir/value"
 )
 
@@ -26,6 +28,12 @@ import (
 //    *ir.TermBr            (https://godoc.org/github.com/llir/llvm/ir#TermBr)
 //    *ir.TermCondBr        (https://godoc.org/github.com/llir/llvm/ir#TermCondBr)
 //    *ir.TermSwitch        (https://godoc.org/github.com/llir/llvm/ir#TermSwitch)
+//    *ir.TermIndirectBr    (https://godoc.org/github.com/llir/llvm/ir#TermIndirectBr)
+//    *ir.TermInvoke        (https://godoc.org/github.com/llir/llvm/ir#TermInvoke)
+//    *ir.TermResume        (https://godoc.org/github.com/llir/llvm/ir#TermResume)
+//    *ir.TermCatchSwitch   (https://godoc.org/github.com/llir/llvm/ir#TermCatchSwitch)
+//    *ir.TermCatchRet      (https://godoc.org/github.com/llir/llvm/ir#TermCatchRet)
+//    *ir.TermCleanupRet    (https://godoc.org/github.com/llir/llvm/ir#TermCleanupRet)
 //    *ir.TermUnreachable   (https://godoc.org/github.com/llir/llvm/ir#TermUnreachable)
 type Terminator interface {
 	Instruction
@@ -47,6 +55,8 @@ type TermRet struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
 }
 
 // NewRet returns a new ret terminator based on the given return value. A nil
@@ -60,14 +70,18 @@ func NewRet(x value.Value) *TermRet {
 
 // String returns the LLVM syntax representation of the terminator.
 func (term *TermRet) String() string {
-	md := metadataString(term.Metadata, ",")
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermRet) bareString() string {
 	if term.X != nil {
-		return fmt.Sprintf("ret %s %s%s",
+		return fmt.Sprintf("ret %s %s",
 			term.X.Type(),
-			term.X.Ident(),
-			md)
+			term.X.Ident())
 	}
-	return fmt.Sprintf("ret void%s", md)
+	return "ret void"
 }
 
 // GetParent returns the parent basic block of the terminator.
@@ -80,6 +94,21 @@ func (term *TermRet) SetParent(parent *BasicBlock) {
 	term.Parent = parent
 }
 
+// GetPos returns the source position of the terminator.
+func (term *TermRet) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermRet) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermRet) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
 // Succs returns the successor basic blocks of the terminator.
 func (term *TermRet) Succs() []*BasicBlock {
 	// ret terminators have no successors.
@@ -102,6 +131,8 @@ type TermBr struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
 }
 
 // NewBr returns a new unconditional br terminator based on the given target
@@ -117,10 +148,14 @@ func NewBr(target *BasicBlock) *TermBr {
 
 // String returns the LLVM syntax representation of the terminator.
 func (term *TermBr) String() string {
-	md := metadataString(term.Metadata, ",")
-	return fmt.Sprintf("br label %s%s",
-		term.Target.Ident(),
-		md)
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermBr) bareString() string {
+	return fmt.Sprintf("br label %s",
+		term.Target.Ident())
 }
 
 // GetParent returns the parent basic block of the terminator.
@@ -133,6 +168,21 @@ func (term *TermBr) SetParent(parent *BasicBlock) {
 	term.Parent = parent
 }
 
+// GetPos returns the source position of the terminator.
+func (term *TermBr) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermBr) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermBr) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
 // Succs returns the successor basic blocks of the terminator.
 func (term *TermBr) Succs() []*BasicBlock {
 	return term.Successors
@@ -158,6 +208,8 @@ type TermCondBr struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
 }
 
 // NewCondBr returns a new conditional br terminator based on the given
@@ -175,12 +227,16 @@ func NewCondBr(cond value.Value, targetTrue, targetFalse *BasicBlock) *TermCondB
 
 // String returns the LLVM syntax representation of the terminator.
 func (term *TermCondBr) String() string {
-	md := metadataString(term.Metadata, ",")
-	return fmt.Sprintf("br i1 %s, label %s, label %s%s",
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermCondBr) bareString() string {
+	return fmt.Sprintf("br i1 %s, label %s, label %s",
 		term.Cond.Ident(),
 		term.TargetTrue.Ident(),
-		term.TargetFalse.Ident(),
-		md)
+		term.TargetFalse.Ident())
 }
 
 // GetParent returns the parent basic block of the terminator.
@@ -193,6 +249,21 @@ func (term *TermCondBr) SetParent(parent *BasicBlock) {
 	term.Parent = parent
 }
 
+// GetPos returns the source position of the terminator.
+func (term *TermCondBr) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermCondBr) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermCondBr) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
 // Succs returns the successor basic blocks of the terminator.
 func (term *TermCondBr) Succs() []*BasicBlock {
 	return term.Successors
@@ -218,6 +289,8 @@ type TermSwitch struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
 }
 
 // TODO: Consider renaming x to control to avoid confusion between term.X() and
@@ -241,6 +314,12 @@ func NewSwitch(x value.Value, targetDefault *BasicBlock, cases ...*Case) *TermSw
 
 // String returns the LLVM syntax representation of the terminator.
 func (term *TermSwitch) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermSwitch) bareString() string {
 	cases := &bytes.Buffer{}
 	for _, c := range term.Cases {
 		fmt.Fprintf(cases, "\t\t%s %s, label %s\n",
@@ -248,13 +327,11 @@ func (term *TermSwitch) String() string {
 			c.X.Ident(),
 			c.Target.Ident())
 	}
-	md := metadataString(term.Metadata, ",")
-	return fmt.Sprintf("switch %s %s, label %s [\n%s\t]%s",
+	return fmt.Sprintf("switch %s %s, label %s [\n%s\t]",
 		term.X.Type(),
 		term.X.Ident(),
 		term.TargetDefault.Ident(),
-		cases,
-		md)
+		cases)
 }
 
 // GetParent returns the parent basic block of the terminator.
@@ -267,6 +344,21 @@ func (term *TermSwitch) SetParent(parent *BasicBlock) {
 	term.Parent = parent
 }
 
+// GetPos returns the source position of the terminator.
+func (term *TermSwitch) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermSwitch) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermSwitch) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
 // Succs returns the successor basic blocks of the terminator.
 func (term *TermSwitch) Succs() []*BasicBlock {
 	return term.Successors
@@ -294,16 +386,616 @@ func NewCase(x *constant.Int, target *BasicBlock) *Case {
 
 // --- [ indirectbr ] ----------------------------------------------------------
 
+// TermIndirectBr represents an indirectbr terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#indirectbr-instruction
+type TermIndirectBr struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Target address.
+	Addr value.Value
+	// Set of valid target basic blocks.
+	ValidTargets []*BasicBlock
+	// Successors basic blocks.
+	Successors []*BasicBlock
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewIndirectBr returns a new indirectbr terminator based on the given target
+// address (derived from a blockaddress constant) and set of valid target basic
+// blocks.
+func NewIndirectBr(addr value.Value, validTargets ...*BasicBlock) *TermIndirectBr {
+	successors := append([]*BasicBlock(nil), validTargets...)
+	return &TermIndirectBr{
+		Addr:         addr,
+		ValidTargets: validTargets,
+		Successors:   successors,
+		Metadata:     make(map[string]*metadata.Metadata),
+	}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermIndirectBr) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermIndirectBr) bareString() string {
+	targets := &bytes.Buffer{}
+	for i, target := range term.ValidTargets {
+		if i != 0 {
+			targets.WriteString(", ")
+		}
+		fmt.Fprintf(targets, "label %s", target.Ident())
+	}
+	return fmt.Sprintf("indirectbr %s %s, [%s]",
+		term.Addr.Type(),
+		term.Addr.Ident(),
+		targets)
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermIndirectBr) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermIndirectBr) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermIndirectBr) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermIndirectBr) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermIndirectBr) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermIndirectBr) Succs() []*BasicBlock {
+	return term.Successors
+}
+
 // --- [ invoke ] --------------------------------------------------------------
 
+// TermInvoke represents an invoke terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#invoke-instruction
+type TermInvoke struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the terminator.
+	Name string
+	// Callee.
+	//
+	// Callee may have one of the following underlying types.
+	//
+	//    *ir.Function
+	//    *types.Param
+	//    *constant.ExprBitCast
+	//    *ir.InstBitCast
+	//    *ir.InstLoad
+	//    *ir.InlineAsm
+	Callee value.Value
+	// Callee signature.
+	Sig *types.FuncType
+	// Function arguments; arguments with parameter attributes are wrapped in an
+	// *ir.Arg. Use ArgValues to access the underlying argument values.
+	Args []value.Value
+	// Calling convention.
+	CallConv CallConv
+	// Target branch when the callee returns normally.
+	Normal *BasicBlock
+	// Target branch when the callee unwinds through an exception.
+	Exception *BasicBlock
+	// Successors basic blocks.
+	Successors []*BasicBlock
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewInvoke returns a new invoke terminator based on the given callee,
+// function arguments, and target branches of normal return and exception.
+//
+// The callee value may have one of the following underlying types.
+//    *ir.Function
+//    *types.Param
+//    *constant.ExprBitCast
+//    *ir.InstBitCast
+//    *ir.InstLoad
+//    *ir.InlineAsm
+func NewInvoke(callee value.Value, args []value.Value, normal, exception *BasicBlock) *TermInvoke {
+	typ, ok := callee.Type().(*types.PointerType)
+	if !ok {
+		panic(fmt.Errorf("invalid callee type, expected *types.PointerType, got %T", callee.Type()))
+	}
+	sig, ok := typ.Elem.(*types.FuncType)
+	if !ok {
+		panic(fmt.Errorf("invalid callee signature type, expected *types.FuncType, got %T", typ.Elem))
+	}
+	successors := []*BasicBlock{normal, exception}
+	return &TermInvoke{
+		Callee:     callee,
+		Sig:        sig,
+		Args:       args,
+		Normal:     normal,
+		Exception:  exception,
+		Successors: successors,
+		Metadata:   make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the terminator.
+func (term *TermInvoke) Type() types.Type {
+	return term.Sig.Ret
+}
+
+// Ident returns the identifier associated with the terminator.
+func (term *TermInvoke) Ident() string {
+	return enc.Local(term.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// terminator.
+func (term *TermInvoke) GetName() string {
+	return term.Name
+}
+
+// SetName sets the name of the local variable associated with the terminator.
+func (term *TermInvoke) SetName(name string) {
+	term.Name = name
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermInvoke) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermInvoke) bareString() string {
+	ident := &bytes.Buffer{}
+	if !term.Type().Equal(types.Void) {
+		fmt.Fprintf(ident, "%s = ", term.Ident())
+	}
+	callconv := &bytes.Buffer{}
+	if term.CallConv != CallConvNone {
+		fmt.Fprintf(callconv, " %s", term.CallConv)
+	}
+	// Print callee signature instead of return type for variadic callees.
+	sig := term.Sig
+	ret := sig.Ret.String()
+	if sig.Variadic {
+		ret = sig.String()
+	}
+	args := &bytes.Buffer{}
+	for i, arg := range term.Args {
+		if i != 0 {
+			args.WriteString(", ")
+		}
+		fmt.Fprintf(args, "%s %s",
+			arg.Type(),
+			arg.Ident())
+	}
+	return fmt.Sprintf("%sinvoke%s %s %s(%s) to label %s unwind label %s",
+		ident,
+		callconv,
+		ret,
+		term.Callee.Ident(),
+		args,
+		term.Normal.Ident(),
+		term.Exception.Ident())
+}
+
+// ArgValues returns the function arguments of the invoke terminator, with
+// *ir.Arg wrappers replaced by their underlying argument values.
+func (term *TermInvoke) ArgValues() []value.Value {
+	return argValues(term.Args)
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermInvoke) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermInvoke) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermInvoke) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermInvoke) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermInvoke) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermInvoke) Succs() []*BasicBlock {
+	return term.Successors
+}
+
 // --- [ resume ] --------------------------------------------------------------
 
+// TermResume represents a resume terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#resume-instruction
+type TermResume struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Exception value being propagated.
+	X value.Value
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewResume returns a new resume terminator based on the given exception value
+// to propagate.
+func NewResume(x value.Value) *TermResume {
+	return &TermResume{
+		X:        x,
+		Metadata: make(map[string]*metadata.Metadata),
+	}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermResume) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermResume) bareString() string {
+	return fmt.Sprintf("resume %s %s",
+		term.X.Type(),
+		term.X.Ident())
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermResume) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermResume) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermResume) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermResume) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermResume) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermResume) Succs() []*BasicBlock {
+	// resume terminators have no successors.
+	return nil
+}
+
 // --- [ catchswitch ] ---------------------------------------------------------
 
+// TermCatchSwitch represents a catchswitch terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchswitch-instruction
+type TermCatchSwitch struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Name of the local variable associated with the terminator.
+	Name string
+	// Parent exception pad; or the none token constant if not within a
+	// funclet.
+	Within value.Value
+	// Exception handlers.
+	Handlers []*BasicBlock
+	// Unwind target; or nil if unwinding to the caller.
+	Unwind *BasicBlock
+	// Successors basic blocks.
+	Successors []*BasicBlock
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCatchSwitch returns a new catchswitch terminator based on the given parent
+// exception pad, exception handlers and unwind target. A nil unwind target
+// indicates that the catchswitch unwinds to the caller.
+func NewCatchSwitch(within value.Value, handlers []*BasicBlock, unwind *BasicBlock) *TermCatchSwitch {
+	successors := append([]*BasicBlock(nil), handlers...)
+	if unwind != nil {
+		successors = append(successors, unwind)
+	}
+	return &TermCatchSwitch{
+		Within:     within,
+		Handlers:   handlers,
+		Unwind:     unwind,
+		Successors: successors,
+		Metadata:   make(map[string]*metadata.Metadata),
+	}
+}
+
+// Type returns the type of the terminator.
+func (term *TermCatchSwitch) Type() types.Type {
+	return types.Token
+}
+
+// Ident returns the identifier associated with the terminator.
+func (term *TermCatchSwitch) Ident() string {
+	return enc.Local(term.Name)
+}
+
+// GetName returns the name of the local variable associated with the
+// terminator.
+func (term *TermCatchSwitch) GetName() string {
+	return term.Name
+}
+
+// SetName sets the name of the local variable associated with the terminator.
+func (term *TermCatchSwitch) SetName(name string) {
+	term.Name = name
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermCatchSwitch) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermCatchSwitch) bareString() string {
+	handlers := &bytes.Buffer{}
+	for i, handler := range term.Handlers {
+		if i != 0 {
+			handlers.WriteString(", ")
+		}
+		fmt.Fprintf(handlers, "label %s", handler.Ident())
+	}
+	return fmt.Sprintf("%s = catchswitch within %s [%s] unwind %s",
+		term.Ident(),
+		term.Within.Ident(),
+		handlers,
+		unwindString(term.Unwind))
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermCatchSwitch) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermCatchSwitch) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCatchSwitch) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermCatchSwitch) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermCatchSwitch) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermCatchSwitch) Succs() []*BasicBlock {
+	return term.Successors
+}
+
 // --- [ catchret ] ------------------------------------------------------------
 
+// TermCatchRet represents a catchret terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#catchret-instruction
+type TermCatchRet struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Exiting catchpad.
+	From value.Value
+	// Target branch.
+	To *BasicBlock
+	// Successors basic blocks.
+	Successors []*BasicBlock
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCatchRet returns a new catchret terminator based on the given exiting
+// catchpad and target branch.
+func NewCatchRet(from value.Value, to *BasicBlock) *TermCatchRet {
+	successors := []*BasicBlock{to}
+	return &TermCatchRet{
+		From:       from,
+		To:         to,
+		Successors: successors,
+		Metadata:   make(map[string]*metadata.Metadata),
+	}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermCatchRet) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermCatchRet) bareString() string {
+	return fmt.Sprintf("catchret from %s to label %s",
+		term.From.Ident(),
+		term.To.Ident())
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermCatchRet) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermCatchRet) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCatchRet) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermCatchRet) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermCatchRet) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermCatchRet) Succs() []*BasicBlock {
+	return term.Successors
+}
+
 // --- [ cleanupret ] ----------------------------------------------------------
 
+// TermCleanupRet represents a cleanupret terminator.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#cleanupret-instruction
+type TermCleanupRet struct {
+	// Parent basic block.
+	Parent *BasicBlock
+	// Exiting cleanuppad.
+	From value.Value
+	// Unwind target; or nil if unwinding to the caller.
+	Unwind *BasicBlock
+	// Successors basic blocks.
+	Successors []*BasicBlock
+	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
+	// instruction.
+	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
+}
+
+// NewCleanupRet returns a new cleanupret terminator based on the given exiting
+// cleanuppad and unwind target. A nil unwind target indicates that the
+// cleanupret unwinds to the caller.
+func NewCleanupRet(from value.Value, unwind *BasicBlock) *TermCleanupRet {
+	var successors []*BasicBlock
+	if unwind != nil {
+		successors = append(successors, unwind)
+	}
+	return &TermCleanupRet{
+		From:       from,
+		Unwind:     unwind,
+		Successors: successors,
+		Metadata:   make(map[string]*metadata.Metadata),
+	}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (term *TermCleanupRet) String() string {
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermCleanupRet) bareString() string {
+	return fmt.Sprintf("cleanupret from %s unwind %s",
+		term.From.Ident(),
+		unwindString(term.Unwind))
+}
+
+// GetParent returns the parent basic block of the terminator.
+func (term *TermCleanupRet) GetParent() *BasicBlock {
+	return term.Parent
+}
+
+// SetParent sets the parent basic block of the terminator.
+func (term *TermCleanupRet) SetParent(parent *BasicBlock) {
+	term.Parent = parent
+}
+
+// GetPos returns the source position of the terminator.
+func (term *TermCleanupRet) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermCleanupRet) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermCleanupRet) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
+// Succs returns the successor basic blocks of the terminator.
+func (term *TermCleanupRet) Succs() []*BasicBlock {
+	return term.Successors
+}
+
+// unwindString returns the LLVM syntax representation of the given unwind
+// target of a catchswitch or cleanupret terminator.
+func unwindString(unwind *BasicBlock) string {
+	if unwind == nil {
+		return "to caller"
+	}
+	return fmt.Sprintf("label %s", unwind.Ident())
+}
+
 // --- [ unreachable ] ---------------------------------------------------------
 
 // TermUnreachable represents an unreachable terminator.
@@ -316,6 +1008,8 @@ type TermUnreachable struct {
 	// Map from metadata identifier (e.g. !dbg) to metadata associated with the
 	// instruction.
 	Metadata map[string]*metadata.Metadata
+	// Source position of the terminator; or the zero value if unknown.
+	Pos Position
 }
 
 // NewUnreachable returns a new unreachable terminator.
@@ -327,8 +1021,13 @@ func NewUnreachable() *TermUnreachable {
 
 // String returns the LLVM syntax representation of the terminator.
 func (term *TermUnreachable) String() string {
-	md := metadataString(term.Metadata, ",")
-	return fmt.Sprintf("unreachable%s", md)
+	return term.bareString() + metadataString(term.Metadata, ",")
+}
+
+// bareString returns the LLVM syntax representation of the terminator, without
+// metadata attachments.
+func (term *TermUnreachable) bareString() string {
+	return "unreachable"
 }
 
 // GetParent returns the parent basic block of the terminator.
@@ -341,6 +1040,21 @@ func (term *TermUnreachable) SetParent(parent *BasicBlock) {
 	term.Parent = parent
 }
 
+// GetPos returns the source position of the terminator.
+func (term *TermUnreachable) GetPos() Position {
+	return term.Pos
+}
+
+// SetPos sets the source position of the terminator.
+func (term *TermUnreachable) SetPos(pos Position) {
+	term.Pos = pos
+}
+
+// MDAttachments returns the metadata attachments of the terminator.
+func (term *TermUnreachable) MDAttachments() map[string]*metadata.Metadata {
+	return term.Metadata
+}
+
 // Succs returns the successor basic blocks of the terminator.
 func (term *TermUnreachable) Succs() []*BasicBlock {
 	// unreachable terminators have no successors.
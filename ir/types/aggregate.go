@@ -78,7 +78,11 @@ type StructType struct {
 	Name string
 	// Struct fields.
 	Fields []Type
-	// Opaque struct type.
+	// Packed struct type, with one byte alignment and no padding between
+	// fields.
+	Packed bool
+	// Opaque struct type; the body of opaque struct types is unknown until set
+	// by SetBody.
 	//
 	// References:
 	//    http://llvm.org/docs/LangRef.html#opaque-structure-types
@@ -90,6 +94,18 @@ func NewStruct(fields ...Type) *StructType {
 	return &StructType{Fields: fields}
 }
 
+// NewPackedStruct returns a new packed struct type based on the given struct
+// fields.
+func NewPackedStruct(fields ...Type) *StructType {
+	return &StructType{Fields: fields, Packed: true}
+}
+
+// NewOpaqueStruct returns a new opaque struct type. Opaque struct types must be
+// named, e.g. through ir.Module.NewType.
+func NewOpaqueStruct() *StructType {
+	return &StructType{Opaque: true}
+}
+
 // String returns the LLVM syntax representation of the type.
 func (t *StructType) String() string {
 	if t.Identified() {
@@ -104,6 +120,9 @@ func (t *StructType) Def() string {
 		return "opaque"
 	}
 	buf := &bytes.Buffer{}
+	if t.Packed {
+		buf.WriteString("<")
+	}
 	buf.WriteString("{")
 	if len(t.Fields) > 0 {
 		// Use same output format as Clang.
@@ -120,30 +139,25 @@ func (t *StructType) Def() string {
 		buf.WriteString(" ")
 	}
 	buf.WriteString("}")
+	if t.Packed {
+		buf.WriteString(">")
+	}
 	return buf.String()
 }
 
 // Equal reports whether t and u are of equal type.
+//
+// Identified struct types are equal if they have the same type name and
+// structurally equal bodies, and literal struct types are equal if they are
+// structurally equal. Recursive struct types are supported.
 func (t *StructType) Equal(u Type) bool {
-	if u, ok := u.(*StructType); ok {
-		// Identified struct types are uniqued by type names, not by structural
-		// identity.
-		if t.Identified() || u.Identified() {
-			return t.Name == u.Name
-		}
-		// Literal struct types are uniqued by structural identity.
-		if len(t.Fields) != len(u.Fields) {
-			return false
-		}
-		for i, tf := range t.Fields {
-			uf := u.Fields[i]
-			if !tf.Equal(uf) {
-				return false
-			}
-		}
-		return true
-	}
-	return false
+	return equal(t, u, make(map[structPair]bool))
+}
+
+// SetBody sets the struct fields of t, which is no longer opaque.
+func (t *StructType) SetBody(fields ...Type) {
+	t.Fields = fields
+	t.Opaque = false
 }
 
 // GetName returns the name of the type.
@@ -160,3 +174,69 @@ func (t *StructType) SetName(name string) {
 func (t *StructType) Identified() bool {
 	return len(t.Name) > 0
 }
+
+// ### [ Helper functions ] ####################################################
+
+// structPair is a pair of struct types being compared for equality.
+type structPair struct {
+	t, u *StructType
+}
+
+// equal reports whether t and u are of equal type. Struct type pairs are
+// recorded in visited when first compared and assumed equal when revisited, to
+// ensure that the comparison of recursive types terminates.
+func equal(t, u Type, visited map[structPair]bool) bool {
+	switch t := t.(type) {
+	case *FuncType:
+		u, ok := u.(*FuncType)
+		if !ok || len(t.Params) != len(u.Params) || t.Variadic != u.Variadic {
+			return false
+		}
+		if !equal(t.Ret, u.Ret, visited) {
+			return false
+		}
+		for i, tp := range t.Params {
+			if !equal(tp.Typ, u.Params[i].Typ, visited) {
+				return false
+			}
+		}
+		return true
+	case *PointerType:
+		u, ok := u.(*PointerType)
+		return ok && equal(t.Elem, u.Elem, visited)
+	case *VectorType:
+		u, ok := u.(*VectorType)
+		return ok && t.Len == u.Len && equal(t.Elem, u.Elem, visited)
+	case *ArrayType:
+		u, ok := u.(*ArrayType)
+		return ok && t.Len == u.Len && equal(t.Elem, u.Elem, visited)
+	case *StructType:
+		u, ok := u.(*StructType)
+		if !ok {
+			return false
+		}
+		if t == u {
+			return true
+		}
+		// Identified struct types are uniqued by type names.
+		if t.Name != u.Name {
+			return false
+		}
+		pair := structPair{t: t, u: u}
+		if visited[pair] {
+			return true
+		}
+		visited[pair] = true
+		if t.Opaque != u.Opaque || t.Packed != u.Packed || len(t.Fields) != len(u.Fields) {
+			return false
+		}
+		for i, tf := range t.Fields {
+			if !equal(tf, u.Fields[i], visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		return t.Equal(u)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/llir/llvm/internal/enc"
+	"github.com/llir/llvm/ir/attr"
 )
 
 // --- [ void ] ----------------------------------------------------------------
@@ -147,6 +148,8 @@ type Param struct {
 	Name string
 	// Parameter type.
 	Typ Type
+	// Parameter attributes.
+	Attrs []attr.Attribute
 }
 
 // NewParam returns a new function parameter based on the given parameter name
@@ -254,3 +257,45 @@ func (t *MetadataType) GetName() string {
 func (t *MetadataType) SetName(name string) {
 	t.Name = name
 }
+
+// --- [ token ] ---------------------------------------------------------------
+
+// TokenType represents a token type, which is used for values associated with
+// an instruction that may not be inspected or obscured; e.g. exception handling
+// pads.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#token-type
+type TokenType struct {
+	// Type name alias.
+	Name string
+}
+
+// String returns the LLVM syntax representation of the type.
+func (t *TokenType) String() string {
+	if len(t.Name) > 0 {
+		return enc.Local(t.Name)
+	}
+	return t.Def()
+}
+
+// Def returns the LLVM syntax representation of the definition of the type.
+func (t *TokenType) Def() string {
+	return "token"
+}
+
+// Equal reports whether t and u are of equal type.
+func (t *TokenType) Equal(u Type) bool {
+	_, ok := u.(*TokenType)
+	return ok
+}
+
+// GetName returns the name of the type.
+func (t *TokenType) GetName() string {
+	return t.Name
+}
+
+// SetName sets the name of the type.
+func (t *TokenType) SetName(name string) {
+	t.Name = name
+}
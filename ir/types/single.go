@@ -243,3 +243,44 @@ func (t *VectorType) GetName() string {
 func (t *VectorType) SetName(name string) {
 	t.Name = name
 }
+
+// --- [ x86_mmx ] -------------------------------------------------------------
+
+// MMXType represents an x86 MMX type, which is used for values held in an MMX
+// register of an x86 machine.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#x86-mmx-type
+type MMXType struct {
+	// Type name alias.
+	Name string
+}
+
+// String returns the LLVM syntax representation of the type.
+func (t *MMXType) String() string {
+	if len(t.Name) > 0 {
+		return enc.Local(t.Name)
+	}
+	return t.Def()
+}
+
+// Def returns the LLVM syntax representation of the definition of the type.
+func (t *MMXType) Def() string {
+	return "x86_mmx"
+}
+
+// Equal reports whether t and u are of equal type.
+func (t *MMXType) Equal(u Type) bool {
+	_, ok := u.(*MMXType)
+	return ok
+}
+
+// GetName returns the name of the type.
+func (t *MMXType) GetName() string {
+	return t.Name
+}
+
+// SetName sets the name of the type.
+func (t *MMXType) SetName(name string) {
+	t.Name = name
+}
@@ -18,8 +18,10 @@ import "fmt"
 //    *types.FloatType      (https://godoc.org/github.com/llir/llvm/ir/types#FloatType)
 //    *types.PointerType    (https://godoc.org/github.com/llir/llvm/ir/types#PointerType)
 //    *types.VectorType     (https://godoc.org/github.com/llir/llvm/ir/types#VectorType)
+//    *types.MMXType        (https://godoc.org/github.com/llir/llvm/ir/types#MMXType)
 //    *types.LabelType      (https://godoc.org/github.com/llir/llvm/ir/types#LabelType)
 //    *types.MetadataType   (https://godoc.org/github.com/llir/llvm/ir/types#MetadataType)
+//    *types.TokenType      (https://godoc.org/github.com/llir/llvm/ir/types#TokenType)
 //    *types.ArrayType      (https://godoc.org/github.com/llir/llvm/ir/types#ArrayType)
 //    *types.StructType     (https://godoc.org/github.com/llir/llvm/ir/types#StructType)
 type Type interface {
@@ -62,10 +64,14 @@ var (
 	X86_FP80 = &FloatType{Kind: FloatKindDoubleExtended_80}
 	// PPC_FP128 represents the `ppc_fp128` floating-point type.
 	PPC_FP128 = &FloatType{Kind: FloatKindDoubleDouble_128}
+	// MMX represents the `x86_mmx` type.
+	MMX = &MMXType{}
 	// Label represents the `label` type.
 	Label = &LabelType{}
 	// Metadata represents the `metadata` type.
 	Metadata = &MetadataType{}
+	// Token represents the `token` type.
+	Token = &TokenType{}
 )
 
 // Equal reports whether t and u are of equal type.
@@ -118,6 +124,12 @@ func IsVector(t Type) bool {
 	return ok
 }
 
+// IsMMX reports whether the given type is an x86 MMX type.
+func IsMMX(t Type) bool {
+	_, ok := t.(*MMXType)
+	return ok
+}
+
 // IsLabel reports whether the given type is a label type.
 func IsLabel(t Type) bool {
 	_, ok := t.(*LabelType)
@@ -130,6 +142,12 @@ func IsMetadata(t Type) bool {
 	return ok
 }
 
+// IsToken reports whether the given type is a token type.
+func IsToken(t Type) bool {
+	_, ok := t.(*TokenType)
+	return ok
+}
+
 // IsArray reports whether the given type is an array type.
 func IsArray(t Type) bool {
 	_, ok := t.(*ArrayType)
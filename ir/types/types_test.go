@@ -77,6 +77,22 @@ func TestMetadataTypeString(t *testing.T) {
 	}
 }
 
+func TestTokenTypeString(t *testing.T) {
+	const want = "token"
+	got := types.Token.String()
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMMXTypeString(t *testing.T) {
+	const want = "x86_mmx"
+	got := types.MMX.String()
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
 func TestFuncTypeString(t *testing.T) {
 	i8, i32 := types.I8, types.I32
 	formatParam := types.NewParam("format", types.NewPointer(i8))
@@ -157,6 +173,9 @@ func TestStructTypeString(t *testing.T) {
 		{want: "{ i32, i8* }", typ: types.NewStruct(types.I32, types.NewPointer(types.I8))},
 		{want: "{ i32, i16, i8 }", typ: types.NewStruct(types.I32, types.I16, types.I8)},
 		{want: "{}", typ: types.NewStruct()},
+		{want: "<{ i32, i8 }>", typ: types.NewPackedStruct(types.I32, types.I8)},
+		{want: "<{}>", typ: types.NewPackedStruct()},
+		{want: "opaque", typ: types.NewOpaqueStruct()},
 	}
 	for i, g := range golden {
 		got := g.typ.String()
@@ -1047,6 +1066,36 @@ func TestStructEqual(t *testing.T) {
 	}
 }
 
+func TestRecursiveStructEqual(t *testing.T) {
+	// newList returns a new identified struct type of a linked list, which
+	// refers to itself through a pointer.
+	newList := func(name string, elem types.Type) *types.StructType {
+		list := types.NewOpaqueStruct()
+		list.SetName(name)
+		list.SetBody(elem, types.NewPointer(list))
+		return list
+	}
+	list := newList("list", types.I32)
+	golden := []struct {
+		want bool
+		t, u types.Type
+	}{
+		{want: true, t: list, u: list},
+		{want: true, t: list, u: newList("list", types.I32)},
+		{want: false, t: list, u: newList("list", types.I8)},
+		{want: false, t: list, u: newList("node", types.I32)},
+		{want: true, t: types.NewPointer(list), u: types.NewPointer(newList("list", types.I32))},
+		{want: false, t: list, u: &types.StructType{Name: "list", Opaque: true}},
+		{want: false, t: types.NewStruct(types.I32), u: types.NewPackedStruct(types.I32)},
+	}
+	for i, g := range golden {
+		got := g.t.Equal(g.u)
+		if got != g.want {
+			t.Errorf("i=%d; expected %v, got %v", i, g.want, got)
+		}
+	}
+}
+
 // Validate that the relevant types satisfy the types.Type interface.
 var (
 	_ types.Type = &types.VoidType{}
@@ -1057,6 +1106,8 @@ var (
 	_ types.Type = &types.VectorType{}
 	_ types.Type = &types.LabelType{}
 	_ types.Type = &types.MetadataType{}
+	_ types.Type = &types.TokenType{}
+	_ types.Type = &types.MMXType{}
 	_ types.Type = &types.ArrayType{}
 	_ types.Type = &types.StructType{}
 )
@@ -11,6 +11,7 @@ import "github.com/llir/llvm/ir/types"
 //    constant.Constant   (https://godoc.org/github.com/llir/llvm/ir/constant#Constant)
 //    value.Named         (https://godoc.org/github.com/llir/llvm/ir/value#Named)
 //    *metadata.Value     (https://godoc.org/github.com/llir/llvm/ir/metadata#Value)
+//    *ir.InlineAsm       (https://godoc.org/github.com/llir/llvm/ir#InlineAsm)
 type Value interface {
 	// Type returns the type of the value.
 	Type() types.Type
@@ -24,6 +25,8 @@ type Value interface {
 //
 //    *ir.Global       (https://godoc.org/github.com/llir/llvm/ir#Global)
 //    *ir.Function     (https://godoc.org/github.com/llir/llvm/ir#Function)
+//    *ir.Alias        (https://godoc.org/github.com/llir/llvm/ir#Alias)
+//    *ir.IFunc        (https://godoc.org/github.com/llir/llvm/ir#IFunc)
 //    *types.Param     (https://godoc.org/github.com/llir/llvm/ir/types#Param)
 //    *ir.BasicBlock   (https://godoc.org/github.com/llir/llvm/ir#BasicBlock)
 //    ir.Instruction   (https://godoc.org/github.com/llir/llvm/ir#Instruction)
@@ -0,0 +1,103 @@
+package ir_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/llir/llvm/asm"
+	"github.com/llir/llvm/ir"
+)
+
+// Validate that the relevant types satisfy the io.WriterTo interface.
+var (
+	_ io.WriterTo = &ir.Module{}
+	_ io.WriterTo = &ir.Function{}
+	_ io.WriterTo = &ir.BasicBlock{}
+)
+
+func TestWriteTo(t *testing.T) {
+	golden := []struct {
+		path string
+	}{
+		{path: "../asm/testdata/empty.ll"},
+		{path: "../asm/testdata/module.ll"},
+		{path: "../asm/testdata/alias.ll"},
+		{path: "../asm/testdata/func.ll"},
+		{path: "../asm/testdata/metadata.ll"},
+		{path: "../asm/testdata/term.ll"},
+		{path: "../asm/testdata/rand.ll"},
+	}
+	for _, g := range golden {
+		m, err := asm.ParseFile(g.path)
+		if err != nil {
+			t.Errorf("%q: unable to parse file; %v", g.path, err)
+			continue
+		}
+		check := func(name string, x interface {
+			io.WriterTo
+			String() string
+		}) {
+			buf := &bytes.Buffer{}
+			n, err := x.WriteTo(buf)
+			if err != nil {
+				t.Errorf("%q: unable to write %s; %v", g.path, name, err)
+				return
+			}
+			want, got := x.String(), buf.String()
+			if want != got {
+				t.Errorf("%q: output mismatch of %s; expected %q, got %q", g.path, name, want, got)
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("%q: byte count mismatch of %s; expected %d, got %d", g.path, name, buf.Len(), n)
+			}
+		}
+		check("module", m)
+		for _, f := range m.Funcs {
+			check(f.Ident(), f)
+			for _, block := range f.Blocks {
+				check(f.Ident()+" "+block.Ident(), block)
+			}
+		}
+	}
+}
+
+// errWriter is a writer which fails after writing limit bytes.
+type errWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+var errLimit = errors.New("write limit reached")
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		n := w.limit - w.buf.Len()
+		w.buf.Write(p[:n])
+		return n, errLimit
+	}
+	return w.buf.Write(p)
+}
+
+func TestWriteToError(t *testing.T) {
+	const path = "../asm/testdata/rand.ll"
+	m, err := asm.ParseFile(path)
+	if err != nil {
+		t.Fatalf("%q: unable to parse file; %v", path, err)
+	}
+	want := m.String()
+	for _, limit := range []int{0, 1, 100, len(want) / 2, len(want) - 1} {
+		w := &errWriter{limit: limit}
+		n, err := m.WriteTo(w)
+		if err != errLimit {
+			t.Errorf("limit %d: error mismatch; expected %v, got %v", limit, errLimit, err)
+		}
+		if n != int64(limit) {
+			t.Errorf("limit %d: byte count mismatch; expected %d, got %d", limit, limit, n)
+		}
+		if got := w.buf.String(); got != want[:limit] {
+			t.Errorf("limit %d: output mismatch; expected %q, got %q", limit, want[:limit], got)
+		}
+	}
+}
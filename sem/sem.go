@@ -10,6 +10,7 @@ import (
 	"github.com/llir/llvm/ir/constant"
 	"github.com/llir/llvm/ir/irutil"
 	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
 	"github.com/pkg/errors"
 )
 
@@ -31,6 +32,10 @@ func Check(m *ir.Module) error {
 		switch n := n.(type) {
 		case *ir.Global:
 			sem.checkGlobal(n)
+		case *ir.Alias:
+			sem.checkAlias(n)
+		case *ir.IFunc:
+			sem.checkIFunc(n)
 		case *ir.Function:
 			sem.checkFunc(n)
 		case *ir.BasicBlock:
@@ -39,10 +44,12 @@ func Check(m *ir.Module) error {
 			sem.checkType(n)
 		case constant.Constant:
 			sem.checkConst(n)
-		case ir.Instruction:
-			sem.checkInst(n)
+		// Terminators also implement ir.Instruction, and must therefore be
+		// matched first.
 		case ir.Terminator:
 			sem.checkTerm(n)
+		case ir.Instruction:
+			sem.checkInst(n)
 		}
 	}
 	irutil.Walk(m, check)
@@ -104,6 +111,67 @@ func (sem *sem) checkGlobal(global *ir.Global) {
 	}
 }
 
+// --- [ Aliases ] -------------------------------------------------------------
+
+// checkAlias validates the semantics of the given alias.
+func (sem *sem) checkAlias(alias *ir.Alias) {
+	// Validate alias name.
+	if len(alias.Name) == 0 {
+		sem.Errorf("alias name missing")
+	} else if !isValidIdent(alias.Name) {
+		sem.Errorf("invalid alias name `%v`", enc.Global(alias.Name))
+	}
+	// Validate aliasee.
+	switch aliasee := alias.Aliasee.(type) {
+	case nil:
+		sem.Errorf("aliasee of alias `%v` missing", enc.Global(alias.Name))
+		return
+	case *ir.Global, *ir.Function, *ir.Alias, constant.Expr:
+		// valid aliasee.
+	default:
+		sem.Errorf("invalid aliasee of alias `%v`; expected global variable, function, alias or constant expression, got %T", enc.Global(alias.Name), aliasee)
+	}
+	// Validate alias type.
+	if typ := alias.Aliasee.Type(); !typ.Equal(alias.Typ) {
+		sem.Errorf("alias type `%v` and aliasee type `%v` mismatch", alias.Typ, typ)
+	}
+	// Validate that the aliasee chain does not form a cycle.
+	if hasAliasCycle(alias.Aliasee, map[*ir.Alias]bool{alias: true}) {
+		sem.Errorf("invalid aliasee of alias `%v`; alias cycle detected", enc.Global(alias.Name))
+	}
+}
+
+// --- [ IFuncs ] --------------------------------------------------------------
+
+// checkIFunc validates the semantics of the given IFunc.
+func (sem *sem) checkIFunc(ifunc *ir.IFunc) {
+	// Validate IFunc name.
+	if len(ifunc.Name) == 0 {
+		sem.Errorf("IFunc name missing")
+	} else if !isValidIdent(ifunc.Name) {
+		sem.Errorf("invalid IFunc name `%v`", enc.Global(ifunc.Name))
+	}
+	// Validate IFunc type.
+	content, elem := ifunc.Content, ifunc.Typ.Elem
+	if !content.Equal(elem) {
+		sem.Errorf("IFunc content type `%v` and element type `%v` mismatch", content, elem)
+	}
+	// Validate resolver.
+	switch resolver := ifunc.Resolver.(type) {
+	case nil:
+		sem.Errorf("resolver of IFunc `%v` missing", enc.Global(ifunc.Name))
+		return
+	case *ir.Function, constant.Expr:
+		// valid resolver.
+	default:
+		sem.Errorf("invalid resolver of IFunc `%v`; expected function or constant expression, got %T", enc.Global(ifunc.Name), resolver)
+	}
+	// Validate resolver type.
+	if typ, ok := ifunc.Resolver.Type().(*types.PointerType); !ok || !types.IsFunc(typ.Elem) {
+		sem.Errorf("invalid resolver type of IFunc `%v`; expected pointer to function type, got `%v`", enc.Global(ifunc.Name), ifunc.Resolver.Type())
+	}
+}
+
 // --- [ Functions ] -----------------------------------------------------------
 
 // checkFunc validates the semantics of the given function.
@@ -208,8 +276,12 @@ func (sem *sem) checkType(t types.Type) {
 		if !types.IsInt(t.Elem) && !types.IsFloat(t.Elem) && !types.IsPointer(t.Elem) {
 			sem.Errorf("invalid vector element type; expected integer, floating-point or pointer type, got %T", t.Elem)
 		}
+	case *types.MMXType:
+		// nothing to do.
 	case *types.LabelType:
 		// nothing to do.
+	case *types.TokenType:
+		// nothing to do.
 	case *types.MetadataType:
 		// nothing to do.
 	case *types.ArrayType:
@@ -242,11 +314,13 @@ func (sem *sem) checkConst(c constant.Constant) {
 	case *constant.Float:
 		// c.Typ is validated when later traversed.
 		// Validate floating-point value.
-		if c.X == nil {
+		if c.X == nil && !c.NaN {
 			sem.Errorf("floating-point constant value missing")
 		}
 	case *constant.Null:
 		// c.Typ is validated when later traversed.
+	case *constant.NoneToken:
+		// nothing to do.
 
 	// Complex constants.
 	case *constant.Vector:
@@ -594,7 +668,46 @@ func (sem *sem) checkConst(c constant.Constant) {
 
 	// Memory expressions.
 	case *constant.ExprGetElementPtr:
-		panic("not yet implemented")
+		// References:
+		//    http://llvm.org/docs/LangRef.html#getelementptr-instruction
+
+		// c.Src is validated when later traversed.
+		// c.Indices are validated when later traversed.
+		inrange := 0
+		for _, index := range c.Indices {
+			if index, ok := index.(*constant.Index); ok && index.InRange {
+				inrange++
+			}
+		}
+		if inrange > 1 {
+			sem.Errorf("invalid number of `getelementptr` inrange indices; expected at most one, got %d", inrange)
+		}
+	case *constant.Index:
+		// c.Constant is validated when later traversed.
+
+	// Addresses of basic blocks.
+	case *constant.BlockAddress:
+		// References:
+		//    http://llvm.org/docs/LangRef.html#addresses-of-basic-blocks
+
+		f, ok := c.Func.(*ir.Function)
+		if !ok {
+			sem.Errorf("invalid `blockaddress` function type; expected *ir.Function, got %T", c.Func)
+			break
+		}
+		block, ok := c.Block.(*ir.BasicBlock)
+		if !ok {
+			sem.Errorf("invalid `blockaddress` basic block type; expected *ir.BasicBlock, got %T", c.Block)
+			break
+		}
+		// Validate that the basic block is a non-entry basic block of the
+		// function.
+		switch {
+		case block.Parent != f:
+			sem.Errorf("invalid `blockaddress` basic block %v; not present in function %v", block.Ident(), f.Ident())
+		case block == f.Blocks[0]:
+			sem.Errorf("invalid `blockaddress` basic block %v; unable to take address of entry basic block", block.Ident())
+		}
 
 	// Conversion expressions.
 	case *constant.ExprTrunc:
@@ -684,9 +797,48 @@ func (sem *sem) checkInst(inst ir.Instruction) {
 	case *ir.InstAlloca:
 		panic("not yet implemented")
 	case *ir.InstLoad:
-		panic("not yet implemented")
+		// Validate atomic ordering.
+		switch inst.Ordering {
+		case ir.AtomicOrderingNone, ir.AtomicOrderingUnordered, ir.AtomicOrderingMonotonic, ir.AtomicOrderingAcquire, ir.AtomicOrderingSequentiallyConsistent:
+			// valid
+		default:
+			sem.Errorf("invalid `load` ordering; expected unordered, monotonic, acquire or seq_cst, got %v", inst.Ordering)
+		}
 	case *ir.InstStore:
-		panic("not yet implemented")
+		// Validate atomic ordering.
+		switch inst.Ordering {
+		case ir.AtomicOrderingNone, ir.AtomicOrderingUnordered, ir.AtomicOrderingMonotonic, ir.AtomicOrderingRelease, ir.AtomicOrderingSequentiallyConsistent:
+			// valid
+		default:
+			sem.Errorf("invalid `store` ordering; expected unordered, monotonic, release or seq_cst, got %v", inst.Ordering)
+		}
+	case *ir.InstFence:
+		// Validate atomic ordering.
+		switch inst.Ordering {
+		case ir.AtomicOrderingAcquire, ir.AtomicOrderingRelease, ir.AtomicOrderingAcquireRelease, ir.AtomicOrderingSequentiallyConsistent:
+			// valid
+		default:
+			sem.Errorf("invalid `fence` ordering; expected acquire, release, acq_rel or seq_cst, got %v", inst.Ordering)
+		}
+	case *ir.InstCmpXchg:
+		// Validate success ordering.
+		if inst.SuccessOrdering < ir.AtomicOrderingMonotonic {
+			sem.Errorf("invalid `cmpxchg` success ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got %v", inst.SuccessOrdering)
+		}
+		// Validate failure ordering.
+		switch inst.FailureOrdering {
+		case ir.AtomicOrderingMonotonic, ir.AtomicOrderingAcquire, ir.AtomicOrderingSequentiallyConsistent:
+			if isStrongerOrdering(inst.FailureOrdering, inst.SuccessOrdering) {
+				sem.Errorf("`cmpxchg` failure ordering `%v` stronger than success ordering `%v`", inst.FailureOrdering, inst.SuccessOrdering)
+			}
+		default:
+			sem.Errorf("invalid `cmpxchg` failure ordering; expected monotonic, acquire or seq_cst, got %v", inst.FailureOrdering)
+		}
+	case *ir.InstAtomicRMW:
+		// Validate atomic ordering.
+		if inst.Ordering < ir.AtomicOrderingMonotonic {
+			sem.Errorf("invalid `atomicrmw` ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got %v", inst.Ordering)
+		}
 	case *ir.InstGetElementPtr:
 		panic("not yet implemented")
 	// Conversion instructions.
@@ -726,7 +878,34 @@ func (sem *sem) checkInst(inst ir.Instruction) {
 	case *ir.InstSelect:
 		panic("not yet implemented")
 	case *ir.InstCall:
+		// inst.Callee is validated when later traversed.
+		// inst.Args are validated when later traversed.
+		if inst.Tail == ir.TailMustTail {
+			sem.checkMustTail(inst)
+		}
+	case *ir.InstVAArg:
+		// Validate argument list type.
+		if _, ok := inst.ArgList.Type().(*types.PointerType); !ok {
+			sem.Errorf("invalid `va_arg` argument list type; expected pointer type, got %T", inst.ArgList.Type())
+		}
+	case *ir.InstLandingPad:
 		panic("not yet implemented")
+	case *ir.InstCatchPad:
+		// Validate parent catchswitch.
+		if sem.checkToken("catchpad", "parent", inst.Within) {
+			if _, ok := inst.Within.(*ir.TermCatchSwitch); !ok {
+				sem.Errorf("invalid `catchpad` parent; expected catchswitch, got %T", inst.Within)
+			}
+		}
+		// inst.Args are validated when later traversed.
+	case *ir.InstCleanupPad:
+		// Validate parent exception pad.
+		if sem.checkToken("cleanuppad", "parent", inst.Within) {
+			if !isExceptionParent(inst.Within) {
+				sem.Errorf("invalid `cleanuppad` parent; expected none, catchpad or cleanuppad, got %T", inst.Within)
+			}
+		}
+		// inst.Args are validated when later traversed.
 	default:
 		panic(fmt.Errorf("support for instruction %T not yet implemented", inst))
 	}
@@ -738,15 +917,57 @@ func (sem *sem) checkInst(inst ir.Instruction) {
 func (sem *sem) checkTerm(term ir.Terminator) {
 	switch term := term.(type) {
 	case *ir.TermRet:
-		panic("not yet implemented")
+		// term.X is validated when later traversed.
+		ret := term.Parent.Parent.Sig.Ret
+		switch {
+		case term.X == nil && !ret.Equal(types.Void):
+			sem.Errorf("`ret` value missing; expected value of function return type `%v`", ret)
+		case term.X != nil && !term.X.Type().Equal(ret):
+			sem.Errorf("`ret` value type `%v` and function return type `%v` mismatch", term.X.Type(), ret)
+		}
 	case *ir.TermBr:
 		panic("not yet implemented")
 	case *ir.TermCondBr:
 		panic("not yet implemented")
 	case *ir.TermSwitch:
 		panic("not yet implemented")
-	case *ir.TermUnreachable:
+	case *ir.TermIndirectBr:
+		// term.Addr is validated when later traversed.
+		// Validate target address type.
+		if _, ok := term.Addr.Type().(*types.PointerType); !ok {
+			sem.Errorf("invalid `indirectbr` address type; expected pointer type, got %T", term.Addr.Type())
+		}
+	case *ir.TermInvoke:
+		panic("not yet implemented")
+	case *ir.TermResume:
 		panic("not yet implemented")
+	case *ir.TermCatchSwitch:
+		// Validate parent exception pad.
+		if sem.checkToken("catchswitch", "parent", term.Within) {
+			if !isExceptionParent(term.Within) {
+				sem.Errorf("invalid `catchswitch` parent; expected none, catchpad or cleanuppad, got %T", term.Within)
+			}
+		}
+		// Validate exception handlers.
+		if len(term.Handlers) == 0 {
+			sem.Errorf("`catchswitch` exception handlers missing")
+		}
+	case *ir.TermCatchRet:
+		// Validate catchpad.
+		if sem.checkToken("catchret", "from", term.From) {
+			if _, ok := term.From.(*ir.InstCatchPad); !ok {
+				sem.Errorf("invalid `catchret` from; expected catchpad, got %T", term.From)
+			}
+		}
+	case *ir.TermCleanupRet:
+		// Validate cleanuppad.
+		if sem.checkToken("cleanupret", "from", term.From) {
+			if _, ok := term.From.(*ir.InstCleanupPad); !ok {
+				sem.Errorf("invalid `cleanupret` from; expected cleanuppad, got %T", term.From)
+			}
+		}
+	case *ir.TermUnreachable:
+		// nothing to do.
 	default:
 		panic(fmt.Errorf("support for instruction %T not yet implemented", term))
 	}
@@ -754,6 +975,207 @@ func (sem *sem) checkTerm(term ir.Terminator) {
 
 // ### [ Helper functions ] ####################################################
 
+// checkToken validates that the given operand of an exception handling
+// instruction or terminator is of token type, and reports whether it is.
+func (sem *sem) checkToken(opcode, operand string, x value.Value) bool {
+	if !types.IsToken(x.Type()) {
+		sem.Errorf("invalid `%s` %s type; expected token type, got %T", opcode, operand, x.Type())
+		return false
+	}
+	return true
+}
+
+// checkMustTail validates that the given musttail call instruction is
+// immediately followed by a ret terminator, optionally with an intermediate
+// bitcast of the call result, and that the ret returns the call result or void.
+//
+// References:
+//    http://llvm.org/docs/LangRef.html#call-instruction
+func (sem *sem) checkMustTail(inst *ir.InstCall) {
+	block := inst.Parent
+	var next []ir.Instruction
+	for i, v := range block.Insts {
+		if v == inst {
+			next = block.Insts[i+1:]
+			break
+		}
+	}
+	var result value.Value = inst
+	if len(next) > 0 {
+		if cast, ok := next[0].(*ir.InstBitCast); ok && cast.From == result {
+			result = cast
+			next = next[1:]
+		}
+	}
+	if len(next) > 0 {
+		sem.Errorf("invalid instruction following `musttail` call; expected ret, got %T", next[0])
+		return
+	}
+	ret, ok := block.Term.(*ir.TermRet)
+	if !ok {
+		sem.Errorf("invalid terminator following `musttail` call; expected ret, got %T", block.Term)
+		return
+	}
+	if ret.X != nil && ret.X != result {
+		sem.Errorf("invalid `ret` value following `musttail` call; expected call result, got `%v`", ret.X.Ident())
+	}
+}
+
+// hasAliasCycle reports whether the given aliasee refers back to an alias of the
+// given path of aliases, following the aliasees of aliases and the operands of
+// constant expressions.
+func hasAliasCycle(aliasee constant.Constant, path map[*ir.Alias]bool) bool {
+	switch aliasee := aliasee.(type) {
+	case *ir.Alias:
+		if path[aliasee] {
+			return true
+		}
+		if aliasee.Aliasee == nil {
+			return false
+		}
+		path[aliasee] = true
+		defer delete(path, aliasee)
+		return hasAliasCycle(aliasee.Aliasee, path)
+	case *constant.Index:
+		return hasAliasCycle(aliasee.Constant, path)
+	case constant.Expr:
+		for _, op := range exprOperands(aliasee) {
+			if hasAliasCycle(op, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exprOperands returns the constant operands of the given constant expression.
+func exprOperands(expr constant.Expr) []constant.Constant {
+	switch expr := expr.(type) {
+	// Binary and bitwise expressions.
+	case *constant.ExprAdd:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFAdd:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprSub:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFSub:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprMul:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFMul:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprUDiv:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprSDiv:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFDiv:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprURem:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprSRem:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFRem:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprShl:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprLShr:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprAShr:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprAnd:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprOr:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprXor:
+		return []constant.Constant{expr.X, expr.Y}
+	// Vector expressions.
+	case *constant.ExprExtractElement:
+		return []constant.Constant{expr.X, expr.Index}
+	case *constant.ExprInsertElement:
+		return []constant.Constant{expr.X, expr.Elem, expr.Index}
+	case *constant.ExprShuffleVector:
+		return []constant.Constant{expr.X, expr.Y, expr.Mask}
+	// Aggregate expressions.
+	case *constant.ExprExtractValue:
+		return []constant.Constant{expr.X}
+	case *constant.ExprInsertValue:
+		return []constant.Constant{expr.X, expr.Elem}
+	// Memory expressions.
+	case *constant.ExprGetElementPtr:
+		return append([]constant.Constant{expr.Src}, expr.Indices...)
+	// Conversion expressions.
+	case *constant.ExprTrunc:
+		return []constant.Constant{expr.From}
+	case *constant.ExprZExt:
+		return []constant.Constant{expr.From}
+	case *constant.ExprSExt:
+		return []constant.Constant{expr.From}
+	case *constant.ExprFPTrunc:
+		return []constant.Constant{expr.From}
+	case *constant.ExprFPExt:
+		return []constant.Constant{expr.From}
+	case *constant.ExprFPToUI:
+		return []constant.Constant{expr.From}
+	case *constant.ExprFPToSI:
+		return []constant.Constant{expr.From}
+	case *constant.ExprUIToFP:
+		return []constant.Constant{expr.From}
+	case *constant.ExprSIToFP:
+		return []constant.Constant{expr.From}
+	case *constant.ExprPtrToInt:
+		return []constant.Constant{expr.From}
+	case *constant.ExprIntToPtr:
+		return []constant.Constant{expr.From}
+	case *constant.ExprBitCast:
+		return []constant.Constant{expr.From}
+	case *constant.ExprAddrSpaceCast:
+		return []constant.Constant{expr.From}
+	// Other expressions.
+	case *constant.ExprICmp:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprFCmp:
+		return []constant.Constant{expr.X, expr.Y}
+	case *constant.ExprSelect:
+		return []constant.Constant{expr.Cond, expr.X, expr.Y}
+	default:
+		panic(fmt.Errorf("support for constant expression %T not yet implemented", expr))
+	}
+}
+
+// isExceptionParent reports whether the given value is a valid parent of a
+// cleanuppad instruction or catchswitch terminator; i.e. the none token or a
+// token produced by a catchpad or cleanuppad instruction.
+func isExceptionParent(x value.Value) bool {
+	switch x.(type) {
+	case *constant.NoneToken, *ir.InstCatchPad, *ir.InstCleanupPad:
+		return true
+	default:
+		return false
+	}
+}
+
+// isStrongerOrdering reports whether the atomic ordering a is strictly
+// stronger than b. Note that acquire and release are incomparable.
+func isStrongerOrdering(a, b ir.AtomicOrdering) bool {
+	if a == b {
+		return false
+	}
+	switch a {
+	case ir.AtomicOrderingUnordered:
+		return b == ir.AtomicOrderingNone
+	case ir.AtomicOrderingMonotonic:
+		return b == ir.AtomicOrderingNone || b == ir.AtomicOrderingUnordered
+	case ir.AtomicOrderingAcquire, ir.AtomicOrderingRelease:
+		return b == ir.AtomicOrderingNone || b == ir.AtomicOrderingUnordered || b == ir.AtomicOrderingMonotonic
+	case ir.AtomicOrderingAcquireRelease:
+		return b != ir.AtomicOrderingSequentiallyConsistent
+	case ir.AtomicOrderingSequentiallyConsistent:
+		return true
+	default:
+		return false
+	}
+}
+
 const (
 	asciiLetter  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	letter       = asciiLetter + "$-._"
@@ -839,8 +1261,12 @@ func isFirstClassType(t types.Type) bool {
 		return true
 	case *types.VectorType:
 		return true
+	case *types.MMXType:
+		return true
 	case *types.LabelType:
 		return true
+	case *types.TokenType:
+		return true
 	case *types.MetadataType:
 		return true
 	case *types.ArrayType:
@@ -867,8 +1293,12 @@ func isSingleValueType(t types.Type) bool {
 		return true
 	case *types.VectorType:
 		return true
+	case *types.MMXType:
+		return true
 	case *types.LabelType:
 		return false
+	case *types.TokenType:
+		return false
 	case *types.MetadataType:
 		return false
 	case *types.ArrayType:
@@ -895,8 +1325,12 @@ func isAggregateType(t types.Type) bool {
 		return true
 	case *types.VectorType:
 		return true
+	case *types.MMXType:
+		return true
 	case *types.LabelType:
 		return false
+	case *types.TokenType:
+		return false
 	case *types.MetadataType:
 		return false
 	case *types.ArrayType:
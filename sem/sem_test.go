@@ -21,6 +21,21 @@ func TestCheck(t *testing.T) {
 			},
 		},
 
+		// Aliases and IFuncs.
+		{
+			path: "testdata/alias.ll",
+			errs: []string{
+				"alias type `i8*` and aliasee type `i32*` mismatch",
+				"invalid aliasee of alias `@d`; expected global variable, function, alias or constant expression, got *constant.Null",
+				"invalid aliasee of alias `@y`; alias cycle detected",
+				"invalid aliasee of alias `@x`; alias cycle detected",
+				"invalid aliasee of alias `@w`; alias cycle detected",
+				"invalid aliasee of alias `@z`; alias cycle detected",
+				"invalid resolver of IFunc `@h`; expected function or constant expression, got *ir.Global",
+				"invalid resolver type of IFunc `@h`; expected pointer to function type, got `i32*`",
+			},
+		},
+
 		// Types.
 		{
 			path: "testdata/type_func.ll",
@@ -88,6 +103,65 @@ func TestCheck(t *testing.T) {
 			path: "testdata/const_struct.ll",
 			errs: nil,
 		},
+		{
+			path: "testdata/const_blockaddress.ll",
+			errs: []string{
+				"invalid `blockaddress` basic block %0; unable to take address of entry basic block",
+			},
+		},
+
+		// Constant expressions.
+		{
+			path: "testdata/expr_getelementptr.ll",
+			errs: []string{
+				"invalid number of `getelementptr` inrange indices; expected at most one, got 2",
+			},
+		},
+
+		// Instructions.
+		{
+			path: "testdata/inst_pad.ll",
+			errs: []string{
+				"`catchswitch` exception handlers missing",
+				"invalid `catchpad` parent type; expected token type, got *types.IntType",
+				"invalid `catchpad` parent; expected catchswitch, got *ir.InstCleanupPad",
+				"invalid `catchret` from; expected catchpad, got *ir.InstCleanupPad",
+				"invalid `cleanuppad` parent; expected none, catchpad or cleanuppad, got *ir.TermCatchSwitch",
+				"invalid `cleanupret` from; expected cleanuppad, got *ir.InstCatchPad",
+				"invalid `catchswitch` parent type; expected token type, got *types.IntType",
+			},
+		},
+		{
+			path: "testdata/inst_atomic.ll",
+			errs: []string{
+				"invalid `load` ordering; expected unordered, monotonic, acquire or seq_cst, got release",
+				"invalid `load` ordering; expected unordered, monotonic, acquire or seq_cst, got acq_rel",
+				"invalid `store` ordering; expected unordered, monotonic, release or seq_cst, got acquire",
+				"invalid `fence` ordering; expected acquire, release, acq_rel or seq_cst, got monotonic",
+				"invalid `cmpxchg` success ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got unordered",
+				"invalid `cmpxchg` failure ordering; expected monotonic, acquire or seq_cst, got unordered",
+				"invalid `cmpxchg` failure ordering; expected monotonic, acquire or seq_cst, got release",
+				"`cmpxchg` failure ordering `acquire` stronger than success ordering `monotonic`",
+				"invalid `atomicrmw` ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got unordered",
+			},
+		},
+		{
+			path: "testdata/inst_call.ll",
+			errs: []string{
+				"invalid instruction following `musttail` call; expected ret, got *ir.InstStore",
+				"invalid terminator following `musttail` call; expected ret, got *ir.TermUnreachable",
+				"invalid `ret` value following `musttail` call; expected call result, got `42`",
+				"`ret` value type `i64` and function return type `i32` mismatch",
+			},
+		},
+
+		// Terminators.
+		{
+			path: "testdata/term_indirectbr.ll",
+			errs: []string{
+				"invalid `indirectbr` address type; expected pointer type, got *types.IntType",
+			},
+		},
 	}
 	for _, g := range golden {
 		m, err := asm.ParseFile(g.path)
@@ -0,0 +1,27 @@
+@g = global i32 0
+@arr = global [2 x i32] [i32 1, i32 2]
+
+define void @f() {
+	ret void
+}
+
+define void ()* @resolver() {
+	ret void ()* @f
+}
+
+; Aliases.
+@a = alias i32, i32* @g     ; valid
+@b = alias i32, getelementptr ([2 x i32], [2 x i32]* @arr, i32 0, i32 1) ; valid
+@c = alias i8, i32* @g      ; error: alias type `i8*` and aliasee type `i32*` mismatch
+@d = alias i32, i32* null   ; error: invalid aliasee of alias `@d`; expected global variable, function, alias or constant expression, got *constant.Null
+
+; Alias cycles.
+@x = alias i32, i32* @y     ; error: invalid aliasee of alias `@x`; alias cycle detected
+@y = alias i32, i32* @x     ; error: invalid aliasee of alias `@y`; alias cycle detected
+@z = alias i32, getelementptr (i32, i32* @w, i32 0) ; error: invalid aliasee of alias `@z`; alias cycle detected
+@w = alias i32, getelementptr (i32, i32* @z, i32 0) ; error: invalid aliasee of alias `@w`; alias cycle detected
+
+; IFuncs.
+@e = ifunc void (), void ()* ()* @resolver ; valid
+@h = ifunc void (), i32* @g ; error: invalid resolver of IFunc `@h`; expected function or constant expression, got *ir.Global
+                            ; error: invalid resolver type of IFunc `@h`; expected pointer to function type, got `i32*`
@@ -0,0 +1,8 @@
+@valid_1 = global i8* blockaddress(@f, %foo) ; valid
+@invalid_1 = global i8* blockaddress(@f, %0) ; error: invalid `blockaddress` basic block %0; unable to take address of entry basic block
+
+define void @f() {
+	indirectbr i8* blockaddress(@f, %foo), [label %foo]
+foo:
+	ret void
+}
@@ -0,0 +1,5 @@
+@x = global { i32, { [2 x i32], i8 } } zeroinitializer
+
+@valid_1 = global i32* getelementptr ({ i32, { [2 x i32], i8 } }, { i32, { [2 x i32], i8 } }* @x, i32 0, i32 1, i32 0, i32 1)                           ; valid
+@valid_2 = global i32* getelementptr inbounds ({ i32, { [2 x i32], i8 } }, { i32, { [2 x i32], i8 } }* @x, i32 0, inrange i32 1, i32 0, i32 1)          ; valid
+@invalid_1 = global i32* getelementptr inbounds ({ i32, { [2 x i32], i8 } }, { i32, { [2 x i32], i8 } }* @x, i32 0, inrange i32 1, inrange i32 0, i32 1) ; error: invalid number of `getelementptr` inrange indices; expected at most one, got 2
@@ -0,0 +1,24 @@
+define void @valid(i32* %x) {
+	%l1 = load atomic i32, i32* %x unordered, align 4                       ; valid
+	%l2 = load atomic i32, i32* %x acquire, align 4                         ; valid
+	store atomic i32 42, i32* %x monotonic, align 4                         ; valid
+	store atomic i32 42, i32* %x seq_cst, align 4                           ; valid
+	fence acq_rel                                                           ; valid
+	%c1 = cmpxchg i32* %x, i32 1, i32 2 acq_rel acquire                     ; valid
+	%c2 = cmpxchg i32* %x, i32 1, i32 2 release monotonic                   ; valid
+	%a1 = atomicrmw add i32* %x, i32 1 monotonic                            ; valid
+	ret void                                                                ; valid
+}
+
+define void @invalid(i32* %x) {
+	%l1 = load atomic i32, i32* %x release, align 4                         ; error: invalid `load` ordering; expected unordered, monotonic, acquire or seq_cst, got release
+	%l2 = load atomic i32, i32* %x acq_rel, align 4                         ; error: invalid `load` ordering; expected unordered, monotonic, acquire or seq_cst, got acq_rel
+	store atomic i32 42, i32* %x acquire, align 4                           ; error: invalid `store` ordering; expected unordered, monotonic, release or seq_cst, got acquire
+	fence monotonic                                                         ; error: invalid `fence` ordering; expected acquire, release, acq_rel or seq_cst, got monotonic
+	%c1 = cmpxchg i32* %x, i32 1, i32 2 unordered unordered                 ; error: invalid `cmpxchg` success ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got unordered
+	                                                                        ; error: invalid `cmpxchg` failure ordering; expected monotonic, acquire or seq_cst, got unordered
+	%c2 = cmpxchg i32* %x, i32 1, i32 2 seq_cst release                     ; error: invalid `cmpxchg` failure ordering; expected monotonic, acquire or seq_cst, got release
+	%c3 = cmpxchg i32* %x, i32 1, i32 2 monotonic acquire                   ; error: `cmpxchg` failure ordering `acquire` stronger than success ordering `monotonic`
+	%a1 = atomicrmw xchg i32* %x, i32 1 unordered                           ; error: invalid `atomicrmw` ordering; expected monotonic, acquire, release, acq_rel or seq_cst, got unordered
+	ret void                                                                ; valid
+}
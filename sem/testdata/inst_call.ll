@@ -0,0 +1,39 @@
+declare i32 @f()
+
+declare void @g()
+
+define i32 @valid_1() {
+	%r = musttail call i32 @f()                 ; valid
+	ret i32 %r                                  ; valid
+}
+
+define void @valid_2() {
+	musttail call void @g()                     ; valid
+	ret void                                    ; valid
+}
+
+define i32 @valid_3() {
+	%r = tail call i32 @f()                     ; valid
+	store i32 %r, i32* null                     ; valid
+	ret i32 %r                                  ; valid
+}
+
+define i32 @invalid_1(i32* %p) {
+	%r = musttail call i32 @f()                 ; error: invalid instruction following `musttail` call; expected ret, got *ir.InstStore
+	store i32 %r, i32* %p                       ; valid
+	ret i32 %r                                  ; valid
+}
+
+define i32 @invalid_2() {
+	%r = musttail call i32 @f()                 ; error: invalid terminator following `musttail` call; expected ret, got *ir.TermUnreachable
+	unreachable                                 ; valid
+}
+
+define i32 @invalid_3() {
+	%r = musttail call i32 @f()                 ; error: invalid `ret` value following `musttail` call; expected call result, got `42`
+	ret i32 42                                  ; valid
+}
+
+define i32 @invalid_4() {
+	ret i64 42                                  ; error: `ret` value type `i64` and function return type `i32` mismatch
+}
@@ -0,0 +1,29 @@
+define void @valid() {
+dispatch:
+	%cs = catchswitch within none [label %catch] unwind label %cleanup   ; valid
+catch:
+	%cp = catchpad within %cs [i32 0]                                    ; valid
+	catchret from %cp to label %cleanup                                  ; valid
+cleanup:
+	%cl = cleanuppad within %cp []                                       ; valid
+	cleanupret from %cl unwind to caller                                 ; valid
+}
+
+define void @invalid(i32 %x) {
+cleanup:
+	%cl = cleanuppad within none []                                      ; valid
+	cleanupret from %cl unwind label %dispatch                           ; valid
+dispatch:
+	%cs = catchswitch within %cl [] unwind to caller                     ; error: `catchswitch` exception handlers missing
+catch_1:
+	%cp1 = catchpad within %x []                                         ; error: invalid `catchpad` parent type; expected token type, got *types.IntType
+	catchret from %cp1 to label %cleanup                                 ; valid
+catch_2:
+	%cp2 = catchpad within %cl []                                        ; error: invalid `catchpad` parent; expected catchswitch, got *ir.InstCleanupPad
+	catchret from %cl to label %cleanup                                  ; error: invalid `catchret` from; expected catchpad, got *ir.InstCleanupPad
+nested_cleanup:
+	%cl2 = cleanuppad within %cs []                                      ; error: invalid `cleanuppad` parent; expected none, catchpad or cleanuppad, got *ir.TermCatchSwitch
+	cleanupret from %cp2 unwind to caller                                ; error: invalid `cleanupret` from; expected cleanuppad, got *ir.InstCatchPad
+nested_dispatch:
+	%cs2 = catchswitch within %x [label %catch_1] unwind to caller       ; error: invalid `catchswitch` parent type; expected token type, got *types.IntType
+}
@@ -0,0 +1,11 @@
+define void @valid_1() {
+	indirectbr i8* blockaddress(@valid_1, %foo), [label %foo] ; valid
+foo:
+	ret void
+}
+
+define void @invalid_1() {
+	indirectbr i64 42, [label %foo] ; error: invalid `indirectbr` address type; expected pointer type, got *types.IntType
+foo:
+	ret void
+}